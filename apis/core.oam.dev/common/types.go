@@ -274,9 +274,30 @@ type AppStatus struct {
 	// +optional
 	LatestRevision *Revision `json:"latestRevision,omitempty"`
 
+	// LastRollback records the last automatic rollback of the application
+	// +optional
+	LastRollback *RollbackRecord `json:"lastRollback,omitempty"`
+
 	// AppliedResources record the resources that the  workflow step apply.
 	AppliedResources []ClusterObjectReference `json:"appliedResources,omitempty"`
 
+	// DriftedResources record the resources whose live state drifted from the desired state in the last state-keep.
+	// +optional
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
+
+	// PlacementFailovers record the placements of the topology policies with failover.
+	// +optional
+	PlacementFailovers []PlacementFailoverStatus `json:"placementFailovers,omitempty"`
+
+	// Exports record the outputs exported to the Applications depending on this Application.
+	// +optional
+	Exports map[string]string `json:"exports,omitempty"`
+
+	// NextDeployTime is the next time the deploy-window policy allows the workflow to run,
+	// recorded while a new revision waits for the deploy window to open.
+	// +optional
+	NextDeployTime *metav1.Time `json:"nextDeployTime,omitempty"`
+
 	// AppliedApplicationPolicies lists Application-scoped policies (both global and explicit)
 	// that were discovered and applied (or skipped) during reconciliation.
 	// +optional
@@ -292,6 +313,55 @@ type AppStatus struct {
 	PolicyStatus []PolicyStatus `json:"policy,omitempty"`
 }
 
+// DriftedResource records a resource whose live state drifted from the desired state.
+type DriftedResource struct {
+	ClusterObjectReference `json:",inline"`
+	// Component is the component that the resource belongs to
+	Component string `json:"component,omitempty"`
+	// Fields are the paths of the drifted fields
+	Fields []string `json:"fields,omitempty"`
+	// Missing indicates the resource does not exist in the cluster
+	Missing bool `json:"missing,omitempty"`
+	// Corrected indicates the resource has been re-applied to correct the drift
+	Corrected bool `json:"corrected,omitempty"`
+}
+
+// RollbackRecord records an automatic rollback of the application.
+type RollbackRecord struct {
+	// FromRevision is the revision which is rolled back
+	FromRevision string `json:"fromRevision"`
+	// ToRevision is the previous healthy revision which is redeployed
+	ToRevision string `json:"toRevision"`
+	// Reason explains why the application is rolled back
+	Reason string `json:"reason,omitempty"`
+	// Time is the time when the rollback happens
+	Time metav1.Time `json:"time,omitempty"`
+}
+
+// PlacementFailoverStatus records the placement of a topology policy with failover.
+type PlacementFailoverStatus struct {
+	// Policy is the name of the topology policy
+	Policy string `json:"policy"`
+	// Clusters are the clusters that the components are placed in
+	Clusters []string `json:"clusters,omitempty"`
+	// UnhealthyClusters are the clusters failing the probes
+	UnhealthyClusters []string `json:"unhealthyClusters,omitempty"`
+	// Changes record the recent placement changes, the latest one comes last
+	Changes []PlacementChange `json:"changes,omitempty"`
+}
+
+// PlacementChange records a change of the placement caused by failover or failback.
+type PlacementChange struct {
+	// From is the cluster that the components are moved from
+	From string `json:"from"`
+	// To is the cluster that the components are moved to
+	To string `json:"to"`
+	// Reason explains why the placement is changed
+	Reason string `json:"reason,omitempty"`
+	// Time is the time when the placement is changed
+	Time metav1.Time `json:"time,omitempty"`
+}
+
 // PolicyStatus records the status of policy
 // Deprecated
 type PolicyStatus struct {
@@ -320,6 +390,58 @@ type WorkflowStatus struct {
 	StartTime metav1.Time `json:"startTime,omitempty"`
 	// +nullable
 	EndTime metav1.Time `json:"endTime,omitempty"`
+
+	// Approvals records the approvals of the approval steps in the workflow for audit
+	// +optional
+	Approvals []WorkflowStepApproval `json:"approvals,omitempty"`
+
+	// DeployWaves records the status of the waves in the deploy steps deploying clusters in waves
+	// +optional
+	DeployWaves []DeployWaveStatus `json:"deployWaves,omitempty"`
+}
+
+// DeployWaveStatus is the status of a wave in a deploy step deploying clusters in waves
+type DeployWaveStatus struct {
+	// Step is the name of the deploy step
+	Step string `json:"step"`
+	// Name is the name of the wave
+	Name string `json:"name"`
+	// Phase is the phase of the wave, one of pending, progressing, succeeded and halted
+	Phase string `json:"phase"`
+	// Clusters are the clusters in the wave
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+	// HealthyClusters are the clusters passing the health check
+	// +optional
+	HealthyClusters []string `json:"healthyClusters,omitempty"`
+	// UnavailableClusters are the clusters failing the health check, or not healthy before the wave timeout
+	// +optional
+	UnavailableClusters []string `json:"unavailableClusters,omitempty"`
+	// StartTime is the time when the wave starts to be deployed
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Message explains the phase of the wave
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// WorkflowStepApproval is an approval of an approval step in the workflow
+type WorkflowStepApproval struct {
+	// Step is the name of the approval step
+	Step string `json:"step"`
+	// Revision is the revision of the workflow run approved, the approvals of the previous runs are kept for audit
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// User is the user who approved the step
+	User string `json:"user"`
+	// Groups are the groups of the user when approving
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// Comment is the comment of the approval
+	// +optional
+	Comment string `json:"comment,omitempty"`
+	// Time is the time of the approval
+	Time metav1.Time `json:"time"`
 }
 
 // DefinitionType describes the type of DefinitionRevision.
@@ -481,15 +603,27 @@ const (
 	WorkflowCondition
 	// ReadyCondition indicates whether whole application processing is successful.
 	ReadyCondition
+	// DriftedCondition indicates whether the live state of resources drifted from the desired state.
+	DriftedCondition
+	// DependencyCondition indicates whether the Applications depended on are ready.
+	DependencyCondition
+	// DeployWindowCondition indicates whether the deploy-window policy allows the workflow to run.
+	DeployWindowCondition
+	// FailoverCondition indicates whether the clusters of the topology policies with failover are probed.
+	FailoverCondition
 )
 
 var conditions = map[ApplicationConditionType]string{
-	ParsedCondition:   "Parsed",
-	RevisionCondition: "Revision",
-	PolicyCondition:   "Policy",
-	RenderCondition:   "Render",
-	WorkflowCondition: "Workflow",
-	ReadyCondition:    "Ready",
+	ParsedCondition:       "Parsed",
+	RevisionCondition:     "Revision",
+	PolicyCondition:       "Policy",
+	RenderCondition:       "Render",
+	WorkflowCondition:     "Workflow",
+	ReadyCondition:        "Ready",
+	DriftedCondition:      "Drifted",
+	DependencyCondition:   "Dependency",
+	DeployWindowCondition: "DeployWindow",
+	FailoverCondition:     "Failover",
 }
 
 // String returns the string corresponding to the condition type.
@@ -62,6 +62,12 @@ const (
 	ReasonReconcileError   ConditionReason = "ReconcileError"
 )
 
+// Reasons a resource is or is not drifted.
+const (
+	ReasonDriftDetected ConditionReason = "DriftDetected"
+	ReasonNoDrift       ConditionReason = "NoDrift"
+)
+
 // A Condition that may apply to a resource.
 type Condition struct {
 	// Type of this condition. At most one of each condition type may apply to
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+const (
+	// AppDependencyPolicyType refers to the type of app-dependency policy
+	AppDependencyPolicyType = "app-dependency"
+)
+
+// AppDependencyPolicySpec defines the spec of app-dependency policy
+type AppDependencyPolicySpec struct {
+	// DependsOn the Applications this Application depends on. The workflow does not start
+	// until all of them are running, and their exports are available in context.dependencies.
+	DependsOn []AppDependency `json:"dependsOn,omitempty"`
+	// Exports the outputs exported to the Applications depending on this Application
+	Exports []AppExport `json:"exports,omitempty"`
+	// ExportTo the namespaces of the Applications allowed to depend on this Application from
+	// other namespaces, "*" allows all namespaces. The Applications in the same namespace
+	// are always allowed.
+	ExportTo []string `json:"exportTo,omitempty"`
+}
+
+// AppDependency refers to an Application depended on
+type AppDependency struct {
+	// Name the name of the Application
+	Name string `json:"name"`
+	// Namespace the namespace of the Application, default to the namespace of the dependant
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AppExport is an output exported to the dependants. The value is either set
+// literally or taken from the status details of a component.
+type AppExport struct {
+	// Name the name of the output
+	Name string `json:"name"`
+	// Value the literal value of the output, or the default value if the detail is not
+	// found in the status of the component
+	Value string `json:"value,omitempty"`
+	// Component the component whose status details the value is taken from
+	Component string `json:"component,omitempty"`
+	// Detail the key in the status details of the component
+	Detail string `json:"detail,omitempty"`
+}
+
+// Type the type name of the policy
+func (in *AppDependencyPolicySpec) Type() string {
+	return AppDependencyPolicyType
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "time"
+
+const (
+	// AutoRollbackPolicyType refers to the type of auto-rollback policy
+	AutoRollbackPolicyType = "auto-rollback"
+
+	// DefaultAutoRollbackDeadline is the default duration to wait for the components to be healthy
+	DefaultAutoRollbackDeadline = 5 * time.Minute
+)
+
+// AutoRollbackPolicySpec defines the spec of auto-rollback policy
+type AutoRollbackPolicySpec struct {
+	// Deadline the duration to wait for the components to be healthy after the workflow
+	// of a new revision succeeds, like 10m. Default to be 5m.
+	Deadline string `json:"deadline,omitempty"`
+}
+
+// Type the type name of the policy
+func (in *AutoRollbackPolicySpec) Type() string {
+	return AutoRollbackPolicyType
+}
+
+// GetDeadline return the deadline of the auto-rollback policy
+func (in *AutoRollbackPolicySpec) GetDeadline() (time.Duration, error) {
+	if in == nil || in.Deadline == "" {
+		return DefaultAutoRollbackDeadline, nil
+	}
+	return time.ParseDuration(in.Deadline)
+}
@@ -19,6 +19,8 @@ package v1alpha1
 const (
 	// RefObjectsComponentType refers to the type of ref-objects
 	RefObjectsComponentType = "ref-objects"
+	// K8sObjectsComponentType refers to the type of k8s-objects
+	K8sObjectsComponentType = "k8s-objects"
 )
 
 // RefObjectsComponentSpec defines the spec of ref-objects component
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "time"
+
+const (
+	// DeployWindowPolicyType refers to the type of deploy-window policy
+	DeployWindowPolicyType = "deploy-window"
+)
+
+// DeployWindowPolicySpec defines the spec of deploy-window policy. A new run of the workflow,
+// for a new revision or publish version, only starts when the deployment is allowed.
+type DeployWindowPolicySpec struct {
+	// TimeZone the IANA time zone of the schedules and the freeze dates, like Asia/Shanghai.
+	// Default to be UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+	// Allowed the windows in which the deployment is allowed. If not set, the deployment is
+	// allowed at any time outside the blocked windows and the freezes.
+	Allowed []DeployWindow `json:"allowed,omitempty"`
+	// Blocked the windows in which the deployment is not allowed
+	Blocked []DeployWindow `json:"blocked,omitempty"`
+	// Freezes the date ranges in which the deployment is not allowed, like holidays
+	Freezes []DeployFreeze `json:"freezes,omitempty"`
+}
+
+// DeployWindow is a recurring time window
+type DeployWindow struct {
+	// Name the name of the window, used in the status message
+	Name string `json:"name,omitempty"`
+	// Schedule the cron expression of the start of the window, like "0 22 * * 1-5"
+	Schedule string `json:"schedule"`
+	// Duration the length of the window, like 2h
+	Duration string `json:"duration"`
+}
+
+// DeployFreeze is a date range in which the deployment is not allowed
+type DeployFreeze struct {
+	// Name the name of the freeze, used in the status message
+	Name string `json:"name,omitempty"`
+	// Start the first day of the freeze like 2026-12-24, or a RFC3339 time
+	Start string `json:"start"`
+	// End the last day of the freeze like 2026-12-26, or a RFC3339 time
+	End string `json:"end"`
+}
+
+// Type the type name of the policy
+func (in *DeployWindowPolicySpec) Type() string {
+	return DeployWindowPolicyType
+}
+
+// GetLocation return the time zone of the deploy-window policy
+func (in *DeployWindowPolicySpec) GetLocation() (*time.Location, error) {
+	if in == nil || in.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(in.TimeZone)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+const (
+	// DriftDetectionPolicyType refers to the type of drift-detection policy
+	DriftDetectionPolicyType = "drift-detection"
+)
+
+// DriftDetectionMode the mode of handling drifted resources in state-keep
+type DriftDetectionMode string
+
+const (
+	// DriftDetectionModeAutoCorrect records the drift and re-applies the resource
+	DriftDetectionModeAutoCorrect DriftDetectionMode = "auto-correct"
+	// DriftDetectionModeReportOnly records the drift without re-applying the resource
+	DriftDetectionModeReportOnly DriftDetectionMode = "report-only"
+)
+
+// DriftDetectionPolicySpec defines the spec of drift-detection policy
+type DriftDetectionPolicySpec struct {
+	// Mode the default mode for all resources, auto-correct if not set
+	Mode DriftDetectionMode `json:"mode,omitempty"`
+	// Rules override the mode for the selected resources
+	Rules []DriftDetectionPolicyRule `json:"rules,omitempty"`
+}
+
+// Type the type name of the policy
+func (in *DriftDetectionPolicySpec) Type() string {
+	return DriftDetectionPolicyType
+}
+
+// DriftDetectionPolicyRule defines the rule for drift-detection resources
+type DriftDetectionPolicyRule struct {
+	// Selector picks which resources should be affected
+	Selector ResourcePolicyRuleSelector `json:"selector"`
+	// Mode the mode for the selected resources
+	Mode DriftDetectionMode `json:"mode"`
+}
+
+// FindMode return the drift detection mode of the target resource
+func (in *DriftDetectionPolicySpec) FindMode(manifest *unstructured.Unstructured) DriftDetectionMode {
+	if in == nil {
+		return DriftDetectionModeAutoCorrect
+	}
+	for _, rule := range in.Rules {
+		if rule.Selector.Match(manifest) && rule.Mode != "" {
+			return rule.Mode
+		}
+	}
+	if in.Mode != "" {
+		return in.Mode
+	}
+	return DriftDetectionModeAutoCorrect
+}
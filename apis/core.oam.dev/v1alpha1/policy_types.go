@@ -34,6 +34,40 @@ type TopologyPolicySpec struct {
 	// Namespace is the target namespace to deploy in the selected clusters.
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
+	// Failover moves the components from the unhealthy clusters to the spare clusters
+	// matching the clusterLabelSelector.
+	// +optional
+	Failover *TopologyFailover `json:"failover,omitempty"`
+}
+
+const (
+	// DefaultTopologyFailureThreshold is the default number of consecutive failed probes
+	// before a cluster is marked as unhealthy
+	DefaultTopologyFailureThreshold = 3
+)
+
+// TopologyFailover describes how to fail over the placements of the topology policy
+type TopologyFailover struct {
+	// Replicas is the number of clusters to deploy to. The clusters matching the
+	// clusterLabelSelector are sorted by name, the first ones are used and the others
+	// are spare clusters. If not set, all the matched clusters are used.
+	// +optional
+	Replicas *int `json:"replicas,omitempty"`
+	// FailureThreshold is the number of consecutive failed probes before a cluster is
+	// marked as unhealthy. Default to 3.
+	// +optional
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// Failback moves the components back to the original clusters once they recover.
+	// +optional
+	Failback bool `json:"failback,omitempty"`
+}
+
+// GetFailureThreshold returns the failure threshold of the failover
+func (in *TopologyFailover) GetFailureThreshold() int {
+	if in.FailureThreshold <= 0 {
+		return DefaultTopologyFailureThreshold
+	}
+	return in.FailureThreshold
 }
 
 // Placement describes which clusters to be selected in this topology
@@ -66,4 +100,44 @@ type ReplicationPolicySpec struct {
 	Keys []string `json:"keys,omitempty"`
 	// Selector is the subset of selected components which will be replicated.
 	Selector []string `json:"selector,omitempty"`
+	// Distribution splits the replicas of the selected components across the clusters.
+	// If no keys are given, the components are only distributed without replication.
+	// +optional
+	Distribution *ReplicaDistribution `json:"distribution,omitempty"`
+}
+
+const (
+	// DefaultReplicasField is the default field of replicas in the component properties
+	DefaultReplicasField = "replicas"
+)
+
+// ReplicaDistribution describes how to split the replicas of a component across the clusters.
+// The replicas of each cluster are rewritten into the component properties when deploying
+// to the cluster. Without weights, the replicas are split evenly.
+type ReplicaDistribution struct {
+	// Replicas is the total number of replicas of each selected component
+	Replicas int `json:"replicas"`
+	// Weights splits the replicas by the weights of the clusters. The replicas of each weight
+	// are split evenly across the matched clusters, the clusters matching no weight get zero
+	// replicas.
+	// +optional
+	Weights []ClusterWeight `json:"weights,omitempty"`
+	// ByCapacity splits the replicas by the allocatable CPU of the clusters reported by the
+	// cluster metrics manager. Exclusive to weights.
+	// +optional
+	ByCapacity bool `json:"byCapacity,omitempty"`
+	// ReplicasField is the path of the replicas in the component properties, default to be
+	// "replicas".
+	// +optional
+	ReplicasField string `json:"replicasField,omitempty"`
+}
+
+// ClusterWeight is the weight of the clusters selected by names or labels
+type ClusterWeight struct {
+	// Clusters is the names of the clusters to select.
+	Clusters []string `json:"clusters,omitempty"`
+	// ClusterLabelSelector is the label selector for clusters.
+	ClusterLabelSelector map[string]string `json:"clusterLabelSelector,omitempty"`
+	// Weight is the weight of the selected clusters
+	Weight int `json:"weight"`
 }
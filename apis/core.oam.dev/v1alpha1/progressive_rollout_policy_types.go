@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// ProgressiveRolloutPolicyType refers to the type of progressive-rollout policy
+	ProgressiveRolloutPolicyType = "progressive-rollout"
+)
+
+// ProgressiveRolloutPolicySpec defines the spec of progressive-rollout policy
+type ProgressiveRolloutPolicySpec struct {
+	// Components the components to roll out progressively, all the components if empty
+	Components []string `json:"components,omitempty"`
+	// Steps the canary steps, the new version will be promoted after all the steps succeed
+	Steps []ProgressiveRolloutStep `json:"steps"`
+	// Analysis the metrics to judge whether the canary of each step is successful
+	Analysis []RolloutAnalysisMetric `json:"analysis,omitempty"`
+}
+
+// Type the type name of the policy
+func (in *ProgressiveRolloutPolicySpec) Type() string {
+	return ProgressiveRolloutPolicyType
+}
+
+// ProgressiveRolloutStep defines one canary step. A step only decides how many of the
+// original replicas run the new version. The canary pods are selected by the Service of
+// the stable pods, so the requests are split by the ratio of the pods. Shifting traffic
+// weight through a gateway or service mesh is out of scope of the policy.
+type ProgressiveRolloutStep struct {
+	// CanaryReplicas the number or percentage of the original replicas running the new version
+	CanaryReplicas *intstr.IntOrString `json:"canaryReplicas,omitempty"`
+	// CanaryPercent the percentage of the original replicas running the new version,
+	// ignored if CanaryReplicas is set
+	CanaryPercent *int32 `json:"canaryPercent,omitempty"`
+	// Pause the duration to wait after the canary is ready and before the analysis, like 30s
+	Pause string `json:"pause,omitempty"`
+}
+
+// RolloutAnalysisMetric defines a metric to judge the canary
+type RolloutAnalysisMetric struct {
+	// Name the name of the metric
+	Name string `json:"name"`
+	// Type the analysis backend, like prometheus or cue
+	Type string `json:"type"`
+	// Properties the parameters of the analysis backend
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Properties *runtime.RawExtension `json:"properties,omitempty"`
+}
@@ -132,6 +132,8 @@ func (apprev *ApplicationRevisionSpec) UnmarshalJSON(data []byte) error {
 type ApplicationRevisionStatus struct {
 	// Succeeded records if the workflow finished running with success
 	Succeeded bool `json:"succeeded"`
+	// Healthy records if all the components of the revision have been healthy once after the workflow succeeded
+	Healthy bool `json:"healthy,omitempty"`
 	// Workflow the running status of the workflow
 	Workflow *common.WorkflowStatus `json:"workflow,omitempty"`
 	// Record the context values to the revision.
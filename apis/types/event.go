@@ -31,6 +31,10 @@ const (
 	ReasonFailedApply     = "FailedApply"
 	ReasonFailedStateKeep = "FailedStateKeep"
 	ReasonFailedGC        = "FailedGC"
+	ReasonDrifted         = "Drifted"
+	ReasonRolledBack      = "RolledBack"
+	ReasonFailedRollback  = "FailedRollback"
+	ReasonPlacementMoved  = "PlacementMoved"
 )
 
 // event message for Application
@@ -64,6 +64,12 @@ const (
 	LabelDefinitionDeprecated = "custom.definition.oam.dev/deprecated"
 	// LabelDefinitionHidden is the label which describe whether the capability is hidden by UI
 	LabelDefinitionHidden = "custom.definition.oam.dev/ui-hidden"
+	// LabelDefinitionModule is the label which describe the definition module the definition is installed from
+	LabelDefinitionModule = "definition.oam.dev/module"
+	// AnnoDefinitionModuleVersion is the annotation which describe the version of the definition module the definition is installed from
+	AnnoDefinitionModuleVersion = "definition.oam.dev/module-version"
+	// LabelDefinitionModuleRecord is the label for the ConfigMap recording an installed definition module
+	LabelDefinitionModuleRecord = "definition.oam.dev/module-record"
 	// LabelNodeRoleGateway gateway role of node
 	LabelNodeRoleGateway = "node-role.kubernetes.io/gateway"
 	// LabelNodeRoleWorker worker role of node
@@ -98,6 +104,18 @@ const (
 	AnnotationConfigAlias = "config.oam.dev/alias"
 	// AnnotationConfigDistributionSpec is the annotation key of the application that distributes the configs
 	AnnotationConfigDistributionSpec = "config.oam.dev/distribution-spec"
+	// AnnotationConfigVersion is the annotation for the version of the config
+	AnnotationConfigVersion = "config.oam.dev/version"
+	// AnnotationConfigUpdatedBy is the annotation for the user who updated the config to the version
+	AnnotationConfigUpdatedBy = "config.oam.dev/updated-by"
+	// AnnotationConfigUpdatedAt is the annotation for the time when the config is updated to the version
+	AnnotationConfigUpdatedAt = "config.oam.dev/updated-at"
+	// AnnotationConfigReferences is the annotation for the configs referenced by the config
+	AnnotationConfigReferences = "config.oam.dev/references"
+	// LabelConfigName is the label marked as the config that the history version belongs to
+	LabelConfigName = "config.oam.dev/name"
+	// LabelConfigReferencePrefix is the prefix of the labels marked as the configs referenced by the config
+	LabelConfigReferencePrefix = "reference.config.oam.dev/"
 )
 
 const (
@@ -184,6 +202,8 @@ const (
 	HelmRepository = "helm-repository"
 	// CatalogConfigDistribution is the catalog type
 	CatalogConfigDistribution = "config-distribution"
+	// CatalogConfigHistory is the catalog type of the history versions of the configs
+	CatalogConfigHistory = "config-history"
 )
 
 const (
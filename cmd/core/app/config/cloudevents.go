@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/oam-dev/kubevela/pkg/cloudevents"
+)
+
+// CloudEventsConfig contains the configuration of emitting the lifecycle events of applications as CloudEvents.
+type CloudEventsConfig struct {
+	SinkURL           string
+	Mode              string
+	SigningSecretFile string
+	Retries           int
+	Backoff           time.Duration
+	Timeout           time.Duration
+}
+
+// NewCloudEventsConfig creates a new CloudEventsConfig with defaults.
+func NewCloudEventsConfig() *CloudEventsConfig {
+	return &CloudEventsConfig{
+		SinkURL:           "",
+		Mode:              cloudevents.ModeStructured,
+		SigningSecretFile: "",
+		Retries:           3,
+		Backoff:           time.Second,
+		Timeout:           30 * time.Second,
+	}
+}
+
+// AddFlags registers cloudevents configuration flags.
+func (c *CloudEventsConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.SinkURL, "cloudevents-sink-url", c.SinkURL,
+		"The url of the sink to receive the CloudEvents of the application lifecycle transitions. No events are emitted if empty.")
+	fs.StringVar(&c.Mode, "cloudevents-mode", c.Mode,
+		"The content mode of the CloudEvents requests, structured or binary.")
+	fs.StringVar(&c.SigningSecretFile, "cloudevents-signing-secret-file", c.SigningSecretFile,
+		"The file containing the key to sign the CloudEvents requests with HMAC-SHA256. The requests are not signed if empty.")
+	fs.IntVar(&c.Retries, "cloudevents-retries", c.Retries,
+		"The times to retry after the first failed delivery of a CloudEvent.")
+	fs.DurationVar(&c.Backoff, "cloudevents-backoff", c.Backoff,
+		"The wait before the first retry of a CloudEvent, doubled after each retry.")
+	fs.DurationVar(&c.Timeout, "cloudevents-timeout", c.Timeout,
+		"The timeout of each CloudEvents request.")
+}
+
+// NewSender creates the sender of the CloudEvents, nil if the sink is not set.
+func (c *CloudEventsConfig) NewSender() (*cloudevents.Sender, error) {
+	if c.SinkURL == "" {
+		return nil, nil
+	}
+	if c.Mode != cloudevents.ModeStructured && c.Mode != cloudevents.ModeBinary {
+		return nil, fmt.Errorf("invalid cloudevents mode %q, must be one of %s and %s", c.Mode, cloudevents.ModeStructured, cloudevents.ModeBinary)
+	}
+	sender := &cloudevents.Sender{
+		URL:     c.SinkURL,
+		Mode:    c.Mode,
+		Retries: c.Retries,
+		Backoff: c.Backoff,
+		Client:  &http.Client{Timeout: c.Timeout},
+	}
+	if c.SigningSecretFile != "" {
+		bs, err := os.ReadFile(c.SigningSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cloudevents signing secret: %w", err)
+		}
+		sender.Secret = strings.TrimSpace(string(bs))
+	}
+	return sender, nil
+}
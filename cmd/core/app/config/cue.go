@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/pflag"
 	"k8s.io/klog/v2"
 
+	"github.com/oam-dev/kubevela/pkg/cue/cuex/providers/helm"
 	"github.com/oam-dev/kubevela/pkg/cue/upgrade"
 )
 
@@ -32,6 +33,8 @@ type CUEConfig struct {
 	EnableExternalPackageWatch    bool
 	EnableCUEVersionCompatibility bool
 	CUECompatibilityCacheSize     int
+	HelmChartCacheDir             string
+	HelmChartCacheMaxSize         int64
 }
 
 // NewCUEConfig creates a new CUEConfig with defaults.
@@ -41,6 +44,8 @@ func NewCUEConfig() *CUEConfig {
 		EnableExternalPackageWatch:    cuex.EnableExternalPackageWatchForDefaultCompiler,
 		EnableCUEVersionCompatibility: upgrade.EnableCUEVersionCompatibility,
 		CUECompatibilityCacheSize:     upgrade.CompatibilityCacheSize,
+		HelmChartCacheDir:             helm.ChartCacheDir,
+		HelmChartCacheMaxSize:         helm.ChartCacheMaxSize,
 	}
 }
 
@@ -62,6 +67,14 @@ func (c *CUEConfig) AddFlags(fs *pflag.FlagSet) {
 		"cue-compatibility-cache-size",
 		c.CUECompatibilityCacheSize,
 		"Maximum number of CUE templates to cache after version compatibility rewriting. Set to 0 to disable caching.")
+	fs.StringVar(&c.HelmChartCacheDir,
+		"helm-chart-cache-dir",
+		c.HelmChartCacheDir,
+		"Directory of the persistent, content-addressed cache of the charts fetched by the helm provider. Mount a persistent volume to keep the charts across restarts. Disabled when empty.")
+	fs.Int64Var(&c.HelmChartCacheMaxSize,
+		"helm-chart-cache-max-size",
+		c.HelmChartCacheMaxSize,
+		"Maximum total size in bytes of the charts in the persistent chart cache. The least recently used charts are evicted when it is exceeded.")
 }
 
 // SyncToCUEGlobals syncs the parsed configuration values to CUE package global variables.
@@ -77,6 +90,8 @@ func (c *CUEConfig) SyncToCUEGlobals(ctx context.Context) {
 	cuex.EnableExternalPackageForDefaultCompiler = c.EnableExternalPackage
 	cuex.EnableExternalPackageWatchForDefaultCompiler = c.EnableExternalPackageWatch
 	upgrade.EnableCUEVersionCompatibility = c.EnableCUEVersionCompatibility
+	helm.ChartCacheDir = c.HelmChartCacheDir
+	helm.ChartCacheMaxSize = c.HelmChartCacheMaxSize
 	if c.CUECompatibilityCacheSize < 0 {
 		klog.Warningf("cue-compatibility-cache-size %d is invalid (must be >= 0); caching disabled", c.CUECompatibilityCacheSize)
 		c.CUECompatibilityCacheSize = 0
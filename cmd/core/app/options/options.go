@@ -44,6 +44,7 @@ type CoreOptions struct {
 	Profiling     *config.ProfilingConfig
 	KLog          *config.KLogConfig
 	Controller    *config.ControllerConfig
+	CloudEvents   *config.CloudEventsConfig
 }
 
 // NewCoreOptions creates a new NewVelaCoreOptions object with default parameters
@@ -68,6 +69,7 @@ func NewCoreOptions() *CoreOptions {
 	profiling := config.NewProfilingConfig()
 	klog := config.NewKLogConfig(observability)
 	controller := config.NewControllerConfig()
+	cloudEvents := config.NewCloudEventsConfig()
 
 	s := &CoreOptions{
 		// Config modules
@@ -90,6 +92,7 @@ func NewCoreOptions() *CoreOptions {
 		Profiling:     profiling,
 		KLog:          klog,
 		Controller:    controller,
+		CloudEvents:   cloudEvents,
 	}
 
 	return s
@@ -113,6 +116,7 @@ func (s *CoreOptions) Flags() cliflag.NamedFlagSets {
 	s.Resource.AddFlags(fss.FlagSet("resource"))
 	s.Workflow.AddFlags(fss.FlagSet("workflow"))
 	s.Controller.AddFlags(fss.FlagSet("controller"))
+	s.CloudEvents.AddFlags(fss.FlagSet("cloudevents"))
 
 	// External package configurations (now wrapped in config modules)
 	s.Client.AddFlags(fss.FlagSet("client"))
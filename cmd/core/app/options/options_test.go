@@ -94,6 +94,12 @@ func TestNewCoreOptions_DefaultValues(t *testing.T) {
 	// Test Resource defaults
 	assert.Equal(t, 10, opt.Resource.MaxDispatchConcurrent)
 
+	// Test CloudEvents defaults
+	assert.Equal(t, "", opt.CloudEvents.SinkURL)
+	assert.Equal(t, "structured", opt.CloudEvents.Mode)
+	assert.Equal(t, 3, opt.CloudEvents.Retries)
+	assert.Equal(t, time.Second, opt.CloudEvents.Backoff)
+
 	// Ensure all config modules are initialized
 	assert.NotNil(t, opt.Admission)
 	assert.NotNil(t, opt.Client)
@@ -103,6 +109,7 @@ func TestNewCoreOptions_DefaultValues(t *testing.T) {
 	assert.NotNil(t, opt.Profiling)
 	assert.NotNil(t, opt.KLog)
 	assert.NotNil(t, opt.Controller)
+	assert.NotNil(t, opt.CloudEvents)
 }
 
 func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
@@ -142,6 +149,8 @@ func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
 		// CUE flags
 		"--enable-external-package-for-default-compiler=true",
 		"--enable-external-package-watch-for-default-compiler=true",
+		"--helm-chart-cache-dir=/var/cache/kubevela/charts",
+		"--helm-chart-cache-max-size=536870912",
 		// Application flags
 		"--application-re-sync-period=5s",
 		// OAM flags
@@ -162,6 +171,11 @@ func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
 		"--max-workflow-step-error-retry-times=5",
 		// Resource flags
 		"--max-dispatch-concurrent=5",
+		// CloudEvents flags
+		"--cloudevents-sink-url=https://events.example.com",
+		"--cloudevents-mode=binary",
+		"--cloudevents-retries=5",
+		"--cloudevents-backoff=2s",
 	}
 
 	err := fs.Parse(args)
@@ -200,6 +214,8 @@ func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
 	// Verify CUE flags
 	assert.True(t, opt.CUE.EnableExternalPackage)
 	assert.True(t, opt.CUE.EnableExternalPackageWatch)
+	assert.Equal(t, "/var/cache/kubevela/charts", opt.CUE.HelmChartCacheDir)
+	assert.Equal(t, int64(536870912), opt.CUE.HelmChartCacheMaxSize)
 
 	// Verify Application flags
 	assert.Equal(t, 5*time.Second, opt.Application.ReSyncPeriod)
@@ -226,6 +242,12 @@ func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
 
 	// Verify Resource flags
 	assert.Equal(t, 5, opt.Resource.MaxDispatchConcurrent)
+
+	// Verify CloudEvents flags
+	assert.Equal(t, "https://events.example.com", opt.CloudEvents.SinkURL)
+	assert.Equal(t, "binary", opt.CloudEvents.Mode)
+	assert.Equal(t, 5, opt.CloudEvents.Retries)
+	assert.Equal(t, 2*time.Second, opt.CloudEvents.Backoff)
 }
 
 func TestCuexOptions_SyncToGlobals(t *testing.T) {
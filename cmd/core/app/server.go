@@ -186,6 +186,12 @@ func run(ctx context.Context, coreOptions *options.CoreOptions) error {
 		return fmt.Errorf("failed to start application monitor: %w", err)
 	}
 
+	// Start application cloudevents watcher
+	if err := startApplicationEventsWatcher(ctx, manager, coreOptions.CloudEvents); err != nil {
+		klog.ErrorS(err, "Failed to start application cloudevents watcher")
+		return fmt.Errorf("failed to start application cloudevents watcher: %w", err)
+	}
+
 	// Start the manager
 	klog.InfoS("Starting controller manager")
 	if err := manager.Start(ctx); err != nil {
@@ -403,6 +409,24 @@ func startApplicationMonitor(ctx context.Context, manager ctrl.Manager) error {
 	return nil
 }
 
+// startApplicationEventsWatcher emits the lifecycle events of applications to the cloudevents sink if configured.
+// The watcher only runs in the leader so that each event is emitted once.
+func startApplicationEventsWatcher(ctx context.Context, mgr ctrl.Manager, cfg *config.CloudEventsConfig) error {
+	sender, err := cfg.NewSender()
+	if err != nil || sender == nil {
+		return err
+	}
+	klog.InfoS("Starting vela application cloudevents watcher", "sink", cfg.SinkURL, "mode", cfg.Mode)
+	applicationInformer, err := mgr.GetCache().GetInformer(ctx, &v1beta1.Application{})
+	if err != nil {
+		klog.ErrorS(err, "Unable to get informer for application")
+		return err
+	}
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return watcher.RunApplicationEventsWatcher(ctx, applicationInformer, sender)
+	}))
+}
+
 // performCleanup handles any necessary cleanup operations
 func performCleanup(coreOptions *options.CoreOptions) {
 	klog.V(2).InfoS("Performing cleanup operations")
@@ -193,6 +193,14 @@ type Appfile struct {
 	Debug bool
 }
 
+// PlacementFailovers returns the failover status of the topology policies recorded in the application
+func (af *Appfile) PlacementFailovers() []common.PlacementFailoverStatus {
+	if af.app == nil {
+		return nil
+	}
+	return af.app.Status.PlacementFailovers
+}
+
 // GeneratePolicyManifests generates policy manifests from an appFile
 // internal policies like apply-once, topology, will not render manifests
 func (af *Appfile) GeneratePolicyManifests(ctx context.Context, cli client.Client) ([]*unstructured.Unstructured, error) {
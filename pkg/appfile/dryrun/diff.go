@@ -58,6 +58,10 @@ const (
 	PolicyKind        ManifestKind = "Policy"
 	WorkflowKind      ManifestKind = "Workflow"
 	ReferredObject    ManifestKind = "ReferredObject"
+	// DesiredStateKind is the diff from the last-applied state to the desired state of a resource
+	DesiredStateKind ManifestKind = "DesiredState"
+	// LiveStateKind is the diff from the last-applied state to the live state of a resource
+	LiveStateKind ManifestKind = "LiveState"
 )
 
 // DiffEntry records diff info of OAM object
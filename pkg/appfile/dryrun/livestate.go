@@ -0,0 +1,343 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/aryann/difflib"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/resourcetracker"
+)
+
+// liveStateObject holds the three states of one resource for the three-way live diff
+type liveStateObject struct {
+	component   string
+	displayName string
+	desired     *unstructured.Unstructured
+	lastApplied *unstructured.Unstructured
+	live        *unstructured.Unstructured
+}
+
+// DiffLiveState calculates the three-way diff between the rendered desired state, the
+// last-applied state and the live state in the target clusters for the resources of the
+// application. The resources are the ones recorded in the current ResourceTracker of the
+// application plus the ones rendered from the application, the live state is fetched from
+// each cluster through the cluster-gateway.
+//
+// The returned entry is an Application entry whose subs are the components. Each resource
+// of the component has a DesiredStateKind entry, which is the diff from the last-applied
+// state to the desired state, and a LiveStateKind entry, which is the diff from the
+// last-applied state to the live state, a.k.a. the drift.
+func (l *LiveDiffOption) DiffLiveState(ctx context.Context, app *v1beta1.Application) (*DiffEntry, error) {
+	opt, ok := l.DryRun.(*Option)
+	if !ok {
+		return nil, errors.New("live state diff is only supported with the default dry-run option")
+	}
+	if app.Namespace == "" {
+		app.Namespace = corev1.NamespaceDefault
+	}
+	objs, keys, err := l.loadDesiredState(ctx, opt, app)
+	if err != nil {
+		return nil, err
+	}
+	if err = l.loadAppliedState(ctx, opt, app, objs, &keys); err != nil {
+		return nil, err
+	}
+
+	filterLabels, filterAnnotations := liveStateFilterKeys(app)
+	root := &DiffEntry{Name: app.Name, Kind: AppKind}
+	comps := map[string]*DiffEntry{}
+	for _, key := range keys {
+		obj := objs[key]
+		lastApplied := normalizeLiveStateObject(obj.lastApplied, filterLabels, filterAnnotations)
+		desired := normalizeLiveStateObject(obj.desired, filterLabels, filterAnnotations)
+		live := normalizeLiveStateObject(obj.live, filterLabels, filterAnnotations)
+		// server defaulted fields only exist in the live state, compare the fields managed
+		// by KubeVela only
+		switch {
+		case lastApplied != nil:
+			live = pruneToShape(live, lastApplied)
+		case desired != nil:
+			live = pruneToShape(live, desired)
+		}
+		entries := []*DiffEntry{
+			newLiveStateEntry(obj.displayName, DesiredStateKind, lastApplied, desired),
+			newLiveStateEntry(obj.displayName, LiveStateKind, lastApplied, live),
+		}
+		parent := root
+		if obj.component != "" {
+			if parent = comps[obj.component]; parent == nil {
+				parent = &DiffEntry{Name: obj.component, Kind: AppConfigCompKind}
+				comps[obj.component] = parent
+				root.Subs = append(root.Subs, parent)
+			}
+		}
+		for _, entry := range entries {
+			if entry.DiffType != NoDiff {
+				root.DiffType = ModifyDiff
+			}
+			parent.Subs = append(parent.Subs, entry)
+		}
+	}
+	return root, nil
+}
+
+// loadDesiredState renders the desired state of the application by applying its
+// Application-scoped policies and executing its workflow in dry-run mode, the same as
+// the controller does
+func (l *LiveDiffOption) loadDesiredState(ctx context.Context, opt *Option, app *v1beta1.Application) (map[string]*liveStateObject, []string, error) {
+	result, policyErrs, err := opt.ExecuteWorkflowDryRunWithPolicies(ctx, app)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the controller refuses to reconcile the application if its policies cannot be applied
+	if len(policyErrs) > 0 {
+		return nil, nil, errors.Errorf("failed to apply application-scoped policies: %s", strings.Join(policyErrs, "; "))
+	}
+	objs := map[string]*liveStateObject{}
+	var keys []string
+	var collect func(steps []*StepDryRunResult) error
+	collect = func(steps []*StepDryRunResult) error {
+		for _, step := range steps {
+			if step.Phase == StepPhaseFailed {
+				return errors.Errorf("failed to render desired state in step %s: %s", step.Name, step.Message)
+			}
+			for _, m := range step.Manifests {
+				for _, o := range m.Objects {
+					mr := v1beta1.ManagedResource{ClusterObjectReference: oamClusterObjectReference(o, m.Cluster)}
+					key := mr.ResourceKey()
+					if _, found := objs[key]; !found {
+						keys = append(keys, key)
+					}
+					objs[key] = &liveStateObject{component: m.Component, displayName: mr.DisplayName(), desired: o}
+				}
+			}
+			if err := collect(step.SubSteps); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err = collect(result.Steps); err != nil {
+		return nil, nil, err
+	}
+	return objs, keys, nil
+}
+
+// loadAppliedState loads the last-applied and live state of the resources recorded in the
+// current ResourceTracker of the application
+func (l *LiveDiffOption) loadAppliedState(ctx context.Context, opt *Option, app *v1beta1.Application, objs map[string]*liveStateObject, keys *[]string) error {
+	_, currentRT, _, _, err := resourcetracker.ListApplicationResourceTrackers(ctx, opt.Client, app)
+	if err != nil {
+		return errors.WithMessage(err, "cannot list resource trackers of the application")
+	}
+	if currentRT == nil {
+		return nil
+	}
+	for _, mr := range currentRT.Spec.ManagedResources {
+		if mr.Deleted {
+			continue
+		}
+		key := mr.ResourceKey()
+		obj, found := objs[key]
+		if !found {
+			obj = &liveStateObject{component: mr.Component, displayName: mr.DisplayName()}
+			objs[key] = obj
+			*keys = append(*keys, key)
+		}
+		if obj.component == "" {
+			obj.component = mr.Component
+		}
+		live := mr.ToUnstructured()
+		if err = opt.Client.Get(multicluster.ContextWithClusterName(ctx, mr.Cluster), mr.NamespacedName(), live); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return errors.Wrapf(err, "cannot get live state of %s", mr.DisplayName())
+			}
+			live = nil
+		}
+		obj.live = live
+		if lastApplied, err := mr.ToUnstructuredWithData(); err == nil && mr.Data != nil {
+			obj.lastApplied = lastApplied
+		} else if live != nil {
+			obj.lastApplied = lastAppliedFromAnnotation(live)
+		}
+	}
+	return nil
+}
+
+func oamClusterObjectReference(obj *unstructured.Unstructured, cluster string) v1beta1.ClusterObjectReference {
+	if c := oam.GetCluster(obj); c != "" {
+		cluster = c
+	}
+	if cluster == velatypes.ClusterLocalName {
+		cluster = ""
+	}
+	ref := v1beta1.ClusterObjectReference{Cluster: cluster}
+	ref.APIVersion = obj.GetAPIVersion()
+	ref.Kind = obj.GetKind()
+	ref.Name = obj.GetName()
+	ref.Namespace = obj.GetNamespace()
+	return ref
+}
+
+// lastAppliedFromAnnotation restores the last-applied state from the annotation recorded by
+// the three-way merge patch of KubeVela or kubectl
+func lastAppliedFromAnnotation(live *unstructured.Unstructured) *unstructured.Unstructured {
+	annotations := live.GetAnnotations()
+	raw, ok := annotations[oam.AnnotationLastAppliedConfig]
+	if !ok || raw == "-" || raw == "skip" {
+		raw = annotations[corev1.LastAppliedConfigAnnotation]
+	}
+	if raw == "" || raw == "-" || raw == "skip" {
+		return nil
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal([]byte(raw), &obj.Object); err != nil {
+		return nil
+	}
+	return obj
+}
+
+// liveStateFilterKeys returns the label and annotation keys which should be ignored in the
+// live state diff, including the ones specified by the filter.oam.dev annotations of the app
+func liveStateFilterKeys(app *v1beta1.Application) (labels []string, annotations []string) {
+	labels = []string{oam.LabelAppRevision, oam.LabelAppCluster}
+	annotations = []string{
+		oam.AnnotationKubeVelaVersion,
+		oam.AnnotationAppRevision,
+		oam.AnnotationLastAppliedConfig,
+		oam.AnnotationLastAppliedTime,
+		corev1.LastAppliedConfigAnnotation,
+	}
+	appAnnotations := app.GetAnnotations()
+	split := func(s string) (keys []string) {
+		for _, key := range strings.Split(s, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		return keys
+	}
+	labels = append(labels, split(appAnnotations[oam.AnnotationFilterLabelKeys])...)
+	annotations = append(annotations, split(appAnnotations[oam.AnnotationFilterAnnotationKeys])...)
+	return labels, annotations
+}
+
+// normalizeLiveStateObject removes the server-populated fields, the status and the filtered
+// labels and annotations of the object
+func normalizeLiveStateObject(obj *unstructured.Unstructured, filterLabels, filterAnnotations []string) map[string]interface{} {
+	if obj == nil {
+		return nil
+	}
+	o := obj.DeepCopy()
+	labels, annotations := o.GetLabels(), o.GetAnnotations()
+	for _, key := range filterLabels {
+		delete(labels, key)
+	}
+	for _, key := range filterAnnotations {
+		delete(annotations, key)
+	}
+	metadata := map[string]interface{}{"name": o.GetName()}
+	if o.GetNamespace() != "" {
+		metadata["namespace"] = o.GetNamespace()
+	}
+	if len(labels) > 0 {
+		metadata["labels"] = stringMapToInterface(labels)
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = stringMapToInterface(annotations)
+	}
+	o.Object["metadata"] = metadata
+	delete(o.Object, "status")
+	return o.Object
+}
+
+func stringMapToInterface(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// pruneToShape removes the fields in obj which are not present in ref, so that the fields
+// defaulted by the server or set by other controllers are not treated as drift
+func pruneToShape(obj map[string]interface{}, ref map[string]interface{}) map[string]interface{} {
+	if obj == nil {
+		return nil
+	}
+	pruned, _ := pruneValueToShape(obj, ref).(map[string]interface{})
+	return pruned
+}
+
+func pruneValueToShape(val interface{}, ref interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		r, ok := ref.(map[string]interface{})
+		if !ok {
+			return val
+		}
+		out := map[string]interface{}{}
+		for k, sub := range v {
+			if refSub, found := r[k]; found {
+				out[k] = pruneValueToShape(sub, refSub)
+			}
+		}
+		return out
+	case []interface{}:
+		r, ok := ref.([]interface{})
+		if !ok || len(r) != len(v) {
+			return val
+		}
+		out := make([]interface{}, len(v))
+		for i := range v {
+			out[i] = pruneValueToShape(v[i], r[i])
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+func newLiveStateEntry(name string, kind ManifestKind, base, target map[string]interface{}) *DiffEntry {
+	toLines := func(obj map[string]interface{}) []string {
+		if obj == nil {
+			return []string{""}
+		}
+		bs, err := yaml.Marshal(obj)
+		if err != nil {
+			return []string{""}
+		}
+		return strings.Split(string(bs), "\n")
+	}
+	entry := &DiffEntry{Name: name, Kind: kind}
+	entry.Diffs = difflib.Diff(toLines(base), toLines(target))
+	entry.DiffType = calDiffType(entry.Diffs)
+	return entry
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	oamutil "github.com/oam-dev/kubevela/pkg/oam/util"
+	utilcommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestDiffLiveState(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	cd, err := oamutil.UnMarshalStringToComponentDefinition(readDataFromFile("./testdata/cd-myworker.yaml"))
+	r.NoError(err)
+	cdObj, err := oamutil.Object2Unstructured(cd)
+	r.NoError(err)
+	wfsd := &v1beta1.WorkflowStepDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(readDataFromFile("./testdata/wd-deploy.yaml")), wfsd))
+	wfsd.SetNamespace(types.DefaultKubeVelaNS)
+
+	app := &v1beta1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "live-app", Namespace: "default"},
+		Spec: v1beta1.ApplicationSpec{
+			Components: []common.ApplicationComponent{{
+				Name:       "myweb",
+				Type:       "myworker",
+				Properties: &runtime.RawExtension{Raw: []byte(`{"image":"nginx"}`)},
+			}},
+		},
+	}
+
+	// render the desired state first and treat it as the last-applied state
+	cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).WithObjects(wfsd).Build()
+	result, err := NewDryRunOption(cli, nil, []*unstructured.Unstructured{cdObj}, false).ExecuteWorkflowDryRun(ctx, app)
+	r.NoError(err)
+	r.Len(result.Steps, 1)
+	r.Len(result.Steps[0].Manifests, 1)
+	lastApplied := result.Steps[0].Manifests[0].Objects[0].DeepCopy()
+	lastAppliedRaw, err := json.Marshal(lastApplied.Object)
+	r.NoError(err)
+
+	// the live deployment is modified in the cluster and defaulted by the server
+	live := lastApplied.DeepCopy()
+	r.NoError(unstructured.SetNestedSlice(live.Object, []interface{}{map[string]interface{}{"name": "myweb", "image": "busybox"}}, "spec", "template", "spec", "containers"))
+	r.NoError(unstructured.SetNestedField(live.Object, int64(1), "spec", "replicas"))
+	r.NoError(unstructured.SetNestedField(live.Object, int64(1), "status", "readyReplicas"))
+	live.SetUID("uid")
+
+	// the stale configmap is not rendered by the application anymore
+	stale := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "stale",
+			Namespace:   "default",
+			Annotations: map[string]string{oam.AnnotationLastAppliedConfig: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"stale","namespace":"default"},"data":{"key":"value"}}`},
+		},
+		Data: map[string]string{"key": "value"},
+	}
+	rt := &v1beta1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "live-app-v1-default",
+			Labels: map[string]string{oam.LabelAppName: "live-app", oam.LabelAppNamespace: "default"},
+		},
+		Spec: v1beta1.ResourceTrackerSpec{
+			Type: v1beta1.ResourceTrackerTypeVersioned,
+			ManagedResources: []v1beta1.ManagedResource{{
+				ClusterObjectReference: common.ClusterObjectReference{ObjectReference: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "myweb"}},
+				OAMObjectReference:     common.OAMObjectReference{Component: "myweb"},
+				Data:                   &runtime.RawExtension{Raw: lastAppliedRaw},
+			}, {
+				ClusterObjectReference: common.ClusterObjectReference{ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "stale"}},
+				OAMObjectReference:     common.OAMObjectReference{Component: "myweb"},
+			}},
+		},
+	}
+	cli = fake.NewClientBuilder().WithScheme(utilcommon.Scheme).WithObjects(wfsd, rt, stale, live).Build()
+	opt := &LiveDiffOption{DryRun: NewDryRunOption(cli, nil, []*unstructured.Unstructured{cdObj}, false)}
+	diff, err := opt.DiffLiveState(ctx, app)
+	r.NoError(err)
+	r.Equal(AppKind, diff.Kind)
+	r.Equal(ModifyDiff, diff.DiffType)
+	r.Len(diff.Subs, 1)
+	comp := diff.Subs[0]
+	r.Equal("myweb", comp.Name)
+	r.Len(comp.Subs, 4)
+
+	entries := map[string]*DiffEntry{}
+	for _, sub := range comp.Subs {
+		entries[string(sub.Kind)+"/"+sub.Name] = sub
+	}
+	deploy := "Deployment myweb (Namespace: default)"
+	cm := "ConfigMap stale (Namespace: default)"
+	r.Equal(NoDiff, entries[string(DesiredStateKind)+"/"+deploy].DiffType)
+	r.Equal(ModifyDiff, entries[string(LiveStateKind)+"/"+deploy].DiffType)
+	r.Equal(RemoveDiff, entries[string(DesiredStateKind)+"/"+cm].DiffType)
+	r.Equal(NoDiff, entries[string(LiveStateKind)+"/"+cm].DiffType)
+
+	buff := &bytes.Buffer{}
+	NewReportDiffOption(-1, buff).PrintDiffReport(diff)
+	r.Contains(buff.String(), "* Component (myweb) / Live State (Deployment myweb (Namespace: default)) has been modified(*)")
+	r.Contains(buff.String(), "image: busybox")
+	r.NotContains(buff.String(), "replicas")
+}
+
+func TestLiveStateFilterKeys(t *testing.T) {
+	r := require.New(t)
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		oam.AnnotationFilterLabelKeys:      "team, owner",
+		oam.AnnotationFilterAnnotationKeys: "note",
+	}}}
+	labels, annotations := liveStateFilterKeys(app)
+	r.Contains(labels, "team")
+	r.Contains(labels, "owner")
+	r.Contains(labels, oam.LabelAppRevision)
+	r.Contains(annotations, "note")
+	r.Contains(annotations, oam.AnnotationLastAppliedConfig)
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "cm",
+			"resourceVersion": "1",
+			"labels":          map[string]interface{}{"team": "a", "app": "b"},
+			"annotations":     map[string]interface{}{"note": "c"},
+		},
+		"status": map[string]interface{}{"phase": "Ready"},
+	}}
+	normalized := normalizeLiveStateObject(obj, labels, annotations)
+	r.Equal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":   "cm",
+			"labels": map[string]interface{}{"app": "b"},
+		},
+	}, normalized)
+}
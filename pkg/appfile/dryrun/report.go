@@ -17,12 +17,17 @@ limitations under the License.
 package dryrun
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
+	"strings"
 
 	"github.com/aryann/difflib"
 	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -75,6 +80,10 @@ func (r *ReportDiffOption) printDiffReport(diff *DiffEntry, prefix string) {
 		header = "External Workflow"
 	case ReferredObject:
 		header = "Referred Object"
+	case DesiredStateKind:
+		header = "Desired State"
+	case LiveStateKind:
+		header = "Live State"
 	default:
 		return
 	}
@@ -89,7 +98,7 @@ func (r *ReportDiffOption) printDiffReport(diff *DiffEntry, prefix string) {
 	}
 	for _, sub := range diff.Subs {
 		var subPrefix string
-		if sub.Kind == TraitKind && diff.Kind == AppConfigCompKind {
+		if diff.Kind == AppConfigCompKind && (sub.Kind == TraitKind || sub.Kind == DesiredStateKind || sub.Kind == LiveStateKind) {
 			subPrefix = fmt.Sprintf("Component (%s) / ", diff.Name)
 		}
 		r.printDiffReport(sub, subPrefix)
@@ -164,3 +173,187 @@ func printDiffRecord(to io.Writer, diff difflib.DiffRecord) {
 		_, _ = fmt.Fprintf(to, "  %s\n", data)
 	}
 }
+
+// ReportFormat is the output format of the diff report
+type ReportFormat string
+
+// enum formats of diff report
+const (
+	// TextReport prints the coloured unified diff
+	TextReport ReportFormat = "text"
+	// JSONReport prints the structured diff report in json
+	JSONReport ReportFormat = "json"
+	// YAMLReport prints the structured diff report in yaml
+	YAMLReport ReportFormat = "yaml"
+	// JSONPatchReport prints the structured diff report in json, with the change of
+	// each resource described as RFC 6902 JSON Patch operations
+	JSONPatchReport ReportFormat = "json-patch"
+)
+
+// DiffReport is the machine-readable diff report
+type DiffReport struct {
+	Summary   DiffSummary     `json:"summary"`
+	Resources []*ResourceDiff `json:"resources"`
+}
+
+// DiffSummary counts the resources by their diff type
+type DiffSummary struct {
+	Added     int `json:"added"`
+	Modified  int `json:"modified"`
+	Removed   int `json:"removed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// ResourceDiff is the diff of one resource in the diff report
+type ResourceDiff struct {
+	Kind ManifestKind `json:"kind"`
+	Name string       `json:"name"`
+	// Component is set for the rendered resources of a component
+	Component string `json:"component,omitempty"`
+	// DiffType is one of added, modified, removed and unchanged
+	DiffType string `json:"diffType"`
+	// Diff is the unified diff lines, only set for json and yaml format
+	Diff []string `json:"diff,omitempty"`
+	// Patch is the JSON Patch transforming the old resource into the new one,
+	// only set for json-patch format
+	Patch []jsonpatch.Operation `json:"patch,omitempty"`
+}
+
+var diffTypeNames = map[DiffType]string{
+	AddDiff:    "added",
+	ModifyDiff: "modified",
+	RemoveDiff: "removed",
+	NoDiff:     "unchanged",
+}
+
+// PrintDiffReportWithFormat prints the diff data into target io.Writer in the given format
+func (r *ReportDiffOption) PrintDiffReportWithFormat(diff *DiffEntry, format ReportFormat) error {
+	switch format {
+	case TextReport, "":
+		r.PrintDiffReport(diff)
+		return nil
+	case JSONReport, YAMLReport, JSONPatchReport:
+	default:
+		return errors.Errorf("unsupported diff report format %s", format)
+	}
+	report, err := GenerateDiffReport(diff, format == JSONPatchReport)
+	if err != nil {
+		return err
+	}
+	var bs []byte
+	if format == YAMLReport {
+		bs, err = yaml.Marshal(report)
+	} else {
+		bs, err = json.MarshalIndent(report, "", "  ")
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal diff report")
+	}
+	_, err = fmt.Fprintln(r.To, string(bs))
+	return err
+}
+
+// GenerateDiffReport flattens the diff entries into a machine-readable report. If withPatch
+// is set, the change of each resource is converted into JSON Patch operations instead of
+// unified diff lines.
+func GenerateDiffReport(diff *DiffEntry, withPatch bool) (*DiffReport, error) {
+	report := &DiffReport{Resources: []*ResourceDiff{}}
+	if err := report.add(diff, "", withPatch); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (report *DiffReport) add(diff *DiffEntry, component string, withPatch bool) error {
+	switch diff.Kind {
+	case AppConfigCompKind:
+		// component is only a group of its rendered resources
+		for _, sub := range diff.Subs {
+			if err := report.add(sub, diff.Name, withPatch); err != nil {
+				return err
+			}
+		}
+		return nil
+	case AppKind, RawCompKind, TraitKind, PolicyKind, WorkflowKind, ReferredObject, DesiredStateKind, LiveStateKind:
+	default:
+		return nil
+	}
+	res := &ResourceDiff{Kind: diff.Kind, Name: diff.Name, Component: component, DiffType: diffTypeNames[diff.DiffType]}
+	switch diff.DiffType {
+	case AddDiff:
+		report.Summary.Added++
+	case ModifyDiff:
+		report.Summary.Modified++
+	case RemoveDiff:
+		report.Summary.Removed++
+	default:
+		report.Summary.Unchanged++
+	}
+	if diff.DiffType != NoDiff {
+		if withPatch {
+			patch, err := diffRecordsToJSONPatch(diff.Diffs)
+			if err != nil {
+				return errors.WithMessagef(err, "cannot generate json patch for %s %s", diff.Kind, diff.Name)
+			}
+			res.Patch = patch
+		} else {
+			for _, d := range diff.Diffs {
+				res.Diff = append(res.Diff, formatDiffRecord(d))
+			}
+		}
+	}
+	report.Resources = append(report.Resources, res)
+	for _, sub := range diff.Subs {
+		if err := report.add(sub, component, withPatch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffRecordsToJSONPatch restores the old and new yaml documents from the line diff and
+// calculates the JSON Patch between them
+func diffRecordsToJSONPatch(diffs []difflib.DiffRecord) ([]jsonpatch.Operation, error) {
+	var oldLines, newLines []string
+	for _, d := range diffs {
+		switch d.Delta {
+		case difflib.Common:
+			oldLines = append(oldLines, d.Payload)
+			newLines = append(newLines, d.Payload)
+		case difflib.LeftOnly:
+			oldLines = append(oldLines, d.Payload)
+		case difflib.RightOnly:
+			newLines = append(newLines, d.Payload)
+		}
+	}
+	toJSON := func(lines []string) ([]byte, error) {
+		bs, err := yaml.YAMLToJSON([]byte(strings.Join(lines, "\n")))
+		if err != nil {
+			return nil, err
+		}
+		if string(bs) == "null" {
+			return []byte("{}"), nil
+		}
+		return bs, nil
+	}
+	oldJSON, err := toJSON(oldLines)
+	if err != nil {
+		return nil, err
+	}
+	newJSON, err := toJSON(newLines)
+	if err != nil {
+		return nil, err
+	}
+	return jsonpatch.CreatePatch(oldJSON, newJSON)
+}
+
+func formatDiffRecord(diff difflib.DiffRecord) string {
+	switch diff.Delta {
+	case difflib.RightOnly:
+		return "+ " + diff.Payload
+	case difflib.LeftOnly:
+		return "- " + diff.Payload
+	default:
+		return "  " + diff.Payload
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aryann/difflib"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func testDiffEntry() *DiffEntry {
+	lines := func(s string) []string { return []string{s, ""} }
+	return &DiffEntry{
+		Name: "app",
+		Kind: AppKind,
+		Subs: []*DiffEntry{{
+			Name: "web",
+			Kind: AppConfigCompKind,
+			Subs: []*DiffEntry{{
+				Name:     "web",
+				Kind:     RawCompKind,
+				DiffType: ModifyDiff,
+				Diffs: difflib.Diff(
+					[]string{"kind: Deployment", "spec:", "  replicas: 1", ""},
+					[]string{"kind: Deployment", "spec:", "  replicas: 3", ""}),
+			}, {
+				Name:     "scaler/scaler",
+				Kind:     TraitKind,
+				DiffType: RemoveDiff,
+				Diffs:    difflib.Diff(lines("kind: HPA"), []string{""}),
+			}, {
+				Name:     "gateway/ingress",
+				Kind:     TraitKind,
+				DiffType: AddDiff,
+				Diffs:    difflib.Diff([]string{""}, lines("kind: Ingress")),
+			}},
+		}},
+	}
+}
+
+func TestGenerateDiffReport(t *testing.T) {
+	r := require.New(t)
+	report, err := GenerateDiffReport(testDiffEntry(), false)
+	r.NoError(err)
+	r.Equal(DiffSummary{Added: 1, Modified: 1, Removed: 1, Unchanged: 1}, report.Summary)
+	r.Len(report.Resources, 4)
+	r.Equal("unchanged", report.Resources[0].DiffType)
+	r.Empty(report.Resources[0].Diff)
+	r.Equal("web", report.Resources[1].Component)
+	r.Equal("modified", report.Resources[1].DiffType)
+	r.Contains(report.Resources[1].Diff, "-   replicas: 1")
+	r.Contains(report.Resources[1].Diff, "+   replicas: 3")
+	r.Nil(report.Resources[1].Patch)
+
+	report, err = GenerateDiffReport(testDiffEntry(), true)
+	r.NoError(err)
+	r.Empty(report.Resources[1].Diff)
+	r.Len(report.Resources[1].Patch, 1)
+	r.Equal("replace", report.Resources[1].Patch[0].Operation)
+	r.Equal("/spec/replicas", report.Resources[1].Patch[0].Path)
+	r.EqualValues(3, report.Resources[1].Patch[0].Value)
+	r.Equal("remove", report.Resources[2].Patch[0].Operation)
+	r.Equal("/kind", report.Resources[2].Patch[0].Path)
+	r.Equal("add", report.Resources[3].Patch[0].Operation)
+}
+
+func TestPrintDiffReportWithFormat(t *testing.T) {
+	r := require.New(t)
+	buff := &bytes.Buffer{}
+	opt := NewReportDiffOption(-1, buff)
+	r.NoError(opt.PrintDiffReportWithFormat(testDiffEntry(), JSONPatchReport))
+	report := &DiffReport{}
+	r.NoError(json.Unmarshal(buff.Bytes(), report))
+	r.Equal(1, report.Summary.Removed)
+
+	buff.Reset()
+	r.NoError(opt.PrintDiffReportWithFormat(testDiffEntry(), YAMLReport))
+	report = &DiffReport{}
+	r.NoError(yaml.Unmarshal(buff.Bytes(), report))
+	r.Equal(1, report.Summary.Added)
+
+	buff.Reset()
+	r.NoError(opt.PrintDiffReportWithFormat(testDiffEntry(), TextReport))
+	r.Contains(buff.String(), "Component (web) / Trait (scaler/scaler) has been removed(-)")
+
+	r.ErrorContains(opt.PrintDiffReportWithFormat(testDiffEntry(), "xml"), "unsupported diff report format")
+}
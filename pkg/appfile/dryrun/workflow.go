@@ -0,0 +1,428 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"cuelang.org/go/cue"
+	wfTypesv1alpha1 "github.com/kubevela/pkg/apis/oam/v1alpha1"
+	wfTypes "github.com/kubevela/workflow/pkg/types"
+	clusterv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	clustercommon "github.com/oam-dev/cluster-gateway/pkg/common"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/appfile"
+	velaapplication "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/application"
+	velaprocess "github.com/oam-dev/kubevela/pkg/cue/process"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	oamutil "github.com/oam-dev/kubevela/pkg/oam/util"
+	multiclusterprovider "github.com/oam-dev/kubevela/pkg/workflow/providers/multicluster"
+	"github.com/oam-dev/kubevela/pkg/workflow/step"
+)
+
+const (
+	// StepPhaseSucceeded means the step is simulated and all its resources are rendered
+	StepPhaseSucceeded = "succeeded"
+	// StepPhaseFailed means the step failed to be simulated
+	StepPhaseFailed = "failed"
+	// StepPhaseSkipped means the step cannot be simulated offline and is ignored
+	StepPhaseSkipped = "skipped"
+
+	// maxDeploySimulationRounds limits how many times a deploy step is re-run to resolve
+	// the dependsOn and inputs/outputs among components, the same as the workflow
+	// re-executes the step until all components are healthy.
+	maxDeploySimulationRounds = 10
+	// defaultDeployParallelism is the default parallelism of the deploy step definition
+	defaultDeployParallelism = 5
+)
+
+// ClusterInventory describes the clusters used to simulate multicluster placement
+// in the workflow dry-run. The local cluster is always available.
+type ClusterInventory struct {
+	Clusters []InventoryCluster `json:"clusters"`
+}
+
+// InventoryCluster is one cluster in the ClusterInventory
+type InventoryCluster struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// LoadClusterInventory reads the cluster inventory from a yaml or json file
+func LoadClusterInventory(path string) (*ClusterInventory, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	inventory := &ClusterInventory{}
+	if err = yaml.Unmarshal(content, inventory); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse cluster inventory %s", path)
+	}
+	for _, cluster := range inventory.Clusters {
+		if cluster.Name == "" {
+			return nil, errors.Errorf("cluster inventory %s contains cluster without name", path)
+		}
+		if cluster.Name == multicluster.ClusterLocalName {
+			return nil, errors.Errorf("cluster name %s is reserved for the hub cluster", multicluster.ClusterLocalName)
+		}
+	}
+	return inventory, nil
+}
+
+// ToObjects converts the inventory into cluster secrets, so that they can be preloaded
+// into a fake client and be resolved by topology policies as joined clusters.
+func (in *ClusterInventory) ToObjects() ([]*unstructured.Unstructured, error) {
+	if in == nil {
+		return nil, nil
+	}
+	var objs []*unstructured.Unstructured
+	for _, cluster := range in.Clusters {
+		labels := map[string]string{
+			clustercommon.LabelKeyClusterEndpointType:   string(clusterv1alpha1.ClusterEndpointTypeConst),
+			clustercommon.LabelKeyClusterCredentialType: string(clusterv1alpha1.CredentialTypeX509Certificate),
+		}
+		for k, v := range cluster.Labels {
+			labels[k] = v
+		}
+		secret := &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.Name,
+				Namespace: multicluster.ClusterGatewaySecretNamespace,
+				Labels:    labels,
+			},
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(secret)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: content})
+	}
+	return objs, nil
+}
+
+// WorkflowDryRunResult is the result of simulating the workflow of an application
+type WorkflowDryRunResult struct {
+	AppName string
+	Steps   []*StepDryRunResult
+}
+
+// StepDryRunResult is the simulated result of one workflow step
+type StepDryRunResult struct {
+	Name      string
+	Type      string
+	Phase     string
+	Message   string
+	Manifests []*ClusterManifest
+	SubSteps  []*StepDryRunResult
+}
+
+// ClusterManifest contains the resources rendered for one component in one placement
+type ClusterManifest struct {
+	Cluster    string
+	Namespace  string
+	Component  string
+	ReplicaKey string
+	Objects    []*unstructured.Unstructured
+}
+
+// ExecuteWorkflowDryRun simulates the workflow of an application. Application-scoped policy
+// transforms should be applied to the application before calling it. Deploy steps are executed
+// by the same executor as the controller, with topology, override and replication policies
+// evaluated against the clusters known by the client, while resources are rendered instead
+// of being dispatched. Steps that need a running control plane are reported as skipped.
+func (d *Option) ExecuteWorkflowDryRun(ctx context.Context, application *v1beta1.Application) (*WorkflowDryRunResult, error) {
+	app := application.DeepCopy()
+	if app.Namespace == "" {
+		app.Namespace = corev1.NamespaceDefault
+	}
+	ctx = oamutil.SetNamespaceInCtx(ctx, app.Namespace)
+	af, err := d.GenerateAppFile(ctx, app)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot generate appFile from application")
+	}
+	if af.Namespace == "" {
+		af.Namespace = corev1.NamespaceDefault
+	}
+	sim := &workflowSimulator{option: d, af: af}
+	result := &WorkflowDryRunResult{AppName: app.Name}
+	for _, wfStep := range af.WorkflowSteps {
+		stepResult := &StepDryRunResult{Name: wfStep.Name, Type: wfStep.Type}
+		if wfStep.Type == wfTypes.WorkflowStepTypeStepGroup {
+			stepResult.Phase = StepPhaseSucceeded
+			for _, sub := range wfStep.SubSteps {
+				subResult := sim.executeStep(ctx, sub)
+				if subResult.Phase == StepPhaseFailed {
+					stepResult.Phase = StepPhaseFailed
+					stepResult.Message = fmt.Sprintf("sub step %s failed", sub.Name)
+				}
+				stepResult.SubSteps = append(stepResult.SubSteps, subResult)
+			}
+		} else {
+			stepResult = sim.executeStep(ctx, wfStep.WorkflowStepBase)
+		}
+		result.Steps = append(result.Steps, stepResult)
+		if stepResult.Phase == StepPhaseFailed {
+			break
+		}
+	}
+	return result, nil
+}
+
+// ExecuteWorkflowDryRunWithPolicies applies the Application-scoped policy transforms to the
+// application through the same code path as the controller, then simulates its workflow. The
+// errors of the policy transforms are returned as warnings, the workflow is simulated with the
+// policies applied so far.
+func (d *Option) ExecuteWorkflowDryRunWithPolicies(ctx context.Context, application *v1beta1.Application) (*WorkflowDryRunResult, []string, error) {
+	policyResult, err := velaapplication.SimulatePolicyApplication(ctx, d.Client, application)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to apply application-scoped policies")
+	}
+	result, err := d.ExecuteWorkflowDryRun(ctx, policyResult.Application)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, policyResult.Errors, nil
+}
+
+type workflowSimulator struct {
+	option *Option
+	af     *appfile.Appfile
+}
+
+func (s *workflowSimulator) executeStep(ctx context.Context, wfStep wfTypesv1alpha1.WorkflowStepBase) *StepDryRunResult {
+	result := &StepDryRunResult{Name: wfStep.Name, Type: wfStep.Type}
+	var err error
+	switch wfStep.Type {
+	case step.DeployWorkflowStep:
+		err = s.deploy(ctx, wfStep, result)
+	case wfTypes.WorkflowStepTypeApplyComponent:
+		err = s.applyComponent(ctx, wfStep, result)
+	case wfTypes.WorkflowStepTypeSuspend:
+		result.Phase = StepPhaseSkipped
+		result.Message = "suspend step is treated as resumed in dry-run"
+		return result
+	default:
+		result.Phase = StepPhaseSkipped
+		result.Message = fmt.Sprintf("step type %s is not supported in workflow dry-run", wfStep.Type)
+		return result
+	}
+	if err != nil {
+		result.Phase = StepPhaseFailed
+		result.Message = err.Error()
+		return result
+	}
+	if result.Phase == "" {
+		result.Phase = StepPhaseSucceeded
+	}
+	return result
+}
+
+func (s *workflowSimulator) deploy(ctx context.Context, wfStep wfTypesv1alpha1.WorkflowStepBase, result *StepDryRunResult) error {
+	parameter := multiclusterprovider.DeployParameter{}
+	if wfStep.Properties != nil {
+		if err := json.Unmarshal(wfStep.Properties.Raw, &parameter); err != nil {
+			return errors.Wrapf(err, "invalid properties for deploy step %s", wfStep.Name)
+		}
+	}
+	if parameter.Parallelism <= 0 {
+		parameter.Parallelism = defaultDeployParallelism
+	}
+	// apply and health check are called concurrently by the deploy executor
+	var mu sync.Mutex
+	rendered := map[string]*ClusterManifest{}
+	apply := func(ctx context.Context, comp common.ApplicationComponent, _ *cue.Value, clusterName string, overrideNamespace string) (*unstructured.Unstructured, []*unstructured.Unstructured, bool, error) {
+		manifest, err := s.render(ctx, comp, clusterName, overrideNamespace)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if _, found := rendered[manifestKey(manifest)]; !found {
+			result.Manifests = append(result.Manifests, manifest)
+		}
+		rendered[manifestKey(manifest)] = manifest
+		return workloadOf(manifest), traitsOf(manifest), true, nil
+	}
+	healthCheck := func(_ context.Context, comp common.ApplicationComponent, _ *cue.Value, clusterName string, overrideNamespace string) (bool, *common.ApplicationComponentStatus, *unstructured.Unstructured, []*unstructured.Unstructured, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		manifest, found := rendered[manifestKey(&ClusterManifest{Cluster: clusterName, Namespace: overrideNamespace, Component: comp.Name, ReplicaKey: comp.ReplicaKey})]
+		if !found {
+			return false, nil, nil, nil, nil
+		}
+		return true, &common.ApplicationComponentStatus{Name: comp.Name, Cluster: clusterName, Healthy: true}, workloadOf(manifest), traitsOf(manifest), nil
+	}
+	renderer := func(ctx context.Context, comp common.ApplicationComponent) (*appfile.Component, error) {
+		return s.option.Parser.ParseComponent(ctx, comp, s.af.AppAnnotations)
+	}
+	executor := multiclusterprovider.NewDeployWorkflowStepExecutor(s.option.Client, s.af, apply, healthCheck, renderer, parameter)
+	var reason string
+	for i := 0; i < maxDeploySimulationRounds; i++ {
+		healthy, msg, err := executor.Deploy(ctx)
+		if err != nil {
+			return err
+		}
+		if healthy {
+			return nil
+		}
+		reason = msg
+	}
+	return errors.Errorf("deploy step %s cannot finish: %s", wfStep.Name, reason)
+}
+
+func (s *workflowSimulator) applyComponent(ctx context.Context, wfStep wfTypesv1alpha1.WorkflowStepBase, result *StepDryRunResult) error {
+	props := struct {
+		Component string `json:"component"`
+		Cluster   string `json:"cluster"`
+		Namespace string `json:"namespace"`
+	}{}
+	if wfStep.Properties != nil {
+		if err := json.Unmarshal(wfStep.Properties.Raw, &props); err != nil {
+			return errors.Wrapf(err, "invalid properties for apply-component step %s", wfStep.Name)
+		}
+	}
+	for _, comp := range s.af.Components {
+		if comp.Name == props.Component {
+			manifest, err := s.render(ctx, comp, props.Cluster, props.Namespace)
+			if err != nil {
+				return err
+			}
+			result.Manifests = append(result.Manifests, manifest)
+			return nil
+		}
+	}
+	return errors.Errorf("component %s not found", props.Component)
+}
+
+// render generates the resources of the component in the given placement, the same as the
+// controller does before dispatching them
+func (s *workflowSimulator) render(ctx context.Context, comp common.ApplicationComponent, clusterName string, overrideNamespace string) (*ClusterManifest, error) {
+	if clusterName == "" {
+		clusterName = multicluster.ClusterLocalName
+	}
+	ctx = multicluster.ContextWithClusterName(ctx, clusterName)
+	wl, err := s.option.Parser.ParseComponent(ctx, comp, s.af.AppAnnotations)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "parse component %s", comp.Name)
+	}
+	cm, err := s.af.GenerateComponentManifest(wl, func(data *velaprocess.ContextData) {
+		data.Cluster = clusterName
+		data.ReplicaKey = comp.ReplicaKey
+		if overrideNamespace != "" {
+			data.Namespace = overrideNamespace
+		}
+	})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "render component %s for cluster %s", comp.Name, clusterName)
+	}
+	if err = s.af.SetOAMContract(cm); err != nil {
+		return nil, err
+	}
+	manifest := &ClusterManifest{Cluster: clusterName, Namespace: overrideNamespace, Component: comp.Name, ReplicaKey: comp.ReplicaKey}
+	for _, obj := range append([]*unstructured.Unstructured{cm.ComponentOutput}, cm.ComponentOutputsAndTraits...) {
+		if obj == nil {
+			continue
+		}
+		if overrideNamespace != "" {
+			obj.SetNamespace(overrideNamespace)
+		}
+		oam.SetClusterIfEmpty(obj, clusterName)
+		manifest.Objects = append(manifest.Objects, obj)
+	}
+	return manifest, nil
+}
+
+func manifestKey(m *ClusterManifest) string {
+	cluster := m.Cluster
+	if cluster == "" {
+		cluster = multicluster.ClusterLocalName
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", cluster, m.Namespace, m.ReplicaKey, m.Component)
+}
+
+func workloadOf(m *ClusterManifest) *unstructured.Unstructured {
+	if len(m.Objects) == 0 {
+		return nil
+	}
+	return m.Objects[0]
+}
+
+func traitsOf(m *ClusterManifest) []*unstructured.Unstructured {
+	if len(m.Objects) <= 1 {
+		return nil
+	}
+	return m.Objects[1:]
+}
+
+// PrintWorkflowDryRun will print the per-step and per-cluster result of the workflow dry-run
+func (d *Option) PrintWorkflowDryRun(buff *bytes.Buffer, result *WorkflowDryRunResult) error {
+	for _, stepResult := range result.Steps {
+		if err := printStepDryRun(buff, result.AppName, stepResult); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printStepDryRun(buff *bytes.Buffer, appName string, stepResult *StepDryRunResult) error {
+	fmt.Fprintf(buff, "# Application(%s) -- Step(%s) -- Type(%s) -- Phase(%s)\n", appName, stepResult.Name, stepResult.Type, stepResult.Phase)
+	if stepResult.Message != "" {
+		fmt.Fprintf(buff, "# %s\n", stepResult.Message)
+	}
+	buff.WriteString("\n")
+	for _, m := range stepResult.Manifests {
+		cluster := m.Cluster
+		if cluster == "" {
+			cluster = multicluster.ClusterLocalName
+		}
+		_, err := fmt.Fprintf(buff, "---\n# Application(%s) -- Step(%s) -- Cluster(%s) -- Component(%s)\n---\n\n", appName, stepResult.Name, cluster, m.Component)
+		if err != nil {
+			return errors.Wrap(err, "fail to write buff")
+		}
+		for _, obj := range m.Objects {
+			result, err := yaml.Marshal(obj)
+			if err != nil {
+				return errors.New("marshal result for component " + m.Component + " object in yaml format")
+			}
+			buff.Write(result)
+			buff.WriteString("\n---\n")
+		}
+		buff.WriteString("\n")
+	}
+	for _, sub := range stepResult.SubSteps {
+		if err := printStepDryRun(buff, appName, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
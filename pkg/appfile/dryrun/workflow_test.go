@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	oamutil "github.com/oam-dev/kubevela/pkg/oam/util"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestLoadClusterInventory(t *testing.T) {
+	r := require.New(t)
+	inventory, err := LoadClusterInventory("./testdata/cluster-inventory.yaml")
+	r.NoError(err)
+	r.Len(inventory.Clusters, 3)
+	r.Equal("hangzhou", inventory.Clusters[0].Labels["region"])
+
+	objs, err := inventory.ToObjects()
+	r.NoError(err)
+	r.Len(objs, 3)
+	r.Equal("Secret", objs[0].GetKind())
+	r.Equal("cluster-hangzhou-1", objs[0].GetName())
+	r.Equal("hangzhou", objs[0].GetLabels()["region"])
+
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.yaml")
+	r.NoError(os.WriteFile(bad, []byte("clusters:\n- name: local\n"), 0600))
+	_, err = LoadClusterInventory(bad)
+	r.ErrorContains(err, "reserved")
+}
+
+func TestExecuteWorkflowDryRun(t *testing.T) {
+	r := require.New(t)
+	var defs []*unstructured.Unstructured
+	cd, err := oamutil.UnMarshalStringToComponentDefinition(readDataFromFile("./testdata/cd-myworker.yaml"))
+	r.NoError(err)
+	cdObj, err := oamutil.Object2Unstructured(cd)
+	r.NoError(err)
+	defs = append(defs, cdObj)
+	wfsd := &v1beta1.WorkflowStepDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(readDataFromFile("./testdata/wd-deploy.yaml")), wfsd))
+	wfsd.SetNamespace(types.DefaultKubeVelaNS)
+
+	inventory, err := LoadClusterInventory("./testdata/cluster-inventory.yaml")
+	r.NoError(err)
+	clusters, err := inventory.ToObjects()
+	r.NoError(err)
+	objs := []client.Object{wfsd}
+	for _, obj := range clusters {
+		objs = append(objs, obj)
+	}
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(objs...).Build()
+
+	app := &v1beta1.Application{}
+	r.NoError(yaml.Unmarshal([]byte(readDataFromFile("./testdata/workflow-app.yaml")), app))
+
+	opt := NewDryRunOption(cli, nil, defs, false)
+	result, err := opt.ExecuteWorkflowDryRun(context.Background(), app)
+	r.NoError(err)
+	r.Len(result.Steps, 3)
+
+	hangzhou := result.Steps[0]
+	r.Equal(StepPhaseSucceeded, hangzhou.Phase, hangzhou.Message)
+	r.Len(hangzhou.Manifests, 2)
+	for _, m := range hangzhou.Manifests {
+		r.Contains([]string{"cluster-hangzhou-1", "cluster-hangzhou-2"}, m.Cluster)
+		r.Equal(m.Cluster, oam.GetCluster(m.Objects[0]))
+	}
+
+	r.Equal(StepPhaseSkipped, result.Steps[1].Phase)
+
+	beijing := result.Steps[2]
+	r.Equal(StepPhaseSucceeded, beijing.Phase, beijing.Message)
+	r.Len(beijing.Manifests, 1)
+	r.Equal("cluster-beijing", beijing.Manifests[0].Cluster)
+	image, _, err := unstructured.NestedSlice(beijing.Manifests[0].Objects[0].Object, "spec", "template", "spec", "containers")
+	r.NoError(err)
+	r.Equal("nginx", image[0].(map[string]interface{})["image"])
+
+	buff := &bytes.Buffer{}
+	r.NoError(opt.PrintWorkflowDryRun(buff, result))
+	r.Contains(buff.String(), "# Application(workflow-app) -- Step(deploy-beijing) -- Cluster(cluster-beijing) -- Component(myweb)")
+	r.Contains(buff.String(), "# Application(workflow-app) -- Step(manual-approve) -- Type(suspend) -- Phase(skipped)")
+}
+
+func TestExecuteWorkflowDryRunClusterNotFound(t *testing.T) {
+	r := require.New(t)
+	cd, err := oamutil.UnMarshalStringToComponentDefinition(readDataFromFile("./testdata/cd-myworker.yaml"))
+	r.NoError(err)
+	cdObj, err := oamutil.Object2Unstructured(cd)
+	r.NoError(err)
+	wfsd := &v1beta1.WorkflowStepDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(readDataFromFile("./testdata/wd-deploy.yaml")), wfsd))
+	wfsd.SetNamespace(types.DefaultKubeVelaNS)
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(wfsd).Build()
+
+	app := &v1beta1.Application{}
+	r.NoError(yaml.Unmarshal([]byte(readDataFromFile("./testdata/workflow-app.yaml")), app))
+	opt := NewDryRunOption(cli, nil, []*unstructured.Unstructured{cdObj}, false)
+	result, err := opt.ExecuteWorkflowDryRun(context.Background(), app)
+	r.NoError(err)
+	r.Len(result.Steps, 1)
+	r.Equal(StepPhaseFailed, result.Steps[0].Phase)
+	r.Contains(result.Steps[0].Message, "failed to find any cluster matches given labels")
+}
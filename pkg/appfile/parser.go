@@ -350,6 +350,11 @@ func (p *Parser) parsePoliciesFromRevision(ctx context.Context, af *Appfile) (er
 		case v1alpha1.TakeOverPolicyType:
 		case v1alpha1.ReadOnlyPolicyType:
 		case v1alpha1.ResourceUpdatePolicyType:
+		case v1alpha1.DriftDetectionPolicyType:
+		case v1alpha1.ProgressiveRolloutPolicyType:
+		case v1alpha1.AutoRollbackPolicyType:
+		case v1alpha1.AppDependencyPolicyType:
+		case v1alpha1.DeployWindowPolicyType:
 		case v1alpha1.EnvBindingPolicyType:
 		case v1alpha1.TopologyPolicyType:
 		case v1alpha1.OverridePolicyType:
@@ -386,6 +391,11 @@ func (p *Parser) parsePolicies(ctx context.Context, af *Appfile) (err error) {
 		case v1alpha1.TakeOverPolicyType:
 		case v1alpha1.ReadOnlyPolicyType:
 		case v1alpha1.ResourceUpdatePolicyType:
+		case v1alpha1.DriftDetectionPolicyType:
+		case v1alpha1.ProgressiveRolloutPolicyType:
+		case v1alpha1.AutoRollbackPolicyType:
+		case v1alpha1.AppDependencyPolicyType:
+		case v1alpha1.DeployWindowPolicyType:
 		case v1alpha1.EnvBindingPolicyType:
 		case v1alpha1.TopologyPolicyType:
 		case v1alpha1.ReplicationPolicyType:
@@ -602,6 +612,12 @@ func setComponentDefinitionsFromRevision(af *Appfile) {
 	}
 }
 
+// ParseComponent resolve an ApplicationComponent and generate a Component
+// with definitions loaded by the template loader of the parser.
+func (p *Parser) ParseComponent(ctx context.Context, comp common.ApplicationComponent, annotations map[string]string) (*Component, error) {
+	return p.parseComponent(ctx, comp, annotations)
+}
+
 // parseComponent resolve an ApplicationComponent and generate a Component
 // containing ALL information required by an Appfile.
 func (p *Parser) parseComponent(ctx context.Context, comp common.ApplicationComponent, annotations map[string]string) (*Component, error) {
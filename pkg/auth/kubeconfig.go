@@ -438,3 +438,16 @@ func ReadIdentityFromKubeConfig(kubeconfigPath string) (*Identity, error) {
 	}
 	return nil, fmt.Errorf("cannot find client certificate or serviceaccount token in kubeconfig")
 }
+
+// ReadIdentityFromSelfSubjectReview returns the identity of the current user as authenticated by
+// the apiserver. The username of a serviceaccount is kept as system:serviceaccount:<ns>:<name>.
+func ReadIdentityFromSelfSubjectReview(ctx context.Context, cli kubernetes.Interface) (*Identity, error) {
+	review, err := cli.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to review the current user: %w", err)
+	}
+	if review.Status.UserInfo.Username == "" {
+		return nil, fmt.Errorf("cannot recognize the current user")
+	}
+	return &Identity{User: review.Status.UserInfo.Username, Groups: review.Status.UserInfo.Groups}, nil
+}
@@ -332,3 +332,24 @@ func TestReadIdentityFromKubeConfig(t *testing.T) {
 		r.Contains(err.Error(), "cannot find client certificate or serviceaccount token in kubeconfig")
 	})
 }
+
+func TestReadIdentityFromSelfSubjectReview(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset()
+	cli.Fake.PrependReactor("create", "selfsubjectreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		review := &authenticationv1.SelfSubjectReview{}
+		review.Status.UserInfo = authenticationv1.UserInfo{Username: "alice", Groups: []string{"sre", "system:authenticated"}}
+		return true, review, nil
+	})
+	identity, err := ReadIdentityFromSelfSubjectReview(ctx, cli)
+	r.NoError(err)
+	r.Equal(&Identity{User: "alice", Groups: []string{"sre", "system:authenticated"}}, identity)
+
+	cli = fake.NewSimpleClientset()
+	cli.Fake.PrependReactor("create", "selfsubjectreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, &authenticationv1.SelfSubjectReview{}, nil
+	})
+	_, err = ReadIdentityFromSelfSubjectReview(ctx, cli)
+	r.ErrorContains(err, "cannot recognize the current user")
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// SpecVersion is the version of the CloudEvents specification the events conform to
+	SpecVersion = "1.0"
+	// ModeStructured sends the whole event as the JSON body of the request
+	ModeStructured = "structured"
+	// ModeBinary sends the attributes of the event as ce-* headers and the data as the body
+	ModeBinary = "binary"
+	// ContentTypeStructured is the content type of the event in structured mode
+	ContentTypeStructured = "application/cloudevents+json"
+	// ContentTypeJSON is the default content type of the data of the event
+	ContentTypeJSON = "application/json"
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of the request, in the format
+	// of sha256=<hex digest>
+	SignatureHeader = "X-Vela-Signature"
+	// SignatureTimestampHeader is the header carrying the unix time the request is signed at, in seconds
+	SignatureTimestampHeader = "X-Vela-Signature-Timestamp"
+	// DefaultSignatureTolerance is the max age of the signature accepted by VerifyRequest by default
+	DefaultSignatureTolerance = 5 * time.Minute
+)
+
+const (
+	// EventTypeWorkflowStarted is the type of the event emitted when the workflow of the application starts
+	EventTypeWorkflowStarted = "dev.oam.vela.application.workflow.started"
+	// EventTypeWorkflowStepFailed is the type of the event emitted when a workflow step fails
+	EventTypeWorkflowStepFailed = "dev.oam.vela.application.workflow.step.failed"
+	// EventTypeWorkflowFailed is the type of the event emitted when the workflow of the application fails
+	EventTypeWorkflowFailed = "dev.oam.vela.application.workflow.failed"
+	// EventTypeApplicationHealthy is the type of the event emitted when the application turns running and healthy
+	EventTypeApplicationHealthy = "dev.oam.vela.application.healthy"
+	// EventTypeApplicationRollback is the type of the event emitted when the application is rolled back
+	EventTypeApplicationRollback = "dev.oam.vela.application.rollback"
+)
+
+// signNow returns the time to sign the requests at, replaced in tests
+var signNow = time.Now
+
+// Event is an event conforming to the CloudEvents 1.0 specification
+type Event struct {
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	SpecVersion     string          `json:"specversion"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            *time.Time      `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// NewEvent creates an event with a random id and the current time, the data is encoded as JSON
+func NewEvent(source, eventType, subject string, data interface{}) (*Event, error) {
+	now := time.Now().UTC()
+	event := &Event{
+		ID:          string(uuid.NewUUID()),
+		Source:      source,
+		SpecVersion: SpecVersion,
+		Type:        eventType,
+		Subject:     subject,
+		Time:        &now,
+	}
+	if data != nil {
+		bs, err := json.Marshal(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encode the data of event %s", eventType)
+		}
+		event.DataContentType = ContentTypeJSON
+		event.Data = bs
+	}
+	return event, nil
+}
+
+// ApplicationSource returns the source of the events about the application
+func ApplicationSource(namespace, name string) string {
+	return fmt.Sprintf("/namespaces/%s/applications/%s", namespace, name)
+}
+
+// Validate checks the required attributes of the event
+func (e *Event) Validate() error {
+	switch {
+	case e.ID == "":
+		return fmt.Errorf("the id of the event is empty")
+	case e.Source == "":
+		return fmt.Errorf("the source of the event is empty")
+	case e.Type == "":
+		return fmt.Errorf("the type of the event is empty")
+	case e.SpecVersion != SpecVersion:
+		return fmt.Errorf("unsupported specversion %q of the event", e.SpecVersion)
+	}
+	return nil
+}
+
+// Sender sends events to the sink over HTTP
+type Sender struct {
+	// URL is the address of the sink
+	URL string
+	// Mode is the content mode of the request, structured or binary, default to structured
+	Mode string
+	// Secret is the key to sign the request body with HMAC-SHA256, no signature if empty
+	Secret string
+	// Header is the extra header of the request
+	Header map[string]string
+	// Retries is the times to retry after the first failed delivery
+	Retries int
+	// Backoff is the wait before the first retry, doubled after each retry
+	Backoff time.Duration
+	// Client is the http client to send the request, default to http.DefaultClient
+	Client *http.Client
+}
+
+// Sign returns the HMAC-SHA256 signature of the request with the secret. The signed content is the
+// timestamp line, the Content-Type and ce-* headers as sorted lines of <lowercase name>:<value>, an
+// empty line and the body, so the attributes of the binary mode can not be replaced either.
+func Sign(secret string, timestamp int64, header http.Header, body []byte) string {
+	var names []string
+	for name := range header {
+		if name = strings.ToLower(name); name == "content-type" || strings.HasPrefix(name, "ce-") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d\n", timestamp)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(mac, "%s:%s\n", name, header.Get(name))
+	}
+	_, _ = fmt.Fprint(mac, "\n")
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyRequest checks the signature of the request received by the sink, which must be signed with
+// the secret no longer than tolerance before now, to reject the requests replayed later
+func VerifyRequest(secret string, header http.Header, body []byte, now time.Time, tolerance time.Duration) error {
+	timestamp, err := strconv.ParseInt(header.Get(SignatureTimestampHeader), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp %q", header.Get(SignatureTimestampHeader))
+	}
+	if age := now.Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("the signature timestamp %d is out of the tolerance %s", timestamp, tolerance)
+	}
+	if !hmac.Equal([]byte(Sign(secret, timestamp, header, body)), []byte(header.Get(SignatureHeader))) {
+		return fmt.Errorf("the signature does not match the request")
+	}
+	return nil
+}
+
+// DeliveryError is the failure to deliver the event to the sink
+type DeliveryError struct {
+	Err error
+	// Retryable tells if the sink is unavailable for now and the delivery is worth retrying
+	Retryable bool
+}
+
+// Error .
+func (e *DeliveryError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap .
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable checks if the error is a failed delivery worth retrying
+func IsRetryable(err error) bool {
+	var deliveryErr *DeliveryError
+	return errors.As(err, &deliveryErr) && deliveryErr.Retryable
+}
+
+func (s *Sender) newRequest(ctx context.Context, event *Event) (*http.Request, error) {
+	var body []byte
+	header := http.Header{}
+	switch s.Mode {
+	case "", ModeStructured:
+		bs, err := json.Marshal(event)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encode event %s", event.ID)
+		}
+		body = bs
+		header.Set("Content-Type", ContentTypeStructured+"; charset=utf-8")
+	case ModeBinary:
+		body = event.Data
+		header.Set("ce-specversion", event.SpecVersion)
+		header.Set("ce-id", event.ID)
+		header.Set("ce-source", event.Source)
+		header.Set("ce-type", event.Type)
+		if event.Subject != "" {
+			header.Set("ce-subject", event.Subject)
+		}
+		if event.Time != nil {
+			header.Set("ce-time", event.Time.Format(time.RFC3339Nano))
+		}
+		if event.DataContentType != "" {
+			header.Set("Content-Type", event.DataContentType)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported mode %q, must be one of %s and %s", s.Mode, ModeStructured, ModeBinary)
+	}
+	for k, v := range s.Header {
+		header.Set(k, v)
+	}
+	if s.Secret != "" {
+		timestamp := signNow().Unix()
+		header.Set(SignatureTimestampHeader, strconv.FormatInt(timestamp, 10))
+		header.Set(SignatureHeader, Sign(s.Secret, timestamp, header, body))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header
+	return req, nil
+}
+
+// send delivers the event once, the failure is a DeliveryError
+func (s *Sender) send(ctx context.Context, event *Event) error {
+	req, err := s.newRequest(ctx, event)
+	if err != nil {
+		return err
+	}
+	cli := s.Client
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+	resp, err := cli.Do(req)
+	if err != nil {
+		return &DeliveryError{Err: err, Retryable: true}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return &DeliveryError{
+		Err:       fmt.Errorf("the sink responds with status %s", resp.Status),
+		Retryable: resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout,
+	}
+}
+
+// Send delivers the event to the sink, retrying with exponential backoff if the sink is unavailable.
+// It blocks during the retries, the callers which must not block should set no Retries and retry
+// the retryable failures, checked by IsRetryable, later by themselves.
+func (s *Sender) Send(ctx context.Context, event *Event) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	backoff := wait.Backoff{Duration: s.Backoff, Factor: 2, Steps: s.Retries + 1}
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		err := s.send(ctx, event)
+		if err == nil {
+			return true, nil
+		}
+		lastErr = err
+		if !IsRetryable(err) {
+			return false, err
+		}
+		return false, nil
+	})
+	if err != nil && lastErr != nil {
+		return errors.Wrapf(lastErr, "failed to send event %s to %s", event.ID, s.URL)
+	}
+	return err
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newTestSink(t *testing.T, statuses ...int) (*httptest.Server, *[]receivedRequest) {
+	var requests []receivedRequest
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, receivedRequest{header: r.Header, body: body})
+		i := int(atomic.AddInt32(&count, 1)) - 1
+		if i < len(statuses) {
+			w.WriteHeader(statuses[i])
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestSend(t *testing.T) {
+	ctx := context.Background()
+	event, err := NewEvent("/namespaces/default/applications/app", EventTypeWorkflowStarted, "app", map[string]string{"appRevision": "app-v1"})
+	require.NoError(t, err)
+
+	t.Run("structured", func(t *testing.T) {
+		r := require.New(t)
+		server, requests := newTestSink(t)
+		sender := &Sender{URL: server.URL, Secret: "s3cret", Header: map[string]string{"Authorization": "Bearer token"}}
+		r.NoError(sender.Send(ctx, event))
+		r.Len(*requests, 1)
+		req := (*requests)[0]
+		r.Equal("application/cloudevents+json; charset=utf-8", req.header.Get("Content-Type"))
+		r.Equal("Bearer token", req.header.Get("Authorization"))
+		r.NoError(VerifyRequest("s3cret", req.header, req.body, time.Now(), DefaultSignatureTolerance))
+		received := &Event{}
+		r.NoError(json.Unmarshal(req.body, received))
+		r.Equal("1.0", received.SpecVersion)
+		r.Equal(event.ID, received.ID)
+		r.Equal(EventTypeWorkflowStarted, received.Type)
+		r.Equal("app", received.Subject)
+		r.JSONEq(`{"appRevision":"app-v1"}`, string(received.Data))
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		r := require.New(t)
+		server, requests := newTestSink(t)
+		sender := &Sender{URL: server.URL, Mode: ModeBinary}
+		r.NoError(sender.Send(ctx, event))
+		req := (*requests)[0]
+		r.Equal("application/json", req.header.Get("Content-Type"))
+		r.Equal("1.0", req.header.Get("ce-specversion"))
+		r.Equal(event.ID, req.header.Get("ce-id"))
+		r.Equal("/namespaces/default/applications/app", req.header.Get("ce-source"))
+		r.Equal(EventTypeWorkflowStarted, req.header.Get("ce-type"))
+		r.Equal("app", req.header.Get("ce-subject"))
+		r.Equal(event.Time.Format(time.RFC3339Nano), req.header.Get("ce-time"))
+		r.Empty(req.header.Get(SignatureHeader))
+		r.JSONEq(`{"appRevision":"app-v1"}`, string(req.body))
+	})
+
+	t.Run("retry with backoff", func(t *testing.T) {
+		r := require.New(t)
+		server, requests := newTestSink(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		sender := &Sender{URL: server.URL, Secret: "s3cret", Retries: 2, Backoff: time.Millisecond}
+		r.NoError(sender.Send(ctx, event))
+		r.Len(*requests, 3)
+		r.Equal((*requests)[0].body, (*requests)[2].body)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		r := require.New(t)
+		server, requests := newTestSink(t, http.StatusBadGateway, http.StatusBadGateway)
+		sender := &Sender{URL: server.URL, Retries: 1, Backoff: time.Millisecond}
+		err := sender.Send(ctx, event)
+		r.ErrorContains(err, "the sink responds with status 502 Bad Gateway")
+		r.True(IsRetryable(err))
+		r.Len(*requests, 2)
+	})
+
+	t.Run("no retry on client error", func(t *testing.T) {
+		r := require.New(t)
+		server, requests := newTestSink(t, http.StatusBadRequest)
+		sender := &Sender{URL: server.URL, Retries: 3, Backoff: time.Millisecond}
+		err := sender.Send(ctx, event)
+		r.ErrorContains(err, "400 Bad Request")
+		r.False(IsRetryable(err))
+		r.Len(*requests, 1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := require.New(t)
+		r.ErrorContains((&Sender{URL: "http://localhost", Mode: "batched"}).Send(ctx, event), `unsupported mode "batched"`)
+		r.ErrorContains((&Sender{URL: "http://localhost"}).Send(ctx, &Event{ID: "1", SpecVersion: SpecVersion, Type: "t"}), "the source of the event is empty")
+	})
+}
+
+func TestVerifyRequest(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	event, err := NewEvent("/namespaces/default/applications/app", EventTypeWorkflowFailed, "app", map[string]string{"reason": "timeout"})
+	r.NoError(err)
+	server, requests := newTestSink(t)
+	r.NoError((&Sender{URL: server.URL, Mode: ModeBinary, Secret: "s3cret"}).Send(ctx, event))
+	req := (*requests)[0]
+	r.NotEmpty(req.header.Get(SignatureTimestampHeader))
+	r.NoError(VerifyRequest("s3cret", req.header, req.body, time.Now(), DefaultSignatureTolerance))
+	r.ErrorContains(VerifyRequest("other", req.header, req.body, time.Now(), DefaultSignatureTolerance), "the signature does not match")
+	r.ErrorContains(VerifyRequest("s3cret", req.header, req.body, time.Now().Add(time.Hour), DefaultSignatureTolerance), "out of the tolerance")
+
+	// the attributes in the ce-* headers are signed as well as the body
+	forged := req.header.Clone()
+	forged.Set("ce-type", EventTypeApplicationHealthy)
+	r.ErrorContains(VerifyRequest("s3cret", forged, req.body, time.Now(), DefaultSignatureTolerance), "the signature does not match")
+	r.ErrorContains(VerifyRequest("s3cret", req.header, []byte(`{"reason":"none"}`), time.Now(), DefaultSignatureTolerance), "the signature does not match")
+	forged = req.header.Clone()
+	forged.Del(SignatureTimestampHeader)
+	r.ErrorContains(VerifyRequest("s3cret", forged, req.body, time.Now(), DefaultSignatureTolerance), "invalid signature timestamp")
+}
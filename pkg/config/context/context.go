@@ -23,6 +23,7 @@ var DefaultContext = []byte(`
 	context: {
 		name: string
 		namespace: string
+		references: {...}
 	}
 `)
 
@@ -30,6 +31,8 @@ var DefaultContext = []byte(`
 type ConfigRenderContext struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
+	// References the properties of the referenced configs, the key is the parameter name
+	References map[string]interface{} `json:"references,omitempty"`
 }
 
 // ReadConfigProvider the provide function for reading the config properties
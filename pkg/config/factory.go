@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -127,6 +128,9 @@ type Template struct {
 
 	ExpandedWriter writer.ExpandedWriterConfig `json:"expandedWriter"`
 
+	// References the parameters that reference other configs, the key is the parameter name.
+	References map[string]ConfigReference `json:"references,omitempty"`
+
 	Schema *openapi3.Schema `json:"schema"`
 
 	ConfigMap *v1.ConfigMap `json:"-"`
@@ -199,6 +203,9 @@ type Distribution struct {
 type CreateDistributionSpec struct {
 	Configs []*NamespacedName `json:"configs"`
 	Targets []*ClusterTarget  `json:"targets"`
+	// Versions pins the configs to the versions, the key is the config name.
+	// The configs that are not pinned follow the latest version.
+	Versions map[string]int `json:"versions,omitempty"`
 }
 
 // Validation the response of the validation
@@ -228,6 +235,8 @@ type Factory interface {
 	DeleteConfig(ctx context.Context, namespace, name string) error
 	CreateOrUpdateConfig(ctx context.Context, i *Config, ns string) error
 	IsExist(ctx context.Context, namespace, name string) (bool, error)
+	ListConfigVersions(ctx context.Context, namespace, name string) ([]*ConfigVersion, error)
+	RollbackConfig(ctx context.Context, namespace, name string, version int) error
 
 	CreateOrUpdateDistribution(ctx context.Context, ns, name string, ads *CreateDistributionSpec) error
 	ListDistributions(ctx context.Context, ns string) ([]*Distribution, error)
@@ -296,6 +305,10 @@ func (k *kubeConfigFactory) ParseTemplate(ctx context.Context, defaultName strin
 	if err != nil {
 		return nil, fmt.Errorf("the properties of the cue script is invalid:%w", err)
 	}
+	references, err := parseTemplateReferences(templateValue)
+	if err != nil {
+		return nil, err
+	}
 	template := &Template{
 		NamespacedName: NamespacedName{
 			Name: tm.Name,
@@ -306,6 +319,7 @@ func (k *kubeConfigFactory) ParseTemplate(ctx context.Context, defaultName strin
 		Template:       cueScript,
 		Schema:         schema,
 		ExpandedWriter: writer.ParseExpandedWriterConfig(templateValue),
+		References:     references,
 	}
 
 	var configmap v1.ConfigMap
@@ -326,6 +340,13 @@ func (k *kubeConfigFactory) ParseTemplate(ctx context.Context, defaultName strin
 		return nil, err
 	}
 	configmap.Data[SaveExpandedWriterKey] = string(data)
+	if len(template.References) > 0 {
+		data, err := yaml.Marshal(template.References)
+		if err != nil {
+			return nil, err
+		}
+		configmap.Data[SaveReferencesKey] = string(data)
+	}
 	configmap.Labels = map[string]string{
 		types.LabelConfigCatalog: types.VelaCoreConfig,
 		types.LabelConfigScope:   template.Scope,
@@ -392,6 +413,13 @@ func convertConfigMap2Template(cm v1.ConfigMap) (*Template, error) {
 		}
 		it.ExpandedWriter = config
 	}
+	if cm.Data[SaveReferencesKey] != "" {
+		var references map[string]ConfigReference
+		if err := yaml.Unmarshal([]byte(cm.Data[SaveReferencesKey]), &references); err != nil {
+			return nil, fmt.Errorf("fail to parse the references: %w", err)
+		}
+		it.References = references
+	}
 	return it, nil
 }
 
@@ -463,9 +491,14 @@ func (k *kubeConfigFactory) ParseConfig(ctx context.Context,
 		if err != nil {
 			return nil, err
 		}
+		references, referenceValues, err := k.resolveReferences(ctx, template, meta)
+		if err != nil {
+			return nil, err
+		}
 		contextValue := icontext.ConfigRenderContext{
-			Name:      meta.Name,
-			Namespace: meta.Namespace,
+			Name:       meta.Name,
+			Namespace:  meta.Namespace,
+			References: referenceValues,
 		}
 		// Compile the config template
 		val, err := template.Template.RunAndOutputWithCueX(ctx, contextValue, meta.Properties)
@@ -506,6 +539,16 @@ func (k *kubeConfigFactory) ParseConfig(ctx context.Context,
 		}
 		secret.Annotations[types.AnnotationConfigSensitive] = fmt.Sprintf("%t", template.Sensitive)
 		secret.Annotations[types.AnnotationConfigTemplateNamespace] = template.Namespace
+		if len(references) > 0 {
+			referencesJSON, err := json.Marshal(references)
+			if err != nil {
+				return nil, err
+			}
+			secret.Annotations[types.AnnotationConfigReferences] = string(referencesJSON)
+			for _, ref := range references {
+				secret.Labels[ConfigReferenceLabel(ref.Namespace, ref.Name)] = "true"
+			}
+		}
 		config.Template = *template
 
 		// Render the expanded writer configuration
@@ -611,9 +654,11 @@ func (k *kubeConfigFactory) GetConfig(ctx context.Context, namespace, name strin
 }
 
 // CreateOrUpdateConfig create or update the config.
+// The prior version is kept as the history if the config changes.
 // Write the expand config to the target server.
 func (k *kubeConfigFactory) CreateOrUpdateConfig(ctx context.Context, i *Config, _ string) error {
 	var secret v1.Secret
+	var current *v1.Secret
 	if err := k.cli.Get(ctx, pkgtypes.NamespacedName{Namespace: i.Namespace, Name: i.Name}, &secret); err == nil {
 		if secret.Labels[types.LabelConfigType] != i.Template.Name {
 			return ErrChangeTemplate
@@ -621,6 +666,13 @@ func (k *kubeConfigFactory) CreateOrUpdateConfig(ctx context.Context, i *Config,
 		if i.Secret.Type != "" && secret.Type != i.Secret.Type {
 			return ErrChangeSecretType
 		}
+		current = &secret
+	}
+	if err := checkReferenceCycle(ctx, k.cli, i.Secret); err != nil {
+		return err
+	}
+	if err := k.setConfigVersion(ctx, current, i.Secret); err != nil {
+		return err
 	}
 
 	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(i.Secret)
@@ -709,6 +761,9 @@ func (k *kubeConfigFactory) DeleteConfig(ctx context.Context, namespace, name st
 	if secret.Labels[types.LabelConfigCatalog] != types.VelaCoreConfig {
 		return fmt.Errorf("found a secret but is not a config")
 	}
+	if err := CheckConfigNotReferenced(ctx, k.cli, namespace, name); err != nil {
+		return err
+	}
 
 	if objects, exist := secret.Data[SaveObjectReferenceKey]; exist {
 		var objectReferences []v1.ObjectReference
@@ -721,6 +776,9 @@ func (k *kubeConfigFactory) DeleteConfig(ctx context.Context, namespace, name st
 			}
 		}
 	}
+	if err := k.deleteConfigHistory(ctx, namespace, name); err != nil {
+		return err
+	}
 
 	return k.cli.Delete(ctx, &secret)
 }
@@ -772,10 +830,11 @@ func (k *kubeConfigFactory) CreateOrUpdateDistribution(ctx context.Context, ns,
 		return ErrNoConfigOrTarget
 	}
 	// create the share policy
+	pinnedName := name + "-pinned"
 	shareSpec := v1alpha1.SharedResourcePolicySpec{
 		Rules: []v1alpha1.SharedResourcePolicyRule{{
 			Selector: v1alpha1.ResourcePolicyRuleSelector{
-				CompNames: []string{name},
+				CompNames: []string{name, pinnedName},
 			},
 		}},
 	}
@@ -791,20 +850,48 @@ func (k *kubeConfigFactory) CreateOrUpdateDistribution(ctx context.Context, ns,
 	}
 
 	var objects []map[string]string
+	var pinnedObjects []*unstructured.Unstructured
 	for _, s := range ads.Configs {
+		if version, ok := ads.Versions[s.Name]; ok {
+			// the pinned version is distributed by the content, with the name of the config
+			obj, err := k.renderPinnedConfig(ctx, s.Namespace, s.Name, version)
+			if err != nil {
+				return err
+			}
+			pinnedObjects = append(pinnedObjects, obj)
+			continue
+		}
 		objects = append(objects, map[string]string{
 			"name":      s.Name,
 			"namespace": s.Namespace,
 			"resource":  "secret",
 		})
 	}
-	if len(objects) == 0 {
+	if len(objects) == 0 && len(pinnedObjects) == 0 {
 		return ErrNoConfigOrTarget
 	}
-
-	objectsBytes, err := json.Marshal(map[string][]map[string]string{"objects": objects})
-	if err != nil {
-		return err
+	var components []common.ApplicationComponent
+	if len(objects) > 0 {
+		objectsBytes, err := json.Marshal(map[string][]map[string]string{"objects": objects})
+		if err != nil {
+			return err
+		}
+		components = append(components, common.ApplicationComponent{
+			Name:       name,
+			Type:       v1alpha1.RefObjectsComponentType,
+			Properties: &runtime.RawExtension{Raw: objectsBytes},
+		})
+	}
+	if len(pinnedObjects) > 0 {
+		objectsBytes, err := json.Marshal(map[string][]*unstructured.Unstructured{"objects": pinnedObjects})
+		if err != nil {
+			return err
+		}
+		components = append(components, common.ApplicationComponent{
+			Name:       pinnedName,
+			Type:       v1alpha1.K8sObjectsComponentType,
+			Properties: &runtime.RawExtension{Raw: objectsBytes},
+		})
 	}
 
 	reqByte, err := json.Marshal(ads)
@@ -827,14 +914,8 @@ func (k *kubeConfigFactory) CreateOrUpdateDistribution(ctx context.Context, ns,
 			},
 		},
 		Spec: v1beta1.ApplicationSpec{
-			Components: []common.ApplicationComponent{
-				{
-					Name:       name,
-					Type:       v1alpha1.RefObjectsComponentType,
-					Properties: &runtime.RawExtension{Raw: objectsBytes},
-				},
-			},
-			Policies: policies,
+			Components: components,
+			Policies:   policies,
 		},
 	}
 	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(distribution)
@@ -895,6 +976,45 @@ func (k *kubeConfigFactory) DeleteDistribution(ctx context.Context, ns, name str
 	return nil
 }
 
+// renderPinnedConfig renders the secret of the config version to distribute. The sensitive config
+// can not be pinned because the content is saved in the application.
+func (k *kubeConfigFactory) renderPinnedConfig(ctx context.Context, namespace, name string, version int) (*unstructured.Unstructured, error) {
+	secret, err := k.getConfigVersionSecret(ctx, namespace, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("fail to pin the config %s to the version %d: %w", name, version, err)
+	}
+	if secret.Annotations[types.AnnotationConfigSensitive] == "true" {
+		return nil, fmt.Errorf("the config %s can not be pinned to a version: %w", name, ErrSensitiveConfig)
+	}
+	labels := map[string]string{}
+	for key, value := range secret.Labels {
+		labels[key] = value
+	}
+	labels[types.LabelConfigCatalog] = types.VelaCoreConfig
+	labels[types.LabelConfigType] = secret.Labels[types.LabelConfigType]
+	if secret.Name != name {
+		labels[types.LabelConfigType] = secret.Annotations[types.LabelConfigType]
+		delete(labels, types.LabelConfigName)
+	}
+	pinned := &v1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+			Annotations: map[string]string{
+				types.AnnotationConfigVersion: strconv.Itoa(version),
+			},
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pinned)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
 func convertTarget2TopologyPolicy(targets []*ClusterTarget) (policies []v1beta1.AppPolicy) {
 	for _, target := range targets {
 		policySpec := v1alpha1.TopologyPolicySpec{
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgtypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+)
+
+// MaxConfigHistory is the max number of the history versions kept for each config
+var MaxConfigHistory = 10
+
+// ErrConfigVersionNotFound means the version of the config does not exist
+var ErrConfigVersionNotFound = errors.New("the version of the config does not exist")
+
+// ConfigVersion the version of the config
+type ConfigVersion struct {
+	Version   int       `json:"version"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Current means the version is the current version of the config
+	Current bool `json:"current"`
+}
+
+// historySecretNamePrefix returns the prefix of the generated name of the secret saving the history
+// version of the config. The history secrets are found by the labels instead of the names, so the
+// generated names never collide with the other configs.
+func historySecretNamePrefix(configName string) string {
+	return configName + "-history-"
+}
+
+// getConfigVersion returns the version of the config secret, the secret created before
+// the versioning is the first version
+func getConfigVersion(secret *v1.Secret) int {
+	version, err := strconv.Atoi(secret.Annotations[types.AnnotationConfigVersion])
+	if err != nil || version < 1 {
+		return 1
+	}
+	return version
+}
+
+func convertSecret2ConfigVersion(secret *v1.Secret) *ConfigVersion {
+	cv := &ConfigVersion{
+		Version:   getConfigVersion(secret),
+		UpdatedBy: secret.Annotations[types.AnnotationConfigUpdatedBy],
+		UpdatedAt: secret.CreationTimestamp.Time,
+	}
+	if updatedAt, err := time.Parse(time.RFC3339, secret.Annotations[types.AnnotationConfigUpdatedAt]); err == nil {
+		cv.UpdatedAt = updatedAt
+	}
+	return cv
+}
+
+// setConfigVersion records the version of the config and who changed it. If the content is not
+// changed, the current version is kept. Otherwise, the current secret is saved as a history version.
+func (k *kubeConfigFactory) setConfigVersion(ctx context.Context, current *v1.Secret, secret *v1.Secret) error {
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	version := 1
+	if current != nil {
+		version = getConfigVersion(current)
+		if reflect.DeepEqual(current.Data, mergedSecretData(secret)) {
+			secret.Annotations[types.AnnotationConfigVersion] = strconv.Itoa(version)
+			secret.Annotations[types.AnnotationConfigUpdatedBy] = current.Annotations[types.AnnotationConfigUpdatedBy]
+			secret.Annotations[types.AnnotationConfigUpdatedAt] = current.Annotations[types.AnnotationConfigUpdatedAt]
+			return nil
+		}
+		if err := k.saveConfigHistory(ctx, current); err != nil {
+			return fmt.Errorf("fail to save the history version of the config: %w", err)
+		}
+		version++
+	}
+	secret.Annotations[types.AnnotationConfigVersion] = strconv.Itoa(version)
+	secret.Annotations[types.AnnotationConfigUpdatedAt] = time.Now().UTC().Format(time.RFC3339)
+	delete(secret.Annotations, types.AnnotationConfigUpdatedBy)
+	if u, ok := request.UserFrom(ctx); ok && u.GetName() != "" {
+		secret.Annotations[types.AnnotationConfigUpdatedBy] = u.GetName()
+	}
+	return nil
+}
+
+// mergedSecretData returns the data of the secret after the string data is merged by the API server
+func mergedSecretData(secret *v1.Secret) map[string][]byte {
+	if len(secret.StringData) == 0 {
+		return secret.Data
+	}
+	data := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for key, value := range secret.Data {
+		data[key] = value
+	}
+	for key, value := range secret.StringData {
+		data[key] = []byte(value)
+	}
+	return data
+}
+
+// saveConfigHistory copies the config secret as a history version, and prunes the oldest versions
+func (k *kubeConfigFactory) saveConfigHistory(ctx context.Context, current *v1.Secret) error {
+	version := getConfigVersion(current)
+	history := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: historySecretNamePrefix(current.Name),
+			Namespace:    current.Namespace,
+			Labels:       map[string]string{},
+			Annotations:  map[string]string{},
+		},
+		Type: current.Type,
+		Data: current.Data,
+	}
+	for key, value := range current.Labels {
+		history.Labels[key] = value
+	}
+	for key, value := range current.Annotations {
+		history.Annotations[key] = value
+	}
+	// the history version should not be selected as the config of the template, so the template
+	// is moved from the labels to the annotations
+	history.Annotations[types.LabelConfigType] = current.Labels[types.LabelConfigType]
+	delete(history.Labels, types.LabelConfigType)
+	history.Labels[types.LabelConfigCatalog] = types.CatalogConfigHistory
+	history.Labels[types.LabelConfigName] = current.Name
+	history.Annotations[types.AnnotationConfigVersion] = strconv.Itoa(version)
+	histories, err := k.listConfigHistory(ctx, current.Namespace, current.Name)
+	if err != nil {
+		return err
+	}
+	// the version is saved already if the config failed to be updated after saving its history
+	var existing *v1.Secret
+	for i := range histories {
+		if getConfigVersion(&histories[i]) == version {
+			existing = &histories[i]
+		}
+	}
+	if existing != nil {
+		existing.Labels, existing.Annotations, existing.Data = history.Labels, history.Annotations, history.Data
+		if err := k.cli.Update(ctx, existing); err != nil {
+			return err
+		}
+	} else {
+		if err := k.cli.Create(ctx, history); err != nil {
+			return err
+		}
+		histories = append(histories, *history)
+	}
+	for i := 0; i < len(histories)-MaxConfigHistory; i++ {
+		if err := k.cli.Delete(ctx, &histories[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// listConfigHistory lists the history secrets of the config, sorted by the version
+func (k *kubeConfigFactory) listConfigHistory(ctx context.Context, namespace, name string) ([]v1.Secret, error) {
+	var list v1.SecretList
+	if err := k.cli.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{
+		types.LabelConfigCatalog: types.CatalogConfigHistory,
+		types.LabelConfigName:    name,
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return getConfigVersion(&list.Items[i]) < getConfigVersion(&list.Items[j])
+	})
+	return list.Items, nil
+}
+
+// getConfigVersionSecret returns the secret of the specified version, it may be the current config secret
+func (k *kubeConfigFactory) getConfigVersionSecret(ctx context.Context, namespace, name string, version int) (*v1.Secret, error) {
+	var secret v1.Secret
+	if err := k.cli.Get(ctx, pkgtypes.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrConfigNotFound
+		}
+		return nil, err
+	}
+	if getConfigVersion(&secret) == version {
+		return &secret, nil
+	}
+	histories, err := k.listConfigHistory(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range histories {
+		if getConfigVersion(&histories[i]) == version {
+			return &histories[i], nil
+		}
+	}
+	return nil, ErrConfigVersionNotFound
+}
+
+// ListConfigVersions list the versions of the config, the latest version is the first one
+func (k *kubeConfigFactory) ListConfigVersions(ctx context.Context, namespace, name string) ([]*ConfigVersion, error) {
+	var secret v1.Secret
+	if err := k.cli.Get(ctx, pkgtypes.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrConfigNotFound
+		}
+		return nil, err
+	}
+	current := convertSecret2ConfigVersion(&secret)
+	current.Current = true
+	versions := []*ConfigVersion{current}
+	histories, err := k.listConfigHistory(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(histories) - 1; i >= 0; i-- {
+		versions = append(versions, convertSecret2ConfigVersion(&histories[i]))
+	}
+	return versions, nil
+}
+
+// RollbackConfig rolls back the config to the specified version. The config is rendered by the
+// template with the properties of the version, and saved as a new version.
+func (k *kubeConfigFactory) RollbackConfig(ctx context.Context, namespace, name string, version int) error {
+	secret, err := k.getConfigVersionSecret(ctx, namespace, name, version)
+	if err != nil {
+		return err
+	}
+	if secret.Name == name {
+		return fmt.Errorf("the config %s is already at the version %d", name, version)
+	}
+	var properties = map[string]interface{}{}
+	if err := json.Unmarshal(secret.Data[SaveInputPropertiesKey], &properties); err != nil {
+		return fmt.Errorf("fail to parse the properties of the version %d: %w", version, err)
+	}
+	config, err := k.ParseConfig(ctx, NamespacedName{
+		Name:      secret.Annotations[types.LabelConfigType],
+		Namespace: secret.Annotations[types.AnnotationConfigTemplateNamespace],
+	}, Metadata{
+		NamespacedName: NamespacedName{Name: name, Namespace: namespace},
+		Alias:          secret.Annotations[types.AnnotationConfigAlias],
+		Description:    secret.Annotations[types.AnnotationConfigDescription],
+		Properties:     properties,
+	})
+	if err != nil {
+		return fmt.Errorf("fail to render the version %d: %w", version, err)
+	}
+	return k.CreateOrUpdateConfig(ctx, config, namespace)
+}
+
+// deleteConfigHistory deletes all the history versions of the config
+func (k *kubeConfigFactory) deleteConfigHistory(ctx context.Context, namespace, name string) error {
+	histories, err := k.listConfigHistory(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	for i := range histories {
+		if err := k.cli.Delete(ctx, &histories[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("fail to delete the history version %s:%w", histories[i].Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgtypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/utils/apply"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newFakeConfigFactory(cli client.Client) *kubeConfigFactory {
+	return &kubeConfigFactory{cli: cli, apiApply: func(ctx context.Context, objs []*unstructured.Unstructured, _ []apply.ApplyOption) error {
+		for _, obj := range objs {
+			existing := obj.DeepCopy()
+			err := cli.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+			if apierrors.IsNotFound(err) {
+				err = cli.Create(ctx, obj)
+			} else if err == nil {
+				obj.SetResourceVersion(existing.GetResourceVersion())
+				err = cli.Update(ctx, obj)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+}
+
+func TestConfigHistory(t *testing.T) {
+	r := require.New(t)
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+	fac := newFakeConfigFactory(cli)
+	ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: "alice"})
+	save := func(ctx context.Context, properties map[string]interface{}) {
+		config, err := fac.ParseConfig(ctx, NamespacedName{}, Metadata{
+			NamespacedName: NamespacedName{Name: "db", Namespace: "vela-system"},
+			Properties:     properties,
+		})
+		r.NoError(err)
+		r.NoError(fac.CreateOrUpdateConfig(ctx, config, "vela-system"))
+	}
+	current := func() (*v1.Secret, map[string]interface{}) {
+		secret := &v1.Secret{}
+		r.NoError(cli.Get(ctx, pkgtypes.NamespacedName{Namespace: "vela-system", Name: "db"}, secret))
+		properties := map[string]interface{}{}
+		r.NoError(json.Unmarshal(secret.Data[SaveInputPropertiesKey], &properties))
+		return secret, properties
+	}
+
+	save(ctx, map[string]interface{}{"password": "v1"})
+	secret, _ := current()
+	r.Equal("1", secret.Annotations[types.AnnotationConfigVersion])
+	r.Equal("alice", secret.Annotations[types.AnnotationConfigUpdatedBy])
+
+	// the prior version is kept when the config changes
+	save(request.WithUser(context.Background(), &user.DefaultInfo{Name: "bob"}), map[string]interface{}{"password": "v2"})
+	secret, _ = current()
+	r.Equal("2", secret.Annotations[types.AnnotationConfigVersion])
+	r.Equal("bob", secret.Annotations[types.AnnotationConfigUpdatedBy])
+	histories, err := fac.listConfigHistory(ctx, "vela-system", "db")
+	r.NoError(err)
+	r.Len(histories, 1)
+	r.True(strings.HasPrefix(histories[0].Name, "db-history-"))
+	r.Equal(types.CatalogConfigHistory, histories[0].Labels[types.LabelConfigCatalog])
+	r.Equal("1", histories[0].Annotations[types.AnnotationConfigVersion])
+
+	// the history is not listed as a config
+	configs, err := fac.ListConfigs(ctx, "vela-system", "", "", false)
+	r.NoError(err)
+	r.Len(configs, 1)
+
+	// the config named like a version does not collide with the history
+	config, err := fac.ParseConfig(ctx, NamespacedName{}, Metadata{
+		NamespacedName: NamespacedName{Name: "db-v1", Namespace: "vela-system"},
+		Properties:     map[string]interface{}{"password": "other"},
+	})
+	r.NoError(err)
+	r.NoError(fac.CreateOrUpdateConfig(ctx, config, "vela-system"))
+
+	// the version is not changed without any change
+	save(ctx, map[string]interface{}{"password": "v2"})
+	versions, err := fac.ListConfigVersions(ctx, "vela-system", "db")
+	r.NoError(err)
+	r.Len(versions, 2)
+	r.Equal(2, versions[0].Version)
+	r.True(versions[0].Current)
+	r.Equal("bob", versions[0].UpdatedBy)
+	r.Equal(1, versions[1].Version)
+	r.Equal("alice", versions[1].UpdatedBy)
+
+	// the string data merged into the same data is not a change
+	unchanged := &v1.Secret{StringData: map[string]string{}}
+	for key, value := range secret.Data {
+		unchanged.StringData[key] = string(value)
+	}
+	r.NoError(fac.setConfigVersion(ctx, secret, unchanged))
+	r.Equal("2", unchanged.Annotations[types.AnnotationConfigVersion])
+
+	// roll back as a new version
+	r.NoError(fac.RollbackConfig(ctx, "vela-system", "db", 1))
+	secret, properties := current()
+	r.Equal("3", secret.Annotations[types.AnnotationConfigVersion])
+	r.Equal("v1", properties["password"])
+	r.Error(fac.RollbackConfig(ctx, "vela-system", "db", 3))
+	r.True(errors.Is(fac.RollbackConfig(ctx, "vela-system", "db", 10), ErrConfigVersionNotFound))
+
+	// the oldest versions are pruned
+	original := MaxConfigHistory
+	MaxConfigHistory = 2
+	t.Cleanup(func() { MaxConfigHistory = original })
+	save(ctx, map[string]interface{}{"password": "v4"})
+	versions, err = fac.ListConfigVersions(ctx, "vela-system", "db")
+	r.NoError(err)
+	r.Len(versions, 3)
+	r.Equal([]int{4, 3, 2}, []int{versions[0].Version, versions[1].Version, versions[2].Version})
+
+	// the history is deleted with the config
+	r.NoError(fac.DeleteConfig(ctx, "vela-system", "db"))
+	r.NoError(fac.DeleteConfig(ctx, "vela-system", "db-v1"))
+	secrets := &v1.SecretList{}
+	r.NoError(cli.List(ctx, secrets))
+	r.Len(secrets.Items, 0)
+}
+
+func TestDistributePinnedConfig(t *testing.T) {
+	r := require.New(t)
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+	fac := newFakeConfigFactory(cli)
+	ctx := context.Background()
+	for _, password := range []string{"v1", "v2"} {
+		config, err := fac.ParseConfig(ctx, NamespacedName{}, Metadata{
+			NamespacedName: NamespacedName{Name: "db", Namespace: "vela-system"},
+			Properties:     map[string]interface{}{"password": password},
+		})
+		r.NoError(err)
+		r.NoError(fac.CreateOrUpdateConfig(ctx, config, "vela-system"))
+	}
+	spec := &CreateDistributionSpec{
+		Configs:  []*NamespacedName{{Name: "db", Namespace: "vela-system"}},
+		Targets:  []*ClusterTarget{{ClusterName: "local", Namespace: "default"}},
+		Versions: map[string]int{"db": 1},
+	}
+	r.NoError(fac.CreateOrUpdateDistribution(ctx, "vela-system", DefaultDistributionName("db"), spec))
+	app := &v1beta1.Application{}
+	r.NoError(cli.Get(ctx, pkgtypes.NamespacedName{Namespace: "vela-system", Name: DefaultDistributionName("db")}, app))
+	r.Len(app.Spec.Components, 1)
+	r.Equal(v1alpha1.K8sObjectsComponentType, app.Spec.Components[0].Type)
+	var properties struct {
+		Objects []v1.Secret `json:"objects"`
+	}
+	r.NoError(json.Unmarshal(app.Spec.Components[0].Properties.Raw, &properties))
+	r.Len(properties.Objects, 1)
+	r.Equal("db", properties.Objects[0].Name)
+	r.Equal("1", properties.Objects[0].Annotations[types.AnnotationConfigVersion])
+	r.Equal(types.VelaCoreConfig, properties.Objects[0].Labels[types.LabelConfigCatalog])
+	r.JSONEq(`{"password":"v1"}`, string(properties.Objects[0].Data[SaveInputPropertiesKey]))
+
+	// follow the latest version
+	spec.Versions = nil
+	r.NoError(fac.CreateOrUpdateDistribution(ctx, "vela-system", DefaultDistributionName("db"), spec))
+	r.NoError(cli.Get(ctx, pkgtypes.NamespacedName{Namespace: "vela-system", Name: DefaultDistributionName("db")}, app))
+	r.Len(app.Spec.Components, 1)
+	r.Equal(v1alpha1.RefObjectsComponentType, app.Spec.Components[0].Type)
+
+	spec.Versions = map[string]int{"db": 5}
+	r.Error(fac.CreateOrUpdateDistribution(ctx, "vela-system", DefaultDistributionName("db"), spec))
+}
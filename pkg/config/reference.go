@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	pkgtypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+)
+
+// SaveReferencesKey define the key name for saving the config references of the template
+const SaveReferencesKey = "references"
+
+// ErrConfigReferenced means the config is referenced by other configs
+var ErrConfigReferenced = errors.New("the config is referenced by other configs")
+
+// ConfigReference declares that a parameter of the template references another config.
+// The value of the parameter is the name of the referenced config, in the format of
+// <name> or <namespace>/<name>.
+type ConfigReference struct {
+	// Template is the template of the referenced config
+	Template string `json:"template"`
+}
+
+// ReferencedConfig is the config referenced by another config
+type ReferencedConfig struct {
+	NamespacedName
+	Template string `json:"template"`
+}
+
+// String returns the namespaced name of the referenced config
+func (r ReferencedConfig) String() string {
+	return r.Namespace + "/" + r.Name
+}
+
+// parseTemplateReferences parse the config references declared in the template
+func parseTemplateReferences(templateValue cue.Value) (map[string]ConfigReference, error) {
+	value := templateValue.LookupPath(cue.ParsePath(SaveReferencesKey))
+	if !value.Exists() {
+		return nil, nil
+	}
+	var references map[string]ConfigReference
+	if err := value.Decode(&references); err != nil {
+		return nil, fmt.Errorf("the references of the template are invalid: %w", err)
+	}
+	for key, ref := range references {
+		if ref.Template == "" {
+			return nil, fmt.Errorf("the template of the reference %s must be specified", key)
+		}
+	}
+	return references, nil
+}
+
+// resolveReferences resolves the configs referenced by the properties. It returns the referenced
+// configs and their properties, which are rendered as the context.references.
+func (k *kubeConfigFactory) resolveReferences(ctx context.Context, template *Template, meta Metadata) ([]ReferencedConfig, map[string]interface{}, error) {
+	if len(template.References) == 0 {
+		return nil, nil, nil
+	}
+	var keys []string
+	for key := range template.References {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var references []ReferencedConfig
+	var values = map[string]interface{}{}
+	for _, key := range keys {
+		value, ok := meta.Properties[key]
+		if !ok || value == nil {
+			continue
+		}
+		name, ok := value.(string)
+		if !ok || name == "" {
+			return nil, nil, fmt.Errorf("the parameter %s must be the name of a config", key)
+		}
+		ref := ReferencedConfig{NamespacedName: NamespacedName{Name: name, Namespace: meta.Namespace}, Template: template.References[key].Template}
+		if strings.Contains(name, "/") {
+			namespacedName := strings.SplitN(name, "/", 2)
+			ref.Namespace, ref.Name = namespacedName[0], namespacedName[1]
+		}
+		secret, err := getReferencedConfig(ctx, k.cli, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		if secret.Annotations[types.AnnotationConfigSensitive] == "true" && !template.Sensitive {
+			return nil, nil, fmt.Errorf("the sensitive config %s can only be referenced by a sensitive config", ref)
+		}
+		var properties = map[string]interface{}{}
+		if err := json.Unmarshal(secret.Data[SaveInputPropertiesKey], &properties); err != nil {
+			return nil, nil, fmt.Errorf("fail to parse the properties of the config %s: %w", ref, err)
+		}
+		references = append(references, ref)
+		values[key] = properties
+	}
+	return references, values, nil
+}
+
+// getReferencedConfig gets the secret of the referenced config, and checks the template of the config
+func getReferencedConfig(ctx context.Context, cli client.Client, ref ReferencedConfig) (*v1.Secret, error) {
+	var secret v1.Secret
+	if err := cli.Get(ctx, pkgtypes.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("the referenced config %s does not exist", ref)
+		}
+		return nil, err
+	}
+	if secret.Labels[types.LabelConfigCatalog] != types.VelaCoreConfig {
+		return nil, fmt.Errorf("the referenced secret %s is not a config", ref)
+	}
+	if secret.Labels[types.LabelConfigType] != ref.Template {
+		return nil, fmt.Errorf("the referenced config %s is created by the template %s, expect %s", ref, secret.Labels[types.LabelConfigType], ref.Template)
+	}
+	return &secret, nil
+}
+
+// ConfigReferenceLabel returns the label marked on the configs referencing the config. The namespaced
+// name of the referenced config is hashed to fit in the label key.
+func ConfigReferenceLabel(namespace, name string) string {
+	sum := sha256.Sum256([]byte(namespace + "/" + name))
+	return types.LabelConfigReferencePrefix + hex.EncodeToString(sum[:16])
+}
+
+// GetConfigReferences returns the configs referenced by the config secret
+func GetConfigReferences(secret *v1.Secret) ([]ReferencedConfig, error) {
+	content, ok := secret.Annotations[types.AnnotationConfigReferences]
+	if !ok || content == "" {
+		return nil, nil
+	}
+	var references []ReferencedConfig
+	if err := json.Unmarshal([]byte(content), &references); err != nil {
+		return nil, fmt.Errorf("the references of the config %s are invalid: %w", secret.Name, err)
+	}
+	return references, nil
+}
+
+// ValidateConfigReferences checks all the configs referenced by the config secret exist, are
+// created by the declared templates, and do not reference the config back.
+func ValidateConfigReferences(ctx context.Context, cli client.Client, secret *v1.Secret) error {
+	references, err := GetConfigReferences(secret)
+	if err != nil {
+		return err
+	}
+	for _, ref := range references {
+		if ref.Namespace == secret.Namespace && ref.Name == secret.Name {
+			return fmt.Errorf("the config %s can not reference itself", ref)
+		}
+		if _, err := getReferencedConfig(ctx, cli, ref); err != nil {
+			return err
+		}
+	}
+	return checkReferenceCycle(ctx, cli, secret)
+}
+
+// checkReferenceCycle follows the references of the configs referenced by the config secret, and
+// returns an error if the config is referenced back, which makes none of the configs deletable.
+func checkReferenceCycle(ctx context.Context, cli client.Client, secret *v1.Secret) error {
+	references, err := GetConfigReferences(secret)
+	if err != nil {
+		return err
+	}
+	self := NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+	visited := map[NamespacedName]bool{}
+	var visit func(path []string, references []ReferencedConfig) error
+	visit = func(path []string, references []ReferencedConfig) error {
+		for _, ref := range references {
+			refPath := append(append([]string{}, path...), ref.String())
+			if ref.NamespacedName == self {
+				return fmt.Errorf("the config references form a cycle: %s", strings.Join(refPath, " -> "))
+			}
+			if visited[ref.NamespacedName] {
+				continue
+			}
+			visited[ref.NamespacedName] = true
+			var referenced v1.Secret
+			if err := cli.Get(ctx, pkgtypes.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, &referenced); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			next, err := GetConfigReferences(&referenced)
+			if err != nil {
+				return err
+			}
+			if err := visit(refPath, next); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit([]string{secret.Namespace + "/" + secret.Name}, references)
+}
+
+// ListConfigDependants lists the configs that reference the config. The configs are selected by the
+// reference label, and confirmed by the references annotation.
+func ListConfigDependants(ctx context.Context, cli client.Client, namespace, name string) ([]NamespacedName, error) {
+	var list v1.SecretList
+	if err := cli.List(ctx, &list, client.MatchingLabels{
+		types.LabelConfigCatalog:              types.VelaCoreConfig,
+		ConfigReferenceLabel(namespace, name): "true",
+	}); err != nil {
+		return nil, err
+	}
+	var dependants []NamespacedName
+	for i := range list.Items {
+		references, err := GetConfigReferences(&list.Items[i])
+		if err != nil {
+			continue
+		}
+		for _, ref := range references {
+			if ref.Namespace == namespace && ref.Name == name {
+				dependants = append(dependants, NamespacedName{Name: list.Items[i].Name, Namespace: list.Items[i].Namespace})
+				break
+			}
+		}
+	}
+	return dependants, nil
+}
+
+// CheckConfigNotReferenced returns an error with the list of the dependants if the config is referenced
+func CheckConfigNotReferenced(ctx context.Context, cli client.Client, namespace, name string) error {
+	dependants, err := ListConfigDependants(ctx, cli, namespace, name)
+	if err != nil {
+		return err
+	}
+	if len(dependants) == 0 {
+		return nil
+	}
+	var names []string
+	for _, d := range dependants {
+		names = append(names, d.Namespace+"/"+d.Name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("%w, the config %s/%s is referenced by: %s", ErrConfigReferenced, namespace, name, strings.Join(names, ", "))
+}
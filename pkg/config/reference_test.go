@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	pkgtypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+const databaseTemplate = `
+metadata: {
+	name:      "database"
+	sensitive: %s
+}
+template: {
+	parameter: {
+		host: string
+		port: *3306 | int
+	}
+}
+`
+
+const applicationTemplate = `
+metadata: {
+	name: "app-settings"
+}
+template: {
+	references: database: template: "database"
+	output: {
+		apiVersion: "v1"
+		kind:       "Secret"
+		stringData: {
+			dsn: "\(context.references.database.host):\(context.references.database.port)"
+		}
+	}
+	parameter: {
+		database: string
+	}
+}
+`
+
+func TestConfigReferences(t *testing.T) {
+	r := require.New(t)
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+	fac := newFakeConfigFactory(cli)
+	ctx := context.Background()
+	applyTemplate := func(content string) {
+		template, err := fac.ParseTemplate(ctx, "", []byte(content))
+		r.NoError(err)
+		r.NoError(fac.CreateOrUpdateConfigTemplate(ctx, "vela-system", template))
+	}
+	save := func(template, name string, properties map[string]interface{}) error {
+		config, err := fac.ParseConfig(ctx, NamespacedName{Name: template, Namespace: "vela-system"}, Metadata{
+			NamespacedName: NamespacedName{Name: name, Namespace: "vela-system"},
+			Properties:     properties,
+		})
+		if err != nil {
+			return err
+		}
+		return fac.CreateOrUpdateConfig(ctx, config, "vela-system")
+	}
+	applyTemplate(fmt.Sprintf(databaseTemplate, "false"))
+	applyTemplate(applicationTemplate)
+
+	template, err := fac.LoadTemplate(ctx, "app-settings", "vela-system")
+	r.NoError(err)
+	r.Equal(map[string]ConfigReference{"database": {Template: "database"}}, template.References)
+
+	// the referenced config must exist
+	err = save("app-settings", "app", map[string]interface{}{"database": "db"})
+	r.Error(err)
+	r.Contains(err.Error(), "does not exist")
+
+	r.NoError(save("database", "db", map[string]interface{}{"host": "127.0.0.1"}))
+	config, err := fac.ParseConfig(ctx, NamespacedName{Name: "app-settings", Namespace: "vela-system"}, Metadata{
+		NamespacedName: NamespacedName{Name: "app", Namespace: "vela-system"},
+		Properties:     map[string]interface{}{"database": "db"},
+	})
+	r.NoError(err)
+	r.Equal("127.0.0.1:3306", config.Secret.StringData["dsn"])
+	r.NoError(fac.CreateOrUpdateConfig(ctx, config, "vela-system"))
+	secret := &v1.Secret{}
+	r.NoError(cli.Get(ctx, pkgtypes.NamespacedName{Namespace: "vela-system", Name: "app"}, secret))
+	references, err := GetConfigReferences(secret)
+	r.NoError(err)
+	r.Equal([]ReferencedConfig{{NamespacedName: NamespacedName{Name: "db", Namespace: "vela-system"}, Template: "database"}}, references)
+	r.NoError(ValidateConfigReferences(ctx, cli, secret))
+
+	// the referenced config must be created by the declared template
+	r.NoError(save("app-settings", "app-2", map[string]interface{}{"database": "vela-system/db"}))
+	err = save("app-settings", "app-3", map[string]interface{}{"database": "app"})
+	r.Error(err)
+	r.Contains(err.Error(), "expect database")
+
+	// the referenced config can not be deleted
+	err = fac.DeleteConfig(ctx, "vela-system", "db")
+	r.True(errors.Is(err, ErrConfigReferenced))
+	r.Contains(err.Error(), "vela-system/app, vela-system/app-2")
+	r.NoError(fac.DeleteConfig(ctx, "vela-system", "app"))
+	r.NoError(fac.DeleteConfig(ctx, "vela-system", "app-2"))
+	r.NoError(ValidateConfigReferences(ctx, cli, &v1.Secret{}))
+	r.NoError(fac.DeleteConfig(ctx, "vela-system", "db"))
+
+	// the sensitive config can only be referenced by the sensitive config
+	applyTemplate(fmt.Sprintf(databaseTemplate, "true"))
+	r.NoError(save("database", "db", map[string]interface{}{"host": "127.0.0.1"}))
+	err = save("app-settings", "app", map[string]interface{}{"database": "db"})
+	r.Error(err)
+	r.Contains(err.Error(), "can only be referenced by a sensitive config")
+}
+
+func TestValidateConfigReferences(t *testing.T) {
+	r := require.New(t)
+	db := &v1.Secret{}
+	db.Name, db.Namespace = "db", "default"
+	db.Labels = map[string]string{types.LabelConfigCatalog: types.VelaCoreConfig, types.LabelConfigType: "database"}
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(db).Build()
+	ctx := context.Background()
+
+	secret := &v1.Secret{}
+	secret.Name, secret.Namespace = "app", "default"
+	secret.Annotations = map[string]string{types.AnnotationConfigReferences: `[{"name":"db","namespace":"default","template":"database"}]`}
+	r.NoError(ValidateConfigReferences(ctx, cli, secret))
+
+	secret.Annotations[types.AnnotationConfigReferences] = `[{"name":"db","namespace":"default","template":"redis"}]`
+	r.Error(ValidateConfigReferences(ctx, cli, secret))
+	secret.Annotations[types.AnnotationConfigReferences] = `[{"name":"cache","namespace":"default","template":"database"}]`
+	r.Error(ValidateConfigReferences(ctx, cli, secret))
+	secret.Annotations[types.AnnotationConfigReferences] = `[{"name":"app","namespace":"default","template":"database"}]`
+	r.Error(ValidateConfigReferences(ctx, cli, secret))
+	secret.Annotations[types.AnnotationConfigReferences] = `invalid`
+	r.Error(ValidateConfigReferences(ctx, cli, secret))
+
+	// db -> app -> db
+	db.Annotations = map[string]string{types.AnnotationConfigReferences: `[{"name":"app","namespace":"default","template":"app-settings"}]`}
+	r.NoError(cli.Update(ctx, db))
+	secret.Annotations[types.AnnotationConfigReferences] = `[{"name":"db","namespace":"default","template":"database"}]`
+	err := ValidateConfigReferences(ctx, cli, secret)
+	r.Error(err)
+	r.Contains(err.Error(), "form a cycle: default/app -> default/db -> default/app")
+}
+
+func TestConfigReferenceLabel(t *testing.T) {
+	r := require.New(t)
+	label := ConfigReferenceLabel("default", "db")
+	r.True(strings.HasPrefix(label, types.LabelConfigReferencePrefix))
+	r.Equal(label, ConfigReferenceLabel("default", "db"))
+	r.NotEqual(label, ConfigReferenceLabel("vela-system", "db"))
+	r.Empty(validation.IsQualifiedName(label))
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	icontext "github.com/oam-dev/kubevela/pkg/config/context"
+	"github.com/oam-dev/kubevela/pkg/cue/script"
+)
+
+// EtcdConfig defines the etcd output, the content is saved as the value of the key.
+type EtcdConfig struct {
+	Endpoint ConfigRef `json:"endpoint"`
+	// Format defines the format in which Data will be output.
+	Format   string             `json:"format"`
+	Metadata EtcdConfigMetadata `json:"metadata"`
+}
+
+// EtcdConfigMetadata the metadata of the etcd config
+type EtcdConfigMetadata struct {
+	Key string `json:"key"`
+}
+
+// EtcdData merge the etcd endpoint config and the rendered data
+type EtcdData struct {
+	EtcdConfig
+	Content []byte       `json:"-"`
+	Client  *http.Client `json:"-"`
+}
+
+func init() {
+	RegisterWriter("etcd", typedWriterRender(renderEtcd))
+}
+
+func renderEtcd(config *EtcdConfig, template script.CUE, context icontext.ConfigRenderContext, properties map[string]interface{}) (*EtcdData, error) {
+	var etcdData EtcdData
+	out, err := renderWriter("etcd", template, context, properties, config.Format, &etcdData)
+	if err != nil {
+		return nil, err
+	}
+	etcdData.Content = out
+	if etcdData.Endpoint.Namespace == "" {
+		etcdData.Endpoint.Namespace = types.DefaultKubeVelaNS
+	}
+	return &etcdData, nil
+}
+
+// Write puts the config to the etcd server by the JSON gRPC gateway of etcd v3. The config of the
+// etcd server supports the endpoints and the username and password. The endpoints are tried in order.
+func (e *EtcdData) Write(ctx context.Context, configReader icontext.ReadConfigProvider) error {
+	config, err := readServerConfig(ctx, configReader, e.Endpoint, "etcd")
+	if err != nil {
+		return err
+	}
+	if e.Metadata.Key == "" {
+		return fmt.Errorf("the key of the etcd config is empty")
+	}
+	var endpoints []string
+	items, _ := config["endpoints"].([]interface{})
+	for _, item := range items {
+		if endpoint, ok := item.(string); ok && endpoint != "" {
+			endpoints = append(endpoints, strings.TrimSuffix(endpoint, "/"))
+		}
+	}
+	if len(endpoints) == 0 {
+		return fmt.Errorf("the endpoints of the etcd server %s are empty", e.Endpoint.Name)
+	}
+	body, err := json.Marshal(map[string]string{
+		"key":   base64.StdEncoding.EncodeToString([]byte(e.Metadata.Key)),
+		"value": base64.StdEncoding.EncodeToString(e.Content),
+	})
+	if err != nil {
+		return err
+	}
+	for _, endpoint := range endpoints {
+		if err = e.put(ctx, endpoint, readString(config, "username"), readString(config, "password"), body); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("fail to put the config to the etcd server:%w", err)
+}
+
+func (e *EtcdData) put(ctx context.Context, endpoint, username, password string, body []byte) error {
+	headers := map[string]string{"Content-Type": "application/json"}
+	if username != "" {
+		token, err := e.authenticate(ctx, endpoint, username, password)
+		if err != nil {
+			return err
+		}
+		headers["Authorization"] = token
+	}
+	req, err := newWriterRequest(ctx, http.MethodPost, endpoint+"/v3/kv/put", headers, body)
+	if err != nil {
+		return err
+	}
+	_, err = doWriterRequest(e.Client, req)
+	return err
+}
+
+func (e *EtcdData) authenticate(ctx context.Context, endpoint, username, password string) (string, error) {
+	body, err := json.Marshal(map[string]string{"name": username, "password": password})
+	if err != nil {
+		return "", err
+	}
+	req, err := newWriterRequest(ctx, http.MethodPost, endpoint+"/v3/auth/authenticate", map[string]string{"Content-Type": "application/json"}, body)
+	if err != nil {
+		return "", err
+	}
+	resp, err := doWriterRequest(e.Client, req)
+	if err != nil {
+		return "", err
+	}
+	var auth struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(resp, &auth); err != nil {
+		return "", err
+	}
+	return auth.Token, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	configcontext "github.com/oam-dev/kubevela/pkg/config/context"
+	"github.com/oam-dev/kubevela/pkg/cue/script"
+)
+
+func TestEtcdWriter(t *testing.T) {
+	r := require.New(t)
+	store := map[string]string{}
+	// a local stand-in of the JSON gRPC gateway of etcd
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v3/auth/authenticate":
+			var auth map[string]string
+			_ = json.NewDecoder(req.Body).Decode(&auth)
+			if auth["name"] != "root" || auth["password"] != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"token-1"}`))
+		case "/v3/kv/put":
+			if req.Header.Get("Authorization") != "token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var kv map[string]string
+			_ = json.NewDecoder(req.Body).Decode(&kv)
+			key, _ := base64.StdEncoding.DecodeString(kv["key"])
+			value, _ := base64.StdEncoding.DecodeString(kv["value"])
+			store[string(key)] = string(value)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	data, err := renderEtcd(&EtcdConfig{Format: "yaml"}, script.CUE(`
+	template: {
+		etcd: {
+			endpoint: name: "etcd-server"
+			metadata: key: "/configs/\(context.namespace)/\(context.name)"
+			content: parameter
+		}
+		parameter: {
+			host: string
+			port: int
+		}
+	}
+	`), configcontext.ConfigRenderContext{Name: "db", Namespace: "vela"}, map[string]interface{}{
+		"host": "127.0.0.1",
+		"port": 3306,
+	})
+	r.NoError(err)
+	r.Equal("/configs/vela/db", data.Metadata.Key)
+
+	serverConfig := map[string]interface{}{
+		// the unreachable endpoint is skipped
+		"endpoints": []interface{}{"http://127.0.0.1:0", server.URL},
+		"username":  "root",
+		"password":  "pass",
+	}
+	readConfig := func(ctx context.Context, namespace, name string) (map[string]interface{}, error) {
+		return serverConfig, nil
+	}
+	r.NoError(data.Write(context.Background(), readConfig))
+	r.Equal("host: 127.0.0.1\nport: 3306\n", store["/configs/vela/db"])
+
+	serverConfig["password"] = "wrong"
+	err = data.Write(context.Background(), readConfig)
+	r.Error(err)
+	r.Contains(err.Error(), "returns 401")
+
+	serverConfig["endpoints"] = []interface{}{}
+	r.Error(data.Write(context.Background(), readConfig))
+}
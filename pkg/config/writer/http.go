@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	icontext "github.com/oam-dev/kubevela/pkg/config/context"
+	"github.com/oam-dev/kubevela/pkg/cue/script"
+)
+
+// defaultWriterClient is the http client used by the writers when no client is set
+var defaultWriterClient = &http.Client{Timeout: 30 * time.Second}
+
+// HTTPConfig defines the generic http output, the content is sent to the target url.
+type HTTPConfig struct {
+	Endpoint ConfigRef `json:"endpoint"`
+	// Format defines the format in which Data will be output.
+	Format   string             `json:"format"`
+	Metadata HTTPConfigMetadata `json:"metadata"`
+}
+
+// HTTPConfigMetadata the metadata of the http config
+type HTTPConfigMetadata struct {
+	// Path is appended to the url of the http server
+	Path string `json:"path,omitempty"`
+	// Method is the http method, default to PUT
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// HTTPData merge the http endpoint config and the rendered data
+type HTTPData struct {
+	HTTPConfig
+	Content []byte       `json:"-"`
+	Client  *http.Client `json:"-"`
+}
+
+func init() {
+	RegisterWriter("http", typedWriterRender(renderHTTP))
+}
+
+func renderHTTP(config *HTTPConfig, template script.CUE, context icontext.ConfigRenderContext, properties map[string]interface{}) (*HTTPData, error) {
+	var httpData HTTPData
+	out, err := renderWriter("http", template, context, properties, config.Format, &httpData)
+	if err != nil {
+		return nil, err
+	}
+	httpData.Content = out
+	if httpData.Format == "" {
+		httpData.Format = config.Format
+	}
+	if httpData.Endpoint.Namespace == "" {
+		httpData.Endpoint.Namespace = types.DefaultKubeVelaNS
+	}
+	return &httpData, nil
+}
+
+// Write sends the config to the http server. The config of the http server supports the url,
+// the bearer token, the basic auth and the extra headers.
+func (h *HTTPData) Write(ctx context.Context, configReader icontext.ReadConfigProvider) error {
+	config, err := readServerConfig(ctx, configReader, h.Endpoint, "http")
+	if err != nil {
+		return err
+	}
+	url := readString(config, "url")
+	if url == "" {
+		return fmt.Errorf("the url of the http server %s is empty", h.Endpoint.Name)
+	}
+	if h.Metadata.Path != "" {
+		url = strings.TrimSuffix(url, "/") + "/" + strings.TrimPrefix(h.Metadata.Path, "/")
+	}
+	method := h.Metadata.Method
+	if method == "" {
+		method = http.MethodPut
+	}
+	headers := readStringMap(config, "headers")
+	headers["Content-Type"] = contentType(h.Format)
+	if token := readString(config, "token"); token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	for k, v := range h.Metadata.Headers {
+		headers[k] = v
+	}
+	req, err := newWriterRequest(ctx, strings.ToUpper(method), url, headers, h.Content)
+	if err != nil {
+		return err
+	}
+	if username := readString(config, "username"); username != "" {
+		req.SetBasicAuth(username, readString(config, "password"))
+	}
+	if _, err := doWriterRequest(h.Client, req); err != nil {
+		return fmt.Errorf("fail to send the config to the http server:%w", err)
+	}
+	return nil
+}
+
+func contentType(format string) string {
+	switch strings.ToLower(format) {
+	case "json":
+		return "application/json"
+	case "toml":
+		return "application/toml"
+	case "properties":
+		return "text/plain"
+	default:
+		return "application/yaml"
+	}
+}
+
+func newWriterRequest(ctx context.Context, method, url string, headers map[string]string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+// doWriterRequest sends the request and returns the response body, the non-2xx response is an error
+func doWriterRequest(cli *http.Client, req *http.Request) ([]byte, error) {
+	if cli == nil {
+		cli = defaultWriterClient
+	}
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returns %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	configcontext "github.com/oam-dev/kubevela/pkg/config/context"
+	"github.com/oam-dev/kubevela/pkg/cue/script"
+)
+
+func TestHTTPWriter(t *testing.T) {
+	r := require.New(t)
+	var received *http.Request
+	var body string
+	// a local stand-in of the http target
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received = req
+		data, _ := io.ReadAll(req.Body)
+		body = string(data)
+		if req.URL.Path == "/not-found" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	data, err := renderHTTP(&HTTPConfig{}, script.CUE(`
+	template: {
+		http: {
+			endpoint: name: "config-center"
+			format: "json"
+			metadata: {
+				path: "/configs/\(context.name)"
+				headers: "X-Config-Namespace": context.namespace
+			}
+			content: parameter
+		}
+		parameter: {
+			host: string
+		}
+	}
+	`), configcontext.ConfigRenderContext{Name: "db", Namespace: "vela"}, map[string]interface{}{
+		"host": "127.0.0.1",
+	})
+	r.NoError(err)
+	r.Equal("json", data.Format)
+
+	serverConfig := map[string]interface{}{
+		"url":     server.URL,
+		"token":   "token-1",
+		"headers": map[string]interface{}{"X-Team": "a"},
+	}
+	readConfig := func(ctx context.Context, namespace, name string) (map[string]interface{}, error) {
+		return serverConfig, nil
+	}
+	r.NoError(data.Write(context.Background(), readConfig))
+	r.Equal(http.MethodPut, received.Method)
+	r.Equal("/configs/db", received.URL.Path)
+	r.Equal("Bearer token-1", received.Header.Get("Authorization"))
+	r.Equal("application/json", received.Header.Get("Content-Type"))
+	r.Equal("a", received.Header.Get("X-Team"))
+	r.Equal("vela", received.Header.Get("X-Config-Namespace"))
+	r.Equal(`{"host":"127.0.0.1"}`, body)
+
+	data.Metadata.Method = "post"
+	delete(serverConfig, "token")
+	serverConfig["username"] = "admin"
+	serverConfig["password"] = "pass"
+	r.NoError(data.Write(context.Background(), readConfig))
+	r.Equal(http.MethodPost, received.Method)
+	username, password, ok := received.BasicAuth()
+	r.True(ok)
+	r.Equal("admin", username)
+	r.Equal("pass", password)
+
+	data.Metadata.Path = "not-found"
+	err = data.Write(context.Background(), readConfig)
+	r.Error(err)
+	r.Contains(err.Error(), "returns 404")
+}
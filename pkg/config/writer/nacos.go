@@ -127,13 +127,6 @@ func (n *NacosData) write(ctx context.Context, configReader icontext.ReadConfigP
 	if err != nil {
 		return fmt.Errorf("fail to read the config of the nacos server:%w", err)
 	}
-	readString := func(data map[string]interface{}, key string) string {
-		if v, ok := data[key]; ok {
-			str, _ := v.(string)
-			return str
-		}
-		return ""
-	}
 	readUint64 := func(data map[string]interface{}, key string) uint64 {
 		if v, ok := data[key]; ok {
 			vu, _ := v.(float64)
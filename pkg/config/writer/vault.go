@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	icontext "github.com/oam-dev/kubevela/pkg/config/context"
+	"github.com/oam-dev/kubevela/pkg/cue/script"
+)
+
+// VaultConfig defines the HashiCorp Vault KV output, the content is saved as the key-values of the secret.
+type VaultConfig struct {
+	Endpoint ConfigRef           `json:"endpoint"`
+	Metadata VaultConfigMetadata `json:"metadata"`
+}
+
+// VaultConfigMetadata the metadata of the vault config
+type VaultConfigMetadata struct {
+	// Mount is the mount path of the KV secrets engine, default to secret
+	Mount string `json:"mount,omitempty"`
+	// Path is the path of the secret in the KV secrets engine
+	Path string `json:"path"`
+	// KVVersion is the version of the KV secrets engine, 1 or 2, default to 2
+	KVVersion int `json:"kvVersion,omitempty"`
+}
+
+// VaultData merge the vault endpoint config and the rendered data
+type VaultData struct {
+	VaultConfig
+	Content map[string]interface{} `json:"-"`
+	Client  *http.Client           `json:"-"`
+}
+
+func init() {
+	RegisterWriter("vault", typedWriterRender(renderVault))
+}
+
+func renderVault(config *VaultConfig, template script.CUE, context icontext.ConfigRenderContext, properties map[string]interface{}) (*VaultData, error) {
+	var vaultData VaultData
+	out, err := renderWriter("vault", template, context, properties, "json", &vaultData)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(out, &vaultData.Content); err != nil {
+		return nil, fmt.Errorf("the content of the vault secret must be the key-values:%w", err)
+	}
+	if vaultData.Metadata.Path == "" {
+		vaultData.Metadata.Path = config.Metadata.Path
+	}
+	if vaultData.Endpoint.Namespace == "" {
+		vaultData.Endpoint.Namespace = types.DefaultKubeVelaNS
+	}
+	return &vaultData, nil
+}
+
+// Write saves the config to the KV secrets engine of the vault server. The config of the vault
+// server supports the address, the token and the vault namespace.
+func (v *VaultData) Write(ctx context.Context, configReader icontext.ReadConfigProvider) error {
+	config, err := readServerConfig(ctx, configReader, v.Endpoint, "vault")
+	if err != nil {
+		return err
+	}
+	address := readString(config, "address")
+	if address == "" {
+		return fmt.Errorf("the address of the vault server %s is empty", v.Endpoint.Name)
+	}
+	if v.Metadata.Path == "" {
+		return fmt.Errorf("the path of the vault secret is empty")
+	}
+	mount := v.Metadata.Mount
+	if mount == "" {
+		mount = "secret"
+	}
+	prefix := fmt.Sprintf("%s/v1/%s/", strings.TrimSuffix(address, "/"), strings.Trim(mount, "/"))
+	path := strings.TrimPrefix(v.Metadata.Path, "/")
+	var url string
+	var body interface{}
+	switch v.Metadata.KVVersion {
+	case 0, 2:
+		// the KV version 2 wraps the key-values with the data field
+		url, body = prefix+"data/"+path, map[string]interface{}{"data": v.Content}
+	case 1:
+		url, body = prefix+path, v.Content
+	default:
+		return fmt.Errorf("the kv version %d of the vault secret is not supported", v.Metadata.KVVersion)
+	}
+	content, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{"Content-Type": "application/json", "X-Vault-Token": readString(config, "token")}
+	if namespace := readString(config, "namespace"); namespace != "" {
+		headers["X-Vault-Namespace"] = namespace
+	}
+	req, err := newWriterRequest(ctx, http.MethodPost, url, headers, content)
+	if err != nil {
+		return err
+	}
+	if _, err := doWriterRequest(v.Client, req); err != nil {
+		return fmt.Errorf("fail to write the config to the vault server:%w", err)
+	}
+	return nil
+}
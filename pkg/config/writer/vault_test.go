@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	configcontext "github.com/oam-dev/kubevela/pkg/config/context"
+	"github.com/oam-dev/kubevela/pkg/cue/script"
+)
+
+func TestVaultWriter(t *testing.T) {
+	r := require.New(t)
+	var path, token, namespace string
+	var body map[string]interface{}
+	// a local stand-in of the vault server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path, token, namespace = req.URL.Path, req.Header.Get("X-Vault-Token"), req.Header.Get("X-Vault-Namespace")
+		data, _ := io.ReadAll(req.Body)
+		body = nil
+		_ = json.Unmarshal(data, &body)
+		if token != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	data, err := renderVault(&VaultConfig{}, script.CUE(`
+	template: {
+		vault: {
+			endpoint: name: "vault-server"
+			metadata: {
+				mount: "kv"
+				path:  "apps/\(context.name)"
+				if parameter.kvVersion != _|_ {
+					kvVersion: parameter.kvVersion
+				}
+			}
+			content: parameter.content
+		}
+		parameter: {
+			content: {...}
+			kvVersion?: int
+		}
+	}
+	`), configcontext.ConfigRenderContext{Name: "db", Namespace: "vela"}, map[string]interface{}{
+		"content": map[string]interface{}{"host": "127.0.0.1", "port": 3306},
+	})
+	r.NoError(err)
+	r.Equal("apps/db", data.Metadata.Path)
+	r.Equal("vela-system", data.Endpoint.Namespace)
+
+	serverConfig := map[string]interface{}{"address": server.URL + "/", "token": "root", "namespace": "team-a"}
+	readConfig := func(ctx context.Context, namespace, name string) (map[string]interface{}, error) {
+		r.Equal("vault-server", name)
+		return serverConfig, nil
+	}
+	r.NoError(data.Write(context.Background(), readConfig))
+	r.Equal("/v1/kv/data/apps/db", path)
+	r.Equal("team-a", namespace)
+	r.Equal(map[string]interface{}{"data": map[string]interface{}{"host": "127.0.0.1", "port": float64(3306)}}, body)
+
+	data.Metadata.KVVersion = 1
+	r.NoError(data.Write(context.Background(), readConfig))
+	r.Equal("/v1/kv/apps/db", path)
+	r.Equal(map[string]interface{}{"host": "127.0.0.1", "port": float64(3306)}, body)
+
+	data.Metadata.KVVersion = 3
+	r.Error(data.Write(context.Background(), readConfig))
+
+	data.Metadata.KVVersion = 2
+	serverConfig["token"] = "invalid"
+	err = data.Write(context.Background(), readConfig)
+	r.Error(err)
+	r.Contains(err.Error(), "returns 403")
+
+	delete(serverConfig, "address")
+	r.Error(data.Write(context.Background(), readConfig))
+}
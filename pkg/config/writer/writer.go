@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"cuelang.org/go/cue"
@@ -38,11 +39,53 @@ import (
 // ExpandedWriterConfig define the supported output ways.
 type ExpandedWriterConfig struct {
 	Nacos *NacosConfig `json:"nacos"`
+	// Writers are the configs of the writers registered by RegisterWriter, keyed by the writer type
+	Writers map[string]map[string]interface{} `json:"writers,omitempty"`
 }
 
 // ExpandedWriterData the data for the expanded writer
 type ExpandedWriterData struct {
 	Nacos *NacosData `json:"nacos"`
+	// Writers are the rendered writers registered by RegisterWriter, keyed by the writer type
+	Writers map[string]Writer `json:"-"`
+}
+
+// Writer is the plugin interface of the expanded writers, it writes the rendered config to the destination.
+type Writer interface {
+	// Write writes the rendered config to the destination. The configReader reads the config of
+	// the destination server, such as the address and the credential.
+	Write(ctx context.Context, configReader icontext.ReadConfigProvider) error
+}
+
+// WriterRender renders the writer field of the config template. The config is the writer field decoded
+// before rendering, the fields referencing the parameter are not decoded in it.
+type WriterRender func(config map[string]interface{}, template script.CUE, context icontext.ConfigRenderContext, properties map[string]interface{}) (Writer, error)
+
+var writerRenders = map[string]WriterRender{}
+
+// RegisterWriter registers the writer type. The writer is declared by the field with the type name in
+// the config template.
+func RegisterWriter(writerType string, render WriterRender) {
+	writerRenders[writerType] = render
+}
+
+// typedWriterRender adapts the render function taking the typed writer config into the WriterRender
+func typedWriterRender[C any, W Writer](render func(config *C, template script.CUE, context icontext.ConfigRenderContext, properties map[string]interface{}) (W, error)) WriterRender {
+	return func(config map[string]interface{}, template script.CUE, context icontext.ConfigRenderContext, properties map[string]interface{}) (Writer, error) {
+		c := new(C)
+		bs, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bs, c); err != nil {
+			return nil, err
+		}
+		w, err := render(c, template, context, properties)
+		if err != nil {
+			return nil, err
+		}
+		return w, nil
+	}
 }
 
 // ConfigRef reference a config secret, it must be system scope.
@@ -62,7 +105,21 @@ func ParseExpandedWriterConfig(template cue.Value) ExpandedWriterConfig {
 		}
 		ewc.Nacos = nacosConfig
 	}
-	// parse the other writer configs
+	for writerType := range writerRenders {
+		v := template.LookupPath(cue.ParsePath(writerType))
+		if !v.Exists() {
+			continue
+		}
+		// the fields referencing the parameter can not be decoded before rendering, so the error is only logged
+		config := map[string]interface{}{}
+		if err := v.Decode(&config); err != nil {
+			klog.Warningf("failed to decode the %s config: %s", writerType, err.Error())
+		}
+		if ewc.Writers == nil {
+			ewc.Writers = map[string]map[string]interface{}{}
+		}
+		ewc.Writers[writerType] = config
+	}
 	return ewc
 }
 
@@ -77,6 +134,20 @@ func RenderForExpandedWriter(ewc ExpandedWriterConfig, template script.CUE, cont
 		}
 		klog.Info("the config render to nacos context successfully")
 	}
+	for _, writerType := range sortedKeys(ewc.Writers) {
+		render, found := writerRenders[writerType]
+		if !found {
+			return nil, fmt.Errorf("the writer %s is not supported", writerType)
+		}
+		w, err := render(ewc.Writers[writerType], template, context, properties)
+		if err != nil {
+			return nil, err
+		}
+		if ewd.Writers == nil {
+			ewd.Writers = map[string]Writer{}
+		}
+		ewd.Writers[writerType] = w
+	}
 	return &ewd, nil
 }
 
@@ -89,9 +160,71 @@ func Write(ctx context.Context, ewd *ExpandedWriterData, ri icontext.ReadConfigP
 			klog.Info("the config write to the nacos successfully")
 		}
 	}
+	for _, writerType := range sortedKeys(ewd.Writers) {
+		if err := ewd.Writers[writerType].Write(ctx, ri); err != nil {
+			list = append(list, err)
+		} else {
+			klog.Infof("the config write to the %s successfully", writerType)
+		}
+	}
 	return
 }
 
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// renderWriter renders the writer field of the template, and encodes the content field with the format.
+// The rendered writer field is decoded into the data.
+func renderWriter(name string, template script.CUE, context icontext.ConfigRenderContext, properties map[string]interface{}, defaultFormat string, data interface{}) ([]byte, error) {
+	writer, err := template.RunAndOutput(context, properties, "template", name)
+	if err != nil {
+		return nil, err
+	}
+	format, err := writer.LookupPath(cue.ParsePath("format")).String()
+	if err != nil {
+		format = defaultFormat
+	}
+	if err := value.UnmarshalTo(writer, data); err != nil {
+		return nil, err
+	}
+	content := writer.LookupPath(cue.ParsePath("content"))
+	if content.Err() != nil {
+		return nil, content.Err()
+	}
+	return encodingOutput(content, format)
+}
+
+// readServerConfig reads the config of the destination server referenced by the endpoint
+func readServerConfig(ctx context.Context, configReader icontext.ReadConfigProvider, endpoint ConfigRef, writer string) (map[string]interface{}, error) {
+	config, err := configReader(ctx, endpoint.Namespace, endpoint.Name)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read the config of the %s server:%w", writer, err)
+	}
+	return config, nil
+}
+
+func readString(data map[string]interface{}, key string) string {
+	str, _ := data[key].(string)
+	return str
+}
+
+func readStringMap(data map[string]interface{}, key string) map[string]string {
+	m, _ := data[key].(map[string]interface{})
+	result := map[string]string{}
+	for k, v := range m {
+		if str, ok := v.(string); ok {
+			result[k] = str
+		}
+	}
+	return result
+}
+
 // encodingOutput support the json、toml、xml、properties and yaml formats.
 func encodingOutput(input cue.Value, format string) ([]byte, error) {
 	var data = make(map[string]interface{})
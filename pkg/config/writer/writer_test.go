@@ -19,8 +19,10 @@ package writer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
 	"github.com/stretchr/testify/require"
 
@@ -156,6 +158,80 @@ func TestParseExpandedWriterConfig(t *testing.T) {
 		ewc := ParseExpandedWriterConfig(v)
 		r.True(ewc.Nacos == nil || ewc.Nacos.Endpoint.Name == "")
 	})
+
+	t.Run("writer plugins", func(t *testing.T) {
+		v := cuecontext.New().CompileString(`
+        vault: {
+            endpoint: name: "vault"
+            metadata: path: "app/db"
+        }
+        etcd: {
+            endpoint: name: "etcd"
+            format: "yaml"
+        }
+        http: {
+            endpoint: name: "http"
+            format: "json"
+        }
+        `)
+		r.NoError(v.Err())
+		ewc := ParseExpandedWriterConfig(v)
+		r.Nil(ewc.Nacos)
+		r.Len(ewc.Writers, 3)
+		r.Equal(map[string]interface{}{"name": "vault"}, ewc.Writers["vault"]["endpoint"])
+		r.Equal(map[string]interface{}{"path": "app/db"}, ewc.Writers["vault"]["metadata"])
+		r.Equal("yaml", ewc.Writers["etcd"]["format"])
+		r.Equal("json", ewc.Writers["http"]["format"])
+	})
+}
+
+type fakeWriter struct {
+	Target  string `json:"target"`
+	Content []byte `json:"-"`
+}
+
+func (f *fakeWriter) Write(_ context.Context, _ configcontext.ReadConfigProvider) error {
+	return fmt.Errorf("fail to write to %s", f.Target)
+}
+
+func TestRegisterWriter(t *testing.T) {
+	r := require.New(t)
+	RegisterWriter("fake", typedWriterRender(func(config *fakeWriter, template script.CUE, context configcontext.ConfigRenderContext, properties map[string]interface{}) (*fakeWriter, error) {
+		var data fakeWriter
+		out, err := renderWriter("fake", template, context, properties, "json", &data)
+		if err != nil {
+			return nil, err
+		}
+		data.Content = out
+		if data.Target == "" {
+			data.Target = config.Target
+		}
+		return &data, nil
+	}))
+	t.Cleanup(func() { delete(writerRenders, "fake") })
+
+	template := script.CUE(`
+template: {
+	fake: {
+		target: "default"
+		content: {key: parameter.value}
+	}
+	parameter: {value: string}
+}`)
+	v, err := template.ParseToTemplateValue()
+	r.NoError(err)
+	ewc := ParseExpandedWriterConfig(v.LookupPath(cue.ParsePath("template")))
+	r.Contains(ewc.Writers, "fake")
+
+	ewd, err := RenderForExpandedWriter(ewc, template, configcontext.ConfigRenderContext{}, map[string]interface{}{"value": "v"})
+	r.NoError(err)
+	r.Equal([]byte(`{"key":"v"}`), ewd.Writers["fake"].(*fakeWriter).Content)
+	errs := Write(context.Background(), ewd, nil)
+	r.Len(errs, 1)
+	r.Equal("fail to write to default", errs[0].Error())
+
+	_, err = RenderForExpandedWriter(ExpandedWriterConfig{Writers: map[string]map[string]interface{}{"unknown": {}}}, template, configcontext.ConfigRenderContext{}, nil)
+	r.Error(err)
 }
 
 func TestRenderForExpandedWriter(t *testing.T) {
@@ -189,4 +265,21 @@ func TestWrite(t *testing.T) {
 		r.Len(errs, 1)
 		r.Equal("fail to read the config of the nacos server:read-config-error", errs[0].Error())
 	})
+
+	t.Run("error of each writer", func(t *testing.T) {
+		ewd := &ExpandedWriterData{
+			Writers: map[string]Writer{
+				"vault": &VaultData{},
+				"etcd":  &EtcdData{},
+				"http":  &HTTPData{},
+			},
+		}
+		errs := Write(context.Background(), ewd, func(ctx context.Context, namespace, name string) (map[string]interface{}, error) {
+			return nil, errors.New("read-config-error")
+		})
+		r.Len(errs, 3)
+		r.Equal("fail to read the config of the etcd server:read-config-error", errs[0].Error())
+		r.Equal("fail to read the config of the http server:read-config-error", errs[1].Error())
+		r.Equal("fail to read the config of the vault server:read-config-error", errs[2].Error())
+	})
 }
@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlEvent "sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlHandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/policy"
+)
+
+// appDependencyWaitInterval is the interval to check the dependencies again while waiting.
+// The dependants are also reconciled on the changes of the dependencies through the watch.
+const appDependencyWaitInterval = 30 * time.Second
+
+// Suffix shape for the dependency outputs token appended to desiredRev, the
+// same as the valuesFrom fingerprint: "<base><dependencySuffixSeparator><hex>".
+const (
+	dependencySuffixSeparator = "-dep-"
+	dependencySuffixHexLen    = 32
+)
+
+// AppDependencyIndex maintains an in-memory graph of the dependencies between Applications
+// declared by the app-dependency policy. It is populated by the watch events of Applications,
+// including the initial list when the cache starts, and by the reconciles.
+type AppDependencyIndex struct {
+	mu sync.RWMutex
+	// app → the Applications it depends on
+	dependencies map[types.NamespacedName][]types.NamespacedName
+	// app → the Applications depending on it
+	dependants map[types.NamespacedName]map[types.NamespacedName]struct{}
+}
+
+// NewAppDependencyIndex creates a new empty index
+func NewAppDependencyIndex() *AppDependencyIndex {
+	return &AppDependencyIndex{
+		dependencies: map[types.NamespacedName][]types.NamespacedName{},
+		dependants:   map[types.NamespacedName]map[types.NamespacedName]struct{}{},
+	}
+}
+
+// Package-level singleton index instance
+var appDependencyIndex = NewAppDependencyIndex()
+
+// Set records the dependencies of the app, replacing the previous ones
+func (idx *AppDependencyIndex) Set(app types.NamespacedName, deps []types.NamespacedName) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.deleteLocked(app)
+	if len(deps) == 0 {
+		return
+	}
+	idx.dependencies[app] = deps
+	for _, dep := range deps {
+		if idx.dependants[dep] == nil {
+			idx.dependants[dep] = map[types.NamespacedName]struct{}{}
+		}
+		idx.dependants[dep][app] = struct{}{}
+	}
+}
+
+// Delete removes the dependencies of the app
+func (idx *AppDependencyIndex) Delete(app types.NamespacedName) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.deleteLocked(app)
+}
+
+func (idx *AppDependencyIndex) deleteLocked(app types.NamespacedName) {
+	for _, dep := range idx.dependencies[app] {
+		delete(idx.dependants[dep], app)
+		if len(idx.dependants[dep]) == 0 {
+			delete(idx.dependants, dep)
+		}
+	}
+	delete(idx.dependencies, app)
+}
+
+// Dependencies returns the Applications that the app depends on
+func (idx *AppDependencyIndex) Dependencies(app types.NamespacedName) []types.NamespacedName {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return append([]types.NamespacedName(nil), idx.dependencies[app]...)
+}
+
+// Dependants returns the Applications depending on the app, sorted by namespace and name
+func (idx *AppDependencyIndex) Dependants(app types.NamespacedName) []types.NamespacedName {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	dependants := make([]types.NamespacedName, 0, len(idx.dependants[app]))
+	for dependant := range idx.dependants[app] {
+		dependants = append(dependants, dependant)
+	}
+	sort.Slice(dependants, func(i, j int) bool { return dependants[i].String() < dependants[j].String() })
+	return dependants
+}
+
+// appDependencyEventHandler handles the events of the Applications for the dependency index
+func (r *Reconciler) appDependencyEventHandler() ctrlHandler.Funcs {
+	enqueue := func(q workqueue.TypedRateLimitingInterface[reconcile.Request], requests []reconcile.Request) {
+		for _, req := range requests {
+			q.Add(req)
+		}
+	}
+	return ctrlHandler.Funcs{
+		CreateFunc: func(_ context.Context, e ctrlEvent.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, r.handleAppDependencyChange(e.Object, false))
+		},
+		UpdateFunc: func(_ context.Context, e ctrlEvent.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, r.handleAppDependencyChange(e.ObjectNew, false))
+		},
+		DeleteFunc: func(_ context.Context, e ctrlEvent.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, r.handleAppDependencyChange(e.Object, true))
+		},
+		GenericFunc: func(_ context.Context, e ctrlEvent.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, r.handleAppDependencyChange(e.Object, false))
+		},
+	}
+}
+
+// handleAppDependencyChange indexes the dependencies of the Application, or removes them if the
+// Application is deleted, and returns the Applications depending on it, so they pick up the changes
+// of its phase and exports.
+func (r *Reconciler) handleAppDependencyChange(obj client.Object, deleted bool) []reconcile.Request {
+	app, ok := obj.(*v1beta1.Application)
+	if !ok {
+		return nil
+	}
+	key := types.NamespacedName{Namespace: app.Namespace, Name: app.Name}
+	if deleted {
+		appDependencyIndex.Delete(key)
+	} else if deps, err := policy.GetAppDependencies(app); err == nil {
+		appDependencyIndex.Set(key, deps)
+	}
+	var requests []reconcile.Request
+	for _, dependant := range appDependencyIndex.Dependants(key) {
+		requests = append(requests, reconcile.Request{NamespacedName: dependant})
+	}
+	return requests
+}
+
+// resolveAppDependencies checks the Applications that the app depends on through the app-dependency
+// policy, and stores their exports in the context, so the components are rendered with
+// context.dependencies["<name>"].outputs. It returns true if the app must wait for the dependencies.
+// Only the app which has not finished its workflow waits, an app already deployed keeps running
+// with the last outputs when a dependency is upgraded or unhealthy.
+func (r *Reconciler) resolveAppDependencies(logCtx monitorContext.Context, app *v1beta1.Application) (bool, error) {
+	key := types.NamespacedName{Namespace: app.Namespace, Name: app.Name}
+	deps, err := policy.GetAppDependencies(app)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse app-dependency policy")
+	}
+	appDependencyIndex.Set(key, deps)
+	if len(deps) == 0 {
+		return false, nil
+	}
+	if cycle := policy.FindDependencyCycle(key, appDependencyIndex.Dependencies); cycle != nil {
+		names := make([]string, len(cycle))
+		for i, item := range cycle {
+			names[i] = item.String()
+		}
+		return false, errors.Errorf("dependency cycle detected: %s", strings.Join(names, " -> "))
+	}
+
+	dependencies := map[string]interface{}{}
+	var waiting []string
+	for _, dep := range deps {
+		depApp := &v1beta1.Application{}
+		if err := r.Get(logCtx, dep, depApp); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return false, errors.Wrapf(err, "failed to get application %s", dep)
+			}
+			waiting = append(waiting, fmt.Sprintf("%s is not found", dep))
+			continue
+		}
+		if !policy.AllowsDependant(depApp, app.Namespace) {
+			// the exports are never exposed to the namespaces the app is not exported to
+			waiting = append(waiting, fmt.Sprintf("%s is not exported to namespace %s", dep, app.Namespace))
+			continue
+		}
+		if depApp.Status.Phase != common.ApplicationRunning {
+			phase := depApp.Status.Phase
+			if phase == "" {
+				phase = common.ApplicationStarting
+			}
+			waiting = append(waiting, fmt.Sprintf("%s is %s", dep, phase))
+		}
+		outputs := depApp.Status.Exports
+		if outputs == nil {
+			outputs = map[string]string{}
+		}
+		dependencies[dep.Name] = map[string]interface{}{
+			"name":      dep.Name,
+			"namespace": dep.Namespace,
+			"phase":     string(depApp.Status.Phase),
+			"outputs":   outputs,
+		}
+	}
+	if len(waiting) > 0 && (app.Status.Workflow == nil || !app.Status.Workflow.Finished) {
+		logCtx.Info("Waiting for the dependencies", "dependencies", waiting)
+		app.Status.SetConditions(condition.Condition{
+			Type:               condition.ConditionType(common.DependencyCondition.String()),
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "Waiting",
+			Message:            "waiting for the dependencies: " + strings.Join(waiting, ", "),
+		})
+		return true, nil
+	}
+	app.Status.SetConditions(condition.ReadyCondition(common.DependencyCondition.String()))
+	logCtx.SetContext(context.WithValue(logCtx.GetContext(), oam.AppDependenciesContextKey, dependencies))
+	return false, nil
+}
+
+// computeDependencyOutputsFingerprint returns a stable digest of the outputs of the dependencies
+// resolved into the context, or "" if the app has no dependencies. It is appended to desiredRev,
+// so the workflow restarts when the exports of a dependency change.
+func computeDependencyOutputsFingerprint(ctx context.Context) string {
+	dependencies, ok := ctx.Value(oam.AppDependenciesContextKey).(map[string]interface{})
+	if !ok || len(dependencies) == 0 {
+		return ""
+	}
+	outputs := map[string]interface{}{}
+	for name, dep := range dependencies {
+		if m, ok := dep.(map[string]interface{}); ok {
+			outputs[name] = m["outputs"]
+		}
+	}
+	// map keys are sorted by json.Marshal
+	data, err := json.Marshal(outputs)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// exportAppOutputs records the exports of the app-dependency policy in the status. The values
+// taken from the status details of the components are updated after the health check.
+func exportAppOutputs(app *v1beta1.Application) {
+	spec, err := policy.ParsePolicy[v1alpha1.AppDependencyPolicySpec](app)
+	if err != nil || spec == nil || len(spec.Exports) == 0 {
+		app.Status.Exports = nil
+		return
+	}
+	exports := map[string]string{}
+	for _, export := range spec.Exports {
+		value := export.Value
+		if export.Component != "" {
+			for _, svc := range app.Status.Services {
+				if svc.Name != export.Component {
+					continue
+				}
+				if v, ok := svc.Details[export.Detail]; ok {
+					value = v
+					break
+				}
+			}
+		}
+		exports[export.Name] = value
+	}
+	app.Status.Exports = exports
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	utilcommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newDependencyTestApp(name string, props string) *v1beta1.Application {
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	if props != "" {
+		app.Spec.Policies = []v1beta1.AppPolicy{{
+			Name:       "deps",
+			Type:       v1alpha1.AppDependencyPolicyType,
+			Properties: &runtime.RawExtension{Raw: []byte(props)},
+		}}
+	}
+	return app
+}
+
+func useTestAppDependencyIndex(t *testing.T) {
+	original := appDependencyIndex
+	appDependencyIndex = NewAppDependencyIndex()
+	t.Cleanup(func() { appDependencyIndex = original })
+}
+
+func TestAppDependencyIndex(t *testing.T) {
+	r := require.New(t)
+	idx := NewAppDependencyIndex()
+	web, db, cache := types.NamespacedName{Namespace: "default", Name: "web"}, types.NamespacedName{Namespace: "default", Name: "db"}, types.NamespacedName{Namespace: "default", Name: "cache"}
+	api := types.NamespacedName{Namespace: "default", Name: "api"}
+	idx.Set(web, []types.NamespacedName{db, cache})
+	idx.Set(api, []types.NamespacedName{db})
+	r.Equal([]types.NamespacedName{api, web}, idx.Dependants(db))
+	r.Equal([]types.NamespacedName{db, cache}, idx.Dependencies(web))
+
+	idx.Set(web, []types.NamespacedName{cache})
+	r.Equal([]types.NamespacedName{api}, idx.Dependants(db))
+	idx.Delete(api)
+	r.Empty(idx.Dependants(db))
+	r.Equal([]types.NamespacedName{web}, idx.Dependants(cache))
+}
+
+func TestHandleAppDependencyChange(t *testing.T) {
+	useTestAppDependencyIndex(t)
+	r := require.New(t)
+	reconciler := &Reconciler{}
+	web := newDependencyTestApp("web", `{"dependsOn":[{"name":"db"}]}`)
+	r.Empty(reconciler.handleAppDependencyChange(web, false))
+	r.Equal([]reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}}},
+		reconciler.handleAppDependencyChange(newDependencyTestApp("db", ""), false))
+
+	// the deleted app is removed from the index instead of being indexed again
+	r.Empty(reconciler.handleAppDependencyChange(web, true))
+	r.Empty(appDependencyIndex.Dependencies(types.NamespacedName{Namespace: "default", Name: "web"}))
+	r.Empty(reconciler.handleAppDependencyChange(newDependencyTestApp("db", ""), false))
+}
+
+func TestResolveAppDependencies(t *testing.T) {
+	newDB := func(phase common.ApplicationPhase, endpoint string) *v1beta1.Application {
+		db := newDependencyTestApp("db", "")
+		db.Status.Phase = phase
+		db.Status.Exports = map[string]string{"endpoint": endpoint}
+		return db
+	}
+	resolve := func(app *v1beta1.Application, objs ...client.Object) (monitorContext.Context, bool, error) {
+		cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).WithObjects(objs...).Build()
+		ctx := monitorContext.NewTraceContext(context.Background(), "")
+		wait, err := (&Reconciler{Client: cli}).resolveAppDependencies(ctx, app)
+		return ctx, wait, err
+	}
+
+	t.Run("wait for missing dependency", func(t *testing.T) {
+		useTestAppDependencyIndex(t)
+		r := require.New(t)
+		app := newDependencyTestApp("web", `{"dependsOn":[{"name":"db"}]}`)
+		_, wait, err := resolve(app)
+		r.NoError(err)
+		r.True(wait)
+		cond := app.Status.GetCondition("Dependency")
+		r.Equal(corev1.ConditionFalse, cond.Status)
+		r.Contains(cond.Message, "default/db is not found")
+	})
+
+	t.Run("resolve outputs", func(t *testing.T) {
+		useTestAppDependencyIndex(t)
+		r := require.New(t)
+		app := newDependencyTestApp("web", `{"dependsOn":[{"name":"db"}]}`)
+		ctx, wait, err := resolve(app, newDB(common.ApplicationRunning, "mysql:3306"))
+		r.NoError(err)
+		r.False(wait)
+		r.Equal(corev1.ConditionTrue, app.Status.GetCondition("Dependency").Status)
+		deps := ctx.GetContext().Value(oam.AppDependenciesContextKey).(map[string]interface{})
+		r.Equal(map[string]string{"endpoint": "mysql:3306"}, deps["db"].(map[string]interface{})["outputs"])
+		fp := computeDependencyOutputsFingerprint(ctx)
+		r.Len(fp, 64)
+
+		ctx, _, err = resolve(app, newDB(common.ApplicationRunning, "mysql:3307"))
+		r.NoError(err)
+		r.NotEqual(fp, computeDependencyOutputsFingerprint(ctx))
+		r.Empty(computeDependencyOutputsFingerprint(context.Background()))
+	})
+
+	t.Run("deployed app does not wait", func(t *testing.T) {
+		useTestAppDependencyIndex(t)
+		r := require.New(t)
+		app := newDependencyTestApp("web", `{"dependsOn":[{"name":"db"}]}`)
+		_, wait, err := resolve(app, newDB(common.ApplicationRunningWorkflow, "mysql:3306"))
+		r.NoError(err)
+		r.True(wait)
+		app.Status.Workflow = &common.WorkflowStatus{Finished: true}
+		_, wait, err = resolve(app, newDB(common.ApplicationRunningWorkflow, "mysql:3306"))
+		r.NoError(err)
+		r.False(wait)
+	})
+
+	t.Run("cross namespace dependency must be exported", func(t *testing.T) {
+		useTestAppDependencyIndex(t)
+		r := require.New(t)
+		app := newDependencyTestApp("web", `{"dependsOn":[{"name":"db","namespace":"infra"}]}`)
+		db := newDB(common.ApplicationRunning, "mysql:3306")
+		db.Namespace = "infra"
+		ctx, wait, err := resolve(app, db)
+		r.NoError(err)
+		r.True(wait)
+		r.Contains(app.Status.GetCondition("Dependency").Message, "infra/db is not exported to namespace default")
+		r.Nil(ctx.GetContext().Value(oam.AppDependenciesContextKey))
+
+		// the deployed app does not wait, but the exports are still not exposed
+		app.Status.Workflow = &common.WorkflowStatus{Finished: true}
+		ctx, wait, err = resolve(app, db)
+		r.NoError(err)
+		r.False(wait)
+		deps := ctx.GetContext().Value(oam.AppDependenciesContextKey).(map[string]interface{})
+		r.NotContains(deps, "db")
+
+		app.Status.Workflow = nil
+		db.Spec.Policies = []v1beta1.AppPolicy{{
+			Name:       "exports",
+			Type:       v1alpha1.AppDependencyPolicyType,
+			Properties: &runtime.RawExtension{Raw: []byte(`{"exportTo":["default"]}`)},
+		}}
+		ctx, wait, err = resolve(app, db)
+		r.NoError(err)
+		r.False(wait)
+		deps = ctx.GetContext().Value(oam.AppDependenciesContextKey).(map[string]interface{})
+		r.Equal(map[string]string{"endpoint": "mysql:3306"}, deps["db"].(map[string]interface{})["outputs"])
+	})
+
+	t.Run("detect cycle", func(t *testing.T) {
+		useTestAppDependencyIndex(t)
+		r := require.New(t)
+		db := newDependencyTestApp("db", `{"dependsOn":[{"name":"web"}]}`)
+		appDependencyIndex.Set(types.NamespacedName{Namespace: "default", Name: "db"}, []types.NamespacedName{{Namespace: "default", Name: "web"}})
+		_, _, err := resolve(newDependencyTestApp("web", `{"dependsOn":[{"name":"db"}]}`), db)
+		r.ErrorContains(err, "dependency cycle detected: default/web -> default/db -> default/web")
+	})
+}
+
+func TestExportAppOutputs(t *testing.T) {
+	r := require.New(t)
+	app := newDependencyTestApp("db", `{"exports":[{"name":"secretName","value":"mysql-credentials"},{"name":"endpoint","component":"mysql","detail":"endpoint","value":"mysql:3306"},{"name":"replicas","component":"mysql","detail":"replicas"}]}`)
+	app.Status.Services = []common.ApplicationComponentStatus{{Name: "mysql", Details: map[string]string{"replicas": "3"}}}
+	exportAppOutputs(app)
+	r.Equal(map[string]string{"secretName": "mysql-credentials", "endpoint": "mysql:3306", "replicas": "3"}, app.Status.Exports)
+
+	app.Status.Services[0].Details["endpoint"] = "10.0.0.1:3306"
+	exportAppOutputs(app)
+	r.Equal("10.0.0.1:3306", app.Status.Exports["endpoint"])
+
+	app.Spec.Policies = nil
+	exportAppOutputs(app)
+	r.Nil(app.Status.Exports)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -51,6 +52,7 @@ import (
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	velatypes "github.com/oam-dev/kubevela/apis/types"
 	"github.com/oam-dev/kubevela/pkg/appfile"
@@ -64,6 +66,7 @@ import (
 	"github.com/oam-dev/kubevela/pkg/resourcekeeper"
 	"github.com/oam-dev/kubevela/pkg/resourcetracker"
 	"github.com/oam-dev/kubevela/pkg/workflow"
+	"github.com/oam-dev/kubevela/pkg/workflow/approval"
 	oamprovidertypes "github.com/oam-dev/kubevela/pkg/workflow/providers/types"
 	"github.com/oam-dev/kubevela/version"
 )
@@ -119,6 +122,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}, app); err != nil {
 		if !kerrors.IsNotFound(err) {
 			logCtx.Error(err, "get application")
+		} else {
+			appDependencyIndex.Delete(req.NamespacedName)
 		}
 		return r.result(client.IgnoreNotFound(err)).ret()
 	}
@@ -177,6 +182,23 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	r.emitPolicyEvents(app)
 
+	// Wait for the Applications depended on, and resolve their outputs into context
+	waitDependencies, err := r.resolveAppDependencies(logCtx, app)
+	if err != nil {
+		logCtx.Error(err, "Failed to resolve the dependencies")
+		return r.endWithNegativeCondition(logCtx, app, condition.ErrorCondition(common.DependencyCondition.String(), err), common.ApplicationStarting)
+	}
+	if waitDependencies {
+		phase := app.Status.Phase
+		if phase == "" {
+			phase = common.ApplicationStarting
+		}
+		if err := r.patchStatus(logCtx, app, phase); err != nil {
+			return r.result(errors.WithMessage(err, "cannot update application status")).ret()
+		}
+		return r.result(nil).requeue(appDependencyWaitInterval).ret()
+	}
+
 	appFile, err := appParser.GenerateAppFile(logCtx, app)
 	if err != nil {
 		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedParse, err))
@@ -217,7 +239,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	r.Recorder.Event(app, event.Normal(velatypes.ReasonPolicyGenerated, velatypes.MessagePolicyGenerated))
 
 	// Check if workflow needs restart (combines scheduled restart + revision-based restart)
-	r.checkWorkflowRestart(logCtx, app, handler)
+	if wait := r.checkWorkflowRestart(logCtx, app, handler); wait > 0 {
+		// Postponed by the deploy-window policy, keep the deployed resources and check again when the window opens
+		return r.keepPostponedApplication(logCtx, app, handler, appFile, appParser, min(wait, common2.ApplicationReSyncPeriod))
+	}
+
+	// The approval steps resumed without enough approvals are suspended again
+	suspended, err := approval.Enforce(logCtx, r.Client, app)
+	if err != nil {
+		logCtx.Error(err, "Failed to check the approvals")
+		return r.endWithNegativeCondition(logCtx, app, condition.ErrorCondition(common.WorkflowCondition.String(), err), common.ApplicationWorkflowSuspending)
+	}
+	for _, step := range suspended {
+		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedWorkflow, fmt.Errorf("step %s is resumed without enough approvals and suspended again", step)))
+	}
 
 	workflowInstance, runners, err := handler.GenerateApplicationSteps(logCtx, app, appParser, appFile)
 	if err != nil {
@@ -261,7 +296,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	workflowUpdated := app.Status.Workflow.Message != "" && workflowInstance.Status.Message == ""
 	workflowInstance.Status.Phase = workflowState
+	approvals, deployWaves := app.Status.Workflow.Approvals, app.Status.Workflow.DeployWaves
 	app.Status.Workflow = workflow.ConvertWorkflowStatus(workflowInstance.Status, app.Status.Workflow.AppRevision)
+	app.Status.Workflow.Approvals, app.Status.Workflow.DeployWaves = approvals, deployWaves
 	logCtx.Info(fmt.Sprintf("Workflow return state=%s", workflowState))
 	postDispatchApplied := false
 	applyPostDispatchTraits := func() error {
@@ -327,6 +364,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if !isHealthy {
 		phase = common.ApplicationUnhealthy
 	}
+	exportAppOutputs(app)
+	rollbackCheckAfter, rolledBack := r.autoRollback(logCtx, handler, app, isHealthy)
+	if rolledBack {
+		// the application is restored to a previous revision, the state-keep and garbage
+		// collection of the current revision must not run anymore
+		return r.result(r.patchStatus(logCtx, app, app.Status.Phase)).ret()
+	}
+	failoverCheckAfter := r.failoverPlacements(logCtx, appFile, app)
 
 	// Apply PostDispatch traits for healthy components if not already done in workflow requeue branch
 	if err := applyPostDispatchTraits(); err != nil {
@@ -359,7 +404,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	})
 	r.Recorder.Event(app, event.Normal(velatypes.ReasonDeployed, velatypes.MessageDeployed))
 	// Use Update instead of Patch when components were removed to properly clear status arrays
-	return r.gcResourceTrackers(logCtx, handler, phase, true, componentsRemoved)
+	result, err = r.gcResourceTrackers(logCtx, handler, phase, true, componentsRemoved)
+	if rollbackCheckAfter > 0 && (result.RequeueAfter == 0 || rollbackCheckAfter < result.RequeueAfter) {
+		// check the health again once the deadline of auto-rollback is reached
+		result.RequeueAfter = rollbackCheckAfter
+	}
+	if failoverCheckAfter > 0 && (result.RequeueAfter == 0 || failoverCheckAfter < result.RequeueAfter) {
+		// keep watching the clusters used by the topology policies with failover
+		result.RequeueAfter = failoverCheckAfter
+	}
+	return result, err
 }
 
 func (r *Reconciler) stateKeep(logCtx monitorContext.Context, handler *AppHandler, app *v1beta1.Application) {
@@ -375,6 +429,62 @@ func (r *Reconciler) stateKeep(logCtx monitorContext.Context, handler *AppHandle
 		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedStateKeep, err))
 		app.Status.SetConditions(condition.ErrorCondition("StateKeep", err))
 	}
+	r.reportDrift(app, handler.resourceKeeper.GetDriftedResources())
+}
+
+// reportDrift records the drifted resources detected in state-keep into the application
+// status, emits events and updates the drift metric for the newly drifted resources
+func (r *Reconciler) reportDrift(app *v1beta1.Application, drifted []common.DriftedResource) {
+	previous := map[string]common.DriftedResource{}
+	for _, d := range app.Status.DriftedResources {
+		previous[driftedResourceKey(d)] = d
+	}
+	for _, d := range drifted {
+		if p, found := previous[driftedResourceKey(d)]; found && p.Missing == d.Missing && reflect.DeepEqual(p.Fields, d.Fields) {
+			continue
+		}
+		mode := string(v1alpha1.DriftDetectionModeReportOnly)
+		if d.Corrected {
+			mode = string(v1alpha1.DriftDetectionModeAutoCorrect)
+		}
+		metrics.ApplicationDriftCounter.WithLabelValues(app.Name, app.Namespace, mode).Inc()
+		r.Recorder.Event(app, event.Warning(velatypes.ReasonDrifted, errors.New(driftMessage(d))))
+	}
+	app.Status.DriftedResources = drifted
+	driftedCondition := condition.ConditionType(common.DriftedCondition.String())
+	switch {
+	case len(drifted) > 0:
+		app.Status.SetConditions(condition.Condition{
+			Type:               driftedCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             condition.ReasonDriftDetected,
+			Message:            fmt.Sprintf("%d resource(s) drifted from the desired state", len(drifted)),
+		})
+	case app.Status.GetCondition(driftedCondition).Status != corev1.ConditionUnknown:
+		app.Status.SetConditions(condition.Condition{
+			Type:               driftedCondition,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             condition.ReasonNoDrift,
+		})
+	}
+}
+
+func driftedResourceKey(d common.DriftedResource) string {
+	return strings.Join([]string{d.Cluster, d.APIVersion, d.Kind, d.Namespace, d.Name}, "/")
+}
+
+func driftMessage(d common.DriftedResource) string {
+	mr := v1beta1.ManagedResource{ClusterObjectReference: d.ClusterObjectReference}
+	action := "reported only"
+	if d.Corrected {
+		action = "corrected"
+	}
+	if d.Missing {
+		return fmt.Sprintf("resource %s is missing in the cluster (%s)", mr.DisplayName(), action)
+	}
+	return fmt.Sprintf("resource %s drifted at fields [%s] (%s)", mr.DisplayName(), strings.Join(d.Fields, ", "), action)
 }
 
 func (r *Reconciler) gcResourceTrackers(logCtx monitorContext.Context, handler *AppHandler, phase common.ApplicationPhase, gcOutdated bool, isUpdate bool) (ctrl.Result, error) {
@@ -734,6 +844,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&v1beta1.PolicyDefinition{},
 			ctrlHandler.EnqueueRequestsFromMapFunc(r.handlePolicyDefinitionChange),
 		).
+		Watches(
+			&v1beta1.Application{},
+			r.appDependencyEventHandler(),
+		).
 		For(&v1beta1.Application{}).
 		Complete(r)
 }
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/appfile"
+	common2 "github.com/oam-dev/kubevela/pkg/controller/common"
+	"github.com/oam-dev/kubevela/pkg/policy"
+)
+
+// deployWindowNow returns the current time, replaced in tests
+var deployWindowNow = time.Now
+
+// waitForDeployWindow checks the deploy-window policy before a new run of the workflow starts.
+// It returns how long to wait until the deployment is allowed, 0 if allowed now. While waiting,
+// the next allowed time is recorded in the status of the app.
+func waitForDeployWindow(ctx monitorContext.Context, app *v1beta1.Application) time.Duration {
+	spec, err := policy.ParsePolicy[v1alpha1.DeployWindowPolicySpec](app)
+	if err == nil && spec == nil {
+		app.Status.NextDeployTime = nil
+		removeDeployWindowCondition(app)
+		return 0
+	}
+	now := deployWindowNow()
+	var next time.Time
+	var reason string
+	if err == nil {
+		next, reason, err = policy.NextDeployTime(spec, now)
+	}
+	if err != nil {
+		ctx.Error(err, "Invalid deploy-window policy, the deployment is postponed")
+		app.Status.NextDeployTime = nil
+		app.Status.SetConditions(condition.ErrorCondition(common.DeployWindowCondition.String(), errors.WithMessage(err, "invalid deploy-window policy")))
+		return common2.ApplicationReSyncPeriod
+	}
+	if !next.After(now) {
+		app.Status.NextDeployTime = nil
+		app.Status.SetConditions(condition.ReadyCondition(common.DeployWindowCondition.String()))
+		return 0
+	}
+	ctx.Info("Deployment is postponed by the deploy-window policy", "nextDeployTime", next, "reason", reason)
+	nextDeployTime := metav1.NewTime(next)
+	app.Status.NextDeployTime = &nextDeployTime
+	app.Status.SetConditions(condition.Condition{
+		Type:               condition.ConditionType(common.DeployWindowCondition.String()),
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Waiting",
+		Message:            fmt.Sprintf("deployment is postponed to %s: %s", next.Format(time.RFC3339), reason),
+	})
+	return next.Sub(now)
+}
+
+// removeDeployWindowCondition drops the condition left by a deploy-window policy removed from the app
+func removeDeployWindowCondition(app *v1beta1.Application) {
+	conditions := app.Status.Conditions[:0]
+	for _, cond := range app.Status.Conditions {
+		if string(cond.Type) != common.DeployWindowCondition.String() {
+			conditions = append(conditions, cond)
+		}
+	}
+	app.Status.Conditions = conditions
+}
+
+// keepPostponedApplication runs the reconciliation of the resources already deployed while a new
+// run of the workflow is postponed by the deploy-window policy. The health is checked, the drift is
+// corrected and the resourcetrackers are garbage-collected, then the app is requeued after wait.
+func (r *Reconciler) keepPostponedApplication(ctx monitorContext.Context, app *v1beta1.Application, handler *AppHandler,
+	appFile *appfile.Appfile, appParser *appfile.Parser, wait time.Duration) (ctrl.Result, error) {
+	phase := app.Status.Phase
+	if phase == "" {
+		phase = common.ApplicationStarting
+	}
+	if app.Status.Workflow != nil {
+		handler.addServiceStatus(false, app.Status.Services...)
+		if phase == common.ApplicationRunning || phase == common.ApplicationUnhealthy {
+			phase = common.ApplicationRunning
+			if !evalStatus(ctx, handler, appFile, appParser) {
+				phase = common.ApplicationUnhealthy
+			}
+		}
+		r.stateKeep(ctx, handler, app)
+	}
+	result, err := r.gcResourceTrackers(ctx, handler, phase, false, false)
+	if result.RequeueAfter == 0 || wait < result.RequeueAfter {
+		result.RequeueAfter = wait
+	}
+	return result, err
+}
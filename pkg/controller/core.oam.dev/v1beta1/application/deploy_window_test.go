@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	common2 "github.com/oam-dev/kubevela/pkg/controller/common"
+	utilcommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newDeployWindowTestApp(props string) *v1beta1.Application {
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	if props != "" {
+		app.Spec.Policies = []v1beta1.AppPolicy{{
+			Name:       "window",
+			Type:       v1alpha1.DeployWindowPolicyType,
+			Properties: &runtime.RawExtension{Raw: []byte(props)},
+		}}
+	}
+	return app
+}
+
+func useTestDeployWindowNow(t *testing.T, now string) {
+	tm, err := time.Parse(time.RFC3339, now)
+	require.NoError(t, err)
+	original := deployWindowNow
+	deployWindowNow = func() time.Time { return tm }
+	t.Cleanup(func() { deployWindowNow = original })
+}
+
+func TestWaitForDeployWindow(t *testing.T) {
+	ctx := monitorContext.NewTraceContext(context.Background(), "")
+	useTestDeployWindowNow(t, "2026-10-16T10:00:00Z")
+
+	t.Run("no policy", func(t *testing.T) {
+		r := require.New(t)
+		app := newDeployWindowTestApp("")
+		app.Status.NextDeployTime = &metav1.Time{Time: time.Now()}
+		app.Status.SetConditions(condition.ReadyCondition("Parsed"), condition.ReadyCondition("DeployWindow"))
+		r.Zero(waitForDeployWindow(ctx, app))
+		r.Nil(app.Status.NextDeployTime)
+		r.Equal(corev1.ConditionUnknown, app.Status.GetCondition("DeployWindow").Status)
+		r.Equal(corev1.ConditionTrue, app.Status.GetCondition("Parsed").Status)
+	})
+
+	t.Run("postponed", func(t *testing.T) {
+		r := require.New(t)
+		app := newDeployWindowTestApp(`{"allowed":[{"name":"nightly","schedule":"0 22 * * *","duration":"2h"}]}`)
+		r.Equal(12*time.Hour, waitForDeployWindow(ctx, app))
+		r.Equal("2026-10-16T22:00:00Z", app.Status.NextDeployTime.UTC().Format(time.RFC3339))
+		cond := app.Status.GetCondition("DeployWindow")
+		r.Equal(corev1.ConditionFalse, cond.Status)
+		r.Equal("deployment is postponed to 2026-10-16T22:00:00Z: outside of the allowed windows", cond.Message)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		r := require.New(t)
+		app := newDeployWindowTestApp(`{"blocked":[{"schedule":"0 22 * * *","duration":"2h"}]}`)
+		app.Status.NextDeployTime = &metav1.Time{Time: time.Now()}
+		r.Zero(waitForDeployWindow(ctx, app))
+		r.Nil(app.Status.NextDeployTime)
+		r.Equal(corev1.ConditionTrue, app.Status.GetCondition("DeployWindow").Status)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		r := require.New(t)
+		app := newDeployWindowTestApp(`{"timeZone":"Mars/Olympus"}`)
+		r.Equal(common2.ApplicationReSyncPeriod, waitForDeployWindow(ctx, app))
+		cond := app.Status.GetCondition("DeployWindow")
+		r.Equal(corev1.ConditionFalse, cond.Status)
+		r.Contains(cond.Message, "invalid deploy-window policy")
+	})
+}
+
+func TestCheckWorkflowRestartWithDeployWindow(t *testing.T) {
+	useTestDeployWindowNow(t, "2026-12-25T10:00:00Z")
+	r := require.New(t)
+	ctx := monitorContext.NewTraceContext(context.Background(), "")
+	reconciler := &Reconciler{Client: fake.NewClientBuilder().WithScheme(utilcommon.Scheme).Build()}
+	handler := &AppHandler{
+		currentAppRev: &v1beta1.ApplicationRevision{ObjectMeta: metav1.ObjectMeta{Name: "app-v2"}},
+		latestAppRev: &v1beta1.ApplicationRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-v2"},
+			Status:     v1beta1.ApplicationRevisionStatus{Workflow: &common.WorkflowStatus{}},
+		},
+	}
+	app := newDeployWindowTestApp(`{"freezes":[{"name":"christmas","start":"2026-12-24","end":"2026-12-26"}]}`)
+	app.Status.Workflow = &common.WorkflowStatus{AppRevision: "app-v1", Finished: true}
+
+	r.Equal(38*time.Hour, reconciler.checkWorkflowRestart(ctx, app, handler))
+	r.Equal("app-v1", app.Status.Workflow.AppRevision)
+	r.Equal("2026-12-27T00:00:00Z", app.Status.NextDeployTime.UTC().Format(time.RFC3339))
+
+	useTestDeployWindowNow(t, "2026-12-27T00:00:00Z")
+	r.Zero(reconciler.checkWorkflowRestart(ctx, app, handler))
+	r.Equal("app-v2", app.Status.Workflow.AppRevision)
+	r.Nil(app.Status.NextDeployTime)
+	r.Equal(corev1.ConditionTrue, app.Status.GetCondition("DeployWindow").Status)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/appfile"
+	"github.com/oam-dev/kubevela/pkg/policy"
+	"github.com/oam-dev/kubevela/pkg/utils"
+)
+
+// PlacementFailoverCheckInterval is the interval to check the clusters used by the topology
+// policies with failover
+var PlacementFailoverCheckInterval = 30 * time.Second
+
+// failoverPlacements resolves the placements of the topology policies with failover again after
+// the workflow finishes, and records them in the application status. If the placement changes,
+// the workflow is restarted to move the components to the new clusters. It returns the duration
+// to check again, or zero if no topology policy uses failover. The cluster health is probed by
+// the cluster metrics manager, if it is not enabled, the placements are kept unchanged and the
+// Failover condition is set to false.
+func (r *Reconciler) failoverPlacements(logCtx monitorContext.Context, af *appfile.Appfile, app *v1beta1.Application) time.Duration {
+	var policyNames []string
+	specs := map[string]*v1alpha1.TopologyPolicySpec{}
+	for _, p := range af.Policies {
+		if p.Type != v1alpha1.TopologyPolicyType || p.Properties == nil {
+			continue
+		}
+		spec := &v1alpha1.TopologyPolicySpec{}
+		if err := utils.StrictUnmarshal(p.Properties.Raw, spec); err != nil || !policy.IsFailoverEnabled(spec) {
+			continue
+		}
+		policyNames = append(policyNames, p.Name)
+		specs[p.Name] = spec
+	}
+	if len(policyNames) == 0 {
+		app.Status.PlacementFailovers = nil
+		return 0
+	}
+	if !policy.IsClusterHealthProbed() {
+		app.Status.SetConditions(condition.Condition{
+			Type:               condition.ConditionType(common.FailoverCondition.String()),
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "ClusterMetricsDisabled",
+			Message:            fmt.Sprintf("failover in topology %s requires the controller to run with --enable-cluster-metrics to probe the clusters", strings.Join(policyNames, ",")),
+		})
+		return 0
+	}
+	app.Status.SetConditions(condition.ReadyCondition(common.FailoverCondition.String()))
+	if app.Status.Workflow == nil || !app.Status.Workflow.Finished {
+		return 0
+	}
+	var failovers []common.PlacementFailoverStatus
+	changed := false
+	for _, name := range policyNames {
+		spec := specs[name]
+		last := policy.FindPlacementFailoverStatus(app.Status.PlacementFailovers, name)
+		candidates, err := policy.ListFailoverCandidates(logCtx, r.Client, name, spec)
+		if err != nil {
+			logCtx.Error(err, "Failed to list the clusters for failover", "policy", name)
+			if last != nil {
+				failovers = append(failovers, *last)
+			}
+			continue
+		}
+		status, changes := policy.ResolveFailoverPlacement(name, spec.Failover, candidates, last)
+		for _, change := range changes {
+			msg := errors.Errorf("components in topology %s are moved from cluster %s to %s: %s", name, change.From, change.To, change.Reason)
+			logCtx.Info("Placement is moved", "policy", name, "from", change.From, "to", change.To, "reason", change.Reason)
+			if policy.IsClusterUnhealthy(change.From, spec.Failover.GetFailureThreshold()) {
+				r.Recorder.Event(app, event.Warning(velatypes.ReasonPlacementMoved, msg))
+			} else {
+				r.Recorder.Event(app, event.Normal(velatypes.ReasonPlacementMoved, msg.Error()))
+			}
+		}
+		// the deploy step resolves the same placement when there is no status recorded
+		if last != nil && !slices.Equal(last.Clusters, status.Clusters) {
+			changed = true
+		}
+		failovers = append(failovers, *status)
+	}
+	app.Status.PlacementFailovers = failovers
+	if changed {
+		logCtx.Info("Restart workflow to apply the new placements")
+		app.Status.WorkflowRestartScheduledAt = &metav1.Time{Time: time.Now()}
+		return time.Second
+	}
+	return PlacementFailoverCheckInterval
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	clustercommon "github.com/oam-dev/cluster-gateway/pkg/common"
+
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/appfile"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	"github.com/oam-dev/kubevela/pkg/policy"
+	utilcommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestFailoverPlacements(t *testing.T) {
+	r := require.New(t)
+	ctx := monitorContext.NewTraceContext(context.Background(), "")
+	multicluster.ClusterGatewaySecretNamespace = velatypes.DefaultKubeVelaNS
+	builder := fake.NewClientBuilder().WithScheme(utilcommon.Scheme)
+	for _, name := range []string{"c1", "c2", "c3"} {
+		builder = builder.WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: multicluster.ClusterGatewaySecretNamespace,
+				Labels: map[string]string{
+					clustercommon.LabelKeyClusterEndpointType:   string(clusterv1alpha1.ClusterEndpointTypeConst),
+					clustercommon.LabelKeyClusterCredentialType: string(clusterv1alpha1.CredentialTypeX509Certificate),
+					"env": "prod",
+				},
+			},
+		})
+	}
+	rec := &recordingRecorder{}
+	reconciler := &Reconciler{Client: builder.Build(), Recorder: rec}
+
+	unhealthy := map[string]bool{}
+	probed := false
+	original, originalProbed := policy.IsClusterUnhealthy, policy.IsClusterHealthProbed
+	policy.IsClusterUnhealthy = func(cluster string, _ int) bool { return unhealthy[cluster] }
+	policy.IsClusterHealthProbed = func() bool { return probed }
+	t.Cleanup(func() { policy.IsClusterUnhealthy, policy.IsClusterHealthProbed = original, originalProbed })
+
+	af := &appfile.Appfile{Policies: []v1beta1.AppPolicy{{
+		Name:       "topology-prod",
+		Type:       v1alpha1.TopologyPolicyType,
+		Properties: &runtime.RawExtension{Raw: []byte(`{"clusterLabelSelector":{"env":"prod"},"failover":{"replicas":2,"failback":true}}`)},
+	}}}
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+
+	// the cluster health is not probed without the cluster metrics
+	app.Status.Workflow = &common.WorkflowStatus{Finished: true, EndTime: metav1.NewTime(time.Now().Add(-time.Minute))}
+	r.Equal(time.Duration(0), reconciler.failoverPlacements(ctx, af, app))
+	r.Nil(app.Status.PlacementFailovers)
+	cond := app.Status.GetCondition(condition.ConditionType(common.FailoverCondition.String()))
+	r.Equal(corev1.ConditionFalse, cond.Status)
+	r.Contains(cond.Message, "--enable-cluster-metrics")
+
+	// the workflow is not finished
+	probed = true
+	app.Status.Workflow = nil
+	r.Equal(time.Duration(0), reconciler.failoverPlacements(ctx, af, app))
+	r.Nil(app.Status.PlacementFailovers)
+
+	// record the initial placement
+	app.Status.Workflow = &common.WorkflowStatus{Finished: true, EndTime: metav1.NewTime(time.Now().Add(-time.Minute))}
+	r.Equal(PlacementFailoverCheckInterval, reconciler.failoverPlacements(ctx, af, app))
+	r.Equal(corev1.ConditionTrue, app.Status.GetCondition(condition.ConditionType(common.FailoverCondition.String())).Status)
+	r.Equal(1, len(app.Status.PlacementFailovers))
+	r.Equal([]string{"c1", "c2"}, app.Status.PlacementFailovers[0].Clusters)
+	r.Nil(app.Status.WorkflowRestartScheduledAt)
+
+	// fail over to the spare cluster and restart the workflow
+	unhealthy["c1"] = true
+	r.Equal(time.Second, reconciler.failoverPlacements(ctx, af, app))
+	r.Equal([]string{"c3", "c2"}, app.Status.PlacementFailovers[0].Clusters)
+	r.Equal([]string{"c1"}, app.Status.PlacementFailovers[0].UnhealthyClusters)
+	r.NotNil(app.Status.WorkflowRestartScheduledAt)
+	r.Len(rec.events, 1)
+	r.Equal(velatypes.ReasonPlacementMoved, string(rec.events[0].Reason))
+	r.Equal(event.TypeWarning, rec.events[0].Type)
+
+	// fail back once the cluster recovers
+	app.Status.WorkflowRestartScheduledAt = nil
+	unhealthy["c1"] = false
+	r.Equal(time.Second, reconciler.failoverPlacements(ctx, af, app))
+	r.Equal([]string{"c1", "c2"}, app.Status.PlacementFailovers[0].Clusters)
+	r.Equal(2, len(app.Status.PlacementFailovers[0].Changes))
+	r.Len(rec.events, 2)
+	r.Equal(event.TypeNormal, rec.events[1].Type)
+
+	// failover is ignored in the topology with clusters
+	af.Policies[0].Properties = &runtime.RawExtension{Raw: []byte(`{"clusters":["c1","c2"],"failover":{"replicas":1}}`)}
+	r.Equal(time.Duration(0), reconciler.failoverPlacements(ctx, af, app))
+	r.Nil(app.Status.PlacementFailovers)
+
+	// the status is cleared without failover
+	af.Policies[0].Properties = &runtime.RawExtension{Raw: []byte(`{"clusterLabelSelector":{"env":"prod"}}`)}
+	r.Equal(time.Duration(0), reconciler.failoverPlacements(ctx, af, app))
+	r.Nil(app.Status.PlacementFailovers)
+}
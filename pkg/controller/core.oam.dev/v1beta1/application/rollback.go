@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/controller/utils"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/policy"
+	"github.com/oam-dev/kubevela/pkg/resourcetracker"
+	"github.com/oam-dev/kubevela/pkg/rollout"
+)
+
+// autoRollback records the current revision as healthy once all the components are healthy
+// after the workflow succeeds. With the auto-rollback policy, if the components of a new
+// revision stay unhealthy past the deadline, the application is rolled back to the previous
+// healthy revision by RollbackToRevision, the same as `vela workflow rollback`, except that the
+// workflow runs again if the resources of the previous revision have been recycled. It returns
+// the duration to wait before the deadline is reached, or zero if no further check is needed,
+// and whether the application is rolled back.
+func (r *Reconciler) autoRollback(logCtx monitorContext.Context, handler *AppHandler, app *v1beta1.Application, healthy bool) (time.Duration, bool) {
+	currentRev := handler.currentAppRev
+	if currentRev == nil || DisableAllApplicationRevision {
+		return 0, false
+	}
+	if healthy {
+		if !currentRev.Status.Healthy {
+			currentRev.Status.Healthy = true
+			if err := r.Client.Status().Update(logCtx, currentRev); err != nil {
+				logCtx.Error(err, "Failed to mark application revision healthy", "revision", currentRev.Name)
+			}
+		}
+		return 0, false
+	}
+	// the revision has been healthy once, the components become unhealthy for other reasons
+	if currentRev.Status.Healthy || (app.Status.LastRollback != nil && app.Status.LastRollback.FromRevision == currentRev.Name) {
+		return 0, false
+	}
+	spec, err := policy.ParsePolicy[v1alpha1.AutoRollbackPolicySpec](app)
+	if err != nil || spec == nil {
+		return 0, false
+	}
+	deadline, err := spec.GetDeadline()
+	if err != nil {
+		logCtx.Error(err, "Invalid deadline in auto-rollback policy")
+		return 0, false
+	}
+	if app.Status.Workflow == nil || app.Status.Workflow.EndTime.IsZero() {
+		return 0, false
+	}
+	if remaining := time.Until(app.Status.Workflow.EndTime.Add(deadline)); remaining > 0 {
+		return remaining, false
+	}
+
+	target, err := findRollbackRevision(logCtx, r.Client, app, currentRev)
+	if err != nil {
+		logCtx.Error(err, "Failed to find the revision to rollback")
+		return 0, false
+	}
+	if target == nil {
+		logCtx.Info("No previous healthy revision to rollback", "revision", currentRev.Name)
+		return 0, false
+	}
+	reason := fmt.Sprintf("components [%s] stay unhealthy for %s after revision %s is published",
+		strings.Join(unhealthyComponents(app), ", "), deadline, currentRev.Name)
+	opts := RollbackOptions{RedeployRecycled: true}
+	if oam.GetPublishVersion(app) != "" {
+		// keep the application controlled by the publish version
+		opts.PublishVersion = oam.GetPublishVersion(target)
+	}
+	if _, err = RollbackToRevision(logCtx, r.Client, app, target, opts); err != nil {
+		logCtx.Error(err, "Failed to rollback application", "revision", target.Name)
+		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedRollback, errors.WithMessagef(err, "failed to rollback to revision %s", target.Name)))
+		return 0, false
+	}
+	app.Status.LastRollback = &common.RollbackRecord{
+		FromRevision: currentRev.Name,
+		ToRevision:   target.Name,
+		Reason:       reason,
+		Time:         metav1.Now(),
+	}
+	logCtx.Info("Application is rolled back", "from", currentRev.Name, "to", target.Name, "reason", reason)
+	r.Recorder.Event(app, event.Warning(velatypes.ReasonRolledBack, errors.Errorf("rolled back from revision %s to %s: %s", currentRev.Name, target.Name, reason)))
+	return 0, true
+}
+
+// findRollbackRevision finds the latest healthy revision before the current one, whose spec
+// differs from the current revision
+func findRollbackRevision(ctx context.Context, cli client.Client, app *v1beta1.Application, currentRev *v1beta1.ApplicationRevision) (*v1beta1.ApplicationRevision, error) {
+	revs, err := GetSortedAppRevisions(ctx, cli, app.Name, app.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list revisions for application %s/%s", app.Namespace, app.Name)
+	}
+	currentHash := currentRev.GetLabels()[oam.LabelAppRevisionHash]
+	for i := len(revs) - 1; i >= 0; i-- {
+		rev := revs[i]
+		if rev.Name == currentRev.Name || !rev.Status.Succeeded || !rev.Status.Healthy {
+			continue
+		}
+		if currentHash != "" && rev.GetLabels()[oam.LabelAppRevisionHash] == currentHash {
+			continue
+		}
+		return rev.DeepCopy(), nil
+	}
+	return nil, nil
+}
+
+// RollbackOptions configures how RollbackToRevision rolls back the application
+type RollbackOptions struct {
+	// PublishVersion the publish version set to the application, it is left unchanged if empty
+	PublishVersion string
+	// RedeployRecycled runs the workflow again to redeploy the revision if its resources have
+	// been recycled. Otherwise the rollback fails if the ResourceTracker of the revision is gone.
+	RedeployRecycled bool
+	// Output the writer to print the progress, nothing is printed if nil
+	Output io.Writer
+}
+
+// RollbackToRevision rolls back the application to a previous revision. It is shared by
+// `vela workflow rollback` and the auto-rollback policy. The application is frozen while
+// its spec is restored from the revision.
+//
+// If the ResourceTracker of the revision is still kept, the status of the application is
+// restored to the revision as well, so the resources of the revision are re-applied by
+// state-keep without running the workflow again. Otherwise the resources of the revision
+// have been recycled, and the rollback fails unless RedeployRecycled is set, in which case
+// the workflow runs again to redeploy the restored spec.
+//
+// It returns whether the Kruise rollouts of the application are rolled back as well. The
+// app is updated to the latest state in the cluster once rolled back.
+func RollbackToRevision(ctx context.Context, cli client.Client, app *v1beta1.Application, rev *v1beta1.ApplicationRevision, opts RollbackOptions) (bool, error) {
+	writeOutput := func(format string, args ...interface{}) {
+		if opts.Output != nil {
+			_, _ = fmt.Fprintf(opts.Output, format, args...)
+		}
+	}
+	revisionNumber, err := utils.ExtractRevision(rev.Name)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to extract revision number from revision %s", rev.Name)
+	}
+	_, currentRT, historyRTs, _, err := resourcetracker.ListApplicationResourceTrackers(ctx, cli, app)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list resource trackers for application %s/%s", app.Namespace, app.Name)
+	}
+	var matchRT *v1beta1.ResourceTracker
+	for _, rt := range append(historyRTs, currentRT) {
+		if rt != nil && rt.GetLabels()[oam.LabelAppRevision] == rev.Name {
+			matchRT = rt.DeepCopy()
+		}
+	}
+	if matchRT == nil && !opts.RedeployRecycled {
+		return false, errors.Errorf("cannot find resource tracker for previous revision %s, unable to rollback", rev.Name)
+	}
+	if matchRT != nil && matchRT.DeletionTimestamp != nil {
+		return false, errors.Errorf("previous revision %s is being recycled, unable to rollback", rev.Name)
+	}
+
+	appKey := client.ObjectKeyFromObject(app)
+	controllerRequirement, err := FreezeApplication(ctx, cli, app, func() {
+		app.Spec = rev.Spec.Application.Spec
+		if opts.PublishVersion != "" {
+			oam.SetPublishVersion(app, opts.PublishVersion)
+		}
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to rollback application spec to revision %s", rev.Name)
+	}
+	writeOutput("Application spec rollback successfully.\n")
+
+	if matchRT != nil {
+		if err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			if err := cli.Get(ctx, appKey, app); err != nil {
+				return err
+			}
+			app.Status.Workflow = rev.Status.Workflow
+			app.Status.Services = []common.ApplicationComponentStatus{}
+			app.Status.AppliedResources = []common.ClusterObjectReference{}
+			for _, rsc := range matchRT.Spec.ManagedResources {
+				app.Status.AppliedResources = append(app.Status.AppliedResources, rsc.ClusterObjectReference)
+			}
+			app.Status.LatestRevision = &common.Revision{
+				Name:         rev.Name,
+				Revision:     int64(revisionNumber),
+				RevisionHash: rev.GetLabels()[oam.LabelAppRevisionHash],
+			}
+			return cli.Status().Update(ctx, app)
+		}); err != nil {
+			return false, errors.Wrapf(err, "failed to rollback application status to revision %s", rev.Name)
+		}
+		writeOutput("Application status rollback successfully.\n")
+
+		matchRTKey := client.ObjectKeyFromObject(matchRT)
+		if err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			if err := cli.Get(ctx, matchRTKey, matchRT); err != nil {
+				return err
+			}
+			matchRT.Spec.ApplicationGeneration = app.Generation
+			return cli.Update(ctx, matchRT)
+		}); err != nil {
+			return false, errors.Wrapf(err, "failed to update application generation in resource tracker")
+		}
+	} else {
+		writeOutput("The resources of revision %s have been recycled, the workflow will run again to redeploy it.\n", rev.Name)
+	}
+
+	if err = UnfreezeApplication(ctx, cli, app, nil, controllerRequirement); err != nil {
+		return false, errors.Wrapf(err, "failed to resume application to restart")
+	}
+	return rollout.RollbackRollout(ctx, cli, app, opts.Output)
+}
+
+// FreezeApplication freeze application to disable the reconciling process for it
+func FreezeApplication(ctx context.Context, cli client.Client, app *v1beta1.Application, mutate func()) (string, error) {
+	return oam.GetControllerRequirement(app), updateApplicationWithControllerRequirement(ctx, cli, app, mutate, "Disabled")
+}
+
+// UnfreezeApplication unfreeze application to enable the reconciling process for it
+func UnfreezeApplication(ctx context.Context, cli client.Client, app *v1beta1.Application, mutate func(), originalControllerRequirement string) error {
+	return updateApplicationWithControllerRequirement(ctx, cli, app, mutate, originalControllerRequirement)
+}
+
+func updateApplicationWithControllerRequirement(ctx context.Context, cli client.Client, app *v1beta1.Application, mutate func(), controllerRequirement string) error {
+	appKey := client.ObjectKeyFromObject(app)
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := cli.Get(ctx, appKey, app); err != nil {
+			return err
+		}
+		oam.SetControllerRequirement(app, controllerRequirement)
+		if mutate != nil {
+			mutate()
+		}
+		return cli.Update(ctx, app)
+	})
+}
+
+func unhealthyComponents(app *v1beta1.Application) []string {
+	var comps []string
+	for _, svc := range app.Status.Services {
+		if !svc.Healthy {
+			comps = append(comps, svc.Name)
+		}
+	}
+	return comps
+}
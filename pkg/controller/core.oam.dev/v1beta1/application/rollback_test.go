@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	utilcommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newRollbackTestRevision(name string, hash string, image string, healthy bool) *v1beta1.ApplicationRevision {
+	rev := &v1beta1.ApplicationRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{oam.LabelAppName: "app", oam.LabelAppRevisionHash: hash},
+		},
+		Status: v1beta1.ApplicationRevisionStatus{Succeeded: true, Healthy: healthy},
+	}
+	rev.Spec.Application.Spec = newRollbackTestSpec(image)
+	return rev
+}
+
+func newRollbackTestSpec(image string) v1beta1.ApplicationSpec {
+	return v1beta1.ApplicationSpec{
+		Components: []common.ApplicationComponent{{
+			Name:       "web",
+			Type:       "webservice",
+			Properties: &runtime.RawExtension{Raw: []byte(`{"image":"` + image + `"}`)},
+		}},
+		Policies: []v1beta1.AppPolicy{{
+			Name:       "rollback",
+			Type:       v1alpha1.AutoRollbackPolicyType,
+			Properties: &runtime.RawExtension{Raw: []byte(`{"deadline":"1m"}`)},
+		}},
+	}
+}
+
+func TestAutoRollback(t *testing.T) {
+	ctx := monitorContext.NewTraceContext(context.Background(), "")
+	newApp := func(finishedAgo time.Duration) *v1beta1.Application {
+		app := &v1beta1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       newRollbackTestSpec("nginx:bad"),
+		}
+		app.Status.Workflow = &common.WorkflowStatus{Finished: true, EndTime: metav1.NewTime(time.Now().Add(-finishedAgo))}
+		app.Status.Services = []common.ApplicationComponentStatus{{Name: "web", Healthy: false}}
+		return app
+	}
+	setup := func(app *v1beta1.Application, revs ...*v1beta1.ApplicationRevision) (*Reconciler, *recordingRecorder, client.Client) {
+		objs := []client.Object{app}
+		for _, rev := range revs {
+			objs = append(objs, rev)
+		}
+		cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).WithObjects(objs...).
+			WithStatusSubresource(&v1beta1.ApplicationRevision{}, &v1beta1.Application{}).Build()
+		rec := &recordingRecorder{}
+		return &Reconciler{Client: cli, Recorder: rec}, rec, cli
+	}
+
+	t.Run("mark healthy revision", func(t *testing.T) {
+		r := require.New(t)
+		app := newApp(time.Minute)
+		current := newRollbackTestRevision("app-v2", "h2", "nginx:bad", false)
+		reconciler, _, cli := setup(app, current)
+		after, rolledBack := reconciler.autoRollback(ctx, &AppHandler{currentAppRev: current}, app, true)
+		r.Equal(time.Duration(0), after)
+		r.False(rolledBack)
+		rev := &v1beta1.ApplicationRevision{}
+		r.NoError(cli.Get(ctx, client.ObjectKeyFromObject(current), rev))
+		r.True(rev.Status.Healthy)
+	})
+
+	t.Run("wait for deadline", func(t *testing.T) {
+		r := require.New(t)
+		app := newApp(10 * time.Second)
+		current := newRollbackTestRevision("app-v2", "h2", "nginx:bad", false)
+		reconciler, rec, _ := setup(app, newRollbackTestRevision("app-v1", "h1", "nginx:good", true), current)
+		after, rolledBack := reconciler.autoRollback(ctx, &AppHandler{currentAppRev: current}, app, false)
+		r.True(after > 0 && after <= 50*time.Second)
+		r.False(rolledBack)
+		r.Nil(app.Status.LastRollback)
+		r.Empty(rec.events)
+	})
+
+	t.Run("rollback to previous healthy revision", func(t *testing.T) {
+		r := require.New(t)
+		app := newApp(2 * time.Minute)
+		current := newRollbackTestRevision("app-v3", "h3", "nginx:bad", false)
+		reconciler, rec, cli := setup(app,
+			newRollbackTestRevision("app-v1", "h1", "nginx:good", true),
+			newRollbackTestRevision("app-v2", "h2", "nginx:unknown", false),
+			current)
+		after, rolledBack := reconciler.autoRollback(ctx, &AppHandler{currentAppRev: current}, app, false)
+		r.Equal(time.Duration(0), after)
+		r.True(rolledBack)
+		r.NotNil(app.Status.LastRollback)
+		r.Equal("app-v3", app.Status.LastRollback.FromRevision)
+		r.Equal("app-v1", app.Status.LastRollback.ToRevision)
+		r.Contains(app.Status.LastRollback.Reason, "components [web] stay unhealthy")
+		r.Len(rec.events, 1)
+		r.Equal(velatypes.ReasonRolledBack, string(rec.events[0].Reason))
+
+		updated := &v1beta1.Application{}
+		r.NoError(cli.Get(ctx, client.ObjectKeyFromObject(app), updated))
+		r.Equal(`{"image":"nginx:good"}`, string(updated.Spec.Components[0].Properties.Raw))
+
+		r.Empty(oam.GetControllerRequirement(updated), "the application should be unfrozen")
+
+		// the rollback is not repeated for the same revision
+		_, rolledBack = reconciler.autoRollback(ctx, &AppHandler{currentAppRev: current}, app, false)
+		r.False(rolledBack)
+		r.Len(rec.events, 1)
+	})
+
+	t.Run("restore status when the resources of the revision are kept", func(t *testing.T) {
+		r := require.New(t)
+		app := newApp(2 * time.Minute)
+		current := newRollbackTestRevision("app-v2", "h2", "nginx:bad", false)
+		target := newRollbackTestRevision("app-v1", "h1", "nginx:good", true)
+		target.Status.Workflow = &common.WorkflowStatus{AppRevision: "app-v1", Finished: true}
+		rt := &v1beta1.ResourceTracker{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "app-v1-default",
+				Labels: map[string]string{oam.LabelAppName: "app", oam.LabelAppNamespace: "default", oam.LabelAppRevision: "app-v1"},
+			},
+			Spec: v1beta1.ResourceTrackerSpec{
+				Type:                  v1beta1.ResourceTrackerTypeVersioned,
+				ApplicationGeneration: 1,
+				ManagedResources: []v1beta1.ManagedResource{{
+					ClusterObjectReference: common.ClusterObjectReference{ObjectReference: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}},
+				}},
+			},
+		}
+		reconciler, _, cli := setup(app, target, current)
+		r.NoError(cli.Create(ctx, rt))
+		_, rolledBack := reconciler.autoRollback(ctx, &AppHandler{currentAppRev: current}, app, false)
+		r.True(rolledBack)
+
+		updated := &v1beta1.Application{}
+		r.NoError(cli.Get(ctx, client.ObjectKeyFromObject(app), updated))
+		r.Equal("app-v1", updated.Status.LatestRevision.Name)
+		r.Equal(int64(1), updated.Status.LatestRevision.Revision)
+		r.Equal("app-v1", updated.Status.Workflow.AppRevision)
+		r.Len(updated.Status.AppliedResources, 1)
+		r.NoError(cli.Get(ctx, client.ObjectKeyFromObject(rt), rt))
+		r.Equal(updated.Generation, rt.Spec.ApplicationGeneration)
+	})
+
+	t.Run("no healthy revision", func(t *testing.T) {
+		r := require.New(t)
+		app := newApp(2 * time.Minute)
+		current := newRollbackTestRevision("app-v2", "h2", "nginx:bad", false)
+		reconciler, rec, _ := setup(app, newRollbackTestRevision("app-v1", "h1", "nginx:good", false), current)
+		_, rolledBack := reconciler.autoRollback(ctx, &AppHandler{currentAppRev: current}, app, false)
+		r.False(rolledBack)
+		r.Nil(app.Status.LastRollback)
+		r.Empty(rec.events)
+	})
+
+	t.Run("revision has been healthy", func(t *testing.T) {
+		r := require.New(t)
+		app := newApp(2 * time.Minute)
+		current := newRollbackTestRevision("app-v2", "h2", "nginx:bad", true)
+		reconciler, rec, _ := setup(app, newRollbackTestRevision("app-v1", "h1", "nginx:good", true), current)
+		_, rolledBack := reconciler.autoRollback(ctx, &AppHandler{currentAppRev: current}, app, false)
+		r.False(rolledBack)
+		r.Nil(app.Status.LastRollback)
+		r.Empty(rec.events)
+	})
+}
+
+func TestRollbackToRevision(t *testing.T) {
+	ctx := context.Background()
+	setup := func() (*v1beta1.Application, *v1beta1.ApplicationRevision, client.Client) {
+		app := &v1beta1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Annotations: map[string]string{oam.AnnotationPublishVersion: "v2"}},
+			Spec:       newRollbackTestSpec("nginx:bad"),
+		}
+		rev := newRollbackTestRevision("app-v1", "h1", "nginx:good", true)
+		oam.SetPublishVersion(rev, "v1")
+		cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).WithObjects(app, rev).
+			WithStatusSubresource(&v1beta1.ApplicationRevision{}, &v1beta1.Application{}).Build()
+		return app, rev, cli
+	}
+
+	t.Run("fail if the resources of the revision are recycled", func(t *testing.T) {
+		r := require.New(t)
+		app, rev, cli := setup()
+		_, err := RollbackToRevision(ctx, cli, app, rev, RollbackOptions{PublishVersion: "v1"})
+		r.ErrorContains(err, "cannot find resource tracker for previous revision app-v1")
+		updated := &v1beta1.Application{}
+		r.NoError(cli.Get(ctx, client.ObjectKeyFromObject(app), updated))
+		r.Equal(`{"image":"nginx:bad"}`, string(updated.Spec.Components[0].Properties.Raw))
+		r.Equal("v2", oam.GetPublishVersion(updated))
+	})
+
+	t.Run("redeploy the recycled revision", func(t *testing.T) {
+		r := require.New(t)
+		app, rev, cli := setup()
+		rolloutRolledBack, err := RollbackToRevision(ctx, cli, app, rev, RollbackOptions{PublishVersion: "v1", RedeployRecycled: true})
+		r.NoError(err)
+		r.False(rolloutRolledBack)
+		updated := &v1beta1.Application{}
+		r.NoError(cli.Get(ctx, client.ObjectKeyFromObject(app), updated))
+		r.Equal(`{"image":"nginx:good"}`, string(updated.Spec.Components[0].Properties.Raw))
+		r.Equal("v1", oam.GetPublishVersion(updated))
+		r.Empty(oam.GetControllerRequirement(updated))
+	})
+}
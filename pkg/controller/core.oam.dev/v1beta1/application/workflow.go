@@ -101,33 +101,40 @@ func (r *Reconciler) handleWorkflowRestartAnnotation(ctx context.Context, app *v
 // 1. Scheduled restart (via workflowRestartScheduledAt status field)
 // 2. PublishVersion annotation change
 // 3. Application revision change
-func (r *Reconciler) checkWorkflowRestart(ctx monitorContext.Context, app *v1beta1.Application, handler *AppHandler) {
+//
+// A restart is postponed while the deploy-window policy does not allow the deployment, in which
+// case the time to wait is returned and the current workflow status is kept.
+func (r *Reconciler) checkWorkflowRestart(ctx monitorContext.Context, app *v1beta1.Application, handler *AppHandler) time.Duration {
 	// Check for scheduled restart in status field
 	if app.Status.WorkflowRestartScheduledAt != nil {
 		restartTime := app.Status.WorkflowRestartScheduledAt.Time
 
 		if time.Now().Before(restartTime) {
 			// Not yet time to restart, skip for now
-			return
+			return 0
 		}
 		if app.Status.Workflow == nil || !app.Status.Workflow.Finished {
 			// Workflow is still running or hasn't started - don't restart yet
-			return
+			return 0
 		}
 		if app.Status.Workflow != nil && !app.Status.Workflow.EndTime.IsZero() {
 			lastEndTime := app.Status.Workflow.EndTime.Time
 			if !restartTime.After(lastEndTime) {
 				// Restart time is not after last execution, skip
-				return
+				return 0
 			}
 		}
 
+		if wait := waitForDeployWindow(ctx, app); wait > 0 {
+			return wait
+		}
+
 		// All conditions met: time arrived, workflow finished, and restart time > last execution
 		// Clear the status field and proceed with restart
 		app.Status.WorkflowRestartScheduledAt = nil
 		if err := r.Status().Update(ctx, app); err != nil {
 			ctx.Error(err, "failed to clear workflow restart scheduled time")
-			return
+			return 0
 		}
 		if app.Status.Workflow != nil {
 			if handler.latestAppRev != nil && handler.latestAppRev.Status.Workflow == nil {
@@ -146,7 +153,7 @@ func (r *Reconciler) checkWorkflowRestart(ctx monitorContext.Context, app *v1bet
 		for i, cond := range app.Status.Conditions {
 			condTpy, err := common.ParseApplicationConditionType(string(cond.Type))
 			if err == nil {
-				if condTpy <= common.RenderCondition {
+				if condTpy <= common.RenderCondition || condTpy == common.DeployWindowCondition {
 					reservedConditions = append(reservedConditions, app.Status.Conditions[i])
 				}
 			}
@@ -154,8 +161,9 @@ func (r *Reconciler) checkWorkflowRestart(ctx monitorContext.Context, app *v1bet
 		app.Status.Conditions = reservedConditions
 		app.Status.Workflow = &common.WorkflowStatus{
 			AppRevision: handler.currentAppRev.Name,
+			Approvals:   approvalHistory(app),
 		}
-		return
+		return 0
 	}
 
 	// Check for revision-based restart (publishVersion or normal revision change)
@@ -191,6 +199,15 @@ func (r *Reconciler) checkWorkflowRestart(ctx monitorContext.Context, app *v1bet
 	// a persistent failure (e.g. RBAC change deleting CM read) does not produce
 	// alert-storms across the fleet — the persistent failure surfaces during
 	// the next Render() with a clearer error than could be produced here.
+	// Append a fingerprint of the outputs of the Applications depended on through the
+	// app-dependency policy, so the workflow restarts with the new outputs when a dependency
+	// exports different values. It goes before the valuesFrom suffix, which may reuse
+	// currentRev as a whole on a transient error.
+	if !publishVersionPinned {
+		if depFp := computeDependencyOutputsFingerprint(ctx); depFp != "" {
+			desiredRev = desiredRev + dependencySuffixSeparator + depFp[:dependencySuffixHexLen]
+		}
+	}
 	if !publishVersionPinned {
 		if vfFp, err := computeValuesFromContentFingerprint(ctx, app); err != nil {
 			klog.V(2).InfoS("failed to compute valuesFrom fingerprint; falling back to spec-only workflow gate",
@@ -204,7 +221,10 @@ func (r *Reconciler) checkWorkflowRestart(ctx monitorContext.Context, app *v1bet
 	}
 
 	if currentRev != "" && desiredRev == currentRev {
-		return
+		return 0
+	}
+	if wait := waitForDeployWindow(ctx, app); wait > 0 {
+		return wait
 	}
 
 	// Restart needed - record in revision and clean up
@@ -225,7 +245,7 @@ func (r *Reconciler) checkWorkflowRestart(ctx monitorContext.Context, app *v1bet
 	for i, cond := range app.Status.Conditions {
 		condTpy, err := common.ParseApplicationConditionType(string(cond.Type))
 		if err == nil {
-			if condTpy <= common.RenderCondition {
+			if condTpy <= common.RenderCondition || condTpy == common.DeployWindowCondition {
 				reservedConditions = append(reservedConditions, app.Status.Conditions[i])
 			}
 		}
@@ -233,5 +253,16 @@ func (r *Reconciler) checkWorkflowRestart(ctx monitorContext.Context, app *v1bet
 	app.Status.Conditions = reservedConditions
 	app.Status.Workflow = &common.WorkflowStatus{
 		AppRevision: desiredRev,
+		Approvals:   approvalHistory(app),
+	}
+	return 0
+}
+
+// approvalHistory returns the approvals recorded in the workflow status, kept for audit across the
+// runs of the workflow
+func approvalHistory(app *v1beta1.Application) []common.WorkflowStepApproval {
+	if app.Status.Workflow == nil {
+		return nil
 	}
+	return app.Status.Workflow.Approvals
 }
@@ -29,6 +29,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/cue/cuex/providers/config"
 	"github.com/oam-dev/kubevela/pkg/cue/cuex/providers/helm"
+	"github.com/oam-dev/kubevela/pkg/cue/cuex/providers/kustomize"
 )
 
 // ConfigCompiler ...
@@ -44,6 +45,7 @@ var WorkloadCompiler = singleton.NewSingleton[*cuex.Compiler](func() *cuex.Compi
 	compiler := cuex.NewCompilerWithInternalPackages(
 		config.Package,
 		helm.Package,
+		kustomize.Package,
 		base64.Package,
 		http.Package,
 		kube.Package,
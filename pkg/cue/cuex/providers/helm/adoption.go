@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+// Takeover of releases installed outside KubeVela: history import, adoption policy, and the dry-run report.
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// AdoptionPolicyIfExists takes over an existing release in place, keeping its history
+	AdoptionPolicyIfExists = "IfExists"
+	// AdoptionPolicyNever fails the render when an existing release is not owned by the component
+	AdoptionPolicyNever = "Never"
+	// AdoptionPolicyDryRun reports what the takeover would change, without changing anything
+	AdoptionPolicyDryRun = "DryRun"
+)
+
+// planAdoption decides how an existing release that is not owned by the component is taken over.
+// It returns nil when there is nothing to adopt. For the IfExists policy, the history of a release
+// installed under FromName is imported into the managed release first, so the following upgrade
+// in installOrUpgradeChart continues the same `helm history`. For the DryRun policy, the returned
+// report lists the resource changes and the caller must not install or upgrade the release.
+func (p *Provider) planAdoption(ch *chart.Chart, releaseName, releaseNamespace string, values map[string]interface{}, options *RenderOptionsParams, velaCtx *ContextParams) (*AdoptionReport, error) {
+	if velaCtx == nil {
+		return nil, nil
+	}
+	adoption := &AdoptionParams{}
+	if options != nil && options.Adoption != nil {
+		adoption = options.Adoption
+	}
+	policy := adoption.Policy
+	if policy == "" {
+		policy = AdoptionPolicyIfExists
+	}
+	if policy != AdoptionPolicyIfExists && policy != AdoptionPolicyNever && policy != AdoptionPolicyDryRun {
+		return nil, fmt.Errorf("unsupported adoption policy %q, must be %s, %s or %s", policy, AdoptionPolicyIfExists, AdoptionPolicyNever, AdoptionPolicyDryRun)
+	}
+
+	defer p.lockRelease(releaseName, releaseNamespace)()
+	p.releaseMu.Lock()
+	defer p.releaseMu.Unlock()
+
+	actionConfig, err := p.actionConfigFactory(releaseNamespace)
+	if err != nil {
+		return nil, err
+	}
+	from := releaseName
+	history, err := actionConfig.Releases.History(releaseName)
+	if err != nil || len(history) == 0 {
+		// A corrupted release is recovered by the install path, only a missing
+		// release falls back to the release to import.
+		if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, nil
+		}
+		if adoption.FromName == "" || adoption.FromName == releaseName {
+			return nil, nil
+		}
+		from = adoption.FromName
+		if history, err = actionConfig.Releases.History(from); err != nil || len(history) == 0 {
+			if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+				return nil, errors.Wrapf(err, "failed to read the history of release %s", from)
+			}
+			klog.Infof("Helm provider [%s]: Release %s to adopt is not found in namespace %s, installing %s", velaContextStr(velaCtx), from, releaseNamespace, releaseName)
+			return nil, nil
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Version < history[j].Version })
+	latest := history[len(history)-1]
+	if isOwnedByVela(latest, velaCtx) {
+		return nil, nil
+	}
+
+	report := &AdoptionReport{Release: releaseNamespace + "/" + releaseName, From: from}
+	for _, rel := range history {
+		report.Revisions = append(report.Revisions, rel.Version)
+	}
+
+	switch policy {
+	case AdoptionPolicyNever:
+		return nil, fmt.Errorf("release %s/%s exists and is not managed by component %s, set options.adoption.policy to %s to take it over",
+			releaseNamespace, from, velaCtx.Name, AdoptionPolicyIfExists)
+	case AdoptionPolicyDryRun:
+		report.DryRun = true
+		target, _, err := p.dryRunRender(ch, releaseName, releaseNamespace, values, options, velaCtx)
+		if err != nil {
+			return nil, err
+		}
+		if err := diffAdoption(report, latest.Manifest, target, releaseNamespace); err != nil {
+			return nil, err
+		}
+		report.Summary = fmt.Sprintf("dry run: taking over release %s/%s would adopt %d, change %d, add %d and remove %d resources, keeping %d revisions",
+			releaseNamespace, from, len(report.Adopted), len(report.Changed), len(report.Added), len(report.Removed), len(report.Revisions))
+		klog.Infof("Helm provider [%s]: %s", velaContextStr(velaCtx), report.Summary)
+		return report, nil
+	}
+
+	if from != releaseName {
+		if err := importReleaseHistory(actionConfig, history, releaseName); err != nil {
+			return nil, errors.Wrapf(err, "failed to import the history of release %s into %s", from, releaseName)
+		}
+		klog.Infof("Helm provider [%s]: Imported %d revisions of release %s into %s", velaContextStr(velaCtx), len(history), from, releaseName)
+	}
+	report.Summary = fmt.Sprintf("took over release %s/%s with %d revisions", releaseNamespace, from, len(report.Revisions))
+	return report, nil
+}
+
+// importReleaseHistory copies the revisions of a release to another name in the same namespace,
+// and removes the original records once all of them are copied, so there is only one release
+// claiming the resources. The revision numbers are kept for the continuity of `helm history`.
+func importReleaseHistory(actionConfig *action.Configuration, history []*release.Release, releaseName string) error {
+	for _, rel := range history {
+		imported := *rel
+		imported.Name = releaseName
+		if err := actionConfig.Releases.Create(&imported); err != nil && !errors.Is(err, driver.ErrReleaseExists) {
+			return err
+		}
+	}
+	for _, rel := range history {
+		if _, err := actionConfig.Releases.Delete(rel.Name, rel.Version); err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownershipMetadata is removed before comparing the resources, since the takeover always rewrites it
+var ownershipMetadata = []string{"app.oam.dev/name", "app.oam.dev/namespace", "app.oam.dev/component", "app.oam.dev/owner",
+	"meta.helm.sh/release-name", "meta.helm.sh/release-namespace"}
+
+// diffAdoption compares the manifest of the existing release with the manifest rendered for the
+// takeover, and records the resources that are adopted as-is, changed, added and removed.
+func diffAdoption(report *AdoptionReport, current, target, releaseNamespace string) error {
+	currentObjs, err := parseAdoptionManifest(current, releaseNamespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the manifest of the existing release")
+	}
+	targetObjs, err := parseAdoptionManifest(target, releaseNamespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the rendered manifest")
+	}
+	for key, obj := range targetObjs {
+		existing, ok := currentObjs[key]
+		switch {
+		case !ok:
+			report.Added = append(report.Added, key)
+		case reflect.DeepEqual(existing.Object, obj.Object):
+			report.Adopted = append(report.Adopted, key)
+		default:
+			report.Changed = append(report.Changed, key)
+		}
+	}
+	for key := range currentObjs {
+		if _, ok := targetObjs[key]; !ok {
+			report.Removed = append(report.Removed, key)
+		}
+	}
+	for _, keys := range [][]string{report.Adopted, report.Changed, report.Added, report.Removed} {
+		sort.Strings(keys)
+	}
+	return nil
+}
+
+// parseAdoptionManifest parses the manifest into the resources keyed by "Kind namespace/name",
+// without the ownership metadata
+func parseAdoptionManifest(manifest, releaseNamespace string) (map[string]*unstructured.Unstructured, error) {
+	objs := map[string]*unstructured.Unstructured{}
+	decoder := kyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if stderrors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetNamespace() == "" && !isClusterScopedGVK(obj.GroupVersionKind()) {
+			obj.SetNamespace(releaseNamespace)
+		}
+		labels, annotations := obj.GetLabels(), obj.GetAnnotations()
+		for _, key := range ownershipMetadata {
+			delete(labels, key)
+			delete(annotations, key)
+		}
+		if len(labels) == 0 {
+			labels = nil
+		}
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetLabels(labels)
+		obj.SetAnnotations(annotations)
+		key := obj.GetKind() + " " + obj.GetName()
+		if obj.GetNamespace() != "" {
+			key = obj.GetKind() + " " + obj.GetNamespace() + "/" + obj.GetName()
+		}
+		objs[key] = obj
+	}
+	return objs, nil
+}
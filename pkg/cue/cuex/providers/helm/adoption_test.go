@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+const legacyManifest = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rel-cm
+  namespace: ns
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: legacy-only
+  namespace: ns
+data:
+  key: value
+`
+
+var _ = Describe("release adoption", func() {
+	const (
+		relName = "rel"
+		relNS   = "ns"
+	)
+	var (
+		cfg     *action.Configuration
+		p       *Provider
+		velaCtx *ContextParams
+	)
+
+	// seedRelease stores the revisions of a release installed by `helm install`
+	seedRelease := func(name string, revisions int) {
+		for v := 1; v <= revisions; v++ {
+			status := release.StatusSuperseded
+			if v == revisions {
+				status = release.StatusDeployed
+			}
+			Expect(cfg.Releases.Create(&release.Release{
+				Name:      name,
+				Namespace: relNS,
+				Version:   v,
+				Info:      &release.Info{Status: status},
+				Chart:     minimalChart("c", "1.0.0"),
+				Config:    map[string]interface{}{},
+				Manifest:  legacyManifest,
+			})).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		cfg = fakeActionConfig()
+		p = installProviderWithFake(cfg)
+		velaCtx = &ContextParams{AppName: "app", AppNamespace: relNS, Name: "comp"}
+	})
+
+	It("does nothing without an existing release", func() {
+		report, err := p.planAdoption(minimalChart("c", "1.0.0"), relName, relNS, nil, nil, velaCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(BeNil())
+	})
+
+	It("imports the history of a release installed under another name", func() {
+		seedRelease("legacy", 2)
+		options := &RenderOptionsParams{Adoption: &AdoptionParams{FromName: "legacy"}}
+
+		report, err := p.planAdoption(minimalChart("c", "1.0.0"), relName, relNS, nil, options, velaCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).NotTo(BeNil())
+		Expect(report.DryRun).To(BeFalse())
+		Expect(report.From).To(Equal("legacy"))
+		Expect(report.Revisions).To(Equal([]int{1, 2}))
+
+		_, err = cfg.Releases.History("legacy")
+		Expect(err).To(HaveOccurred(), "the original release records are removed")
+
+		_, _, version, err := p.installOrUpgradeChart(context.Background(), minimalChart("c", "1.0.0"), relName, relNS, map[string]interface{}{}, options, velaCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal(3), "the takeover continues the imported history")
+		history, err := cfg.Releases.History(relName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(3))
+
+		// the release is owned by the component now
+		report, err = p.planAdoption(minimalChart("c", "1.0.0"), relName, relNS, nil, options, velaCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(BeNil())
+	})
+
+	It("refuses to take over the release with the Never policy", func() {
+		seedRelease(relName, 1)
+		options := &RenderOptionsParams{Adoption: &AdoptionParams{Policy: AdoptionPolicyNever}}
+		_, err := p.planAdoption(minimalChart("c", "1.0.0"), relName, relNS, nil, options, velaCtx)
+		Expect(err).To(MatchError(ContainSubstring("is not managed by component comp")))
+	})
+
+	It("reports the changes without touching the release in the DryRun policy", func() {
+		seedRelease("legacy", 1)
+		options := &RenderOptionsParams{Adoption: &AdoptionParams{Policy: AdoptionPolicyDryRun, FromName: "legacy"}}
+		report, err := p.planAdoption(minimalChart("c", "1.0.0"), relName, relNS, map[string]interface{}{}, options, velaCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.DryRun).To(BeTrue())
+		Expect(report.Adopted).To(Equal([]string{"ConfigMap ns/rel-cm"}))
+		Expect(report.Removed).To(Equal([]string{"ConfigMap ns/legacy-only"}))
+		Expect(report.Summary).To(ContainSubstring("would adopt 1, change 0, add 0 and remove 1 resources"))
+
+		history, err := cfg.Releases.History("legacy")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(1))
+		_, err = cfg.Releases.History(relName)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects an unknown policy", func() {
+		_, err := p.planAdoption(minimalChart("c", "1.0.0"), relName, relNS, nil, &RenderOptionsParams{Adoption: &AdoptionParams{Policy: "Always"}}, velaCtx)
+		Expect(err).To(MatchError(ContainSubstring("unsupported adoption policy")))
+	})
+
+	It("diffs the resources ignoring the ownership metadata", func() {
+		report := &AdoptionReport{}
+		target := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rel-cm
+  labels:
+    app.oam.dev/name: app
+  annotations:
+    meta.helm.sh/release-name: rel
+data:
+  key: changed
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: new
+`
+		Expect(diffAdoption(report, legacyManifest, target, relNS)).To(Succeed())
+		Expect(report.Changed).To(Equal([]string{"ConfigMap ns/rel-cm"}))
+		Expect(report.Added).To(Equal([]string{"Secret ns/new"}))
+		Expect(report.Removed).To(Equal([]string{"ConfigMap ns/legacy-only"}))
+		Expect(report.Adopted).To(BeEmpty())
+	})
+})
@@ -0,0 +1,256 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+// Persistent content-addressed on-disk chart cache that survives controller restarts.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// ChartCacheDir is the directory of the persistent chart cache. The cache is
+	// disabled when it is empty. It is set by the --helm-chart-cache-dir flag.
+	ChartCacheDir = ""
+	// ChartCacheMaxSize is the max total size in bytes of the chart archives in the
+	// persistent chart cache. The least recently used archives are evicted when the
+	// size is exceeded. It is set by the --helm-chart-cache-max-size flag.
+	ChartCacheMaxSize int64 = 1 << 30
+)
+
+// chartDiskCache stores the chart archives under blobs/sha256/<hex>, keyed by
+// the digest of the content, and the provenance files next to them. The refs/
+// directory maps a chart reference (source, immutable version and auth tag) to
+// the digest, so a chart pinned to an immutable version is loaded from disk
+// without reaching the source after the controller restarts. The modification time
+// of a blob is its last use, the least recently used blobs are evicted once the
+// total size exceeds maxSize.
+type chartDiskCache struct {
+	dir     string
+	maxSize int64
+	// mu serializes the eviction with the writes
+	mu sync.Mutex
+}
+
+// chartCacheRef is the content of a ref file
+type chartCacheRef struct {
+	Digest    string `json:"digest"`
+	FileName  string `json:"fileName"`
+	OCIDigest string `json:"ociDigest,omitempty"`
+}
+
+// newChartDiskCache creates the cache directories
+func newChartDiskCache(dir string, maxSize int64) (*chartDiskCache, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid chart cache max size %d, it must be positive", maxSize)
+	}
+	for _, sub := range []string{"blobs/sha256", "refs"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0750); err != nil {
+			return nil, errors.Wrapf(err, "failed to create chart cache directory %s", dir)
+		}
+	}
+	return &chartDiskCache{dir: dir, maxSize: maxSize}, nil
+}
+
+// digestOf returns the sha256 digest of the data, in the format of sha256:<hex>
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func (c *chartDiskCache) blobPath(digest string) (string, error) {
+	hexDigest := strings.TrimPrefix(digest, "sha256:")
+	if len(hexDigest) != sha256.Size*2 || strings.Trim(hexDigest, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("invalid chart digest %q", digest)
+	}
+	return filepath.Join(c.dir, "blobs", "sha256", hexDigest), nil
+}
+
+func (c *chartDiskCache) refPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, "refs", hex.EncodeToString(sum[:]))
+}
+
+// writeFile writes the file atomically, so a crash never leaves a partial blob
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// getBlob returns the chart archive and its provenance file of the digest. The
+// content is checked against the digest, a corrupted blob is removed and missed.
+func (c *chartDiskCache) getBlob(digest string) (data []byte, prov []byte, ok bool) {
+	path, err := c.blobPath(digest)
+	if err != nil {
+		return nil, nil, false
+	}
+	data, err = os.ReadFile(path) // #nosec G304 -- the path is derived from a validated digest
+	if err != nil {
+		return nil, nil, false
+	}
+	if digestOf(data) != digest {
+		klog.Warningf("Helm provider: removing corrupted chart cache blob %s", digest)
+		_ = os.Remove(path)
+		return nil, nil, false
+	}
+	prov, _ = os.ReadFile(path + ".prov") // #nosec G304 -- the path is derived from a validated digest
+	touch(path)
+	return data, prov, true
+}
+
+// touch marks the blob as recently used
+func touch(path string) {
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
+
+// putBlob stores the chart archive and its provenance file, and returns the digest
+func (c *chartDiskCache) putBlob(data []byte, prov []byte) (string, error) {
+	digest := digestOf(data)
+	path, err := c.blobPath(digest)
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)+len(prov)) > c.maxSize {
+		return "", fmt.Errorf("chart %s exceeds the max size %d of the chart cache", digest, c.maxSize)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := os.Stat(path); err != nil {
+		if err := writeFile(path, data); err != nil {
+			return "", err
+		}
+	} else {
+		touch(path)
+	}
+	if len(prov) > 0 {
+		if err := writeFile(path+".prov", prov); err != nil {
+			return "", err
+		}
+	}
+	if err := c.evict(path); err != nil {
+		klog.Warningf("Helm provider: failed to evict the chart cache: %v", err)
+	}
+	return digest, nil
+}
+
+// evict removes the least recently used blobs, except the kept one, until the total size of the
+// blobs fits in maxSize. The refs to the evicted blobs are removed when they are missed.
+func (c *chartDiskCache) evict(keep string) error {
+	dir := filepath.Join(c.dir, "blobs", "sha256")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	type blob struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var blobs []blob
+	var total int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".tmp-") || strings.HasSuffix(name, ".prov") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		b := blob{path: filepath.Join(dir, name), size: info.Size(), modTime: info.ModTime()}
+		if provInfo, err := os.Stat(b.path + ".prov"); err == nil {
+			b.size += provInfo.Size()
+		}
+		total += b.size
+		blobs = append(blobs, b)
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].modTime.Before(blobs[j].modTime) })
+	for _, b := range blobs {
+		if total <= c.maxSize {
+			break
+		}
+		if b.path == keep {
+			continue
+		}
+		if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		_ = os.Remove(b.path + ".prov")
+		total -= b.size
+		klog.V(3).Infof("Helm provider: evicted chart cache blob %s", filepath.Base(b.path))
+	}
+	return nil
+}
+
+// get returns the chart archive of the reference key
+func (c *chartDiskCache) get(key string) (*chartArchive, bool) {
+	content, err := os.ReadFile(c.refPath(key))
+	if err != nil {
+		return nil, false
+	}
+	var ref chartCacheRef
+	if err := json.Unmarshal(content, &ref); err != nil {
+		return nil, false
+	}
+	data, prov, ok := c.getBlob(ref.Digest)
+	if !ok {
+		// the blob is evicted or corrupted
+		_ = os.Remove(c.refPath(key))
+		return nil, false
+	}
+	return &chartArchive{Data: data, Prov: prov, FileName: ref.FileName, OCIDigest: ref.OCIDigest}, true
+}
+
+// put stores the chart archive, and links the reference key to it if the key is not empty
+func (c *chartDiskCache) put(key string, archive *chartArchive) error {
+	digest, err := c.putBlob(archive.Data, archive.Prov)
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		return nil
+	}
+	content, err := json.Marshal(chartCacheRef{Digest: digest, FileName: archive.FileName, OCIDigest: archive.OCIDigest})
+	if err != nil {
+		return err
+	}
+	return writeFile(c.refPath(key), content)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("chartDiskCache", func() {
+	var cache *chartDiskCache
+
+	BeforeEach(func() {
+		var err error
+		cache, err = newChartDiskCache(GinkgoT().TempDir(), 1<<20)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("stores the archive by digest and links the reference to it", func() {
+		archive := &chartArchive{Data: []byte("chart"), Prov: []byte("prov"), FileName: "podinfo-1.0.0.tgz"}
+		Expect(cache.put("repo/podinfo/1.0.0", archive)).To(Succeed())
+
+		got, ok := cache.get("repo/podinfo/1.0.0")
+		Expect(ok).To(BeTrue())
+		Expect(got).To(Equal(archive))
+
+		data, prov, ok := cache.getBlob(digestOf([]byte("chart")))
+		Expect(ok).To(BeTrue())
+		Expect(data).To(Equal([]byte("chart")))
+		Expect(prov).To(Equal([]byte("prov")))
+
+		_, ok = cache.get("repo/podinfo/2.0.0")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("removes the corrupted blob instead of serving it", func() {
+		Expect(cache.put("key", &chartArchive{Data: []byte("chart")})).To(Succeed())
+		path, err := cache.blobPath(digestOf([]byte("chart")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(path, []byte("tampered"), 0600)).To(Succeed())
+
+		_, ok := cache.get("key")
+		Expect(ok).To(BeFalse())
+		_, err = os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("rejects the invalid digest", func() {
+		_, err := cache.blobPath("sha256:../../etc/passwd")
+		Expect(err).To(HaveOccurred())
+		_, _, ok := cache.getBlob("md5:abc")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("evicts the least recently used blobs beyond the max size", func() {
+		cache.maxSize = 10
+		old := time.Now().Add(-time.Hour)
+		Expect(cache.put("a", &chartArchive{Data: []byte("chart-a")})).To(Succeed())
+		pathA, err := cache.blobPath(digestOf([]byte("chart-a")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chtimes(pathA, old, old)).To(Succeed())
+		Expect(cache.put("b", &chartArchive{Data: []byte("chart-b")})).To(Succeed())
+
+		_, ok := cache.get("a")
+		Expect(ok).To(BeFalse())
+		_, err = os.Stat(cache.refPath("a"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		_, ok = cache.get("b")
+		Expect(ok).To(BeTrue())
+
+		// the blob larger than the cache is never stored
+		Expect(cache.put("c", &chartArchive{Data: []byte("a very large chart")})).NotTo(Succeed())
+		_, ok = cache.get("b")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("serves the immutable chart from disk after a restart", func() {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			_, _ = w.Write(createMinimalChartArchive("cached-chart", "1.0.0"))
+		}))
+		defer server.Close()
+
+		params := &ChartSourceParams{Source: server.URL + "/cached-chart-1.0.0.tgz", Version: "1.0.0"}
+		p := NewProviderWithConfig(nil)
+		p.diskCache = cache
+		ch, err := p.fetchChart(context.Background(), params, nil, "app-ns", "rel-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ch.Metadata.Name).To(Equal("cached-chart"))
+
+		// a new provider has an empty memory cache, like a restarted controller
+		restarted := NewProviderWithConfig(nil)
+		restarted.diskCache = cache
+		ch, err = restarted.fetchChart(context.Background(), params, nil, "app-ns", "rel-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ch.Metadata.Name).To(Equal("cached-chart"))
+		Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+	})
+})
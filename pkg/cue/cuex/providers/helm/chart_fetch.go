@@ -16,12 +16,14 @@ limitations under the License.
 
 package helm
 
-// Detects chart source type (OCI / URL / repo) and fetches charts with a TTL-bounded in-memory cache.
+// Detects chart source type (OCI / URL / repo) and fetches charts with a TTL-bounded in-memory cache
+// backed by the optional persistent disk cache.
 
 import (
 	"bytes"
 	"context"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
@@ -130,6 +132,15 @@ func (p *Provider) fetchChart(ctx context.Context, params *ChartSourceParams, op
 	if authTag != "" {
 		cacheKey = cacheKey + "/auth-" + authTag
 	}
+	// Charts accepted by one verification must not be served to a request
+	// that verifies with another keyring, or does not verify at all.
+	verifyTag, err := verifyCacheTag(ctx, params, appNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if verifyTag != "" {
+		cacheKey = cacheKey + "/verify-" + verifyTag
+	}
 
 	// Check if caching is disabled
 	if options != nil && options.Cache != nil && options.Cache.TTL == "0" {
@@ -157,7 +168,7 @@ func (p *Provider) fetchChart(ctx context.Context, params *ChartSourceParams, op
 
 	klog.V(4).Infof("Cache miss for key: %s, fetching chart", cacheKey)
 
-	ch, err := p.fetchChartWithoutCache(ctx, params, sourceType, appNamespace, releaseNamespace)
+	ch, err := p.fetchChartWithDiskCache(ctx, params, sourceType, cacheKey, appNamespace, releaseNamespace)
 	if err != nil {
 		return nil, err
 	}
@@ -176,13 +187,60 @@ func (p *Provider) fetchChart(ctx context.Context, params *ChartSourceParams, op
 
 // fetchChartWithoutCache fetches a chart without using cache
 func (p *Provider) fetchChartWithoutCache(ctx context.Context, params *ChartSourceParams, sourceType string, appNamespace, releaseNamespace string) (*chart.Chart, error) {
+	archive, err := p.downloadChart(ctx, params, sourceType, appNamespace, releaseNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.verifyChart(ctx, params, archive, appNamespace, releaseNamespace); err != nil {
+		return nil, err
+	}
+	return loadChartArchive(archive)
+}
+
+// fetchChartWithDiskCache fetches a chart through the persistent disk cache. Only the charts
+// pinned to an immutable version are looked up by the reference, the mutable ones are always
+// downloaded. The chart is verified on every load, so a tampered cache is never trusted.
+func (p *Provider) fetchChartWithDiskCache(ctx context.Context, params *ChartSourceParams, sourceType, cacheKey string, appNamespace, releaseNamespace string) (*chart.Chart, error) {
+	if p.diskCache == nil {
+		return p.fetchChartWithoutCache(ctx, params, sourceType, appNamespace, releaseNamespace)
+	}
+	refKey := ""
+	if !isMutableVersion(params.Version) {
+		refKey = cacheKey
+		if archive, ok := p.diskCache.get(refKey); ok {
+			if err := p.verifyChart(ctx, params, archive, appNamespace, releaseNamespace); err != nil {
+				return nil, err
+			}
+			klog.V(3).Infof("Using chart from the disk cache with key: %s", cacheKey)
+			return loadChartArchive(archive)
+		}
+	}
+	archive, err := p.downloadChart(ctx, params, sourceType, appNamespace, releaseNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.verifyChart(ctx, params, archive, appNamespace, releaseNamespace); err != nil {
+		return nil, err
+	}
+	ch, err := loadChartArchive(archive)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.diskCache.put(refKey, archive); err != nil {
+		klog.Warningf("Failed to store chart %s in the disk cache: %v", chartSourceLabel(params), err)
+	}
+	return ch, nil
+}
+
+// downloadChart downloads the chart archive from the source
+func (p *Provider) downloadChart(ctx context.Context, params *ChartSourceParams, sourceType string, appNamespace, releaseNamespace string) (*chartArchive, error) {
 	switch sourceType {
-	case "oci":
-		return p.fetchOCIChart(ctx, params, appNamespace, releaseNamespace)
-	case "url":
-		return p.fetchURLChart(ctx, params, appNamespace, releaseNamespace)
-	case "repo":
-		return p.fetchRepoChart(ctx, params, appNamespace, releaseNamespace)
+	case sourceTypeOCI:
+		return p.downloadOCIChart(ctx, params, appNamespace, releaseNamespace)
+	case sourceTypeURL:
+		return p.downloadURLChart(ctx, params, appNamespace, releaseNamespace)
+	case sourceTypeRepo:
+		return p.downloadRepoChart(ctx, params, appNamespace, releaseNamespace)
 	default:
 		return nil, fmt.Errorf("unsupported chart source type: %s", sourceType)
 	}
@@ -234,8 +292,33 @@ func (p *Provider) determineCacheTTL(version string, options *RenderOptionsParam
 	return p.cacheTTL.ImmutableVersionTTL
 }
 
-// fetchOCIChart fetches a chart from an OCI registry.
-func (p *Provider) fetchOCIChart(ctx context.Context, params *ChartSourceParams, appNamespace, releaseNamespace string) (*chart.Chart, error) {
+// chartArchive is a chart archive downloaded from the source, with the material to verify it
+type chartArchive struct {
+	Data []byte
+	// FileName is the base name of the archive, which is matched by the provenance file
+	FileName string
+	// Prov is the provenance file. It is only downloaded when the provenance is verified.
+	Prov []byte
+	// OCIDigest is the manifest digest of the OCI chart
+	OCIDigest string
+}
+
+// loadChartArchive loads the chart from the archive
+func loadChartArchive(archive *chartArchive) (*chart.Chart, error) {
+	ch, err := loader.LoadArchive(bytes.NewReader(archive.Data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load chart archive")
+	}
+	return ch, nil
+}
+
+// withProv returns whether the provenance file should be downloaded with the chart
+func withProv(params *ChartSourceParams) bool {
+	return params.Verify != nil && params.Verify.Provider == VerifyProviderProvenance
+}
+
+// downloadOCIChart downloads the chart archive from an OCI registry.
+func (p *Provider) downloadOCIChart(ctx context.Context, params *ChartSourceParams, appNamespace, releaseNamespace string) (*chartArchive, error) {
 	httpOpts, rawDockerCfg, err := resolveHTTPOptions(ctx, params, appNamespace, releaseNamespace, sourceTypeOCI)
 	if err != nil {
 		return nil, errors.Wrap(err, "auth resolution failed")
@@ -264,15 +347,25 @@ func (p *Provider) fetchOCIChart(ctx context.Context, params *ChartSourceParams,
 	if params.Version != "" {
 		ref = fmt.Sprintf("%s:%s", ref, params.Version)
 	}
-	result, err := registryClient.Pull(ref)
+	result, err := registryClient.Pull(ref, registry.PullOptWithProv(withProv(params)))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to pull OCI chart %s", ref)
 	}
-	return loader.LoadArchive(bytes.NewReader(result.Chart.Data))
+	archive := &chartArchive{Data: result.Chart.Data}
+	if result.Chart.Meta != nil {
+		archive.FileName = fmt.Sprintf("%s-%s.tgz", result.Chart.Meta.Name, result.Chart.Meta.Version)
+	}
+	if result.Manifest != nil {
+		archive.OCIDigest = result.Manifest.Digest
+	}
+	if result.Prov != nil {
+		archive.Prov = result.Prov.Data
+	}
+	return archive, nil
 }
 
-// fetchURLChart fetches a chart from a direct URL.
-func (p *Provider) fetchURLChart(ctx context.Context, params *ChartSourceParams, appNamespace, releaseNamespace string) (*chart.Chart, error) {
+// downloadURLChart downloads the chart archive from a direct URL.
+func (p *Provider) downloadURLChart(ctx context.Context, params *ChartSourceParams, appNamespace, releaseNamespace string) (*chartArchive, error) {
 	httpOpts, _, err := resolveHTTPOptions(ctx, params, appNamespace, releaseNamespace, sourceTypeURL)
 	if err != nil {
 		return nil, errors.Wrap(err, "auth resolution failed")
@@ -280,20 +373,11 @@ func (p *Provider) fetchURLChart(ctx context.Context, params *ChartSourceParams,
 	if httpOpts == nil {
 		httpOpts = &common.HTTPOption{}
 	}
-
-	chartBytes, err := common.HTTPGetWithOption(ctx, params.Source, httpOpts)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to download chart from %s", params.Source)
-	}
-	ch, err := loader.LoadArchive(bytes.NewReader(chartBytes))
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to load chart archive")
-	}
-	return ch, nil
+	return p.downloadChartArchive(ctx, params, params.Source, "", httpOpts)
 }
 
-// fetchRepoChart fetches a chart from a Helm repository.
-func (p *Provider) fetchRepoChart(ctx context.Context, params *ChartSourceParams, appNamespace, releaseNamespace string) (*chart.Chart, error) {
+// downloadRepoChart downloads the chart archive from a Helm repository.
+func (p *Provider) downloadRepoChart(ctx context.Context, params *ChartSourceParams, appNamespace, releaseNamespace string) (*chartArchive, error) {
 	if params.RepoURL == "" {
 		return nil, fmt.Errorf("repoURL is required for repository-based charts")
 	}
@@ -333,13 +417,35 @@ func (p *Provider) fetchRepoChart(ctx context.Context, params *ChartSourceParams
 		return nil, fmt.Errorf("no download URL found for chart %s", params.Source)
 	}
 
-	chartBytes, err := common.HTTPGetWithOption(ctx, downloadURL, httpOpts)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to download chart from %s", downloadURL)
+	digest := ""
+	if chartVersion.Digest != "" {
+		digest = "sha256:" + strings.TrimPrefix(chartVersion.Digest, "sha256:")
+	}
+	return p.downloadChartArchive(ctx, params, downloadURL, digest, httpOpts)
+}
+
+// downloadChartArchive downloads the chart archive, and the provenance file next to it if the
+// provenance is verified. When the digest is known, the archive is served from the disk cache.
+func (p *Provider) downloadChartArchive(ctx context.Context, params *ChartSourceParams, archiveURL, digest string, httpOpts *common.HTTPOption) (*chartArchive, error) {
+	archive := &chartArchive{FileName: path.Base(strings.SplitN(archiveURL, "?", 2)[0])}
+	if p.diskCache != nil && digest != "" {
+		if data, prov, ok := p.diskCache.getBlob(digest); ok && (len(prov) > 0 || !withProv(params)) {
+			klog.V(3).Infof("Using chart %s from the disk cache", digest)
+			archive.Data, archive.Prov = data, prov
+			return archive, nil
+		}
 	}
-	ch, err := loader.LoadArchive(bytes.NewReader(chartBytes))
+	chartBytes, err := common.HTTPGetWithOption(ctx, archiveURL, httpOpts)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to load chart archive")
+		return nil, errors.Wrapf(err, "failed to download chart from %s", archiveURL)
 	}
-	return ch, nil
+	archive.Data = chartBytes
+	if withProv(params) {
+		prov, err := common.HTTPGetWithOption(ctx, archiveURL+".prov", httpOpts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to download the provenance file of chart %s", archiveURL)
+		}
+		archive.Prov = prov
+	}
+	return archive, nil
 }
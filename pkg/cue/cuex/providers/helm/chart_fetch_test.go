@@ -253,7 +253,7 @@ var _ = Describe("chart_fetch", func() {
 		})
 	})
 
-	Describe("fetchRepoChart with httptest", func() {
+	Describe("fetchChartWithoutCache from repo source with httptest", func() {
 		It("should fetch chart from a repo server", func() {
 			chartArchive := createMinimalChartArchive("test-repo-chart", "1.0.0")
 
@@ -279,11 +279,11 @@ entries:
 			defer server.Close()
 
 			p := NewProviderWithConfig(nil)
-			ch, err := p.fetchRepoChart(context.Background(), &ChartSourceParams{
+			ch, err := p.fetchChartWithoutCache(context.Background(), &ChartSourceParams{
 				Source:  "test-repo-chart",
 				RepoURL: server.URL,
 				Version: "1.0.0",
-			}, "", "")
+			}, sourceTypeRepo, "", "")
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(ch).ToNot(BeNil())
 			Expect(ch.Metadata.Name).To(Equal("test-repo-chart"))
@@ -313,10 +313,10 @@ entries:
 			defer server.Close()
 
 			p := NewProviderWithConfig(nil)
-			ch, err := p.fetchRepoChart(context.Background(), &ChartSourceParams{
+			ch, err := p.fetchChartWithoutCache(context.Background(), &ChartSourceParams{
 				Source:  "no-ver-chart",
 				RepoURL: server.URL,
-			}, "", "")
+			}, sourceTypeRepo, "", "")
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(ch.Metadata.Name).To(Equal("no-ver-chart"))
 		})
@@ -335,10 +335,10 @@ entries:
 			defer server.Close()
 
 			p := NewProviderWithConfig(nil)
-			_, err := p.fetchRepoChart(context.Background(), &ChartSourceParams{
+			_, err := p.fetchChartWithoutCache(context.Background(), &ChartSourceParams{
 				Source:  "missing-chart",
 				RepoURL: server.URL,
-			}, "", "")
+			}, sourceTypeRepo, "", "")
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("not found in repository"))
 		})
@@ -357,11 +357,11 @@ entries:
 			defer server.Close()
 
 			p := NewProviderWithConfig(nil)
-			_, err := p.fetchRepoChart(context.Background(), &ChartSourceParams{
+			_, err := p.fetchChartWithoutCache(context.Background(), &ChartSourceParams{
 				Source:  "my-chart",
 				RepoURL: server.URL,
 				Version: "99.0.0",
-			}, "", "")
+			}, sourceTypeRepo, "", "")
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("not found"))
 		})
@@ -373,10 +373,10 @@ entries:
 			defer server.Close()
 
 			p := NewProviderWithConfig(nil)
-			_, err := p.fetchRepoChart(context.Background(), &ChartSourceParams{
+			_, err := p.fetchChartWithoutCache(context.Background(), &ChartSourceParams{
 				Source:  "test",
 				RepoURL: server.URL,
-			}, "", "")
+			}, sourceTypeRepo, "", "")
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("failed to parse repository index"))
 		})
@@ -394,17 +394,17 @@ entries:
 			defer server.Close()
 
 			p := NewProviderWithConfig(nil)
-			_, err := p.fetchRepoChart(context.Background(), &ChartSourceParams{
+			_, err := p.fetchChartWithoutCache(context.Background(), &ChartSourceParams{
 				Source:  "empty-urls",
 				RepoURL: server.URL,
 				Version: "1.0.0",
-			}, "", "")
+			}, sourceTypeRepo, "", "")
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("no download URL found"))
 		})
 	})
 
-	Describe("fetchURLChart with httptest", func() {
+	Describe("fetchChartWithoutCache from url source with httptest", func() {
 		It("should fetch chart from a direct URL", func() {
 			chartArchive := createMinimalChartArchive("url-chart", "2.0.0")
 
@@ -415,9 +415,9 @@ entries:
 			defer server.Close()
 
 			p := NewProviderWithConfig(nil)
-			ch, err := p.fetchURLChart(context.Background(), &ChartSourceParams{
+			ch, err := p.fetchChartWithoutCache(context.Background(), &ChartSourceParams{
 				Source: server.URL + "/url-chart-2.0.0.tgz",
-			}, "", "")
+			}, sourceTypeURL, "", "")
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(ch).ToNot(BeNil())
 			Expect(ch.Metadata.Name).To(Equal("url-chart"))
@@ -426,9 +426,9 @@ entries:
 
 		It("should return error for unreachable URL", func() {
 			p := NewProviderWithConfig(nil)
-			_, err := p.fetchURLChart(context.Background(), &ChartSourceParams{
+			_, err := p.fetchChartWithoutCache(context.Background(), &ChartSourceParams{
 				Source: "http://127.0.0.1:1/nonexistent.tgz",
-			}, "", "")
+			}, sourceTypeURL, "", "")
 			Expect(err).Should(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("failed to download chart"))
 		})
@@ -581,7 +581,7 @@ data:
 	return buf.Bytes()
 }
 
-var _ = Describe("fetchURLChart with auth", func() {
+var _ = Describe("fetchChartWithoutCache from url source with auth", func() {
 	var (
 		scheme         *runtime.Scheme
 		origKubeClient client.Client
@@ -620,13 +620,13 @@ var _ = Describe("fetchURLChart with auth", func() {
 			Source: server.URL + "/x.tgz",
 			Auth:   &AuthParams{SecretRef: &SecretRefParams{Name: "creds"}},
 		}
-		_, err := p.fetchURLChart(context.Background(), params, "app-ns", "rel-ns")
+		_, err := p.fetchChartWithoutCache(context.Background(), params, sourceTypeURL, "app-ns", "rel-ns")
 		Expect(err).NotTo(HaveOccurred())
 		Expect(gotAuth).To(HavePrefix("Basic "))
 	})
 })
 
-var _ = Describe("fetchRepoChart with auth", func() {
+var _ = Describe("fetchChartWithoutCache from repo source with auth", func() {
 	var (
 		scheme         *runtime.Scheme
 		origKubeClient client.Client
@@ -681,7 +681,7 @@ entries:
 			Version: "1.0.0",
 			Auth:    &AuthParams{SecretRef: &SecretRefParams{Name: "creds"}},
 		}
-		_, err := p.fetchRepoChart(context.Background(), params, "app-ns", "rel-ns")
+		_, err := p.fetchChartWithoutCache(context.Background(), params, sourceTypeRepo, "app-ns", "rel-ns")
 		Expect(err).NotTo(HaveOccurred())
 		Expect(indexAuth).To(HavePrefix("Basic "))
 		Expect(chartAuth).To(HavePrefix("Basic "))
@@ -689,7 +689,7 @@ entries:
 	})
 })
 
-var _ = Describe("fetchOCIChart with auth", func() {
+var _ = Describe("fetchChartWithoutCache from oci source with auth", func() {
 	var (
 		scheme         *runtime.Scheme
 		origKubeClient client.Client
@@ -718,7 +718,7 @@ var _ = Describe("fetchOCIChart with auth", func() {
 			Source: "oci://ghcr.io/foo/podinfo",
 			Auth:   &AuthParams{SecretRef: &SecretRefParams{Name: "t"}},
 		}
-		_, err := p.fetchOCIChart(context.Background(), params, "app-ns", "rel-ns")
+		_, err := p.fetchChartWithoutCache(context.Background(), params, sourceTypeOCI, "app-ns", "rel-ns")
 		Expect(err).To(MatchError(ContainSubstring(`user-supplied bearer tokens MUST NOT be used with OCI sources`)))
 	})
 })
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+// Verifies Helm provenance files and cosign signatures of OCI charts against keyrings stored in vela configs.
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevela/pkg/util/singleton"
+
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+const (
+	// VerifyProviderProvenance verifies the Helm provenance (.prov) file with a PGP keyring
+	VerifyProviderProvenance = "provenance"
+	// VerifyProviderCosign verifies the cosign signature of an OCI chart with a public key
+	VerifyProviderCosign = "cosign"
+
+	// configPropertiesKey is the key of the secret data that saves the properties of a vela config
+	configPropertiesKey = "input-properties"
+	// keyringPropertyKey is the config property holding the PGP public keyring for provenance files
+	keyringPropertyKey = "keyring"
+	// publicKeyPropertyKey is the config property holding the PEM public key for cosign signatures
+	publicKeyPropertyKey = "publicKey"
+
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// ErrChartVerification means the chart fails the verification
+var ErrChartVerification = errors.New("chart verification failed")
+
+// verifyEnabled returns whether the chart should be verified
+func verifyEnabled(params *ChartSourceParams) bool {
+	return params.Verify != nil && params.Verify.Provider != ""
+}
+
+// readVerifyKey reads the keyring or the public key from the vela config referenced by the params.
+// The config must be in the vela-system namespace or the Application namespace.
+func readVerifyKey(ctx context.Context, params *VerifyParams, appNamespace string) ([]byte, error) {
+	ref := params.KeyringRef
+	if ref.Name == "" {
+		return nil, fmt.Errorf("verify.keyringRef.name must be set")
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = velatypes.DefaultKubeVelaNS
+	}
+	if namespace != velatypes.DefaultKubeVelaNS && namespace != appNamespace {
+		return nil, fmt.Errorf("keyring config %s/%s is rejected: the namespace must be %s or the Application namespace %s",
+			namespace, ref.Name, velatypes.DefaultKubeVelaNS, appNamespace)
+	}
+	var secret corev1.Secret
+	if err := singleton.KubeClient.Get().Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to read keyring config %s/%s", namespace, ref.Name)
+	}
+	if secret.Labels[velatypes.LabelConfigCatalog] != velatypes.VelaCoreConfig {
+		return nil, fmt.Errorf("the secret %s/%s is not a config", namespace, ref.Name)
+	}
+	properties := map[string]interface{}{}
+	if err := json.Unmarshal(secret.Data[configPropertiesKey], &properties); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse keyring config %s/%s", namespace, ref.Name)
+	}
+	property := keyringPropertyKey
+	if params.Provider == VerifyProviderCosign {
+		property = publicKeyPropertyKey
+	}
+	key, _ := properties[property].(string)
+	if key == "" {
+		return nil, fmt.Errorf("keyring config %s/%s must have the property %q", namespace, ref.Name, property)
+	}
+	return []byte(key), nil
+}
+
+// verifyCacheTag returns a tag derived from the verification params and the key, so the cached
+// charts are bound to the verification that accepted them. Returns "" when verification is off.
+func verifyCacheTag(ctx context.Context, params *ChartSourceParams, appNamespace string) (string, error) {
+	if !verifyEnabled(params) {
+		return "", nil
+	}
+	key, err := readVerifyKey(ctx, params.Verify, appNamespace)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(params.Verify.Provider+"\x00"), key...))
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+// verifyChart verifies the chart archive with the provider declared in the params
+func (p *Provider) verifyChart(ctx context.Context, params *ChartSourceParams, archive *chartArchive, appNamespace, releaseNamespace string) error {
+	if !verifyEnabled(params) {
+		return nil
+	}
+	key, err := readVerifyKey(ctx, params.Verify, appNamespace)
+	if err != nil {
+		return err
+	}
+	switch params.Verify.Provider {
+	case VerifyProviderProvenance:
+		err = verifyProvenance(archive, key)
+	case VerifyProviderCosign:
+		if detectChartSourceType(params.Source) != sourceTypeOCI {
+			return fmt.Errorf("cosign verification only supports OCI charts")
+		}
+		httpOpts, _, rerr := resolveHTTPOptions(ctx, params, appNamespace, releaseNamespace, sourceTypeOCI)
+		if rerr != nil {
+			return errors.Wrap(rerr, "auth resolution failed")
+		}
+		err = verifyCosignSignature(ctx, strings.TrimPrefix(params.Source, "oci://"), archive.OCIDigest, key, httpOpts)
+	default:
+		return fmt.Errorf("unsupported verify provider %q, must be %s or %s", params.Verify.Provider, VerifyProviderProvenance, VerifyProviderCosign)
+	}
+	if err != nil {
+		return fmt.Errorf("%w for %s: %s", ErrChartVerification, chartSourceLabel(params), err.Error())
+	}
+	return nil
+}
+
+// readKeyRing reads the armored or binary PGP keyring
+func readKeyRing(key []byte) (openpgp.EntityList, error) {
+	if bytes.Contains(key, []byte("-----BEGIN PGP")) {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(string(key)); err == nil {
+		key = decoded
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(key))
+}
+
+// verifyProvenance checks the provenance file is signed by the keyring, and the archive
+// matches the checksum recorded in the provenance file, like `helm verify` does.
+func verifyProvenance(archive *chartArchive, key []byte) error {
+	if len(archive.Prov) == 0 {
+		return fmt.Errorf("the provenance file of the chart is not found")
+	}
+	keyring, err := readKeyRing(key)
+	if err != nil {
+		return errors.Wrap(err, "invalid keyring")
+	}
+	block, _ := clearsign.Decode(archive.Prov)
+	if block == nil {
+		return fmt.Errorf("the provenance file is not a signed message")
+	}
+	signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(block.Bytes), block.ArmoredSignature.Body, nil)
+	if err != nil {
+		return errors.Wrap(err, "the signature of the provenance file is invalid")
+	}
+	// the signed message is the Chart.yaml and the checksums of the files, separated by "..."
+	parts := bytes.SplitN(block.Plaintext, []byte("\n...\n"), 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("the provenance file has no checksums")
+	}
+	var sums struct {
+		Files map[string]string `json:"files"`
+	}
+	if err := yaml.Unmarshal(parts[1], &sums); err != nil {
+		return errors.Wrap(err, "failed to parse the checksums of the provenance file")
+	}
+	sum, ok := sums.Files[archive.FileName]
+	if !ok {
+		return fmt.Errorf("the provenance file has no checksum for %s", archive.FileName)
+	}
+	if sum != digestOf(archive.Data) {
+		return fmt.Errorf("the checksum of %s does not match the provenance file", archive.FileName)
+	}
+	for id := range signer.Identities {
+		klog.V(3).Infof("Chart %s is signed by %s", archive.FileName, id)
+		break
+	}
+	return nil
+}
+
+// cosignPayload is the simple signing payload signed by cosign
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifyCosignSignature fetches the cosign signatures stored at the tag sha256-<hex>.sig of the
+// repository, and accepts the chart if any signature of the manifest digest is valid.
+func verifyCosignSignature(ctx context.Context, repository, digest string, key []byte, httpOpts *common.HTTPOption) error {
+	if digest == "" {
+		return fmt.Errorf("the manifest digest of the chart is unknown")
+	}
+	publicKey, err := parsePublicKey(key)
+	if err != nil {
+		return err
+	}
+	var nameOpts []name.Option
+	remoteOpts := []remote.Option{remote.WithContext(ctx)}
+	if httpOpts != nil {
+		if httpOpts.PlainHTTP {
+			nameOpts = append(nameOpts, name.Insecure)
+		}
+		if httpOpts.Username != "" {
+			remoteOpts = append(remoteOpts, remote.WithAuth(&authn.Basic{Username: httpOpts.Username, Password: httpOpts.Password}))
+		}
+	}
+	// the repository may carry a tag, the signatures live in the same repository
+	repo, err := name.NewRepository(repositoryOf(repository), nameOpts...)
+	if err != nil {
+		return errors.Wrapf(err, "invalid OCI repository %s", repository)
+	}
+	sigRef := repo.Tag(strings.Replace(digest, ":", "-", 1) + ".sig")
+	img, err := remote.Image(sigRef, remoteOpts...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch the cosign signature %s", sigRef)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return err
+	}
+	for _, desc := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(desc.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			continue
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			continue
+		}
+		payload, err := io.ReadAll(io.LimitReader(rc, 1<<20))
+		_ = rc.Close()
+		if err != nil || !verifySignature(publicKey, payload, signature) {
+			continue
+		}
+		var content cosignPayload
+		if err := json.Unmarshal(payload, &content); err != nil {
+			continue
+		}
+		if content.Critical.Image.DockerManifestDigest == digest {
+			return nil
+		}
+	}
+	return fmt.Errorf("no valid cosign signature of %s is found", digest)
+}
+
+// repositoryOf strips the tag or digest from the reference
+func repositoryOf(ref string) string {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	return ref
+}
+
+func parsePublicKey(key []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, fmt.Errorf("the public key must be PEM encoded")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	return publicKey, nil
+}
+
+func verifySignature(publicKey crypto.PublicKey, payload, signature []byte) bool {
+	hash := sha256.Sum256(payload)
+	switch k := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, hash[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, signature)
+	default:
+		return false
+	}
+}
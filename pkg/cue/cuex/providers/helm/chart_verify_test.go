@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	ggcrtypes "github.com/google/go-containerregistry/pkg/v1/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevela/pkg/util/singleton"
+
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+// newKeyringConfig builds a vela config secret holding the property
+func newKeyringConfig(name, namespace, property, value string) *corev1.Secret {
+	properties, _ := json.Marshal(map[string]string{property: value})
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{velatypes.LabelConfigCatalog: velatypes.VelaCoreConfig},
+		},
+		Data: map[string][]byte{configPropertiesKey: properties},
+	}
+}
+
+// signProvenance generates the provenance file of the chart archive like `helm package --sign`
+func signProvenance(entity *openpgp.Entity, fileName string, data []byte) []byte {
+	message := fmt.Sprintf("apiVersion: v2\nname: podinfo\nversion: 1.0.0\n\n...\nfiles:\n  %s: %s\n", fileName, digestOf(data))
+	buf := &bytes.Buffer{}
+	w, err := clearsign.Encode(buf, entity.PrivateKey, nil)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = w.Write([]byte(message))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(w.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func armoredPublicKey(entity *openpgp.Entity) string {
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entity.Serialize(w)).To(Succeed())
+	Expect(w.Close()).To(Succeed())
+	return buf.String()
+}
+
+var _ = Describe("chart verification", func() {
+	var (
+		scheme         *runtime.Scheme
+		origKubeClient client.Client
+	)
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		origKubeClient = singleton.KubeClient.Get()
+	})
+	AfterEach(func() {
+		singleton.KubeClient.Set(origKubeClient)
+	})
+
+	Context("provenance", func() {
+		var (
+			signer  *openpgp.Entity
+			archive []byte
+			prov    []byte
+			server  *httptest.Server
+		)
+		BeforeEach(func() {
+			var err error
+			signer, err = openpgp.NewEntity("chart signer", "", "signer@example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+			archive = createMinimalChartArchive("podinfo", "1.0.0")
+			prov = signProvenance(signer, "podinfo-1.0.0.tgz", archive)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/podinfo-1.0.0.tgz":
+					_, _ = w.Write(archive)
+				case "/podinfo-1.0.0.tgz.prov":
+					_, _ = w.Write(prov)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			singleton.KubeClient.Set(fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				newKeyringConfig("chart-keyring", velatypes.DefaultKubeVelaNS, keyringPropertyKey, armoredPublicKey(signer)),
+			).Build())
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		params := func() *ChartSourceParams {
+			return &ChartSourceParams{
+				Source: server.URL + "/podinfo-1.0.0.tgz",
+				Verify: &VerifyParams{Provider: VerifyProviderProvenance, KeyringRef: SecretRefParams{Name: "chart-keyring"}},
+			}
+		}
+
+		It("accepts the chart signed by the keyring", func() {
+			ch, err := NewProviderWithConfig(nil).fetchChartWithoutCache(context.Background(), params(), sourceTypeURL, "app-ns", "app-ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ch.Metadata.Name).To(Equal("podinfo"))
+		})
+
+		It("rejects the chart not matching the provenance file", func() {
+			archive = createMinimalChartArchive("podinfo", "1.0.1")
+			_, err := NewProviderWithConfig(nil).fetchChartWithoutCache(context.Background(), params(), sourceTypeURL, "app-ns", "app-ns")
+			Expect(errors.Is(err, ErrChartVerification)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("does not match the provenance file")))
+		})
+
+		It("rejects the provenance file signed by another key", func() {
+			other, err := openpgp.NewEntity("someone else", "", "other@example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+			prov = signProvenance(other, "podinfo-1.0.0.tgz", archive)
+			_, err = NewProviderWithConfig(nil).fetchChartWithoutCache(context.Background(), params(), sourceTypeURL, "app-ns", "app-ns")
+			Expect(errors.Is(err, ErrChartVerification)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("signature of the provenance file is invalid")))
+		})
+
+		It("rejects the chart without a provenance file", func() {
+			Expect(verifyProvenance(&chartArchive{Data: archive, FileName: "podinfo-1.0.0.tgz"}, []byte(armoredPublicKey(signer)))).
+				To(MatchError(ContainSubstring("provenance file of the chart is not found")))
+		})
+	})
+
+	It("only reads the keyring from vela-system or the Application namespace", func() {
+		singleton.KubeClient.Set(fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newKeyringConfig("keyring", "app-ns", keyringPropertyKey, "key"),
+			newKeyringConfig("keyring", "other-ns", keyringPropertyKey, "key"),
+		).Build())
+		key, err := readVerifyKey(context.Background(), &VerifyParams{Provider: VerifyProviderProvenance, KeyringRef: SecretRefParams{Name: "keyring", Namespace: "app-ns"}}, "app-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(key)).To(Equal("key"))
+		_, err = readVerifyKey(context.Background(), &VerifyParams{Provider: VerifyProviderProvenance, KeyringRef: SecretRefParams{Name: "keyring", Namespace: "other-ns"}}, "app-ns")
+		Expect(err).To(MatchError(ContainSubstring("is rejected")))
+		_, err = readVerifyKey(context.Background(), &VerifyParams{Provider: VerifyProviderCosign, KeyringRef: SecretRefParams{Name: "keyring", Namespace: "app-ns"}}, "app-ns")
+		Expect(err).To(MatchError(ContainSubstring(`must have the property "publicKey"`)))
+	})
+
+	It("verifies the cosign signature of the OCI chart", func() {
+		server := httptest.NewServer(registry.New())
+		defer server.Close()
+		repo := strings.TrimPrefix(server.URL, "http://") + "/charts/podinfo"
+
+		img, err := random.Image(256, 1)
+		Expect(err).NotTo(HaveOccurred())
+		ref, err := name.ParseReference(repo+":1.0.0", name.Insecure)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remote.Write(ref, img)).To(Succeed())
+		d, err := img.Digest()
+		Expect(err).NotTo(HaveOccurred())
+		digest := d.String()
+
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		publicKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+		payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"}}`, repo, digest))
+		hash := sha256.Sum256(payload)
+		signature, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+		Expect(err).NotTo(HaveOccurred())
+		sigImg, err := mutate.Append(empty.Image, mutate.Addendum{
+			Layer:       static.NewLayer(payload, ggcrtypes.MediaType("application/vnd.dev.cosign.simplesigning.v1+json")),
+			Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		sigRef, err := name.ParseReference(repo+":"+strings.Replace(digest, ":", "-", 1)+".sig", name.Insecure)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remote.Write(sigRef, sigImg)).To(Succeed())
+
+		httpOpts := &common.HTTPOption{PlainHTTP: true}
+		Expect(verifyCosignSignature(context.Background(), repo+":1.0.0", digest, publicKey, httpOpts)).To(Succeed())
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		der, err = x509.MarshalPKIXPublicKey(&otherKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verifyCosignSignature(context.Background(), repo, digest, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), httpOpts)).
+			To(MatchError(ContainSubstring("no valid cosign signature")))
+	})
+})
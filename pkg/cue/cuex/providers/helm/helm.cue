@@ -31,6 +31,20 @@ package helm
 					namespace?: string
 				}
 			}
+			// +usage=Verify the chart before rendering. A chart failing the verification fails the render.
+			verify?: {
+				// +usage=provenance verifies the Helm .prov file with a PGP keyring,
+				// cosign verifies the signature of an OCI chart with a public key
+				provider: "provenance" | "cosign"
+				// +usage=Reference to the vela config holding the keyring (property keyring) or the
+				// public key (property publicKey). It MUST be in vela-system or the Application namespace.
+				keyringRef: {
+					// +usage=Config name.
+					name: string
+					// +usage=Config namespace, defaults to vela-system.
+					namespace?: string
+				}
+			}
 		}
 		
 		// +usage=Release configuration
@@ -107,6 +121,15 @@ package helm
 				mutableTTL?: string
 			}
 			
+			// +usage=Takeover of an existing release that is not owned by the component
+			adoption?: {
+				// +usage=IfExists takes the release over in place (default), Never fails the render,
+				// DryRun reports what the takeover would change without touching the release
+				policy?: "IfExists" | "Never" | "DryRun"
+				// +usage=Import the history of a release installed under another name in the release namespace
+				fromName?: string
+			}
+			
 			// +usage=Post-rendering configuration
 			postRender?: {
 				// +usage=Kustomize patches
@@ -138,6 +161,55 @@ package helm
 		}]
 		// +usage=Chart notes
 		notes?: string
+		// +usage=Report of the takeover of an existing release
+		adoption?: {
+			release: string
+			from:    string
+			dryRun:  bool
+			revisions?: [...int]
+			adopted?: [...string]
+			changed?: [...string]
+			added?: [...string]
+			removed?: [...string]
+			summary: string
+		}
+	}
+	...
+}
+
+#Test: {
+	#do:       "test"
+	#provider: "helm"
+
+	// +usage=The params for running the test hooks of a Helm release
+	$params: {
+		// +usage=The release to test
+		release: {
+			// +usage=Release name
+			name: string
+			// +usage=Release namespace
+			namespace?: string
+		}
+		// +usage=Time to wait for each test hook, e.g. 5m
+		timeout?: string
+		// +usage=Only run the tests with the names
+		filter?: [...string]
+	}
+
+	// +usage=The returns of running the test hooks
+	$returns?: {
+		// +usage=Whether all the tests passed
+		passed: bool
+		// +usage=Summary of the test run, with the output of the failed tests
+		message: string
+		// +usage=Results of the test hooks
+		tests: [...{
+			name:      string
+			namespace: string
+			phase:     string
+			passed:    bool
+			log?:      string
+		}]
 	}
 	...
 }
@@ -143,6 +143,7 @@ func Render(ctx context.Context, params *providers.Params[RenderParams]) (*provi
 	// blocking for 30-60s on large charts.
 	var manifest string
 	var notes string
+	var adoption *AdoptionReport
 	if isDryRun(ctx) {
 		klog.V(2).Infof("Helm provider: Dry-run mode — rendering chart %s client-side only", ch.Name())
 		manifest, notes, err = p.dryRunRender(ch, releaseName, releaseNamespace, values, renderParams.Options, renderParams.Context)
@@ -150,6 +151,18 @@ func Render(ctx context.Context, params *providers.Params[RenderParams]) (*provi
 			return nil, errors.Wrap(err, "failed to dry-run render chart")
 		}
 	} else {
+		// Take over an existing release that is not owned by the component. A
+		// dry-run adoption only reports the changes: the release is left as is
+		// and no resource is returned, so nothing is dispatched or tracked.
+		adoption, err = p.planAdoption(ch, releaseName, releaseNamespace, values, renderParams.Options, renderParams.Context)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to adopt release")
+		}
+		if adoption != nil && adoption.DryRun {
+			return &providers.Returns[RenderReturns]{
+				Returns: RenderReturns{Resources: []map[string]interface{}{}, Adoption: adoption},
+			}, nil
+		}
 		// Install or upgrade the chart via the Helm SDK
 		manifest, notes, _, err = p.installOrUpgradeChart(ctx, ch, releaseName, releaseNamespace, values, renderParams.Options, renderParams.Context)
 		if err != nil {
@@ -232,6 +245,7 @@ func Render(ctx context.Context, params *providers.Params[RenderParams]) (*provi
 		Returns: RenderReturns{
 			Resources: resources,
 			Notes:     notes,
+			Adoption:  adoption,
 		},
 	}
 
@@ -252,4 +266,5 @@ var Template = template
 // Package exports the provider package for registration
 var Package = runtime.Must(cuexruntime.NewInternalPackage(ProviderName, template, map[string]cuexruntime.ProviderFn{
 	"render": cuexruntime.GenericProviderFn[providers.Params[RenderParams], providers.Returns[RenderReturns]](Render),
+	"test":   cuexruntime.GenericProviderFn[providers.Params[TestParams], providers.Returns[TestReturns]](Test),
 }))
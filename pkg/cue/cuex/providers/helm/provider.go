@@ -28,6 +28,7 @@ import (
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/cli"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
 
 	"github.com/oam-dev/kubevela/pkg/utils"
 )
@@ -79,9 +80,11 @@ func DefaultCacheTTLConfig() *CacheTTLConfig {
 // Provider is the Helm chart provider
 type Provider struct {
 	cache               *utils.MemoryCacheStore
+	diskCache           *chartDiskCache // persistent chart cache, nil when ChartCacheDir is not set
 	helmClient          *cli.EnvSettings
 	cacheTTL            *CacheTTLConfig
 	releaseMu           sync.Mutex        // serializes install/upgrade/uninstall calls
+	releaseLocks        sync.Map          // namespace/releaseName → *sync.Mutex, held while the release is changed or tested
 	releaseFingerprints map[string]string // namespace/releaseName → fingerprint (chartVersion|valuesHash)
 	releaseManifests    map[string]string // namespace/releaseName → last successful manifest
 	releaseVersions     map[string]int    // namespace/releaseName → current release version number
@@ -118,10 +121,27 @@ func NewProvider() *Provider {
 		}
 		globalProvider.actionConfigFactory = globalProvider.getActionConfig
 		globalProvider.kubeClientFactory = globalProvider.getKubeClientset
+		if ChartCacheDir != "" {
+			diskCache, err := newChartDiskCache(ChartCacheDir, ChartCacheMaxSize)
+			if err != nil {
+				klog.Warningf("Helm provider: persistent chart cache is disabled: %v", err)
+			} else {
+				globalProvider.diskCache = diskCache
+			}
+		}
 	})
 	return globalProvider
 }
 
+// lockRelease locks a single release and returns the unlock function. The long running
+// operations on a release, e.g. the tests, hold it instead of releaseMu so that they do
+// not block the other releases.
+func (p *Provider) lockRelease(releaseName, releaseNamespace string) func() {
+	mu, _ := p.releaseLocks.LoadOrStore(releaseCacheKey(releaseNamespace, releaseName), &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
 // NewProviderWithConfig creates a new Helm provider with custom cache configuration
 func NewProviderWithConfig(ttlConfig *CacheTTLConfig) *Provider {
 	if ttlConfig == nil {
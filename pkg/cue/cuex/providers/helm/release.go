@@ -75,6 +75,7 @@ func (p *Provider) installOrUpgradeChart(ctx context.Context, ch *chart.Chart, r
 	fingerprint := computeReleaseFingerprint(ch, values)
 	cacheKey := releaseCacheKey(releaseNamespace, releaseName)
 
+	defer p.lockRelease(releaseName, releaseNamespace)()
 	p.releaseMu.Lock()
 	defer p.releaseMu.Unlock()
 
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+// Runs the `helm.sh/hook: test` hooks of a release, like `helm test`, and collects the logs of the test pods.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/kubevela/pkg/cue/cuex/providers"
+)
+
+const (
+	// defaultTestTimeout is the time to wait for each test hook, the same as `helm test`
+	defaultTestTimeout = 5 * time.Minute
+	// maxTestLogLines bounds the log kept for each test pod, the tail of a long log is kept
+	maxTestLogLines = 200
+)
+
+// TestParams is the params for running the test hooks of a release
+type TestParams struct {
+	Release ReleaseParams `json:"release"`
+	// Timeout is the time to wait for each test hook, e.g. 5m
+	Timeout string `json:"timeout,omitempty"`
+	// Filter only runs the tests with the names
+	Filter []string `json:"filter,omitempty"`
+}
+
+// TestResult is the result of a test hook
+type TestResult struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Phase     string `json:"phase"`
+	Passed    bool   `json:"passed"`
+	Log       string `json:"log,omitempty"`
+}
+
+// TestReturns is the returns of running the test hooks of a release
+type TestReturns struct {
+	Passed  bool         `json:"passed"`
+	Message string       `json:"message"`
+	Tests   []TestResult `json:"tests"`
+}
+
+// Test runs the test hooks of the release. A failed test is not an error: it is reported in the
+// returns with the output of the test pods, so the workflow step can fail with the details.
+func Test(ctx context.Context, params *providers.Params[TestParams]) (*providers.Returns[TestReturns], error) {
+	ret, err := NewProvider().runReleaseTests(ctx, &params.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &providers.Returns[TestReturns]{Returns: *ret}, nil
+}
+
+// runReleaseTests runs the test hooks of the release and collects the results
+func (p *Provider) runReleaseTests(ctx context.Context, params *TestParams) (*TestReturns, error) {
+	releaseName, releaseNamespace := params.Release.Name, params.Release.Namespace
+	if releaseName == "" {
+		return nil, fmt.Errorf("release.name must be set")
+	}
+	if releaseNamespace == "" {
+		releaseNamespace = "default"
+	}
+	timeout := defaultTestTimeout
+	if params.Timeout != "" {
+		d, err := time.ParseDuration(params.Timeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid timeout %q", params.Timeout)
+		}
+		timeout = d
+	}
+
+	// the test run records the hook status in the release, it must not race with an install
+	// or upgrade of the same release. It waits for the test pods, so only the release is locked.
+	defer p.lockRelease(releaseName, releaseNamespace)()
+
+	actionConfig, err := p.actionConfigFactory(releaseNamespace)
+	if err != nil {
+		return nil, err
+	}
+	testing := action.NewReleaseTesting(actionConfig)
+	testing.Namespace = releaseNamespace
+	testing.Timeout = timeout
+	if len(params.Filter) > 0 {
+		testing.Filters = map[string][]string{"name": params.Filter}
+	}
+	klog.Infof("Helm provider: Running tests of release %s/%s", releaseNamespace, releaseName)
+	rel, runErr := testing.Run(releaseName)
+	if rel == nil {
+		if runErr == nil {
+			runErr = fmt.Errorf("release not found")
+		}
+		return nil, errors.Wrapf(runErr, "failed to run the tests of release %s/%s", releaseNamespace, releaseName)
+	}
+
+	ret := &TestReturns{Passed: runErr == nil, Tests: []TestResult{}}
+	var failed []string
+	for _, hook := range rel.Hooks {
+		// hooks skipped by the filter keep the phase of a previous run
+		if !isTestHook(hook) || !matchTestFilter(hook.Name, params.Filter) ||
+			hook.LastRun.Phase == "" || hook.LastRun.Phase == release.HookPhaseUnknown {
+			continue
+		}
+		result := TestResult{
+			Name:      hook.Name,
+			Namespace: releaseNamespace,
+			Phase:     hook.LastRun.Phase.String(),
+			Passed:    hook.LastRun.Phase == release.HookPhaseSucceeded,
+		}
+		if hook.Kind == "Pod" {
+			result.Log = p.testPodLog(ctx, releaseNamespace, hook.Name)
+		}
+		if !result.Passed {
+			ret.Passed = false
+			failed = append(failed, formatFailedTest(result))
+		}
+		ret.Tests = append(ret.Tests, result)
+	}
+
+	switch {
+	case ret.Passed:
+		ret.Message = fmt.Sprintf("%d tests of release %s/%s passed", len(ret.Tests), releaseNamespace, releaseName)
+	case len(failed) > 0:
+		ret.Message = fmt.Sprintf("tests of release %s/%s failed:\n%s", releaseNamespace, releaseName, strings.Join(failed, "\n"))
+	default:
+		ret.Message = fmt.Sprintf("tests of release %s/%s failed: %s", releaseNamespace, releaseName, runErr.Error())
+	}
+	klog.Infof("Helm provider: %s", strings.SplitN(ret.Message, "\n", 2)[0])
+	return ret, nil
+}
+
+// isTestHook returns whether the hook runs on `helm test`
+func isTestHook(hook *release.Hook) bool {
+	for _, event := range hook.Events {
+		if event == release.HookTest {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTestFilter returns whether the test is selected by the filter, all tests are selected by an empty filter
+func matchTestFilter(name string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// testPodLog returns the tail of the log of the test pod. The pod may be deleted by its
+// hook-delete-policy already, which is not an error.
+func (p *Provider) testPodLog(ctx context.Context, namespace, name string) string {
+	clientset, err := p.kubeClientFactory()
+	if err != nil {
+		klog.V(4).Infof("Helm provider: Failed to build kubernetes client for test logs: %v", err)
+		return ""
+	}
+	tail := int64(maxTestLogLines)
+	data, err := clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{TailLines: &tail}).DoRaw(ctx)
+	if err != nil {
+		klog.V(4).Infof("Helm provider: Failed to get logs of test pod %s/%s: %v", namespace, name, err)
+		return ""
+	}
+	return string(data)
+}
+
+func formatFailedTest(result TestResult) string {
+	msg := fmt.Sprintf("test %s: %s", result.Name, result.Phase)
+	if log := strings.TrimSpace(result.Log); log != "" {
+		msg += "\n" + log
+	}
+	return msg
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+var _ = Describe("release tests", func() {
+	const (
+		relName = "rel"
+		relNS   = "ns"
+	)
+	var cfg *action.Configuration
+
+	testHook := func(name string) *release.Hook {
+		return &release.Hook{
+			Name:     name,
+			Kind:     "Pod",
+			Path:     "templates/tests/" + name + ".yaml",
+			Manifest: "apiVersion: v1\nkind: Pod\nmetadata:\n  name: " + name + "\n",
+			Events:   []release.HookEvent{release.HookTest},
+		}
+	}
+
+	BeforeEach(func() {
+		cfg = fakeActionConfig()
+		Expect(cfg.Releases.Create(&release.Release{
+			Name:      relName,
+			Namespace: relNS,
+			Version:   1,
+			Info:      &release.Info{Status: release.StatusDeployed},
+			Chart:     minimalChart("c", "1.0.0"),
+			Hooks: []*release.Hook{
+				testHook("rel-test-connection"),
+				testHook("rel-test-api"),
+				{Name: "rel-migrate", Kind: "Job", Events: []release.HookEvent{release.HookPreUpgrade}},
+			},
+		})).To(Succeed())
+	})
+
+	It("runs the test hooks and collects the logs", func() {
+		p := installProviderWithFake(cfg)
+		ret, err := p.runReleaseTests(context.Background(), &TestParams{Release: ReleaseParams{Name: relName, Namespace: relNS}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ret.Passed).To(BeTrue())
+		Expect(ret.Tests).To(HaveLen(2))
+		Expect(ret.Tests[0].Phase).To(Equal(string(release.HookPhaseSucceeded)))
+		Expect(ret.Tests[0].Log).To(Equal("fake logs"))
+		Expect(ret.Message).To(Equal("2 tests of release ns/rel passed"))
+	})
+
+	It("only runs the tests in the filter", func() {
+		p := installProviderWithFake(cfg)
+		ret, err := p.runReleaseTests(context.Background(), &TestParams{Release: ReleaseParams{Name: relName, Namespace: relNS}, Filter: []string{"rel-test-api"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ret.Tests).To(HaveLen(1))
+		Expect(ret.Tests[0].Name).To(Equal("rel-test-api"))
+	})
+
+	It("reports the failed test with its output", func() {
+		cfg.KubeClient = &kubefake.FailingKubeClient{
+			PrintingKubeClient:   kubefake.PrintingKubeClient{Out: io.Discard},
+			WatchUntilReadyError: errors.New("test pod failed"),
+		}
+		p := installProviderWithFake(cfg)
+		ret, err := p.runReleaseTests(context.Background(), &TestParams{Release: ReleaseParams{Name: relName, Namespace: relNS}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ret.Passed).To(BeFalse())
+		Expect(ret.Message).To(ContainSubstring("tests of release ns/rel failed"))
+		Expect(ret.Message).To(ContainSubstring(": Failed\nfake logs"))
+	})
+
+	It("fails when the release does not exist", func() {
+		p := installProviderWithFake(cfg)
+		_, err := p.runReleaseTests(context.Background(), &TestParams{Release: ReleaseParams{Name: "missing", Namespace: relNS}})
+		Expect(err).To(HaveOccurred())
+		_, err = p.runReleaseTests(context.Background(), &TestParams{Release: ReleaseParams{Name: relName, Namespace: relNS}, Timeout: "soon"})
+		Expect(err).To(MatchError(ContainSubstring("invalid timeout")))
+	})
+
+	It("only locks the tested release", func() {
+		p := installProviderWithFake(cfg)
+		run := func() <-chan error {
+			done := make(chan error, 1)
+			go func() {
+				_, err := p.runReleaseTests(context.Background(), &TestParams{Release: ReleaseParams{Name: relName, Namespace: relNS}})
+				done <- err
+			}()
+			return done
+		}
+
+		// an install of another release does not block the tests
+		p.releaseMu.Lock()
+		Eventually(run(), 10*time.Second).Should(Receive(BeNil()))
+		p.releaseMu.Unlock()
+
+		// an install of the same release does
+		unlock := p.lockRelease(relName, relNS)
+		done := run()
+		Consistently(done, 200*time.Millisecond).ShouldNot(Receive())
+		unlock()
+		Eventually(done, 10*time.Second).Should(Receive(BeNil()))
+	})
+})
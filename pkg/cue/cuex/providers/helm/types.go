@@ -26,6 +26,16 @@ type ChartSourceParams struct {
 	RepoURL string      `json:"repoURL,omitempty"`
 	Version string      `json:"version,omitempty"`
 	Auth    *AuthParams `json:"auth,omitempty"`
+	// Verify enables the verification of the chart before it is rendered
+	Verify *VerifyParams `json:"verify,omitempty"`
+}
+
+// VerifyParams represents the chart verification configuration
+type VerifyParams struct {
+	// Provider is either provenance (Helm .prov files) or cosign (OCI signatures)
+	Provider string `json:"provider"`
+	// KeyringRef references the vela config holding the keyring or the public key
+	KeyringRef SecretRefParams `json:"keyringRef"`
 }
 
 // AuthParams represents authentication configuration
@@ -77,6 +87,16 @@ type RenderOptionsParams struct {
 	CleanupOnFail   bool              `json:"cleanupOnFail,omitempty"`
 	PostRender      *PostRenderParams `json:"postRender,omitempty"`
 	Cache           *CacheParams      `json:"cache,omitempty"`
+	Adoption        *AdoptionParams   `json:"adoption,omitempty"`
+}
+
+// AdoptionParams controls the takeover of an existing release that was not installed by the component
+type AdoptionParams struct {
+	// Policy is IfExists (take over the release, the default), Never (fail the render) or
+	// DryRun (report what the takeover would change without touching the release)
+	Policy string `json:"policy,omitempty"`
+	// FromName imports the history of a release installed under another name, e.g. by `helm install`
+	FromName string `json:"fromName,omitempty"`
 }
 
 // PostRenderParams represents post-rendering configuration
@@ -129,6 +149,29 @@ type RenderParams struct {
 type RenderReturns struct {
 	Resources []map[string]interface{} `json:"resources"`
 	Notes     string                   `json:"notes,omitempty"`
+	Adoption  *AdoptionReport          `json:"adoption,omitempty"`
+}
+
+// AdoptionReport describes the takeover of an existing release
+type AdoptionReport struct {
+	// Release is the managed release, in the format of namespace/name
+	Release string `json:"release"`
+	// From is the name of the release being taken over
+	From string `json:"from"`
+	// DryRun is true when nothing is changed yet
+	DryRun bool `json:"dryRun"`
+	// Revisions are the revisions kept in the history of the release
+	Revisions []int `json:"revisions,omitempty"`
+	// Adopted are the resources taken over without changes other than the ownership metadata
+	Adopted []string `json:"adopted,omitempty"`
+	// Changed are the resources updated by the takeover
+	Changed []string `json:"changed,omitempty"`
+	// Added are the resources created by the takeover
+	Added []string `json:"added,omitempty"`
+	// Removed are the resources deleted by the takeover
+	Removed []string `json:"removed,omitempty"`
+	// Summary is a one-line description of the report
+	Summary string `json:"summary"`
 }
 
 // CacheTTLConfig defines cache TTL settings for different version types
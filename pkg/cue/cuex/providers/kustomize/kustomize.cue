@@ -0,0 +1,77 @@
+package kustomize
+
+#Render: {
+	#do:       "render"
+	#provider: "kustomize"
+
+	// +usage=The params for rendering the kustomization
+	$params: {
+		// +usage=Where the kustomization files are loaded from, exactly one of inline, archive and oci must be set
+		source: {
+			// +usage=Inline files, keyed by the path relative to the source root
+			inline?: [string]: string
+			// +usage=A tar.gz archive of a git repository, e.g. https://github.com/org/repo/archive/refs/tags/v1.0.0.tar.gz.
+			// The top-level directory added by the git servers is stripped.
+			archive?: {
+				// +usage=URL of the archive
+				url: string
+			}
+			// +usage=An OCI artifact whose layers are tar.gz archives of the files
+			oci?: {
+				// +usage=Artifact reference, e.g. oci://ghcr.io/org/manifests:v1.0.0
+				ref: string
+				// +usage=Allow plain HTTP and self-signed registries
+				insecure?: bool
+			}
+			// +usage=Authentication for the archive and oci sources
+			auth?: {
+				// +usage=Reference to a Secret with username/password or token, in the Application namespace
+				secretRef?: {
+					name:       string
+					namespace?: string
+				}
+			}
+		}
+		// +usage=Directory of the kustomization inside the source, e.g. overlays/prod
+		path?: string
+		// +usage=Prefix added to the names of all resources
+		namePrefix?: string
+		// +usage=Suffix added to the names of all resources
+		nameSuffix?: string
+		// +usage=Namespace set on all namespaced resources
+		namespace?: string
+		// +usage=Labels added to all resources and pod templates, the selectors are untouched
+		commonLabels?: [string]: string
+		// +usage=Image overrides
+		images?: [...{
+			name:     string
+			newName?: string
+			newTag?:  string
+			digest?:  string
+		}]
+		// +usage=Strategic merge or JSON 6902 patches
+		patches?: [...{
+			patch: string
+			target?: {
+				group?:              string
+				version?:            string
+				kind?:               string
+				name?:               string
+				namespace?:          string
+				labelSelector?:      string
+				annotationSelector?: string
+			}
+		}]
+		// +usage=Namespace of the Application, the auth secret is read from it
+		appNamespace?: string
+	}
+
+	// +usage=The returns of rendering the kustomization
+	$returns?: {
+		// +usage=Rendered Kubernetes resources
+		resources: [...{...}]
+		// +usage=Rendered resources except the first one, keyed by kind, namespace and name
+		outputs: [string]: {...}
+	}
+	...
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+// Public Render entry point and package registration for the kustomize cuex provider.
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/kustomize/api/krusty"
+	ktypes "sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevela/pkg/cue/cuex/providers"
+	cuexruntime "github.com/kubevela/pkg/cue/cuex/runtime"
+	"github.com/kubevela/pkg/util/runtime"
+)
+
+const (
+	// sourceDir is the directory where the files of the source are written
+	sourceDir = "/source"
+	// overlayDir is the directory of the kustomization generated from the render params
+	overlayDir = "/overlay"
+)
+
+// Render builds the kustomization in the source and returns the rendered resources.
+// The resources are returned to the component definition as outputs, so they are
+// dispatched and recorded in the ResourceTracker like any other component.
+func Render(ctx context.Context, params *providers.Params[RenderParams]) (*providers.Returns[RenderReturns], error) {
+	renderParams := params.Params
+	files, err := loadSource(ctx, &renderParams.Source, renderParams.AppNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load kustomize source")
+	}
+	resources, err := build(files, &renderParams)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build kustomization")
+	}
+	if len(resources) == 0 {
+		return nil, errors.Errorf("the kustomization at %s renders no resources", path.Join(sourceDir, renderParams.Path))
+	}
+	outputs := map[string]map[string]interface{}{}
+	for _, res := range resources[1:] {
+		key := outputKey(&unstructured.Unstructured{Object: res})
+		if _, found := outputs[key]; found {
+			return nil, errors.Errorf("duplicated rendered resource %s", key)
+		}
+		outputs[key] = res
+	}
+	klog.V(2).Infof("Kustomize provider: rendered %d resources from %s", len(resources), path.Join(sourceDir, renderParams.Path))
+	return &providers.Returns[RenderReturns]{Returns: RenderReturns{Resources: resources, Outputs: outputs}}, nil
+}
+
+// build writes the files into an in-memory filesystem and runs kustomize. If any override
+// is set in the params, an overlay kustomization is generated on top of the source path.
+func build(files map[string][]byte, params *RenderParams) ([]map[string]interface{}, error) {
+	fs := filesys.MakeFsInMemory()
+	for p, content := range files {
+		if err := fs.WriteFile(path.Join(sourceDir, p), content); err != nil {
+			return nil, err
+		}
+	}
+	target := sourceDir
+	if params.Path != "" {
+		p, err := cleanRelativePath(params.Path)
+		if err != nil {
+			return nil, err
+		}
+		target = path.Join(sourceDir, p)
+	}
+	if kustomization := overlayKustomization(params, target); kustomization != nil {
+		data, err := yaml.Marshal(kustomization)
+		if err != nil {
+			return nil, err
+		}
+		if err := fs.WriteFile(path.Join(overlayDir, "kustomization.yaml"), data); err != nil {
+			return nil, err
+		}
+		target = overlayDir
+	}
+
+	// plugins and helm charts are disabled by the default options
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := k.Run(fs, target)
+	if err != nil {
+		return nil, err
+	}
+	var resources []map[string]interface{}
+	for _, res := range resMap.Resources() {
+		obj, err := res.Map()
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, obj)
+	}
+	return resources, nil
+}
+
+// outputKey returns the key of the resource in the outputs of the component, which is stable when
+// the order of the rendered resources changes. The key is used as a label value, so it falls back
+// to a hash of the resource id if the readable key is too long.
+func outputKey(res *unstructured.Unstructured) string {
+	kind := strings.ToLower(res.GetKind())
+	parts := []string{kind}
+	if res.GetNamespace() != "" {
+		parts = append(parts, res.GetNamespace())
+	}
+	key := strings.Join(append(parts, res.GetName()), ".")
+	if len(validation.IsValidLabelValue(key)) == 0 {
+		return key
+	}
+	sum := sha256.Sum256([]byte(res.GetAPIVersion() + "/" + res.GetKind() + "/" + res.GetNamespace() + "/" + res.GetName()))
+	return kind + "." + hex.EncodeToString(sum[:8])
+}
+
+// overlayKustomization generates the kustomization that applies the overrides in the params
+// to the target. It returns nil if there is nothing to override.
+func overlayKustomization(params *RenderParams, target string) *ktypes.Kustomization {
+	if params.NamePrefix == "" && params.NameSuffix == "" && params.Namespace == "" &&
+		len(params.CommonLabels) == 0 && len(params.Images) == 0 && len(params.Patches) == 0 {
+		return nil
+	}
+	kustomization := &ktypes.Kustomization{
+		TypeMeta: ktypes.TypeMeta{
+			APIVersion: ktypes.KustomizationVersion,
+			Kind:       ktypes.KustomizationKind,
+		},
+		Resources:  []string{path.Join("..", target)},
+		NamePrefix: params.NamePrefix,
+		NameSuffix: params.NameSuffix,
+		Namespace:  params.Namespace,
+	}
+	if len(params.CommonLabels) > 0 {
+		// the labels are not added to the selectors, which are immutable for the workloads
+		kustomization.Labels = []ktypes.Label{{Pairs: params.CommonLabels, IncludeTemplates: true}}
+	}
+	for _, image := range params.Images {
+		kustomization.Images = append(kustomization.Images, ktypes.Image{
+			Name:    image.Name,
+			NewName: image.NewName,
+			NewTag:  image.NewTag,
+			Digest:  image.Digest,
+		})
+	}
+	for _, patch := range params.Patches {
+		p := ktypes.Patch{Patch: patch.Patch}
+		if patch.Target != nil {
+			p.Target = &ktypes.Selector{
+				ResId: resid.ResId{
+					Gvk:       resid.Gvk{Group: patch.Target.Group, Version: patch.Target.Version, Kind: patch.Target.Kind},
+					Name:      patch.Target.Name,
+					Namespace: patch.Target.Namespace,
+				},
+				LabelSelector:      patch.Target.LabelSelector,
+				AnnotationSelector: patch.Target.AnnotationSelector,
+			}
+		}
+		kustomization.Patches = append(kustomization.Patches, p)
+	}
+	return kustomization
+}
+
+// ProviderName is the name of this provider
+const ProviderName = "kustomize"
+
+//go:embed kustomize.cue
+var template string
+
+// Package exports the provider package for registration
+var Package = runtime.Must(cuexruntime.NewInternalPackage(ProviderName, template, map[string]cuexruntime.ProviderFn{
+	"render": cuexruntime.GenericProviderFn[providers.Params[RenderParams], providers.Returns[RenderReturns]](Render),
+}))
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevela/pkg/cue/cuex/providers"
+)
+
+var overlayFiles = map[string]string{
+	"base/kustomization.yaml": `
+resources:
+  - deployment.yaml
+  - configmap.yaml
+`,
+	"base/deployment.yaml": `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+        - name: web
+          image: nginx:1.25
+`,
+	"base/configmap.yaml": `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+data:
+  mode: base
+`,
+	"overlays/prod/kustomization.yaml": `
+resources:
+  - ../../base
+patches:
+  - patch: |
+      apiVersion: v1
+      kind: ConfigMap
+      metadata:
+        name: web-config
+      data:
+        mode: prod
+`,
+}
+
+func findResource(resources []map[string]interface{}, kind string) *unstructured.Unstructured {
+	for _, res := range resources {
+		if res["kind"] == kind {
+			return &unstructured.Unstructured{Object: res}
+		}
+	}
+	return nil
+}
+
+var _ = Describe("kustomize provider", func() {
+
+	It("should render the overlay of the inline source", func() {
+		ret, err := Render(context.Background(), &providers.Params[RenderParams]{Params: RenderParams{
+			Source: SourceParams{Inline: overlayFiles},
+			Path:   "overlays/prod",
+		}})
+		Expect(err).Should(BeNil())
+		Expect(ret.Returns.Resources).Should(HaveLen(2))
+		cm := findResource(ret.Returns.Resources, "ConfigMap")
+		Expect(cm).ShouldNot(BeNil())
+		mode, _, _ := unstructured.NestedString(cm.Object, "data", "mode")
+		Expect(mode).Should(Equal("prod"))
+		Expect(ret.Returns.Outputs).Should(HaveLen(1))
+		for key, res := range ret.Returns.Outputs {
+			Expect(key).Should(Equal(outputKey(&unstructured.Unstructured{Object: res})))
+		}
+	})
+
+	It("should render the kustomization in the source root", func() {
+		ret, err := Render(context.Background(), &providers.Params[RenderParams]{Params: RenderParams{
+			Source: SourceParams{Inline: map[string]string{
+				"kustomization.yaml": "resources:\n  - configmap.yaml\n",
+				"configmap.yaml":     overlayFiles["base/configmap.yaml"],
+			}},
+			Path: ".",
+		}})
+		Expect(err).Should(BeNil())
+		Expect(ret.Returns.Resources).Should(HaveLen(1))
+		Expect(ret.Returns.Outputs).Should(BeEmpty())
+
+		_, err = Render(context.Background(), &providers.Params[RenderParams]{Params: RenderParams{
+			Source: SourceParams{Inline: map[string]string{"kustomization.yaml": "resources: []\n"}},
+		}})
+		Expect(err).ShouldNot(BeNil())
+		Expect(err.Error()).Should(ContainSubstring("renders no resources"))
+	})
+
+	It("should key the outputs by kind, namespace and name", func() {
+		res := &unstructured.Unstructured{}
+		res.SetAPIVersion("v1")
+		res.SetKind("ConfigMap")
+		res.SetName("web-config")
+		Expect(outputKey(res)).Should(Equal("configmap.web-config"))
+		res.SetNamespace("prod")
+		Expect(outputKey(res)).Should(Equal("configmap.prod.web-config"))
+		res.SetName(strings.Repeat("a", 63))
+		key := outputKey(res)
+		Expect(key).Should(HavePrefix("configmap."))
+		Expect(len(key)).Should(BeNumerically("<=", 63))
+		res.SetNamespace("dev")
+		Expect(outputKey(res)).ShouldNot(Equal(key))
+	})
+
+	It("should apply the overrides on top of the kustomization", func() {
+		resources, err := build(toFiles(overlayFiles), &RenderParams{
+			Path:         "overlays/prod",
+			NamePrefix:   "prod-",
+			Namespace:    "team-a",
+			CommonLabels: map[string]string{"team": "a"},
+			Images:       []ImageParams{{Name: "nginx", NewTag: "1.27"}},
+			Patches: []PatchParams{{
+				Patch:  "- op: replace\n  path: /spec/replicas\n  value: 3\n",
+				Target: &PatchTargetParams{Kind: "Deployment", Name: "web"},
+			}},
+		})
+		Expect(err).Should(BeNil())
+		deploy := findResource(resources, "Deployment")
+		Expect(deploy).ShouldNot(BeNil())
+		Expect(deploy.GetName()).Should(Equal("prod-web"))
+		Expect(deploy.GetNamespace()).Should(Equal("team-a"))
+		Expect(deploy.GetLabels()).Should(HaveKeyWithValue("team", "a"))
+		replicas, _, _ := unstructured.NestedInt64(deploy.Object, "spec", "replicas")
+		Expect(replicas).Should(Equal(int64(3)))
+		containers, _, _ := unstructured.NestedSlice(deploy.Object, "spec", "template", "spec", "containers")
+		Expect(containers[0].(map[string]interface{})["image"]).Should(Equal("nginx:1.27"))
+		selector, _, _ := unstructured.NestedStringMap(deploy.Object, "spec", "selector", "matchLabels")
+		Expect(selector).Should(Equal(map[string]string{"app": "web"}))
+	})
+
+	It("should render the source root without overrides", func() {
+		resources, err := build(toFiles(map[string]string{
+			"kustomization.yaml":      "resources:\n  - base\n",
+			"base/kustomization.yaml": overlayFiles["base/kustomization.yaml"],
+			"base/deployment.yaml":    overlayFiles["base/deployment.yaml"],
+			"base/configmap.yaml":     overlayFiles["base/configmap.yaml"],
+		}), &RenderParams{})
+		Expect(err).Should(BeNil())
+		Expect(resources).Should(HaveLen(2))
+	})
+
+	It("should reject the invalid params", func() {
+		_, err := build(toFiles(overlayFiles), &RenderParams{Path: "../etc"})
+		Expect(err).ShouldNot(BeNil())
+		_, err = build(toFiles(overlayFiles), &RenderParams{Path: "overlays/dev"})
+		Expect(err).ShouldNot(BeNil())
+	})
+})
+
+func toFiles(inline map[string]string) map[string][]byte {
+	files := map[string][]byte{}
+	for p, content := range inline {
+		files[p] = []byte(content)
+	}
+	return files
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+// Loads the kustomization files from an inline file map, a git archive or an OCI artifact.
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubevela/pkg/util/singleton"
+
+	"github.com/oam-dev/kubevela/pkg/utils"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var (
+	// MaxSourceSize is the max total size of the files loaded from a remote source
+	MaxSourceSize int64 = 64 << 20
+	// SourceCacheTTL is how long the files of a remote source are cached
+	SourceCacheTTL = 5 * time.Minute
+
+	sourceCache     *utils.MemoryCacheStore
+	sourceCacheOnce sync.Once
+)
+
+func getSourceCache() *utils.MemoryCacheStore {
+	sourceCacheOnce.Do(func() {
+		sourceCache = utils.NewMemoryCacheStore(context.Background())
+	})
+	return sourceCache
+}
+
+// loadSource returns the files of the source, keyed by the slash separated relative path
+func loadSource(ctx context.Context, params *SourceParams, appNamespace string) (map[string][]byte, error) {
+	count := 0
+	for _, set := range []bool{params.Inline != nil, params.Archive != nil, params.OCI != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return nil, errors.New("exactly one of source.inline, source.archive and source.oci must be set")
+	}
+	if params.Inline != nil {
+		files := map[string][]byte{}
+		for p, content := range params.Inline {
+			cleaned, err := cleanFilePath(p)
+			if err != nil {
+				return nil, err
+			}
+			files[cleaned] = []byte(content)
+		}
+		return files, nil
+	}
+
+	auth, authTag, err := resolveAuth(ctx, params.Auth, appNamespace)
+	if err != nil {
+		return nil, err
+	}
+	var cacheKey string
+	if params.Archive != nil {
+		cacheKey = "archive/" + params.Archive.URL + authTag
+	} else {
+		cacheKey = "oci/" + params.OCI.Ref + authTag
+	}
+	if cached, ok := getSourceCache().Get(cacheKey).(map[string][]byte); ok {
+		return cached, nil
+	}
+
+	var files map[string][]byte
+	if params.Archive != nil {
+		files, err = loadArchive(ctx, params.Archive, auth)
+	} else {
+		files, err = loadOCIArtifact(ctx, params.OCI, auth)
+	}
+	if err != nil {
+		return nil, err
+	}
+	getSourceCache().Put(cacheKey, files, SourceCacheTTL)
+	return files, nil
+}
+
+// resolveAuth reads the credentials from the referenced secret. The secret must be in the
+// namespace of the application. It returns a tag derived from the secret data, so the cached
+// files are bound to the credentials that loaded them.
+func resolveAuth(ctx context.Context, params *AuthParams, appNamespace string) (*common.HTTPOption, string, error) {
+	if params == nil || params.SecretRef == nil {
+		return nil, "", nil
+	}
+	ref := params.SecretRef
+	if ref.Namespace != "" && ref.Namespace != appNamespace {
+		return nil, "", fmt.Errorf("auth secret %s/%s is rejected: the namespace must be the application namespace %s", ref.Namespace, ref.Name, appNamespace)
+	}
+	var secret corev1.Secret
+	if err := singleton.KubeClient.Get().Get(ctx, types.NamespacedName{Namespace: appNamespace, Name: ref.Name}, &secret); err != nil {
+		return nil, "", errors.Wrapf(err, "failed to get auth secret %s/%s", appNamespace, ref.Name)
+	}
+	opts := &common.HTTPOption{
+		Username:    string(secret.Data[corev1.BasicAuthUsernameKey]),
+		Password:    string(secret.Data[corev1.BasicAuthPasswordKey]),
+		BearerToken: string(secret.Data["token"]),
+	}
+	if opts.BearerToken != "" && (opts.Username != "" || opts.Password != "") {
+		return nil, "", fmt.Errorf("auth secret %s/%s must not contain both token and username/password", appNamespace, ref.Name)
+	}
+	if opts.BearerToken == "" && opts.Username == "" {
+		return nil, "", fmt.Errorf("auth secret %s/%s must contain username/password or token", appNamespace, ref.Name)
+	}
+	h := sha256.New()
+	for _, v := range []string{opts.Username, opts.Password, opts.BearerToken} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return opts, "/auth-" + hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// loadArchive downloads the tar.gz archive and extracts the files. The common top-level
+// directory, which is added by the archive endpoints of the git servers, is stripped.
+func loadArchive(ctx context.Context, params *ArchiveParams, auth *common.HTTPOption) (map[string][]byte, error) {
+	if params.URL == "" {
+		return nil, errors.New("source.archive.url must be set")
+	}
+	resp, err := common.HTTPGetResponse(ctx, params.URL, auth)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download the archive %s", params.URL)
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to download the archive %s: HTTP %s", params.URL, resp.Status)
+	}
+	files := map[string][]byte{}
+	if err := extractArchive(newSizeLimitedReader(resp.Body, MaxSourceSize), files); err != nil {
+		return nil, errors.Wrapf(err, "failed to extract the archive %s", params.URL)
+	}
+	return stripCommonDir(files), nil
+}
+
+// loadOCIArtifact pulls the OCI artifact and extracts all the layers in order
+func loadOCIArtifact(ctx context.Context, params *OCIParams, auth *common.HTTPOption) (map[string][]byte, error) {
+	var nameOpts []name.Option
+	if params.Insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	ref, err := name.ParseReference(strings.TrimPrefix(params.Ref, "oci://"), nameOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid OCI reference %s", params.Ref)
+	}
+	remoteOpts := []remote.Option{remote.WithContext(ctx)}
+	if auth != nil {
+		remoteOpts = append(remoteOpts, remote.WithAuth(authn.FromConfig(authn.AuthConfig{
+			Username:      auth.Username,
+			Password:      auth.Password,
+			RegistryToken: auth.BearerToken,
+		})))
+	}
+	img, err := remote.Image(ref, remoteOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to pull the OCI artifact %s", params.Ref)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the layers of the OCI artifact %s", params.Ref)
+	}
+	files := map[string][]byte{}
+	for _, layer := range layers {
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the layer of the OCI artifact %s", params.Ref)
+		}
+		err = extractArchive(rc, files)
+		_ = rc.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to extract the layer of the OCI artifact %s", params.Ref)
+		}
+	}
+	return files, nil
+}
+
+// extractArchive extracts the regular files of the tar or tar.gz stream into files
+func extractArchive(r io.Reader, files map[string][]byte) error {
+	br := bufio.NewReader(r)
+	var reader io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		reader = gz
+	}
+	var total int64
+	for _, content := range files {
+		total += int64(len(content))
+	}
+	// the limit is enforced on the bytes actually read, the sizes in the headers are not trusted
+	tr := tar.NewReader(newSizeLimitedReader(reader, MaxSourceSize-total))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		p, err := cleanFilePath(header.Name)
+		if err != nil {
+			return err
+		}
+		total += header.Size
+		if total > MaxSourceSize {
+			return fmt.Errorf("the size of the files exceeds the limit of %d bytes", MaxSourceSize)
+		}
+		content, err := io.ReadAll(io.LimitReader(tr, header.Size))
+		if err != nil {
+			return err
+		}
+		files[p] = content
+	}
+}
+
+// sizeLimitedReader fails the read once more than the limit is read, instead of silently
+// truncating the stream like io.LimitReader
+type sizeLimitedReader struct {
+	reader io.Reader
+	limit  int64
+}
+
+func newSizeLimitedReader(r io.Reader, limit int64) io.Reader {
+	// read one more byte than the limit to tell an exceeding stream from an exact one
+	return &sizeLimitedReader{reader: io.LimitReader(r, limit+1), limit: limit}
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.limit -= int64(n)
+	if r.limit < 0 {
+		return n, fmt.Errorf("the size of the files exceeds the limit of %d bytes", MaxSourceSize)
+	}
+	return n, err
+}
+
+// cleanRelativePath cleans the path and makes sure it stays inside the source. The source
+// root itself is "."
+func cleanRelativePath(p string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(p, "./"))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid path %q, it must be relative to the source", p)
+	}
+	return cleaned, nil
+}
+
+// cleanFilePath cleans the path of a file in the source, which can not be the source root
+func cleanFilePath(p string) (string, error) {
+	cleaned, err := cleanRelativePath(p)
+	if err != nil {
+		return "", err
+	}
+	if cleaned == "." {
+		return "", fmt.Errorf("invalid file path %q, it must be a file inside the source", p)
+	}
+	return cleaned, nil
+}
+
+// stripCommonDir removes the top-level directory if all the files are inside it
+func stripCommonDir(files map[string][]byte) map[string][]byte {
+	dir := ""
+	for p := range files {
+		i := strings.Index(p, "/")
+		if i < 0 || (dir != "" && p[:i] != dir) {
+			return files
+		}
+		dir = p[:i]
+	}
+	stripped := make(map[string][]byte, len(files))
+	for p, content := range files {
+		stripped[strings.TrimPrefix(p, dir+"/")] = content
+	}
+	return stripped
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func makeTarGz(files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for p, content := range files {
+		Expect(tw.WriteHeader(&tar.Header{Name: p, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})).Should(Succeed())
+		_, err := tw.Write([]byte(content))
+		Expect(err).Should(BeNil())
+	}
+	Expect(tw.Close()).Should(Succeed())
+	Expect(gz.Close()).Should(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("kustomize source", func() {
+
+	It("should require exactly one source", func() {
+		_, err := loadSource(context.Background(), &SourceParams{}, "default")
+		Expect(err).ShouldNot(BeNil())
+		_, err = loadSource(context.Background(), &SourceParams{
+			Inline:  map[string]string{"kustomization.yaml": ""},
+			Archive: &ArchiveParams{URL: "http://127.0.0.1/a.tar.gz"},
+		}, "default")
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("should reject the files outside the source", func() {
+		_, err := loadSource(context.Background(), &SourceParams{Inline: map[string]string{"../kustomization.yaml": ""}}, "default")
+		Expect(err).ShouldNot(BeNil())
+		_, err = loadSource(context.Background(), &SourceParams{Inline: map[string]string{"/etc/passwd": ""}}, "default")
+		Expect(err).ShouldNot(BeNil())
+		err = extractArchive(bytes.NewReader(makeTarGz(map[string]string{"../../evil.yaml": "x"})), map[string][]byte{})
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("should load the git archive and strip the top-level directory", func() {
+		var requests int32
+		archive := makeTarGz(map[string]string{
+			"repo-v1.0.0/overlays/prod/kustomization.yaml": overlayFiles["overlays/prod/kustomization.yaml"],
+			"repo-v1.0.0/base/kustomization.yaml":          overlayFiles["base/kustomization.yaml"],
+			"repo-v1.0.0/base/deployment.yaml":             overlayFiles["base/deployment.yaml"],
+			"repo-v1.0.0/base/configmap.yaml":              overlayFiles["base/configmap.yaml"],
+		})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			_, _ = w.Write(archive)
+		}))
+		defer server.Close()
+
+		params := &SourceParams{Archive: &ArchiveParams{URL: server.URL + "/repo/archive/v1.0.0.tar.gz"}}
+		files, err := loadSource(context.Background(), params, "default")
+		Expect(err).Should(BeNil())
+		Expect(files).Should(HaveKey("overlays/prod/kustomization.yaml"))
+		Expect(files).Should(HaveLen(4))
+		resources, err := build(files, &RenderParams{Path: "overlays/prod"})
+		Expect(err).Should(BeNil())
+		Expect(resources).Should(HaveLen(2))
+
+		// the files are served from the cache
+		_, err = loadSource(context.Background(), params, "default")
+		Expect(err).Should(BeNil())
+		Expect(atomic.LoadInt32(&requests)).Should(Equal(int32(1)))
+	})
+
+	It("should load the OCI artifact", func() {
+		server := httptest.NewServer(registry.New())
+		defer server.Close()
+		host := strings.TrimPrefix(server.URL, "http://")
+		layer := static.NewLayer(makeTarGz(map[string]string{
+			"kustomization.yaml": "resources:\n  - configmap.yaml\n",
+			"configmap.yaml":     overlayFiles["base/configmap.yaml"],
+		}), types.MediaType("application/vnd.cncf.flux.content.v1.tar+gzip"))
+		img, err := mutate.AppendLayers(empty.Image, layer)
+		Expect(err).Should(BeNil())
+		ref, err := name.ParseReference(host+"/manifests/web:v1", name.Insecure)
+		Expect(err).Should(BeNil())
+		Expect(remote.Write(ref, img)).Should(Succeed())
+
+		files, err := loadSource(context.Background(), &SourceParams{OCI: &OCIParams{Ref: "oci://" + host + "/manifests/web:v1", Insecure: true}}, "default")
+		Expect(err).Should(BeNil())
+		resources, err := build(files, &RenderParams{NameSuffix: "-v1"})
+		Expect(err).Should(BeNil())
+		Expect(resources).Should(HaveLen(1))
+		Expect(findResource(resources, "ConfigMap").GetName()).Should(Equal("web-config-v1"))
+	})
+
+	It("should enforce the size limit while reading", func() {
+		data, err := io.ReadAll(newSizeLimitedReader(strings.NewReader("abcd"), 4))
+		Expect(err).Should(BeNil())
+		Expect(string(data)).Should(Equal("abcd"))
+		_, err = io.ReadAll(newSizeLimitedReader(strings.NewReader("abcde"), 4))
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("should clean the relative paths", func() {
+		for p, expected := range map[string]string{".": ".", "./": ".", "./overlays/prod/": "overlays/prod", "base/../overlays": "overlays"} {
+			cleaned, err := cleanRelativePath(p)
+			Expect(err).Should(BeNil())
+			Expect(cleaned).Should(Equal(expected))
+		}
+		for _, p := range []string{"..", "../base", "/etc"} {
+			_, err := cleanRelativePath(p)
+			Expect(err).ShouldNot(BeNil())
+		}
+		_, err := cleanFilePath(".")
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("should keep the files without a common directory", func() {
+		files := map[string][]byte{"kustomization.yaml": nil, "base/a.yaml": nil}
+		Expect(stripCommonDir(files)).Should(HaveLen(2))
+		Expect(stripCommonDir(files)).Should(HaveKey("base/a.yaml"))
+		files = map[string][]byte{"a/kustomization.yaml": nil, "b/a.yaml": nil}
+		Expect(stripCommonDir(files)).Should(HaveKey("a/kustomization.yaml"))
+	})
+})
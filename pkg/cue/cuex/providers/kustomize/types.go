@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+// Param and return struct definitions exchanged with the CUE schema.
+
+// SourceParams represents where the kustomization files are loaded from.
+// Exactly one of Inline, Archive and OCI must be set.
+type SourceParams struct {
+	Inline  map[string]string `json:"inline,omitempty"`
+	Archive *ArchiveParams    `json:"archive,omitempty"`
+	OCI     *OCIParams        `json:"oci,omitempty"`
+	Auth    *AuthParams       `json:"auth,omitempty"`
+}
+
+// ArchiveParams represents a tar.gz archive of a git repository, e.g. the
+// output of `git archive --format=tar.gz` or the archive endpoint of a git server
+type ArchiveParams struct {
+	URL string `json:"url"`
+}
+
+// OCIParams represents an OCI artifact whose layers are tar.gz archives of the files
+type OCIParams struct {
+	Ref      string `json:"ref"`
+	Insecure bool   `json:"insecure,omitempty"`
+}
+
+// AuthParams represents authentication configuration
+type AuthParams struct {
+	SecretRef *SecretRefParams `json:"secretRef,omitempty"`
+}
+
+// SecretRefParams represents a reference to a secret
+type SecretRefParams struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ImageParams represents an image override, the same as the images field of the kustomization
+type ImageParams struct {
+	Name    string `json:"name"`
+	NewName string `json:"newName,omitempty"`
+	NewTag  string `json:"newTag,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+}
+
+// PatchParams represents a strategic merge or JSON 6902 patch applied to the rendered resources
+type PatchParams struct {
+	Patch  string             `json:"patch"`
+	Target *PatchTargetParams `json:"target,omitempty"`
+}
+
+// PatchTargetParams selects the resources that the patch applies to
+type PatchTargetParams struct {
+	Group              string `json:"group,omitempty"`
+	Version            string `json:"version,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Namespace          string `json:"namespace,omitempty"`
+	LabelSelector      string `json:"labelSelector,omitempty"`
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
+}
+
+// RenderParams represents the parameters for rendering a kustomization
+type RenderParams struct {
+	Source SourceParams `json:"source"`
+	// Path is the directory of the kustomization inside the source, e.g. overlays/prod
+	Path         string            `json:"path,omitempty"`
+	NamePrefix   string            `json:"namePrefix,omitempty"`
+	NameSuffix   string            `json:"nameSuffix,omitempty"`
+	Namespace    string            `json:"namespace,omitempty"`
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+	Images       []ImageParams     `json:"images,omitempty"`
+	Patches      []PatchParams     `json:"patches,omitempty"`
+	// AppNamespace is the namespace of the application, the auth secret must be in this namespace
+	AppNamespace string `json:"appNamespace,omitempty"`
+}
+
+// RenderReturns represents the return value from rendering
+type RenderReturns struct {
+	Resources []map[string]interface{} `json:"resources"`
+	// Outputs are the resources except the first one, keyed by kind, namespace and name
+	Outputs map[string]map[string]interface{} `json:"outputs"`
+}
@@ -103,6 +103,11 @@ func NewContext(data ContextData) process.Context {
 	ctx.PushData(ContextAppRevisionNum, revNum)
 	ctx.PushData(ContextCluster, data.Cluster)
 	ctx.PushData(ContextClusterVersion, parseClusterVersion(data.ClusterVersion))
+	if data.Ctx != nil {
+		if deps, ok := data.Ctx.Value(oam.AppDependenciesContextKey).(map[string]interface{}); ok {
+			ctx.PushData(ContextDependencies, deps)
+		}
+	}
 	if data.Output != nil {
 		ctx.PushData(OutputFieldName, data.Output)
 	}
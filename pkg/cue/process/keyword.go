@@ -63,6 +63,8 @@ const (
 	ContextDataArtifacts = "artifacts"
 	// ContextReplicaKey is the key of replication in context
 	ContextReplicaKey = "replicaKey"
+	// ContextDependencies is the outputs of the Applications depended on through the app-dependency policy
+	ContextDependencies = "dependencies"
 	// ContextPolicyName is the instance name of the currently executing policy (from spec.policies[].name)
 	ContextPolicyName = "policyName"
 	// ContextPolicyType is the definition type of the currently executing policy (from spec.policies[].type)
@@ -47,11 +47,42 @@ func (c *ComponentDefinition) RenderAll(ctx *TestContextBuilder) *RenderedOutput
 		c.template(tpl)
 	}
 
-	outputs := &RenderedOutputs{
+	return &RenderedOutputs{
 		Primary:   renderResource(tpl.output, rtCtx),
-		Auxiliary: make(map[string]*RenderedResource),
+		Auxiliary: renderAuxiliary(tpl, rtCtx),
+	}
+}
+
+// RenderAll executes the trait template with the given test context. The
+// primary output is the workload of the test context patched by the Set, SetIf
+// and If operations of the patch, the other patch operations are not applied.
+// The auxiliary outputs are the outputs of the trait.
+func (t *TraitDefinition) RenderAll(ctx *TestContextBuilder) *RenderedOutputs {
+	rtCtx := ctx.Build()
+	setCurrentTestContext(rtCtx)
+	defer clearCurrentTestContext()
+
+	tpl := NewTemplate()
+	if t.template != nil {
+		t.template(tpl)
 	}
 
+	primary := renderResource(rtCtx.Workload(), rtCtx)
+	if primary != nil && tpl.patch != nil {
+		for _, op := range tpl.patch.ops {
+			processOp(primary.data, op, rtCtx)
+		}
+	}
+	return &RenderedOutputs{
+		Primary:   primary,
+		Auxiliary: renderAuxiliary(tpl, rtCtx),
+	}
+}
+
+// renderAuxiliary renders the auxiliary outputs of the template whose output
+// conditions are satisfied.
+func renderAuxiliary(tpl *Template, rtCtx *TestRuntimeContext) map[string]*RenderedResource {
+	auxiliary := make(map[string]*RenderedResource)
 	for name, res := range tpl.outputs {
 		// Check if the resource has an output condition
 		if res.outputCondition != nil {
@@ -60,10 +91,15 @@ func (c *ComponentDefinition) RenderAll(ctx *TestContextBuilder) *RenderedOutput
 				continue
 			}
 		}
-		outputs.Auxiliary[name] = renderResource(res, rtCtx)
+		auxiliary[name] = renderResource(res, rtCtx)
 	}
+	return auxiliary
+}
 
-	return outputs
+// RenderResource renders a standalone resource with the given test context,
+// e.g. the workload set by WithWorkload for trait testing.
+func RenderResource(res *Resource, ctx *TestContextBuilder) *RenderedResource {
+	return renderResource(res, ctx.Build())
 }
 
 // RenderedOutputs contains all rendered resources from a template.
@@ -776,4 +776,61 @@ var _ = Describe("Render", func() {
 			Expect(rendered.Get("data.always")).To(Equal("present"))
 		})
 	})
+
+	Context("RenderResource", func() {
+		It("should render a standalone workload with the test context", func() {
+			ctx := defkit.TestContext().
+				WithName("web").
+				WithParam("image", "nginx").
+				WithWorkload(defkit.NewResource("apps/v1", "Deployment").
+					Set("spec.template.spec.containers[0].image", defkit.String("image")))
+
+			rendered := defkit.RenderResource(ctx.Workload(), ctx)
+			Expect(rendered.Kind()).To(Equal("Deployment"))
+			Expect(rendered.Get("metadata.name")).To(Equal("web"))
+			Expect(rendered.Get("spec.template.spec.containers[0].image")).To(Equal("nginx"))
+		})
+
+		It("should return nil for a nil resource", func() {
+			Expect(defkit.RenderResource(nil, defkit.TestContext())).To(BeNil())
+		})
+	})
+
+	Context("Trait RenderAll", func() {
+		It("should patch the workload and render the outputs of the trait", func() {
+			team := defkit.String("team").Optional()
+			trait := defkit.NewTrait("app-label").
+				Params(team).
+				Template(func(tpl *defkit.Template) {
+					tpl.Patch().
+						Set("metadata.labels.app", defkit.VelaCtx().AppName()).
+						SetIf(team.IsSet(), "metadata.labels.team", team)
+					tpl.Outputs("config", defkit.NewResource("v1", "ConfigMap").
+						Set("data.app", defkit.VelaCtx().AppName()))
+				})
+			ctx := defkit.TestContext().
+				WithName("web").
+				WithAppName("shop").
+				WithWorkload(defkit.NewResource("apps/v1", "Deployment"))
+
+			rendered := trait.RenderAll(ctx)
+			Expect(rendered.Primary.Kind()).To(Equal("Deployment"))
+			Expect(rendered.Primary.Get("metadata.name")).To(Equal("web"))
+			Expect(rendered.Primary.Get("metadata.labels.app")).To(Equal("shop"))
+			Expect(rendered.Primary.Get("metadata.labels.team")).To(BeNil())
+			Expect(rendered.Auxiliary).To(HaveKey("config"))
+			Expect(rendered.Auxiliary["config"].Get("data.app")).To(Equal("shop"))
+
+			rendered = trait.RenderAll(ctx.WithParam("team", "payments"))
+			Expect(rendered.Primary.Get("metadata.labels.team")).To(Equal("payments"))
+		})
+
+		It("should render no primary output without a workload", func() {
+			trait := defkit.NewTrait("app-label").
+				Template(func(tpl *defkit.Template) {
+					tpl.Patch().Set("metadata.labels.app", defkit.VelaCtx().AppName())
+				})
+			Expect(trait.RenderAll(defkit.TestContext()).Primary).To(BeNil())
+		})
+	})
 })
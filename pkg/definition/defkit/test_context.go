@@ -140,6 +140,18 @@ func (t *TestContextBuilder) Params() map[string]any { return t.params }
 // ClusterVersion returns major, minor version.
 func (t *TestContextBuilder) ClusterVersion() (int, int) { return t.clusterMajor, t.clusterMinor }
 
+// AppRevision returns the application revision.
+func (t *TestContextBuilder) AppRevision() string { return t.appRevision }
+
+// OutputStatus returns the simulated output status.
+func (t *TestContextBuilder) OutputStatus() map[string]any { return t.outputStatus }
+
+// OutputsStatus returns the simulated status of the auxiliary outputs by name.
+func (t *TestContextBuilder) OutputsStatus() map[string]map[string]any { return t.outputsStatus }
+
+// Workload returns the workload for trait testing.
+func (t *TestContextBuilder) Workload() *Resource { return t.workload }
+
 // TestRuntimeContext holds the built test context values.
 type TestRuntimeContext struct {
 	name          string
@@ -0,0 +1,350 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cuerender renders defkit definitions through the CUE template engine
+// of the application controller.
+//
+// The Render and RenderAll methods of defkit evaluate the templates in Go, which
+// is fast but may disagree with the CUE generated by defkit. This package
+// compiles the generated CUE and renders it with the same TestContextBuilder
+// inputs, so that the tests can check the real output:
+//
+//	ctx := defkit.TestContext().WithParam("image", "nginx")
+//	Expect(cuerender.Verify(comp, ctx)).To(Succeed())
+package cuerender
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/oam-dev/kubevela/pkg/cue/definition/health"
+	pkgdef "github.com/oam-dev/kubevela/pkg/definition"
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	"github.com/oam-dev/kubevela/pkg/definition/deftest"
+)
+
+// Definition is a defkit definition that can be rendered by both the Go and
+// the CUE renderers, i.e. *defkit.ComponentDefinition or *defkit.TraitDefinition
+type Definition interface {
+	ToCue() string
+	RenderAll(ctx *defkit.TestContextBuilder) *defkit.RenderedOutputs
+}
+
+// Result is the output, the auxiliary outputs and the status rendered by the CUE engine
+type Result = deftest.Result
+
+// Render compiles the CUE generated from the definition and renders it through
+// the CUE template engine with the test context. The status is evaluated if
+// the output status or the outputs status is set in the test context. The
+// workload patched by a trait is rendered through the CUE engine as well.
+func Render(def Definition, ctx *defkit.TestContextBuilder) (*Result, error) {
+	d, in, err := prepare(def, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return deftest.Render(d, in)
+}
+
+// prepare parses the CUE generated from the definition and builds the rendering
+// input of the test context
+func prepare(def Definition, ctx *defkit.TestContextBuilder) (*pkgdef.Definition, deftest.Input, error) {
+	d, err := parse(def)
+	if err != nil {
+		return nil, deftest.Input{}, err
+	}
+	in := deftest.Input{
+		Parameter:     ctx.Params(),
+		Context:       renderContext(ctx),
+		OutputStatus:  ctx.OutputStatus(),
+		OutputsStatus: ctx.OutputsStatus(),
+	}
+	if workload := ctx.Workload(); workload != nil {
+		if in.Workload, err = renderWorkload(workload, ctx); err != nil {
+			return nil, deftest.Input{}, err
+		}
+	}
+	return d, in, nil
+}
+
+// renderContext returns the rendering context of the test context
+func renderContext(ctx *defkit.TestContextBuilder) deftest.Context {
+	major, minor := ctx.ClusterVersion()
+	return deftest.Context{
+		Name:           ctx.Name(),
+		Namespace:      ctx.Namespace(),
+		AppName:        ctx.AppName(),
+		AppRevision:    ctx.AppRevision(),
+		ClusterVersion: fmt.Sprintf("%d.%d", major, minor),
+	}
+}
+
+// parse parses the CUE generated from the definition
+func parse(def Definition) (*pkgdef.Definition, error) {
+	d := &pkgdef.Definition{}
+	if err := d.FromCUEString(def.ToCue(), nil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the generated CUE")
+	}
+	return d, nil
+}
+
+// renderWorkload renders the workload of the test context through the CUE
+// engine as the output of a component, with the parameters of the test context
+func renderWorkload(workload *defkit.Resource, ctx *defkit.TestContextBuilder) (map[string]interface{}, error) {
+	comp := defkit.NewComponent("workload").
+		Workload(workload.APIVersion(), workload.Kind()).
+		Params(referencedParams(workload.Ops(), map[string]bool{})...).
+		Template(func(tpl *defkit.Template) {
+			tpl.Output(workload)
+		})
+	d, err := parse(comp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the workload")
+	}
+	res, err := deftest.Render(d, deftest.Input{Parameter: ctx.Params(), Context: renderContext(ctx)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render the workload with the CUE engine")
+	}
+	return res.Output, nil
+}
+
+// referencedParams returns the parameters set by the operations, so that the
+// component rendering the workload declares them with their defaults
+func referencedParams(ops []defkit.ResourceOp, seen map[string]bool) []defkit.Param {
+	var params []defkit.Param
+	for _, op := range ops {
+		var value defkit.Value
+		switch o := op.(type) {
+		case *defkit.SetOp:
+			value = o.Value()
+		case *defkit.SetIfOp:
+			value = o.Value()
+		case *defkit.IfBlock:
+			params = append(params, referencedParams(o.Ops(), seen)...)
+		}
+		if p, ok := value.(defkit.Param); ok && !seen[p.Name()] {
+			seen[p.Name()] = true
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// Difference is a field rendered differently by the Go and the CUE renderers
+type Difference struct {
+	// Path is the path of the field, e.g. output.spec.replicas
+	Path string
+	// Go is the value rendered by the Go renderer, nil if the field is missing
+	Go interface{}
+	// CUE is the value rendered by the CUE renderer, nil if the field is missing
+	CUE interface{}
+}
+
+// String returns the difference in the form of path: go=value, cue=value
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: go=%s, cue=%s", d.Path, formatValue(d.Go), formatValue(d.CUE))
+}
+
+// Comparison is the result of rendering a definition by both the Go and the CUE renderers
+type Comparison struct {
+	// Go is the outputs rendered by defkit in Go
+	Go *defkit.RenderedOutputs
+	// GoStatus is the status evaluated on the outputs rendered in Go, nil if
+	// no status is set in the test context
+	GoStatus *health.StatusResult
+	// CUE is the outputs and the status rendered by the CUE engine
+	CUE *Result
+	// Differences are the fields rendered differently, sorted by path
+	Differences []Difference
+}
+
+// Equal tells if the Go and the CUE renderers render the same outputs
+func (c *Comparison) Equal() bool {
+	return len(c.Differences) == 0
+}
+
+// String lists the differences line by line
+func (c *Comparison) String() string {
+	lines := make([]string, 0, len(c.Differences))
+	for _, d := range c.Differences {
+		lines = append(lines, d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compare renders the component or the trait by both defkit's RenderAll and
+// the CUE engine with the same test context, and compares the output, i.e. the
+// patched workload for a trait, the auxiliary outputs and, if the output status
+// or the outputs status is set in the test context, the health and the custom
+// status evaluated on the outputs of each renderer. The fields set to nil by
+// the Go renderer, i.e. the unset optional parameters, are dropped before
+// comparing since CUE leaves them out.
+func Compare(def Definition, ctx *defkit.TestContextBuilder) (*Comparison, error) {
+	d, in, err := prepare(def, ctx)
+	if err != nil {
+		return nil, err
+	}
+	cueRes, err := deftest.Render(d, in)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render with the CUE engine")
+	}
+	goRes := def.RenderAll(ctx)
+
+	goOutputs := map[string]interface{}{}
+	if goRes.Primary != nil {
+		goOutputs["output"] = goRes.Primary.Data()
+	}
+	if len(goRes.Auxiliary) > 0 {
+		auxiliary := map[string]interface{}{}
+		for name, res := range goRes.Auxiliary {
+			auxiliary[name] = res.Data()
+		}
+		goOutputs["outputs"] = auxiliary
+	}
+	normalized, err := deftest.Normalize(goOutputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the outputs rendered in Go")
+	}
+	left := pruneNil(normalized).(map[string]interface{})
+
+	comparison := &Comparison{Go: goRes, CUE: cueRes}
+	if in.OutputStatus != nil || len(in.OutputsStatus) > 0 {
+		goResult := &Result{}
+		goResult.Output, _ = left["output"].(map[string]interface{})
+		goResult.Outputs, _ = left["outputs"].(map[string]interface{})
+		if comparison.GoStatus, err = deftest.EvalStatus(d, goResult, in); err != nil {
+			return nil, errors.Wrap(err, "failed to evaluate the status on the outputs rendered in Go")
+		}
+		left["status"] = comparison.GoStatus
+	}
+
+	if left, err = normalizeMap(left); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the results rendered in Go")
+	}
+	right, err := normalizeMap(cueRes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the results rendered in CUE")
+	}
+	compare("", left, right, &comparison.Differences)
+	sort.Slice(comparison.Differences, func(i, j int) bool {
+		return comparison.Differences[i].Path < comparison.Differences[j].Path
+	})
+	return comparison, nil
+}
+
+// Verify compares the Go and the CUE renderings of the component or the trait
+// and returns an error listing the differences if they disagree
+func Verify(def Definition, ctx *defkit.TestContextBuilder) error {
+	comparison, err := Compare(def, ctx)
+	if err != nil {
+		return err
+	}
+	if !comparison.Equal() {
+		return errors.Errorf("the Go renderer disagrees with the CUE renderer on %d field(s):\n%s", len(comparison.Differences), comparison)
+	}
+	return nil
+}
+
+// normalizeMap converts the results into the plain JSON object
+func normalizeMap(v interface{}) (map[string]interface{}, error) {
+	normalized, err := deftest.Normalize(v)
+	if err != nil {
+		return nil, err
+	}
+	m, _ := normalized.(map[string]interface{})
+	return m, nil
+}
+
+// compare collects the differences between the two plain JSON values
+func compare(path string, left, right interface{}, diffs *[]Difference) {
+	leftMap, leftOK := left.(map[string]interface{})
+	rightMap, rightOK := right.(map[string]interface{})
+	if leftOK && rightOK {
+		keys := map[string]bool{}
+		for k := range leftMap {
+			keys[k] = true
+		}
+		for k := range rightMap {
+			keys[k] = true
+		}
+		for k := range keys {
+			compare(joinPath(path, k), leftMap[k], rightMap[k], diffs)
+		}
+		return
+	}
+	leftList, leftOK := left.([]interface{})
+	rightList, rightOK := right.([]interface{})
+	if leftOK && rightOK && len(leftList) == len(rightList) {
+		for i := range leftList {
+			compare(fmt.Sprintf("%s[%d]", path, i), leftList[i], rightList[i], diffs)
+		}
+		return
+	}
+	if !reflect.DeepEqual(left, right) {
+		*diffs = append(*diffs, Difference{Path: path, Go: left, CUE: right})
+	}
+}
+
+// joinPath appends the field to the path
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// pruneNil drops the nil fields, and the structs left empty by dropping them
+func pruneNil(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(val))
+		for k, field := range val {
+			if field == nil {
+				continue
+			}
+			pruned := pruneNil(field)
+			if m, ok := pruned.(map[string]interface{}); ok && len(m) == 0 && len(field.(map[string]interface{})) > 0 {
+				continue
+			}
+			res[k] = pruned
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(val))
+		for i, item := range val {
+			res[i] = pruneNil(item)
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+// formatValue formats the value as JSON, or <missing> for a missing field
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "<missing>"
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(bs)
+}
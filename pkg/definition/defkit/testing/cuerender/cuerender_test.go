@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cuerender_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	"github.com/oam-dev/kubevela/pkg/definition/defkit/testing/cuerender"
+)
+
+func webComponent() *defkit.ComponentDefinition {
+	image := defkit.String("image")
+	replicas := defkit.Int("replicas").Default(1)
+	team := defkit.String("team").Optional()
+	return defkit.NewComponent("web").
+		Workload("apps/v1", "Deployment").
+		Params(image, replicas, team).
+		Template(func(tpl *defkit.Template) {
+			tpl.Output(defkit.NewResource("apps/v1", "Deployment").
+				Set("metadata.name", defkit.VelaCtx().Name()).
+				SetIf(team.IsSet(), "metadata.labels.team", team).
+				Set("spec.replicas", replicas).
+				Set("spec.template.spec.containers[0].image", image))
+			tpl.Outputs("service", defkit.NewResource("v1", "Service").
+				Set("metadata.name", defkit.VelaCtx().Name()).
+				Set("spec.ports[0].port", defkit.Lit(80)))
+		})
+}
+
+func labelTrait() *defkit.TraitDefinition {
+	team := defkit.String("team").Optional()
+	return defkit.NewTrait("app-label").
+		AppliesTo("deployments.apps").
+		Params(team).
+		Template(func(tpl *defkit.Template) {
+			tpl.Patch().
+				Set("metadata.labels.app", defkit.VelaCtx().AppName()).
+				SetIf(team.IsSet(), "metadata.labels.team", team)
+			tpl.Outputs("config", defkit.NewResource("v1", "ConfigMap").
+				Set("metadata.name", defkit.VelaCtx().Name()).
+				Set("data.app", defkit.VelaCtx().AppName()))
+		})
+}
+
+func deployment() *defkit.Resource {
+	return defkit.NewResource("apps/v1", "Deployment").
+		Set("metadata.name", defkit.VelaCtx().Name()).
+		Set("spec.replicas", defkit.Int("replicas").Default(1))
+}
+
+var _ = Describe("CUE Render", func() {
+
+	Context("Render", func() {
+		It("should render a component through the CUE engine", func() {
+			res, err := cuerender.Render(webComponent(), defkit.TestContext().
+				WithName("api").
+				WithParam("image", "nginx"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Output).To(HaveKeyWithValue("kind", "Deployment"))
+			Expect(res.Output["metadata"]).To(Equal(map[string]interface{}{"name": "api"}))
+			Expect(res.Outputs).To(HaveKey("service"))
+			Expect(res.Status).To(BeNil())
+		})
+
+		It("should render a trait patching the workload of the test context", func() {
+			trait := defkit.NewTrait("app-label").
+				AppliesTo("deployments.apps").
+				Template(func(tpl *defkit.Template) {
+					tpl.Patch().Set("metadata.labels.app", defkit.VelaCtx().AppName())
+				})
+			res, err := cuerender.Render(trait, defkit.TestContext().
+				WithName("api").
+				WithAppName("shop").
+				WithWorkload(defkit.NewResource("apps/v1", "Deployment").
+					Set("metadata.name", defkit.VelaCtx().Name())))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Output["metadata"]).To(Equal(map[string]interface{}{
+				"name":   "api",
+				"labels": map[string]interface{}{"app": "shop"},
+			}))
+		})
+
+		It("should fail on the missing required parameters", func() {
+			_, err := cuerender.Render(webComponent(), defkit.TestContext())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Compare", func() {
+		It("should find no difference when the renderers agree", func() {
+			for _, ctx := range []*defkit.TestContextBuilder{
+				defkit.TestContext().WithParam("image", "nginx"),
+				defkit.TestContext().WithParams(map[string]any{"image": "nginx", "replicas": 3, "team": "payments"}),
+			} {
+				comparison, err := cuerender.Compare(webComponent(), ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(comparison.Equal()).To(BeTrue(), comparison.String())
+				Expect(cuerender.Verify(webComponent(), ctx)).To(Succeed())
+			}
+		})
+
+		It("should report the fields rendered differently", func() {
+			// the Go renderer always sets metadata.name while the template does not
+			comp := defkit.NewComponent("config").
+				Workload("v1", "ConfigMap").
+				Template(func(tpl *defkit.Template) {
+					tpl.Output(defkit.NewResource("v1", "ConfigMap").
+						Set("data.app", defkit.VelaCtx().AppName()))
+				})
+			ctx := defkit.TestContext().WithAppName("shop")
+
+			comparison, err := cuerender.Compare(comp, ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comparison.Equal()).To(BeFalse())
+			Expect(comparison.Differences).To(Equal([]cuerender.Difference{
+				{Path: "output.metadata", Go: map[string]interface{}{"name": "test-component"}},
+			}))
+			Expect(comparison.String()).To(Equal(`output.metadata: go={"name":"test-component"}, cue=<missing>`))
+
+			err = cuerender.Verify(comp, ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("disagrees with the CUE renderer on 1 field(s)"))
+		})
+
+		It("should compare the patched workload and the outputs of a trait", func() {
+			for _, ctx := range []*defkit.TestContextBuilder{
+				defkit.TestContext().WithAppName("shop").WithWorkload(deployment()),
+				defkit.TestContext().WithAppName("shop").WithParams(map[string]any{"team": "payments", "replicas": 3}).WithWorkload(deployment()),
+			} {
+				comparison, err := cuerender.Compare(labelTrait(), ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(comparison.Equal()).To(BeTrue(), comparison.String())
+				Expect(comparison.CUE.Output["metadata"]).To(HaveKey("labels"))
+				Expect(comparison.CUE.Outputs).To(HaveKey("config"))
+			}
+		})
+
+		It("should report the fields of a trait rendered differently", func() {
+			// the Go renderer does not apply the patch keys
+			trait := defkit.NewTrait("sidecar").
+				AppliesTo("deployments.apps").
+				Template(func(tpl *defkit.Template) {
+					tpl.Patch().PatchKey("spec.template.spec.containers", "name",
+						defkit.NewArrayElement().Set("name", defkit.Lit("sidecar")).Set("image", defkit.Lit("busybox")))
+				})
+			comparison, err := cuerender.Compare(trait, defkit.TestContext().WithWorkload(deployment()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comparison.Equal()).To(BeFalse())
+			Expect(comparison.Differences[0].Path).To(Equal("output.spec.template"))
+		})
+
+		It("should compare the status evaluated on the outputs", func() {
+			comp := webComponent().
+				HealthPolicy("isHealth: context.output.status.readyReplicas == context.output.spec.replicas").
+				CustomStatus(`message: "\(context.output.status.readyReplicas) of \(context.output.spec.replicas) ready"`)
+			ctx := defkit.TestContext().
+				WithParams(map[string]any{"image": "nginx", "replicas": 2}).
+				WithOutputStatus(map[string]any{"readyReplicas": 2})
+
+			comparison, err := cuerender.Compare(comp, ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comparison.Equal()).To(BeTrue(), comparison.String())
+			Expect(comparison.GoStatus).NotTo(BeNil())
+			Expect(comparison.GoStatus.Healthy).To(BeTrue())
+			Expect(comparison.CUE.Status.Message).To(Equal("2 of 2 ready"))
+		})
+
+		It("should report the status evaluated differently", func() {
+			// the Go renderer always sets metadata.name which the health policy depends on
+			comp := defkit.NewComponent("config").
+				Workload("v1", "ConfigMap").
+				HealthPolicy("isHealth: context.output.metadata.name != _|_").
+				Template(func(tpl *defkit.Template) {
+					tpl.Output(defkit.NewResource("v1", "ConfigMap").
+						Set("data.app", defkit.VelaCtx().AppName()))
+				})
+			ctx := defkit.TestContext().WithOutputStatus(map[string]any{})
+
+			comparison, err := cuerender.Compare(comp, ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comparison.Differences).To(ContainElement(cuerender.Difference{Path: "status.healthy", Go: true, CUE: false}))
+		})
+
+		It("should fail if the CUE engine cannot render the component", func() {
+			_, err := cuerender.Compare(webComponent(), defkit.TestContext())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to render with the CUE engine"))
+		})
+	})
+})
@@ -1014,12 +1014,16 @@ func (g *TraitCUEGenerator) findIfBlockCommonPrefix(ifBlocks []*IfBlock, bareOps
 // writePatchFieldTree writes a field tree as CUE patch syntax.
 // This method reuses the CUEGenerator's writeFieldTree with patch-specific handling.
 func (g *TraitCUEGenerator) writePatchFieldTree(sb *strings.Builder, gen *CUEGenerator, tree *fieldNode, depth int) {
-	// For a single-path case with one child, generate inline nested syntax
+	// For a single-path case with one child, generate inline nested syntax.
+	// A conditional child is written as an if comprehension, which is only
+	// valid inside a struct, so it falls through to the block format.
 	if len(tree.childOrder) == 1 {
 		key := tree.childOrder[0]
 		node := tree.children[key]
-		g.writePatchFieldNode(sb, gen, key, node, depth)
-		return
+		if node.cond == nil {
+			g.writePatchFieldNode(sb, gen, key, node, depth)
+			return
+		}
 	}
 
 	// Multiple keys - write as block
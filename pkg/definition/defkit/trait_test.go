@@ -910,6 +910,22 @@ template: {
 			Expect(cue).NotTo(ContainSubstring("parameter.env != _|_"))
 		})
 
+		It("should wrap a patch with a single conditional field in a struct", func() {
+			optParam := defkit.String("team").Optional()
+
+			trait := defkit.NewTrait("single-conditional-patch").
+				Params(optParam).
+				Template(func(tpl *defkit.Template) {
+					tpl.Patch().
+						SetIf(optParam.IsSet(), "spec.template.metadata.labels.team", optParam)
+				})
+
+			cue := trait.ToCue()
+
+			Expect(cue).NotTo(ContainSubstring("patch: if"))
+			Expect(cue).To(ContainSubstring("patch: {\n\t\tif parameter[\"team\"] != _|_ {"))
+		})
+
 		It("should generate bracket notation for NotSet (negated IsSet) conditions", func() {
 			optParam := defkit.String("debug").Optional()
 
@@ -0,0 +1,393 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deftest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pkgdef "github.com/oam-dev/kubevela/pkg/definition"
+)
+
+const testComponentCUE = `
+"my-worker": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "A test worker"
+	attributes: {
+		workload: definition: {
+			apiVersion: "apps/v1"
+			kind:       "Deployment"
+		}
+		status: {
+			healthPolicy: "isHealth: context.output.status.readyReplicas == context.output.spec.replicas"
+			customStatus: "message: \"ready: \\(context.output.status.readyReplicas)\""
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		metadata: {
+			name:      context.name
+			namespace: context.namespace
+		}
+		spec: {
+			replicas: parameter.replicas
+			template: spec: containers: [{
+				name:  context.name
+				image: parameter.image
+			}]
+		}
+	}
+	outputs: service: {
+		apiVersion: "v1"
+		kind:       "Service"
+		metadata: name: context.name
+		spec: ports: [{port: 80}]
+	}
+	parameter: {
+		image:    string
+		replicas: *1 | int
+	}
+}
+`
+
+const testTraitCUE = `
+"my-labels": {
+	type: "trait"
+	annotations: {}
+	labels: {}
+	description: "Add the app label"
+	attributes: appliesToWorkloads: ["deployments.apps"]
+}
+template: {
+	patch: metadata: labels: app: context.appName
+	parameter: {}
+}
+`
+
+const testSuiteYAML = `# tests of my-worker
+definition: my-worker
+cases:
+  # the defaults
+  - name: default
+    parameter:
+      image: nginx
+  - name: missing image
+    parameter: {}
+    expected:
+      error: image
+`
+
+func parseDefinition(t *testing.T, str string) *pkgdef.Definition {
+	def := &pkgdef.Definition{}
+	require.NoError(t, def.FromCUEString(str, nil))
+	return def
+}
+
+func TestSuite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), TestsDir)
+	require.NoError(t, os.MkdirAll(dir, 0750))
+	path := filepath.Join(dir, "worker.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testSuiteYAML), 0600))
+
+	require.True(t, IsTestFile(path))
+	require.False(t, IsTestFile(filepath.Join(filepath.Dir(dir), "worker.yaml")))
+	require.False(t, IsTestFile(filepath.Join(dir, "worker.cue")))
+
+	suite, err := LoadSuite(path)
+	require.NoError(t, err)
+	require.Equal(t, "my-worker", suite.Definition)
+	require.Len(t, suite.Cases, 2)
+	require.Equal(t, "nginx", suite.Cases[0].Parameter["image"])
+	require.Nil(t, suite.Cases[0].Expected)
+	require.Equal(t, map[string]interface{}{"error": "image"}, suite.Cases[1].Expected)
+
+	require.NoError(t, suite.SetExpected(0, map[string]interface{}{"output": map[string]interface{}{"kind": "Deployment"}}))
+	require.NoError(t, suite.SetExpected(1, map[string]interface{}{"error": "image is required"}))
+	require.Error(t, suite.SetExpected(2, nil))
+	require.NoError(t, suite.Save())
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(bs), "# tests of my-worker")
+	require.Contains(t, string(bs), "# the defaults")
+	saved, err := LoadSuite(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"output": map[string]interface{}{"kind": "Deployment"}}, saved.Cases[0].Expected)
+	require.Equal(t, map[string]interface{}{"error": "image is required"}, saved.Cases[1].Expected)
+}
+
+func TestLoadSuiteErrors(t *testing.T) {
+	testCases := map[string]struct {
+		content string
+		err     string
+	}{
+		"case without name": {
+			content: "cases:\n  - parameter: {}\n",
+			err:     "has no name",
+		},
+		"duplicated case": {
+			content: "cases:\n  - name: a\n  - name: a\n",
+			err:     "duplicated case a",
+		},
+		"invalid yaml": {
+			content: "cases: [",
+			err:     "failed to parse the test file",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "worker.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0600))
+			_, err := LoadSuite(path)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "worker.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("cases: []\n"), 0600))
+	suite, err := LoadSuite(path)
+	require.NoError(t, err)
+	require.Equal(t, "worker", suite.Definition)
+}
+
+func TestContextData(t *testing.T) {
+	testCases := map[string]struct {
+		version    string
+		major      string
+		minor      string
+		gitVersion string
+		err        bool
+	}{
+		"default":        {major: "1", minor: "28", gitVersion: "v1.28.0"},
+		"major.minor":    {version: "1.30", major: "1", minor: "30", gitVersion: "v1.30.0"},
+		"git version":    {version: "v1.29.3", major: "1", minor: "29", gitVersion: "v1.29.3"},
+		"invalid":        {version: "latest", err: true},
+		"missing minor":  {version: "1.", err: true},
+		"only the major": {version: "1", err: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data, err := Context{ClusterVersion: tc.version}.contextData()
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.major, data.ClusterVersion.Major)
+			require.Equal(t, tc.minor, data.ClusterVersion.Minor)
+			require.Equal(t, tc.gitVersion, data.ClusterVersion.GitVersion)
+			require.Equal(t, defaultName, data.CompName)
+			require.Equal(t, defaultNamespace, data.Namespace)
+			require.Equal(t, defaultAppName, data.AppName)
+			require.Equal(t, defaultAppRevision, data.AppRevisionName)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	component := parseDefinition(t, testComponentCUE)
+	trait := parseDefinition(t, testTraitCUE)
+
+	t.Run("component", func(t *testing.T) {
+		res, err := Render(component, Input{
+			Parameter: map[string]interface{}{"image": "nginx", "replicas": 2},
+			Context:   Context{Name: "web", Namespace: "prod"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Deployment", res.Output["kind"])
+		require.Equal(t, map[string]interface{}{"name": "web", "namespace": "prod"}, res.Output["metadata"])
+		require.Contains(t, res.Outputs, "service")
+		require.Nil(t, res.Status)
+	})
+
+	t.Run("component with status", func(t *testing.T) {
+		res, err := Render(component, Input{
+			Parameter:    map[string]interface{}{"image": "nginx"},
+			OutputStatus: map[string]interface{}{"readyReplicas": 1},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, res.Status)
+		require.True(t, res.Status.Healthy)
+		require.Equal(t, "ready: 1", res.Status.Message)
+	})
+
+	t.Run("component with invalid parameter", func(t *testing.T) {
+		_, err := Render(component, Input{Parameter: map[string]interface{}{"image": 1}})
+		require.Error(t, err)
+	})
+
+	t.Run("trait", func(t *testing.T) {
+		res, err := Render(trait, Input{
+			Context:  Context{AppName: "shop"},
+			Workload: map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": map[string]interface{}{"name": "web"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"name": "web", "labels": map[string]interface{}{"app": "shop"}}, res.Output["metadata"])
+		require.Empty(t, res.Outputs)
+	})
+
+	t.Run("trait without workload", func(t *testing.T) {
+		_, err := Render(trait, Input{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid workload")
+	})
+}
+
+func TestRun(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), TestsDir)
+	require.NoError(t, os.MkdirAll(dir, 0750))
+	path := filepath.Join(dir, "my-worker.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testSuiteYAML), 0600))
+	suite, err := LoadSuite(path)
+	require.NoError(t, err)
+	defs := []*Definition{{Definition: parseDefinition(t, testComponentCUE), Source: SourceCUE, Path: "my-worker.cue"}}
+
+	results, err := Run(suite, defs, Options{})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.False(t, results[0].Passed)
+	require.Error(t, results[0].Err)
+	require.Contains(t, results[0].Err.Error(), "--update")
+	require.True(t, results[1].Passed, results[1].Diff)
+
+	results, err = Run(suite, defs, Options{Update: true})
+	require.NoError(t, err)
+	require.True(t, results[0].Passed)
+	require.True(t, results[0].Updated)
+	require.True(t, results[1].Passed)
+	require.False(t, results[1].Updated)
+
+	suite, err = LoadSuite(path)
+	require.NoError(t, err)
+	require.Contains(t, suite.Cases[0].Expected, "output")
+	require.Equal(t, map[string]interface{}{"error": "image"}, suite.Cases[1].Expected)
+	results, err = Run(suite, defs, Options{})
+	require.NoError(t, err)
+	require.True(t, results[0].Passed, results[0].Diff)
+
+	suite.Cases[0].Parameter["image"] = "busybox"
+	results, err = Run(suite, defs, Options{})
+	require.NoError(t, err)
+	require.False(t, results[0].Passed)
+	require.NoError(t, results[0].Err)
+	require.Contains(t, results[0].Diff, "- image: nginx")
+	require.Contains(t, results[0].Diff, "+ image: busybox")
+
+	t.Run("update rendering errors", func(t *testing.T) {
+		path := filepath.Join(dir, "errors.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`definition: my-worker
+cases:
+  - name: undeclared error
+    parameter: {}
+  - name: declared error
+    parameter: {}
+    expectError: true
+  - name: unexpected success
+    parameter:
+      image: nginx
+    expectError: true
+`), 0600))
+		suite, err := LoadSuite(path)
+		require.NoError(t, err)
+		results, err := Run(suite, defs, Options{Update: true})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		require.False(t, results[0].Updated)
+		require.Error(t, results[0].Err)
+		require.Contains(t, results[0].Err.Error(), "set expectError to true")
+		require.True(t, results[1].Updated)
+		require.NoError(t, results[1].Err)
+		require.False(t, results[2].Updated)
+		require.Error(t, results[2].Err)
+		require.Contains(t, results[2].Err.Error(), "the case expects an error but the rendering succeeded")
+
+		suite, err = LoadSuite(path)
+		require.NoError(t, err)
+		require.Nil(t, suite.Cases[0].Expected)
+		require.Contains(t, suite.Cases[1].Expected, "error")
+		require.Nil(t, suite.Cases[2].Expected)
+	})
+
+	policy := &pkgdef.Definition{}
+	require.NoError(t, policy.SetType("policy"))
+	results, err = Run(suite, []*Definition{{Definition: policy, Source: SourceCUE}}, Options{})
+	require.NoError(t, err)
+	require.Error(t, results[0].Err)
+	require.Contains(t, results[0].Err.Error(), "only components and traits are supported")
+}
+
+func TestMatches(t *testing.T) {
+	actual := map[string]interface{}{"output": map[string]interface{}{"spec": map[string]interface{}{"replicas": float64(2)}}}
+	require.True(t, matches(map[string]interface{}{"output": map[string]interface{}{"spec": map[string]interface{}{"replicas": 2}}}, actual))
+	require.False(t, matches(map[string]interface{}{"output": map[string]interface{}{"spec": map[string]interface{}{"replicas": 3}}}, actual))
+	require.False(t, matches(map[string]interface{}{"error": "failed"}, actual))
+	require.True(t, matches(map[string]interface{}{"error": "image"}, map[string]interface{}{"error": "parameter.image: incomplete value string"}))
+}
+
+func TestDiff(t *testing.T) {
+	expected := map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 9}
+	actual := map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 10}
+	d, err := diff(expected, actual)
+	require.NoError(t, err)
+	require.Equal(t, "--- expected\n+++ actual\n  ...\n  f: 6\n  g: 7\n  h: 8\n- i: 9\n+ i: 10\n", d)
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "my-worker.cue"), []byte(testComponentCUE), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "my-labels.cue"), []byte(testTraitCUE), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, TestsDir), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, TestsDir, "my-worker.yaml"), []byte(testSuiteYAML), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, TestsDir, "my-labels.yaml"), []byte("cases: []\n"), 0600))
+
+	// neither the cue module nor the shared schemas are definitions
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "cue.mod", "pkg", "example.com", "schemas"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cue.mod", "module.cue"), []byte("module: \"example.com/defs\"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cue.mod", "pkg", "example.com", "schemas", "port.cue"), []byte("#Port: int & >0\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas.cue"), []byte("#Labels: [string]: string\n"), 0600))
+
+	defs, suites, err := Discover(context.Background(), []string{dir})
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+	require.Len(t, suites, 2)
+
+	defs, suites, err = Discover(context.Background(), []string{filepath.Join(dir, "my-worker.cue")})
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	require.Equal(t, "my-worker", defs[0].GetName())
+	require.Equal(t, SourceCUE, defs[0].Source)
+	require.Len(t, suites, 1)
+	require.Equal(t, "my-worker", suites[0].Definition)
+
+	_, _, err = Discover(context.Background(), []string{filepath.Join(dir, TestsDir, "my-worker.yaml")})
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.cue"), []byte("template: {"), 0600))
+	_, _, err = Discover(context.Background(), []string{dir})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "broken.cue")
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deftest
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/parser"
+	"github.com/pkg/errors"
+
+	pkgdef "github.com/oam-dev/kubevela/pkg/definition"
+	"github.com/oam-dev/kubevela/pkg/definition/goloader"
+)
+
+const (
+	// SourceCUE is the source of the definitions written in CUE
+	SourceCUE = "cue"
+	// SourceDefkit is the source of the definitions written in Go with defkit
+	SourceDefkit = "defkit"
+)
+
+// Definition is a definition under test
+type Definition struct {
+	*pkgdef.Definition
+	// Source is how the definition is written, i.e. cue or defkit
+	Source string
+	// Path is the file of the definition
+	Path string
+}
+
+// LoadCUEDefinition loads the CUE definition file
+func LoadCUEDefinition(path string) (*Definition, error) {
+	bs, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the definition %s", path)
+	}
+	def := &pkgdef.Definition{}
+	if err := def.FromCUEString(string(bs), nil); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the definition %s", path)
+	}
+	return &Definition{Definition: def, Source: SourceCUE, Path: path}, nil
+}
+
+// declaresDefinition tells if the CUE file declares a definition, i.e. it has a
+// top-level template field. The files failing to parse are taken as definitions
+// so that the parse errors are reported when loading them.
+func declaresDefinition(path string) (bool, error) {
+	bs, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read %s", path)
+	}
+	f, err := parser.ParseFile(path, bs)
+	if err != nil {
+		return true, nil //nolint:nilerr // the parse error is reported by LoadCUEDefinition
+	}
+	for _, decl := range f.Decls {
+		if field, ok := decl.(*ast.Field); ok {
+			if name, _, _ := ast.LabelName(field.Label); name == "template" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// LoadDefkitDefinitions loads the defkit definitions of the Go module in the directory
+func LoadDefkitDefinitions(ctx context.Context, dir string) ([]*Definition, error) {
+	module, err := goloader.LoadModule(ctx, dir, goloader.DefaultModuleLoadOptions())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the module %s", dir)
+	}
+	var defs []*Definition
+	for _, result := range module.Definitions {
+		if result.Error != nil {
+			return nil, errors.Wrapf(result.Error, "failed to load the definition %s", result.Definition.FilePath)
+		}
+		def := &pkgdef.Definition{}
+		if err := def.FromCUEString(result.CUE, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the generated CUE of %s", result.Definition.Name)
+		}
+		defs = append(defs, &Definition{Definition: def, Source: SourceDefkit, Path: result.Definition.FilePath})
+	}
+	return defs, nil
+}
+
+// Discover finds the definitions and the test files of the paths. A path is
+// either a CUE definition file, tested by the test files in the tests directory
+// next to it, or a directory. In a directory, the CUE files declaring a
+// definition out of the tests and cue.mod directories are loaded, the defkit
+// definitions are loaded if there are any Go definition files, and all the
+// test files are collected.
+func Discover(ctx context.Context, paths []string) ([]*Definition, []*Suite, error) {
+	var defs []*Definition
+	var suites []*Suite
+	loaded := map[string]bool{}
+	addSuite := func(path string, filter func(*Suite) bool) error {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve the path %s", path)
+		}
+		if loaded[abs] {
+			return nil
+		}
+		suite, err := LoadSuite(path)
+		if err != nil {
+			return err
+		}
+		if filter == nil || filter(suite) {
+			loaded[abs] = true
+			suites = append(suites, suite)
+		}
+		return nil
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read %s", path)
+		}
+		if !info.IsDir() {
+			if filepath.Ext(path) != ".cue" {
+				return nil, nil, errors.Errorf("invalid file %s, must be a cue file or a directory", path)
+			}
+			def, err := LoadCUEDefinition(path)
+			if err != nil {
+				return nil, nil, err
+			}
+			defs = append(defs, def)
+			tests, err := filepath.Glob(filepath.Join(filepath.Dir(path), TestsDir, "*.y*ml"))
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to list the test files of %s", path)
+			}
+			sort.Strings(tests)
+			for _, test := range tests {
+				if err := addSuite(test, func(s *Suite) bool { return s.Definition == def.GetName() }); err != nil {
+					return nil, nil, err
+				}
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != path && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor" || d.Name() == "cue.mod") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			switch {
+			case IsTestFile(p):
+				return addSuite(p, nil)
+			case filepath.Ext(p) == ".cue" && filepath.Base(filepath.Dir(p)) != TestsDir:
+				ok, err := declaresDefinition(p)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return nil
+				}
+				def, err := LoadCUEDefinition(p)
+				if err != nil {
+					return err
+				}
+				defs = append(defs, def)
+			default:
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		goDefs, err := goloader.DiscoverDefinitions(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(goDefs) > 0 {
+			moduleDefs, err := LoadDefkitDefinitions(ctx, path)
+			if err != nil {
+				return nil, nil, err
+			}
+			defs = append(defs, moduleDefs...)
+		}
+	}
+	return defs, suites, nil
+}
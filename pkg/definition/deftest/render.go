@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deftest
+
+import (
+	"encoding/json"
+	"strings"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevela/workflow/pkg/cue/model"
+	wfprocess "github.com/kubevela/workflow/pkg/cue/process"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/cue/definition"
+	"github.com/oam-dev/kubevela/pkg/cue/definition/health"
+	"github.com/oam-dev/kubevela/pkg/cue/process"
+	"github.com/oam-dev/kubevela/pkg/cue/upgrade"
+	pkgdef "github.com/oam-dev/kubevela/pkg/definition"
+)
+
+// the defaults of the rendering context, the same as the test context of defkit
+const (
+	defaultName           = "test-component"
+	defaultNamespace      = "default"
+	defaultAppName        = "test-app"
+	defaultAppRevision    = "test-app-v1"
+	defaultClusterVersion = "1.28"
+)
+
+// renderable are the definition types that can be rendered
+var renderable = map[string]bool{
+	"component": true,
+	"trait":     true,
+}
+
+// Input is the parameters and the context of rendering a definition
+type Input struct {
+	// Parameter is the parameter of the component or the trait
+	Parameter map[string]interface{} `yaml:"parameter,omitempty"`
+	// Context is the rendering context
+	Context Context `yaml:"context,omitempty"`
+	// Workload is the workload patched by the trait, rendered as context.output
+	Workload map[string]interface{} `yaml:"workload,omitempty"`
+	// OutputStatus is the status of the output for evaluating the health and
+	// the custom status, the status is evaluated only if it or OutputsStatus is set
+	OutputStatus map[string]interface{} `yaml:"outputStatus,omitempty"`
+	// OutputsStatus is the status of the auxiliary outputs by name
+	OutputsStatus map[string]map[string]interface{} `yaml:"outputsStatus,omitempty"`
+}
+
+// Context is the rendering context of a definition
+type Context struct {
+	// Name is the component name, default to test-component
+	Name string `yaml:"name,omitempty"`
+	// Namespace is the namespace of the application, default to default
+	Namespace string `yaml:"namespace,omitempty"`
+	// AppName is the application name, default to test-app
+	AppName string `yaml:"appName,omitempty"`
+	// AppRevision is the application revision, default to test-app-v1
+	AppRevision string `yaml:"appRevision,omitempty"`
+	// ClusterVersion is the version of the target cluster, e.g. 1.28 or v1.28.3
+	ClusterVersion string `yaml:"clusterVersion,omitempty"`
+	// AppLabels are the labels of the application
+	AppLabels map[string]string `yaml:"appLabels,omitempty"`
+	// AppAnnotations are the annotations of the application
+	AppAnnotations map[string]string `yaml:"appAnnotations,omitempty"`
+}
+
+// Result is the rendered result of a definition
+type Result struct {
+	// Output is the main workload, or the workload patched by the trait
+	Output map[string]interface{} `json:"output,omitempty"`
+	// Outputs are the auxiliary resources by name
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+	// Status is the evaluated health and custom status
+	Status *health.StatusResult `json:"status,omitempty"`
+}
+
+// contextData returns the process context data of the rendering context
+func (c Context) contextData() (process.ContextData, error) {
+	data := process.ContextData{
+		CompName:        withDefault(c.Name, defaultName),
+		Namespace:       withDefault(c.Namespace, defaultNamespace),
+		AppName:         withDefault(c.AppName, defaultAppName),
+		AppRevisionName: withDefault(c.AppRevision, defaultAppRevision),
+		AppLabels:       c.AppLabels,
+		AppAnnotations:  c.AppAnnotations,
+	}
+	version := withDefault(c.ClusterVersion, defaultClusterVersion)
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return data, errors.Errorf("invalid cluster version %q, expected major.minor, e.g. 1.28", version)
+	}
+	gitVersion := version
+	if !strings.HasPrefix(gitVersion, "v") {
+		gitVersion = "v" + gitVersion
+	}
+	if len(parts) == 2 {
+		gitVersion += ".0"
+	}
+	data.ClusterVersion = types.ClusterVersion{Major: parts[0], Minor: parts[1], GitVersion: gitVersion}
+	return data, nil
+}
+
+func withDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// Render renders the component or trait definition with the input through the
+// CUE template engine of the application controller
+func Render(def *pkgdef.Definition, in Input) (*Result, error) {
+	template, _, err := unstructured.NestedString(def.Object, pkgdef.DefinitionTemplateKeys...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the template of %s", def.GetName())
+	}
+	data, err := in.Context.contextData()
+	if err != nil {
+		return nil, err
+	}
+	ctx := process.NewContext(data)
+
+	var engine definition.AbstractEngine
+	var kind upgrade.DefinitionKind
+	auxiliaryType := definition.AuxiliaryWorkload
+	switch def.GetType() {
+	case "component":
+		engine, kind = definition.NewWorkloadAbstractEngine(def.GetName()), upgrade.ComponentKind
+	case "trait":
+		engine, kind, auxiliaryType = definition.NewTraitAbstractEngine(def.GetName()), upgrade.TraitKind, def.GetName()
+		if in.Workload != nil {
+			if err := setWorkload(ctx, in.Workload); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errors.Errorf("%s definitions cannot be rendered, only components and traits are supported", def.GetType())
+	}
+	if err := engine.Complete(ctx, template, in.Parameter); err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	base, auxiliaries := ctx.Output()
+	if base != nil {
+		obj, err := base.Unstructured()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid output of %s", def.GetName())
+		}
+		res.Output = obj.Object
+	}
+	for _, auxiliary := range auxiliaries {
+		if auxiliary.Type != auxiliaryType {
+			continue
+		}
+		obj, err := auxiliary.Ins.Unstructured()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid outputs(%s) of %s", auxiliary.Name, def.GetName())
+		}
+		if res.Outputs == nil {
+			res.Outputs = map[string]interface{}{}
+		}
+		res.Outputs[auxiliary.Name] = obj.Object
+	}
+
+	if in.OutputStatus != nil || len(in.OutputsStatus) > 0 {
+		if res.Status, err = evalStatus(def, kind, engine, ctx, res, in); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// EvalStatus evaluates the health and the custom status of the definition on
+// the output and the outputs of the result, which may be rendered by another
+// renderer, with the status and the context of the input
+func EvalStatus(def *pkgdef.Definition, res *Result, in Input) (*health.StatusResult, error) {
+	data, err := in.Context.contextData()
+	if err != nil {
+		return nil, err
+	}
+	ctx := process.NewContext(data)
+	switch def.GetType() {
+	case "component":
+		return evalStatus(def, upgrade.ComponentKind, definition.NewWorkloadAbstractEngine(def.GetName()), ctx, res, in)
+	case "trait":
+		return evalStatus(def, upgrade.TraitKind, definition.NewTraitAbstractEngine(def.GetName()), ctx, res, in)
+	default:
+		return nil, errors.Errorf("%s definitions cannot be rendered, only components and traits are supported", def.GetType())
+	}
+}
+
+// setWorkload sets the workload patched by the trait
+func setWorkload(ctx wfprocess.Context, workload map[string]interface{}) error {
+	bs, err := json.Marshal(workload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the workload")
+	}
+	v := cuecontext.New().CompileBytes(bs)
+	if v.Err() != nil {
+		return errors.Wrap(v.Err(), "failed to compile the workload")
+	}
+	base, err := model.NewBase(v)
+	if err != nil {
+		return errors.Wrap(err, "invalid workload")
+	}
+	return ctx.SetBase(base)
+}
+
+// evalStatus evaluates the health and the custom status with the template
+// context built in the same way as the application controller, except that
+// the resources are the rendered ones with the given status instead of the
+// ones in the cluster
+func evalStatus(def *pkgdef.Definition, kind upgrade.DefinitionKind, engine definition.AbstractEngine, ctx wfprocess.Context, res *Result, in Input) (*health.StatusResult, error) {
+	templateContext := map[string]interface{}{}
+	for k, v := range definition.GetBaseContextLabels(ctx) {
+		templateContext[k] = v
+	}
+	// the template context of traits only has the outputs of the trait
+	if kind == upgrade.ComponentKind && res.Output != nil {
+		templateContext[definition.OutputFieldName] = withStatus(res.Output, in.OutputStatus)
+	}
+	if len(res.Outputs) > 0 {
+		outputs := map[string]interface{}{}
+		for name, obj := range res.Outputs {
+			outputs[name] = withStatus(obj.(map[string]interface{}), in.OutputsStatus[name])
+		}
+		templateContext[definition.OutputsFieldName] = outputs
+	}
+	templateContext[process.ParameterFieldName] = in.Parameter
+
+	status, _, err := unstructured.NestedStringMap(def.Object, "spec", "status")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid status of %s", def.GetName())
+	}
+	healthCUE, _ := upgrade.EnsureCueVersionCompatibility(status["healthPolicy"], "health", kind, upgrade.TemplateAreaHealth)
+	customCUE, _ := upgrade.EnsureCueVersionCompatibility(status["customStatus"], "customStatus", kind, upgrade.TemplateAreaCustomStatus)
+	detailsCUE, _ := upgrade.EnsureCueVersionCompatibility(status["details"], "statusDetails", kind, upgrade.TemplateAreaStatusDetail)
+	return engine.Status(templateContext, &health.StatusRequest{
+		Health:    healthCUE,
+		Custom:    customCUE,
+		Details:   detailsCUE,
+		Parameter: in.Parameter,
+	})
+}
+
+// withStatus returns a shallow copy of the resource with the status
+func withStatus(obj map[string]interface{}, status map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(obj)+1)
+	for k, v := range obj {
+		res[k] = v
+	}
+	if status != nil {
+		res["status"] = status
+	}
+	return res
+}
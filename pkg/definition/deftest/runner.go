@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deftest
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/aryann/difflib"
+	"github.com/pkg/errors"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// diffContext is the number of unchanged lines printed around the changes
+const diffContext = 3
+
+// Options is the options of running the tests
+type Options struct {
+	// Update records the rendered results as the expected ones instead of
+	// comparing them
+	Update bool
+}
+
+// CaseResult is the result of running a test case against a definition
+type CaseResult struct {
+	// Suite is the test file of the case
+	Suite *Suite
+	// Case is the name of the case
+	Case string
+	// Definition is the definition under test
+	Definition *Definition
+	// Passed tells if the rendered results are the expected ones
+	Passed bool
+	// Updated tells if the expected results are updated with the rendered ones
+	Updated bool
+	// Diff is the difference between the expected and the rendered results
+	Diff string
+	// Err is the error preventing the case from being checked
+	Err error
+}
+
+// Run runs the test cases of the suite against the definitions, which are the
+// CUE and defkit versions of the definition under test. The expected results
+// are updated with the results of the first definition if opts.Update is set
+// and the test file is saved if any of them changed. A rendering error is only
+// recorded for the cases expecting an error.
+func Run(suite *Suite, defs []*Definition, opts Options) ([]CaseResult, error) {
+	var results []CaseResult
+	updated := false
+	for i, c := range suite.Cases {
+		for j, def := range defs {
+			res := CaseResult{Suite: suite, Case: c.Name, Definition: def}
+			if !renderable[def.GetType()] {
+				res.Err = errors.Errorf("%s definitions cannot be tested, only components and traits are supported", def.GetType())
+				results = append(results, res)
+				continue
+			}
+			actual, err := render(def, c.Input)
+			if err != nil {
+				res.Err = err
+				results = append(results, res)
+				continue
+			}
+			expected := suite.Cases[i].Expected
+			if opts.Update && j == 0 && (expected == nil || !matches(expected, actual)) {
+				if res.Err = checkUpdate(suite.Cases[i], actual); res.Err != nil {
+					results = append(results, res)
+					continue
+				}
+				if err := suite.SetExpected(i, actual); err != nil {
+					return nil, err
+				}
+				res.Passed, res.Updated, updated = true, true, true
+				results = append(results, res)
+				continue
+			}
+			switch {
+			case expected == nil:
+				res.Err = errors.New("no expected results, run with --update to record them")
+			case matches(expected, actual):
+				res.Passed = true
+			default:
+				if res.Diff, err = diff(expected, actual); err != nil {
+					res.Err = err
+				}
+			}
+			results = append(results, res)
+		}
+	}
+	if updated {
+		if err := suite.Save(); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// render renders the definition into the plain JSON value of the results, the
+// rendering error is recorded as the error of the results
+func render(def *Definition, in Input) (map[string]interface{}, error) {
+	res, err := Render(def.Definition, in)
+	if err != nil {
+		return map[string]interface{}{errorKey: err.Error()}, nil
+	}
+	actual, err := Normalize(res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the rendered results")
+	}
+	return actual.(map[string]interface{}), nil
+}
+
+// checkUpdate checks if the rendered results can be recorded as the expected
+// results of the case, a rendering error must be expected by the case and the
+// case expecting an error must not be rendered successfully
+func checkUpdate(c Case, actual map[string]interface{}) error {
+	msg, failed := actual[errorKey]
+	switch {
+	case failed && !c.expectsError():
+		return errors.Errorf("failed to render: %v, set expectError to true to record the error as the expected result", msg)
+	case !failed && c.expectsError():
+		return errors.New("the case expects an error but the rendering succeeded, remove the expected error to record the results")
+	default:
+		return nil
+	}
+}
+
+// matches tells if the rendered results are the expected ones, the expected
+// error only needs to be a part of the rendering error
+func matches(expected, actual map[string]interface{}) bool {
+	if msg, ok := expected[errorKey].(string); ok && len(expected) == 1 {
+		actualMsg, ok := actual[errorKey].(string)
+		return ok && strings.Contains(actualMsg, msg)
+	}
+	normalized, err := Normalize(expected)
+	return err == nil && reflect.DeepEqual(normalized, actual)
+}
+
+// Normalize converts the value into the plain JSON value, e.g. all numbers are
+// float64, so that the rendered and the expected results can be compared
+func Normalize(v interface{}) (interface{}, error) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var res interface{}
+	if err := json.Unmarshal(bs, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// diff returns the line diff between the YAML of the expected and the actual results
+func diff(expected, actual map[string]interface{}) (string, error) {
+	left, err := sigsyaml.Marshal(expected)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the expected results")
+	}
+	right, err := sigsyaml.Marshal(actual)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the rendered results")
+	}
+	records := difflib.Diff(strings.Split(strings.TrimSuffix(string(left), "\n"), "\n"), strings.Split(strings.TrimSuffix(string(right), "\n"), "\n"))
+
+	// distance from each line to the closest changed line
+	distance := make([]int, len(records))
+	last := -len(records) - diffContext
+	for i, r := range records {
+		if r.Delta != difflib.Common {
+			last = i
+		}
+		distance[i] = i - last
+	}
+	last = 2*len(records) + diffContext
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Delta != difflib.Common {
+			last = i
+		}
+		if last-i < distance[i] {
+			distance[i] = last - i
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("--- expected\n+++ actual\n")
+	skipped := false
+	for i, r := range records {
+		if distance[i] > diffContext {
+			if !skipped {
+				sb.WriteString("  ...\n")
+				skipped = true
+			}
+			continue
+		}
+		skipped = false
+		switch r.Delta {
+		case difflib.LeftOnly:
+			sb.WriteString("- " + r.Payload + "\n")
+		case difflib.RightOnly:
+			sb.WriteString("+ " + r.Payload + "\n")
+		default:
+			sb.WriteString("  " + r.Payload + "\n")
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deftest runs the declarative golden-file tests of X-Definitions.
+//
+// The tests are YAML files in a tests directory next to the definitions, each
+// of them lists the cases of one definition with the parameters, the context
+// and the expected results:
+//
+//	definition: webservice
+//	cases:
+//	  - name: default
+//	    parameter:
+//	      image: nginx
+//	    context:
+//	      clusterVersion: "1.28"
+//	    expected:
+//	      output:
+//	        apiVersion: apps/v1
+//	        kind: Deployment
+//	        ...
+//	  - name: missing image
+//	    parameter: {}
+//	    expectError: true
+//	    expected:
+//	      error: image
+//
+// The cases are rendered through the CUE template engine used by the
+// application controller, so the CUE definitions and the defkit definitions
+// (whose CUE is generated from Go) are tested the same way.
+package deftest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	// TestsDir is the directory of the test files next to the definitions
+	TestsDir = "tests"
+	// expectedKey is the key of the expected results of a case
+	expectedKey = "expected"
+	// errorKey is the key of the expected error in the expected results
+	errorKey = "error"
+)
+
+// Suite is a test file of a definition
+type Suite struct {
+	// Definition is the name of the definition under test, default to the file
+	// name without the extension
+	Definition string `yaml:"definition,omitempty"`
+	// Cases are the test cases of the definition
+	Cases []Case `yaml:"cases"`
+
+	path string
+	doc  *yaml.Node
+}
+
+// Case is a test case of a definition
+type Case struct {
+	// Name is the name of the case
+	Name string `yaml:"name"`
+	// Input is the parameters and the context of the case
+	Input `yaml:",inline"`
+	// ExpectError declares that the rendering of the case fails, so that the
+	// error can be recorded as the expected result when updating the case
+	ExpectError bool `yaml:"expectError,omitempty"`
+	// Expected is the expected results of the case, i.e. the rendered output,
+	// outputs and status, or the expected error message
+	Expected map[string]interface{} `yaml:"expected,omitempty"`
+}
+
+// expectsError tells if the rendering of the case is expected to fail, either
+// declared by expectError or by an expected error message
+func (c Case) expectsError() bool {
+	_, ok := c.Expected[errorKey]
+	return c.ExpectError || ok
+}
+
+// Path returns the path of the test file
+func (s *Suite) Path() string {
+	return s.path
+}
+
+// IsTestFile tells if the path is a test file, i.e. a YAML file in a tests directory
+func IsTestFile(path string) bool {
+	ext := filepath.Ext(path)
+	return (ext == ".yaml" || ext == ".yml") && filepath.Base(filepath.Dir(path)) == TestsDir
+}
+
+// LoadSuite loads the test file
+func LoadSuite(path string) (*Suite, error) {
+	bs, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the test file %s", path)
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(bs, doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the test file %s", path)
+	}
+	suite := &Suite{}
+	if len(doc.Content) > 0 {
+		if err := doc.Decode(suite); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the test file %s", path)
+		}
+	}
+	if suite.Definition == "" {
+		suite.Definition = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	names := map[string]bool{}
+	for i, c := range suite.Cases {
+		if c.Name == "" {
+			return nil, fmt.Errorf("the case %d of the test file %s has no name", i, path)
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("duplicated case %s in the test file %s", c.Name, path)
+		}
+		names[c.Name] = true
+	}
+	suite.path, suite.doc = path, doc
+	return suite, nil
+}
+
+// SetExpected records the expected results of the case, the test file is
+// updated by Save with the comments of the other fields kept
+func (s *Suite) SetExpected(index int, expected map[string]interface{}) error {
+	c := s.caseNode(index)
+	if c == nil {
+		return fmt.Errorf("case %d not found in the test file %s", index, s.path)
+	}
+	// marshal through JSON so that the keys are sorted and the values are plain
+	bs, err := sigsyaml.Marshal(expected)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the expected results of case %s", s.Cases[index].Name)
+	}
+	value := &yaml.Node{}
+	if err := yaml.Unmarshal(bs, value); err != nil {
+		return errors.Wrapf(err, "failed to marshal the expected results of case %s", s.Cases[index].Name)
+	}
+	for i := 0; i+1 < len(c.Content); i += 2 {
+		if c.Content[i].Value == expectedKey {
+			c.Content[i+1] = value.Content[0]
+			s.Cases[index].Expected = expected
+			return nil
+		}
+	}
+	c.Content = append(c.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: expectedKey}, value.Content[0])
+	s.Cases[index].Expected = expected
+	return nil
+}
+
+// Save writes the test file
+func (s *Suite) Save() error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(s.doc); err != nil {
+		return errors.Wrapf(err, "failed to encode the test file %s", s.path)
+	}
+	if err := encoder.Close(); err != nil {
+		return errors.Wrapf(err, "failed to encode the test file %s", s.path)
+	}
+	if err := os.WriteFile(s.path, buf.Bytes(), 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the test file %s", s.path)
+	}
+	return nil
+}
+
+// caseNode returns the mapping node of the case in the test file
+func (s *Suite) caseNode(index int) *yaml.Node {
+	if s.doc == nil || len(s.doc.Content) == 0 {
+		return nil
+	}
+	root := s.doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "cases" {
+			continue
+		}
+		cases := root.Content[i+1]
+		if cases.Kind != yaml.SequenceNode || index >= len(cases.Content) || cases.Content[index].Kind != yaml.MappingNode {
+			return nil
+		}
+		return cases.Content[index]
+	}
+	return nil
+}
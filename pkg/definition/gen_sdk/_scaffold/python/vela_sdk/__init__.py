@@ -0,0 +1 @@
+from vela_sdk.apis.common.application import ApplicationBuilder, from_application, new_application  # noqa: F401
@@ -0,0 +1,178 @@
+from __future__ import annotations
+
+import json
+from typing import Dict, List, Optional
+
+import yaml
+
+from vela_sdk.apis.common.catalog import from_component, from_policy, from_workflow_step
+from vela_sdk.apis.types import Application, Component, Policy, WorkflowMode, WorkflowStep
+
+
+class ApplicationBuilder:
+    def __init__(self) -> None:
+        self._name = ""
+        self._namespace = ""
+        self._labels: Optional[Dict[str, str]] = None
+        self._annotations: Optional[Dict[str, str]] = None
+        self._resource_version: Optional[str] = None
+
+        self._components: List[Component] = []
+        self._steps: List[WorkflowStep] = []
+        self._policies: List[Policy] = []
+        self._workflow_mode: Optional[Dict[str, WorkflowMode]] = None
+
+    def name(self, name: str) -> ApplicationBuilder:
+        self._name = name
+        return self
+
+    def namespace(self, namespace: str) -> ApplicationBuilder:
+        self._namespace = namespace
+        return self
+
+    def labels(self, labels: Dict[str, str]) -> ApplicationBuilder:
+        self._labels = labels
+        return self
+
+    def annotations(self, annotations: Dict[str, str]) -> ApplicationBuilder:
+        self._annotations = annotations
+        return self
+
+    def set_workflow_mode(self, steps: WorkflowMode, sub_steps: WorkflowMode) -> ApplicationBuilder:
+        """Set the workflow mode of the steps and the sub-steps."""
+        self._workflow_mode = {"steps": steps, "subSteps": sub_steps}
+        return self
+
+    def set_components(self, *components: Component) -> ApplicationBuilder:
+        """Set components to the application, the component with the same name is replaced."""
+        for comp in components:
+            for i, c in enumerate(self._components):
+                if c.component_name() == comp.component_name():
+                    self._components[i] = comp
+                    break
+            else:
+                self._components.append(comp)
+        return self
+
+    def set_workflow_steps(self, *steps: WorkflowStep) -> ApplicationBuilder:
+        """Set workflow steps to the application, the step with the same name is replaced."""
+        for step in steps:
+            for i, s in enumerate(self._steps):
+                if s.workflow_step_name() == step.workflow_step_name():
+                    self._steps[i] = step
+                    break
+            else:
+                self._steps.append(step)
+        return self
+
+    def set_policies(self, *policies: Policy) -> ApplicationBuilder:
+        """Set policies to the application, the policy with the same name is replaced."""
+        for policy in policies:
+            for i, p in enumerate(self._policies):
+                if p.policy_name() == policy.policy_name():
+                    self._policies[i] = policy
+                    break
+            else:
+                self._policies.append(policy)
+        return self
+
+    def get_name(self) -> str:
+        return self._name
+
+    def get_namespace(self) -> str:
+        return self._namespace
+
+    def get_labels(self) -> Optional[Dict[str, str]]:
+        return self._labels
+
+    def get_annotations(self) -> Optional[Dict[str, str]]:
+        return self._annotations
+
+    def get_component_by_name(self, name: str) -> Optional[Component]:
+        return next((c for c in self._components if c.component_name() == name), None)
+
+    def get_components_by_type(self, type_: str) -> List[Component]:
+        return [c for c in self._components if c.def_type() == type_]
+
+    def get_workflow_step_by_name(self, name: str) -> Optional[WorkflowStep]:
+        return next((s for s in self._steps if s.workflow_step_name() == name), None)
+
+    def get_workflow_steps_by_type(self, type_: str) -> List[WorkflowStep]:
+        return [s for s in self._steps if s.def_type() == type_]
+
+    def get_policy_by_name(self, name: str) -> Optional[Policy]:
+        return next((p for p in self._policies if p.policy_name() == name), None)
+
+    def get_policies_by_type(self, type_: str) -> List[Policy]:
+        return [p for p in self._policies if p.def_type() == type_]
+
+    def build(self) -> Application:
+        metadata: Dict[str, object] = {"name": self._name}
+        if self._namespace:
+            metadata["namespace"] = self._namespace
+        if self._labels is not None:
+            metadata["labels"] = self._labels
+        if self._annotations is not None:
+            metadata["annotations"] = self._annotations
+        if self._resource_version is not None:
+            metadata["resourceVersion"] = self._resource_version
+        spec: Dict[str, object] = {"components": [c.build() for c in self._components]}
+        if self._policies:
+            spec["policies"] = [p.build() for p in self._policies]
+        if self._steps or self._workflow_mode is not None:
+            workflow: Dict[str, object] = {"steps": [s.build() for s in self._steps]}
+            if self._workflow_mode is not None:
+                workflow["mode"] = self._workflow_mode
+            spec["workflow"] = workflow
+        return {
+            "apiVersion": "core.oam.dev/v1beta1",
+            "kind": "Application",
+            "metadata": metadata,
+            "spec": spec,
+        }
+
+    def to_json(self) -> str:
+        return json.dumps(self.build())
+
+    def to_yaml(self) -> str:
+        return yaml.safe_dump(self.build(), sort_keys=False)
+
+    def validate(self) -> None:
+        """Validate the application name/namespace/component/step/policy.
+
+        For component/step/policy, it validates if the required fields are set.
+        """
+        if not self._name:
+            raise ValueError("name is required")
+        if not self._namespace:
+            raise ValueError("namespace is required")
+        for c in self._components:
+            c.validate()
+        for s in self._steps:
+            s.validate()
+        for p in self._policies:
+            p.validate()
+
+    @staticmethod
+    def from_application(app: Application) -> ApplicationBuilder:
+        metadata = app.get("metadata") or {}
+        spec = app.get("spec") or {}
+        res = ApplicationBuilder().name(metadata.get("name", "")).namespace(metadata.get("namespace", ""))
+        res._labels = metadata.get("labels")
+        res._annotations = metadata.get("annotations")
+        res._resource_version = metadata.get("resourceVersion")
+        res.set_components(*[from_component(c) for c in spec.get("components") or []])
+        res.set_policies(*[from_policy(p) for p in spec.get("policies") or []])
+        workflow = spec.get("workflow")
+        if workflow is not None:
+            res.set_workflow_steps(*[from_workflow_step(s) for s in workflow.get("steps") or []])
+            res._workflow_mode = workflow.get("mode")
+        return res
+
+
+def new_application() -> ApplicationBuilder:
+    return ApplicationBuilder()
+
+
+def from_application(app: Application) -> ApplicationBuilder:
+    return ApplicationBuilder.from_application(app)
@@ -0,0 +1,68 @@
+from __future__ import annotations
+
+from typing import Callable, Dict
+
+from vela_sdk.apis.types import (
+    ApplicationComponent,
+    ApplicationPolicy,
+    ApplicationTrait,
+    ApplicationWorkflowStep,
+    Component,
+    Policy,
+    Trait,
+    WorkflowStep,
+)
+
+ComponentConstructor = Callable[[ApplicationComponent], Component]
+TraitConstructor = Callable[[ApplicationTrait], Trait]
+WorkflowStepConstructor = Callable[[ApplicationWorkflowStep], WorkflowStep]
+PolicyConstructor = Callable[[ApplicationPolicy], Policy]
+
+component_builders: Dict[str, ComponentConstructor] = {}
+trait_builders: Dict[str, TraitConstructor] = {}
+workflow_step_builders: Dict[str, WorkflowStepConstructor] = {}
+policy_builders: Dict[str, PolicyConstructor] = {}
+
+
+def register_component(type_: str, c: ComponentConstructor) -> None:
+    component_builders[type_] = c
+
+
+def register_trait(type_: str, c: TraitConstructor) -> None:
+    trait_builders[type_] = c
+
+
+def register_workflow_step(type_: str, c: WorkflowStepConstructor) -> None:
+    workflow_step_builders[type_] = c
+
+
+def register_policy(type_: str, c: PolicyConstructor) -> None:
+    policy_builders[type_] = c
+
+
+def from_component(comp: ApplicationComponent) -> Component:
+    build = component_builders.get(comp["type"])
+    if build is None:
+        raise ValueError(f"no component type {comp['type']} registered")
+    return build(comp)
+
+
+def from_trait(trait: ApplicationTrait) -> Trait:
+    build = trait_builders.get(trait["type"])
+    if build is None:
+        raise ValueError(f"no trait type {trait['type']} registered")
+    return build(trait)
+
+
+def from_workflow_step(step: ApplicationWorkflowStep) -> WorkflowStep:
+    build = workflow_step_builders.get(step["type"])
+    if build is None:
+        raise ValueError(f"no workflow step type {step['type']} registered")
+    return build(step)
+
+
+def from_policy(policy: ApplicationPolicy) -> Policy:
+    build = policy_builders.get(policy["type"])
+    if build is None:
+        raise ValueError(f"no policy type {policy['type']} registered")
+    return build(policy)
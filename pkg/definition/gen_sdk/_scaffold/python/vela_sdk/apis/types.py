@@ -0,0 +1,150 @@
+from __future__ import annotations
+
+import abc
+from dataclasses import dataclass, field
+from typing import Any, Dict, List, Literal, Optional, TypedDict
+
+WorkflowMode = Literal["StepByStep", "DAG"]
+
+StepInput = TypedDict("StepInput", {
+    "from": str,
+    "parameterKey": str,
+}, total=False)
+
+StepOutput = TypedDict("StepOutput", {
+    "name": str,
+    "valueFrom": str,
+}, total=False)
+
+WorkflowStepMeta = TypedDict("WorkflowStepMeta", {
+    "alias": str,
+}, total=False)
+
+ApplicationTrait = TypedDict("ApplicationTrait", {
+    "type": str,
+    "properties": Dict[str, Any],
+}, total=False)
+
+ApplicationComponent = TypedDict("ApplicationComponent", {
+    "name": str,
+    "type": str,
+    "properties": Dict[str, Any],
+    "traits": List[ApplicationTrait],
+    "dependsOn": List[str],
+    "inputs": List[StepInput],
+    "outputs": List[StepOutput],
+}, total=False)
+
+ApplicationWorkflowStep = TypedDict("ApplicationWorkflowStep", {
+    "name": str,
+    "type": str,
+    "meta": WorkflowStepMeta,
+    "properties": Dict[str, Any],
+    "subSteps": List["ApplicationWorkflowStep"],
+    "if": str,
+    "timeout": str,
+    "dependsOn": List[str],
+    "inputs": List[StepInput],
+    "outputs": List[StepOutput],
+}, total=False)
+
+ApplicationPolicy = TypedDict("ApplicationPolicy", {
+    "name": str,
+    "type": str,
+    "properties": Dict[str, Any],
+}, total=False)
+
+Application = Dict[str, Any]
+
+
+class Component(abc.ABC):
+    @abc.abstractmethod
+    def component_name(self) -> str: ...
+
+    @abc.abstractmethod
+    def def_type(self) -> str: ...
+
+    @abc.abstractmethod
+    def build(self) -> ApplicationComponent: ...
+
+    @abc.abstractmethod
+    def get_trait(self, type_: str) -> Optional[Trait]: ...
+
+    @abc.abstractmethod
+    def get_all_traits(self) -> List[Trait]: ...
+
+    @abc.abstractmethod
+    def validate(self) -> None: ...
+
+
+class Trait(abc.ABC):
+    @abc.abstractmethod
+    def def_type(self) -> str: ...
+
+    @abc.abstractmethod
+    def build(self) -> ApplicationTrait: ...
+
+    @abc.abstractmethod
+    def validate(self) -> None: ...
+
+
+class WorkflowStep(abc.ABC):
+    @abc.abstractmethod
+    def workflow_step_name(self) -> str: ...
+
+    @abc.abstractmethod
+    def def_type(self) -> str: ...
+
+    @abc.abstractmethod
+    def build(self) -> ApplicationWorkflowStep: ...
+
+    @abc.abstractmethod
+    def validate(self) -> None: ...
+
+
+class Policy(abc.ABC):
+    @abc.abstractmethod
+    def policy_name(self) -> str: ...
+
+    @abc.abstractmethod
+    def def_type(self) -> str: ...
+
+    @abc.abstractmethod
+    def build(self) -> ApplicationPolicy: ...
+
+    @abc.abstractmethod
+    def validate(self) -> None: ...
+
+
+@dataclass
+class ComponentBase:
+    name: str
+    type: str
+    depends_on: Optional[List[str]] = None
+    inputs: Optional[List[StepInput]] = None
+    outputs: Optional[List[StepOutput]] = None
+    traits: List[Trait] = field(default_factory=list)
+
+
+@dataclass
+class TraitBase:
+    type: str
+
+
+@dataclass
+class WorkflowStepBase:
+    name: str
+    type: str
+    meta: Optional[WorkflowStepMeta] = None
+    sub_steps: List[WorkflowStep] = field(default_factory=list)
+    if_: Optional[str] = None
+    timeout: Optional[str] = None
+    depends_on: Optional[List[str]] = None
+    inputs: Optional[List[StepInput]] = None
+    outputs: Optional[List[StepOutput]] = None
+
+
+@dataclass
+class PolicyBase:
+    name: str
+    type: str
@@ -0,0 +1,200 @@
+import { stringify } from "yaml";
+
+import { Application, Component, Policy, WorkflowMode, WorkflowStep } from "../types";
+import { fromComponent, fromPolicy, fromWorkflowStep } from "./catalog";
+
+export class ApplicationBuilder {
+  private appName = "";
+  private appNamespace = "";
+  private appLabels?: Record<string, string>;
+  private appAnnotations?: Record<string, string>;
+  private resourceVersion?: string;
+
+  private components: Component[] = [];
+  private steps: WorkflowStep[] = [];
+  private policies: Policy[] = [];
+  private workflowMode?: { steps?: WorkflowMode; subSteps?: WorkflowMode };
+
+  name(name: string): this {
+    this.appName = name;
+    return this;
+  }
+
+  namespace(namespace: string): this {
+    this.appNamespace = namespace;
+    return this;
+  }
+
+  labels(labels: Record<string, string>): this {
+    this.appLabels = labels;
+    return this;
+  }
+
+  annotations(annotations: Record<string, string>): this {
+    this.appAnnotations = annotations;
+    return this;
+  }
+
+  // setWorkflowMode sets the workflow mode of the steps and the sub-steps
+  setWorkflowMode(steps: WorkflowMode, subSteps: WorkflowMode): this {
+    this.workflowMode = { steps, subSteps };
+    return this;
+  }
+
+  // setComponents sets components to the application, the component with the same name is replaced
+  setComponents(...components: Component[]): this {
+    for (const comp of components) {
+      const i = this.components.findIndex((c) => c.componentName() === comp.componentName());
+      if (i >= 0) {
+        this.components[i] = comp;
+      } else {
+        this.components.push(comp);
+      }
+    }
+    return this;
+  }
+
+  // setWorkflowSteps sets workflow steps to the application, the step with the same name is replaced
+  setWorkflowSteps(...steps: WorkflowStep[]): this {
+    for (const step of steps) {
+      const i = this.steps.findIndex((s) => s.workflowStepName() === step.workflowStepName());
+      if (i >= 0) {
+        this.steps[i] = step;
+      } else {
+        this.steps.push(step);
+      }
+    }
+    return this;
+  }
+
+  // setPolicies sets policies to the application, the policy with the same name is replaced
+  setPolicies(...policies: Policy[]): this {
+    for (const policy of policies) {
+      const i = this.policies.findIndex((p) => p.policyName() === policy.policyName());
+      if (i >= 0) {
+        this.policies[i] = policy;
+      } else {
+        this.policies.push(policy);
+      }
+    }
+    return this;
+  }
+
+  getName(): string {
+    return this.appName;
+  }
+
+  getNamespace(): string {
+    return this.appNamespace;
+  }
+
+  getLabels(): Record<string, string> | undefined {
+    return this.appLabels;
+  }
+
+  getAnnotations(): Record<string, string> | undefined {
+    return this.appAnnotations;
+  }
+
+  getComponentByName(name: string): Component | undefined {
+    return this.components.find((c) => c.componentName() === name);
+  }
+
+  getComponentsByType(type: string): Component[] {
+    return this.components.filter((c) => c.defType() === type);
+  }
+
+  getWorkflowStepByName(name: string): WorkflowStep | undefined {
+    return this.steps.find((s) => s.workflowStepName() === name);
+  }
+
+  getWorkflowStepsByType(type: string): WorkflowStep[] {
+    return this.steps.filter((s) => s.defType() === type);
+  }
+
+  getPolicyByName(name: string): Policy | undefined {
+    return this.policies.find((p) => p.policyName() === name);
+  }
+
+  getPoliciesByType(type: string): Policy[] {
+    return this.policies.filter((p) => p.defType() === type);
+  }
+
+  build(): Application {
+    const app: Application = {
+      apiVersion: "core.oam.dev/v1beta1",
+      kind: "Application",
+      metadata: { name: this.appName },
+      spec: { components: this.components.map((c) => c.build()) },
+    };
+    if (this.appNamespace !== "") {
+      app.metadata.namespace = this.appNamespace;
+    }
+    if (this.appLabels !== undefined) {
+      app.metadata.labels = this.appLabels;
+    }
+    if (this.appAnnotations !== undefined) {
+      app.metadata.annotations = this.appAnnotations;
+    }
+    if (this.resourceVersion !== undefined) {
+      app.metadata.resourceVersion = this.resourceVersion;
+    }
+    if (this.policies.length > 0) {
+      app.spec.policies = this.policies.map((p) => p.build());
+    }
+    if (this.steps.length > 0 || this.workflowMode !== undefined) {
+      app.spec.workflow = { steps: this.steps.map((s) => s.build()) };
+      if (this.workflowMode !== undefined) {
+        app.spec.workflow.mode = this.workflowMode;
+      }
+    }
+    return app;
+  }
+
+  toJSONString(): string {
+    return JSON.stringify(this.build());
+  }
+
+  toYAML(): string {
+    return stringify(this.build());
+  }
+
+  // validate validates the application name/namespace/component/step/policy.
+  // For component/step/policy, it validates if the required fields are set.
+  validate(): void {
+    if (this.appName === "") {
+      throw new Error("name is required");
+    }
+    if (this.appNamespace === "") {
+      throw new Error("namespace is required");
+    }
+    this.components.forEach((c) => c.validate());
+    this.steps.forEach((s) => s.validate());
+    this.policies.forEach((p) => p.validate());
+  }
+
+  static fromApplication(app: Application): ApplicationBuilder {
+    const res = new ApplicationBuilder().name(app.metadata.name);
+    if (app.metadata.namespace !== undefined) {
+      res.namespace(app.metadata.namespace);
+    }
+    res.appLabels = app.metadata.labels;
+    res.appAnnotations = app.metadata.annotations;
+    res.resourceVersion = app.metadata.resourceVersion;
+    res.setComponents(...app.spec.components.map(fromComponent));
+    res.setPolicies(...(app.spec.policies ?? []).map(fromPolicy));
+    if (app.spec.workflow !== undefined) {
+      res.setWorkflowSteps(...app.spec.workflow.steps.map(fromWorkflowStep));
+      res.workflowMode = app.spec.workflow.mode;
+    }
+    return res;
+  }
+}
+
+export function newApplication(): ApplicationBuilder {
+  return new ApplicationBuilder();
+}
+
+export function fromApplication(app: Application): ApplicationBuilder {
+  return ApplicationBuilder.fromApplication(app);
+}
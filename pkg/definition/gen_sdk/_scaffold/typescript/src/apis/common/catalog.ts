@@ -0,0 +1,68 @@
+import {
+  ApplicationComponent,
+  ApplicationPolicy,
+  ApplicationTrait,
+  ApplicationWorkflowStep,
+  Component,
+  Policy,
+  Trait,
+  WorkflowStep,
+} from "../types";
+
+export type ComponentConstructor = (comp: ApplicationComponent) => Component;
+export type TraitConstructor = (trait: ApplicationTrait) => Trait;
+export type WorkflowStepConstructor = (step: ApplicationWorkflowStep) => WorkflowStep;
+export type PolicyConstructor = (policy: ApplicationPolicy) => Policy;
+
+const componentBuilders = new Map<string, ComponentConstructor>();
+const traitBuilders = new Map<string, TraitConstructor>();
+const workflowStepBuilders = new Map<string, WorkflowStepConstructor>();
+const policyBuilders = new Map<string, PolicyConstructor>();
+
+export function registerComponent(type: string, c: ComponentConstructor): void {
+  componentBuilders.set(type, c);
+}
+
+export function registerTrait(type: string, c: TraitConstructor): void {
+  traitBuilders.set(type, c);
+}
+
+export function registerWorkflowStep(type: string, c: WorkflowStepConstructor): void {
+  workflowStepBuilders.set(type, c);
+}
+
+export function registerPolicy(type: string, c: PolicyConstructor): void {
+  policyBuilders.set(type, c);
+}
+
+export function fromComponent(comp: ApplicationComponent): Component {
+  const build = componentBuilders.get(comp.type);
+  if (build === undefined) {
+    throw new Error(`no component type ${comp.type} registered`);
+  }
+  return build(comp);
+}
+
+export function fromTrait(trait: ApplicationTrait): Trait {
+  const build = traitBuilders.get(trait.type);
+  if (build === undefined) {
+    throw new Error(`no trait type ${trait.type} registered`);
+  }
+  return build(trait);
+}
+
+export function fromWorkflowStep(step: ApplicationWorkflowStep): WorkflowStep {
+  const build = workflowStepBuilders.get(step.type);
+  if (build === undefined) {
+    throw new Error(`no workflow step type ${step.type} registered`);
+  }
+  return build(step);
+}
+
+export function fromPolicy(policy: ApplicationPolicy): Policy {
+  const build = policyBuilders.get(policy.type);
+  if (build === undefined) {
+    throw new Error(`no policy type ${policy.type} registered`);
+  }
+  return build(policy);
+}
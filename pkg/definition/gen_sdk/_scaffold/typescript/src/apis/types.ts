@@ -0,0 +1,131 @@
+export type WorkflowMode = "StepByStep" | "DAG";
+
+export interface StepInput {
+  from: string;
+  parameterKey?: string;
+}
+
+export interface StepOutput {
+  name: string;
+  valueFrom: string;
+}
+
+export interface WorkflowStepMeta {
+  alias?: string;
+}
+
+export interface ApplicationTrait {
+  type: string;
+  properties?: Record<string, any>;
+}
+
+export interface ApplicationComponent {
+  name: string;
+  type: string;
+  properties?: Record<string, any>;
+  traits?: ApplicationTrait[];
+  dependsOn?: string[];
+  inputs?: StepInput[];
+  outputs?: StepOutput[];
+}
+
+export interface ApplicationWorkflowStep {
+  name: string;
+  type: string;
+  meta?: WorkflowStepMeta;
+  properties?: Record<string, any>;
+  subSteps?: ApplicationWorkflowStep[];
+  if?: string;
+  timeout?: string;
+  dependsOn?: string[];
+  inputs?: StepInput[];
+  outputs?: StepOutput[];
+}
+
+export interface ApplicationPolicy {
+  name: string;
+  type: string;
+  properties?: Record<string, any>;
+}
+
+export interface Application {
+  apiVersion: string;
+  kind: string;
+  metadata: {
+    name: string;
+    namespace?: string;
+    labels?: Record<string, string>;
+    annotations?: Record<string, string>;
+    resourceVersion?: string;
+  };
+  spec: {
+    components: ApplicationComponent[];
+    policies?: ApplicationPolicy[];
+    workflow?: {
+      steps: ApplicationWorkflowStep[];
+      mode?: {
+        steps?: WorkflowMode;
+        subSteps?: WorkflowMode;
+      };
+    };
+  };
+}
+
+export interface Component {
+  componentName(): string;
+  defType(): string;
+  build(): ApplicationComponent;
+  getTrait(type: string): Trait | undefined;
+  getAllTraits(): Trait[];
+  validate(): void;
+}
+
+export interface Trait {
+  defType(): string;
+  build(): ApplicationTrait;
+  validate(): void;
+}
+
+export interface WorkflowStep {
+  workflowStepName(): string;
+  defType(): string;
+  build(): ApplicationWorkflowStep;
+  validate(): void;
+}
+
+export interface Policy {
+  policyName(): string;
+  defType(): string;
+  build(): ApplicationPolicy;
+  validate(): void;
+}
+
+export interface ComponentBase {
+  name: string;
+  type: string;
+  dependsOn?: string[];
+  inputs?: StepInput[];
+  outputs?: StepOutput[];
+  traits: Trait[];
+}
+
+export interface TraitBase {
+  type: string;
+}
+
+export interface WorkflowStepBase {
+  name: string;
+  type: string;
+  meta?: WorkflowStepMeta;
+  subSteps: WorkflowStep[];
+  if?: string;
+  timeout?: string;
+  dependsOn?: string[];
+  inputs?: StepInput[];
+  outputs?: StepOutput[];
+}
+
+export interface PolicyBase {
+  name: string;
+  type: string;
+}
@@ -0,0 +1,4 @@
+export * from "./apis/types";
+export * from "./apis/common/catalog";
+export * from "./apis/common/application";
+export * from "./apis";
@@ -23,9 +23,9 @@ var (
 	// Templates contains different template files for different languages
 	Templates embed.FS
 	// SupportedLangs is supported languages
-	SupportedLangs = map[string]bool{"go": true}
-	//go:embed _scaffold
-	// Scaffold is scaffold files for different languages
+	SupportedLangs = map[string]bool{"go": true, "typescript": true, "python": true}
+	//go:embed all:_scaffold
+	// Scaffold is scaffold files for different languages, "all:" keeps the __init__.py files of Python
 	Scaffold embed.FS
 	// ScaffoldDir is scaffold dir name
 	ScaffoldDir = "_scaffold"
@@ -54,7 +54,13 @@ type byteHandler func([]byte) []byte
 
 var (
 	defaultAPIDir = map[string]string{
-		"go": "pkg/apis",
+		"go":         "pkg/apis",
+		"typescript": tsRuntimeDir,
+	}
+	// defaultPackage is the package name used when --package is not set for the language
+	defaultPackage = map[string]string{
+		"typescript": TSPackagePlaceHolder,
+		"python":     PyPackagePlaceHolder,
 	}
 	// LangArgsRegistry is used to store the argument info
 	LangArgsRegistry = map[string]map[langArgKey]LangArg{}
@@ -62,9 +68,10 @@ var (
 
 // GenMeta stores the metadata for generator.
 type GenMeta struct {
-	config *rest.Config
-	name   string
-	kind   string
+	config      *rest.Config
+	name        string
+	kind        string
+	description string
 
 	Output       string
 	APIDirectory string
@@ -88,6 +95,7 @@ type Generator struct {
 	meta          *GenMeta
 	def           definition.Definition
 	openapiSchema []byte
+	openapiDoc    *openapi3.T
 	// defModifiers are the modifiers for each definition.
 	defModifiers []Modifier
 	// moduleModifiers are the modifiers for the whole module. It will be executed after generating all definitions.
@@ -183,8 +191,20 @@ func (meta *GenMeta) Init(c common.Args, langArgs []string) (err error) {
 	}
 
 	// Init arguments
+	if pkg, ok := defaultPackage[meta.Lang]; ok && (meta.Package == "" || meta.Package == PackagePlaceHolder) {
+		meta.Package = pkg
+	}
+	if meta.Lang != "go" && meta.IsSubModule {
+		return fmt.Errorf("sub-module is only supported for language go")
+	}
+	if meta.Lang == "python" && !pyIdentifier.MatchString(meta.Package) {
+		return fmt.Errorf("package %s is not a valid Python package name", meta.Package)
+	}
 	if meta.APIDirectory == "" {
 		meta.APIDirectory = defaultAPIDir[meta.Lang]
+		if meta.Lang == "python" {
+			meta.APIDirectory = path.Join(meta.Package, "apis")
+		}
 	}
 
 	meta.LangArgs, err = NewLanguageArgs(meta.Lang, langArgs)
@@ -195,6 +215,12 @@ func (meta *GenMeta) Init(c common.Args, langArgs []string) (err error) {
 		"go": func(b []byte) []byte {
 			return bytes.ReplaceAll(b, []byte(PackagePlaceHolder), []byte(meta.Package))
 		},
+		"typescript": func(b []byte) []byte {
+			return bytes.ReplaceAll(b, []byte(TSPackagePlaceHolder), []byte(meta.Package))
+		},
+		"python": func(b []byte) []byte {
+			return bytes.ReplaceAll(b, []byte(PyPackagePlaceHolder), []byte(meta.Package))
+		},
 	}
 
 	meta.packageFunc = packageFuncs[meta.Lang]
@@ -250,7 +276,8 @@ func (meta *GenMeta) CreateScaffold() error {
 			return err
 		}
 		fileContent = meta.packageFunc(fileContent)
-		fileName := path.Join(meta.Output, strings.TrimPrefix(_path, langDirPrefix))
+		// the package placeholder can be in the path too, e.g. the package directory of Python
+		fileName := path.Join(meta.Output, string(meta.packageFunc([]byte(strings.TrimPrefix(_path, langDirPrefix)))))
 		// go.mod_ is a special file name, it will be renamed to go.mod. Go will ignore directory containing go.mod during the build process.
 		fileName = strings.ReplaceAll(fileName, "go.mod_", "go.mod")
 		fileDir := path.Dir(fileName)
@@ -264,6 +291,9 @@ func (meta *GenMeta) CreateScaffold() error {
 
 // PrepareGeneratorAndTemplate will make a copy of the embedded openapi-generator-cli and templates/{meta.Lang} to local
 func (meta *GenMeta) PrepareGeneratorAndTemplate() error {
+	if !usesOpenAPIGenerator(meta.Lang) {
+		return nil
+	}
 	var err error
 	ogImageName := "openapitools/openapi-generator-cli"
 	ogImageTag := "v6.3.0"
@@ -349,6 +379,7 @@ func (meta *GenMeta) Run(ctx context.Context) error {
 			return err
 		}
 		g.meta.SetDefinition(defName, defKind)
+		g.meta.description = g.def.GetAnnotations()[definition.DescriptionKey]
 
 		err = g.GenOpenAPISchema(template)
 		if err != nil {
@@ -450,6 +481,7 @@ func (g *Generator) GenOpenAPISchema(val cue.Value) error {
 		return err
 	}
 
+	g.openapiDoc = doc
 	openapiSchema, err := doc.MarshalJSON()
 	g.openapiSchema = openapiSchema
 	if g.meta.Verbose {
@@ -486,8 +518,12 @@ func (g *Generator) completeOpenAPISchema(doc *openapi3.T) error {
 	return nil
 }
 
-// GenerateCode will call openapi-generator to generate code and modify it
+// GenerateCode will call openapi-generator to generate code and modify it.
+// The languages with a native renderer are rendered from the OpenAPI schema directly.
 func (g *Generator) GenerateCode() (err error) {
+	if render, ok := nativeRenderers[g.meta.Lang]; ok {
+		return g.generateNativeCode(render)
+	}
 	tmpFile, err := os.CreateTemp("", g.meta.name+"-*.json")
 	if err != nil {
 		return err
@@ -704,6 +740,10 @@ func appendModifiersByLanguage(g *Generator, meta *GenMeta) {
 	case "go":
 		g.defModifiers = append(g.defModifiers, &GoDefModifier{GenMeta: meta})
 		g.moduleModifiers = append(g.moduleModifiers, &GoModuleModifier{GenMeta: meta})
+	case "typescript":
+		g.moduleModifiers = append(g.moduleModifiers, &TSModuleModifier{GenMeta: meta})
+	case "python":
+		g.moduleModifiers = append(g.moduleModifiers, &PyModuleModifier{GenMeta: meta})
 	default:
 		panic(fmt.Sprintf("unsupported language: %s", meta.Lang))
 	}
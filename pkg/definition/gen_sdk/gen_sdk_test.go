@@ -132,6 +132,79 @@ var _ = Describe("Test Generating SDK", func() {
 
 })
 
+var _ = Describe("Test Generating TypeScript and Python SDK", func() {
+	genWithMeta := func(meta *GenMeta) {
+		Expect(meta.Init(common.Args{}, nil)).Should(Succeed())
+		Expect(meta.CreateScaffold()).Should(Succeed())
+		Expect(meta.PrepareGeneratorAndTemplate()).Should(Succeed())
+		Expect(meta.Run(context.Background())).Should(Succeed())
+	}
+	readFile := func(file string) string {
+		content, err := os.ReadFile(file)
+		Expect(err).Should(BeNil())
+		return string(content)
+	}
+	files := []string{
+		filepath.Join("testdata", "cron-task.cue"),
+		filepath.Join("testdata", "json-merge-patch.cue"),
+		filepath.Join("testdata", "step-group.cue"),
+		filepath.Join("testdata", "one_of.cue"),
+		filepath.Join("testdata", "shared-resource.cue"),
+	}
+
+	It("Test generating TypeScript SDK", func() {
+		outputDir := GinkgoT().TempDir()
+		genWithMeta(&GenMeta{Output: outputDir, Lang: "typescript", Package: PackagePlaceHolder, File: files, InitSDK: true})
+
+		Expect(readFile(filepath.Join(outputDir, "package.json"))).Should(ContainSubstring(`"name": "vela-sdk"`))
+		apiDir := filepath.Join(outputDir, "src", "apis")
+		Expect(readFile(filepath.Join(apiDir, "index.ts"))).Should(ContainSubstring(`export * as workflowStep from "./workflow-step";`))
+		Expect(readFile(filepath.Join(apiDir, "component", "index.ts"))).Should(ContainSubstring(`export * from "./cron-task";`))
+
+		cronTask := readFile(filepath.Join(apiDir, "component", "cron-task.ts"))
+		Expect(cronTask).Should(ContainSubstring("export class CronTaskComponent implements Component {"))
+		Expect(cronTask).Should(ContainSubstring("setSchedule(value: string): this {"))
+		Expect(cronTask).Should(ContainSubstring("registerComponent(CronTaskType, CronTaskComponent.fromComponent);"))
+
+		By("check the free form parameter")
+		Expect(readFile(filepath.Join(apiDir, "trait", "json-merge-patch.ts"))).Should(ContainSubstring("export type JsonMergePatchSpec = Record<string, any>;"))
+		By("check the oneOf parameter")
+		Expect(readFile(filepath.Join(apiDir, "workflow-step", "one_of.ts"))).Should(ContainSubstring("OneOfSpecLarkUrlOption1 | OneOfSpecLarkUrlOption2"))
+		By("check if addSubStep is generated")
+		Expect(readFile(filepath.Join(apiDir, "workflow-step", "step-group.ts"))).Should(ContainSubstring("addSubStep(subStep: WorkflowStep): this {"))
+		Expect(readFile(filepath.Join(apiDir, "policy", "shared-resource.ts"))).Should(ContainSubstring("export class SharedResourcePolicy implements Policy {"))
+	})
+
+	It("Test generating Python SDK", func() {
+		outputDir := GinkgoT().TempDir()
+		genWithMeta(&GenMeta{Output: outputDir, Lang: "python", Package: "my_sdk", File: files, InitSDK: true})
+
+		Expect(readFile(filepath.Join(outputDir, "pyproject.toml"))).Should(ContainSubstring(`name = "my_sdk"`))
+		Expect(readFile(filepath.Join(outputDir, "my_sdk", "__init__.py"))).Should(ContainSubstring("from my_sdk.apis.common.application import"))
+		apiDir := filepath.Join(outputDir, "my_sdk", "apis")
+		Expect(readFile(filepath.Join(apiDir, "__init__.py"))).Should(ContainSubstring("from . import component, trait, workflow_step, policy"))
+		Expect(readFile(filepath.Join(apiDir, "component", "__init__.py"))).Should(ContainSubstring("from .cron_task import *"))
+
+		cronTask := readFile(filepath.Join(apiDir, "component", "cron_task.py"))
+		Expect(cronTask).Should(ContainSubstring("from my_sdk.apis.types import"))
+		Expect(cronTask).Should(ContainSubstring("class CronTaskComponent(Component):"))
+		Expect(cronTask).Should(ContainSubstring("def set_schedule(self, value: str) -> CronTaskComponent:"))
+		Expect(cronTask).Should(ContainSubstring("register_component(CRON_TASK_TYPE, CronTaskComponent.from_component)"))
+
+		By("check the free form parameter")
+		Expect(readFile(filepath.Join(apiDir, "trait", "json_merge_patch.py"))).Should(ContainSubstring("JsonMergePatchSpec = Dict[str, Any]"))
+		By("check the oneOf parameter")
+		Expect(readFile(filepath.Join(apiDir, "workflow_step", "one_of.py"))).Should(ContainSubstring(`Union["OneOfSpecLarkUrlOption1", "OneOfSpecLarkUrlOption2"]`))
+		By("check if add_sub_step is generated")
+		Expect(readFile(filepath.Join(apiDir, "workflow_step", "step_group.py"))).Should(ContainSubstring("def add_sub_step(self, sub_step: WorkflowStep) -> StepGroupWorkflowStep:"))
+	})
+
+	It("Test invalid Python package name", func() {
+		meta := &GenMeta{Output: GinkgoT().TempDir(), Lang: "python", Package: "my-sdk"}
+		Expect(meta.Init(common.Args{}, nil)).Should(MatchError(ContainSubstring("not a valid Python package name")))
+	})
+})
+
 var _ = AfterSuite(func() {
 	By("Cleaning up generated files")
 	_ = os.RemoveAll(_outputDir)
@@ -0,0 +1,385 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gen_sdk
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ettle/strcase"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+// codeRenderer renders the API file of a definition from its OpenAPI schema.
+// It returns the file path relative to the API directory and the content.
+type codeRenderer func(meta *GenMeta, doc *openapi3.T) (string, []byte, error)
+
+// nativeRenderers are the languages generated from the OpenAPI schema directly,
+// without openapi-generator
+var nativeRenderers = map[string]codeRenderer{
+	"typescript": renderTypeScript,
+	"python":     renderPython,
+}
+
+// usesOpenAPIGenerator tells if the language is generated by openapi-generator
+func usesOpenAPIGenerator(lang string) bool {
+	_, ok := nativeRenderers[lang]
+	return !ok
+}
+
+// generateNativeCode renders the API file of the definition and writes it into the API directory
+func (g *Generator) generateNativeCode(render codeRenderer) error {
+	file, content, err := render(g.meta, g.openapiDoc)
+	if err != nil {
+		return errors.Wrapf(err, "render %s code of %s", g.meta.Lang, g.meta.name)
+	}
+	loc := path.Join(g.meta.Output, g.meta.APIDirectory, file)
+	if err = os.MkdirAll(filepath.Dir(loc), 0750); err != nil {
+		return errors.Wrapf(err, "create directory of %s", loc)
+	}
+	if g.meta.Verbose {
+		klog.Infof("Writing %s", loc)
+	}
+	if err = os.WriteFile(loc, content, 0600); err != nil {
+		return errors.Wrapf(err, "write %s", loc)
+	}
+	for _, m := range g.defModifiers {
+		if err := m.Modify(); err != nil {
+			return errors.Wrapf(err, "modify fail by %s", m.Name())
+		}
+	}
+	return nil
+}
+
+// namedType is an object type in the parameter schema, which is rendered as
+// an interface in TypeScript or a TypedDict in Python
+type namedType struct {
+	name        string
+	description string
+	fields      []typeField
+}
+
+// typeField is a property of a named type
+type typeField struct {
+	name        string
+	description string
+	required    bool
+	schema      *openapi3.SchemaRef
+}
+
+// typeCollector names the object types of the parameter schema. The types are
+// collected children first, so that they are declared before being used.
+type typeCollector struct {
+	prefix string
+	names  map[*openapi3.Schema]string
+	used   map[string]bool
+	types  []*namedType
+}
+
+func newTypeCollector(prefix string) *typeCollector {
+	return &typeCollector{prefix: prefix, names: map[*openapi3.Schema]string{}, used: map[string]bool{}}
+}
+
+// collect names the object types in the schema, name is used for the schema
+// itself if it is an object type, and as the prefix of the nested types
+func (c *typeCollector) collect(name string, ref *openapi3.SchemaRef) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	s := ref.Value
+	if _, ok := c.names[s]; ok {
+		return
+	}
+	if ref.Ref != "" {
+		name = c.prefix + identPascal(path.Base(ref.Ref))
+	}
+	for _, items := range []openapi3.SchemaRefs{s.OneOf, s.AnyOf, s.AllOf} {
+		for i, item := range items {
+			c.collect(fmt.Sprintf("%sOption%d", name, i+1), item)
+		}
+	}
+	switch {
+	case s.Type.Is(openapi3.TypeArray):
+		c.collect(name, s.Items)
+	case len(s.Properties) > 0:
+		t := &namedType{name: c.unique(name), description: s.Description}
+		c.names[s] = t.name
+		required := map[string]bool{}
+		for _, r := range s.Required {
+			required[r] = true
+		}
+		for _, prop := range sortedKeys(s.Properties) {
+			field := s.Properties[prop]
+			c.collect(t.name+identPascal(prop), field)
+			desc := ""
+			if field.Value != nil {
+				desc = field.Value.Description
+			}
+			t.fields = append(t.fields, typeField{name: prop, description: desc, required: required[prop], schema: field})
+		}
+		c.types = append(c.types, t)
+	case s.AdditionalProperties.Schema != nil:
+		c.collect(name, s.AdditionalProperties.Schema)
+	}
+}
+
+// collectSpec names the types of the definition parameter. It returns the name
+// of the spec type, and the properties of the spec if it is a named type.
+func (c *typeCollector) collectSpec(name string, spec *openapi3.SchemaRef) (string, bool, []typeField) {
+	c.collect(name, spec)
+	if spec != nil {
+		if specName, ok := c.names[spec.Value]; ok {
+			return specName, true, c.types[len(c.types)-1].fields
+		}
+	}
+	return c.unique(name), false, nil
+}
+
+// reserve takes the names, so that no type is named after them
+func (c *typeCollector) reserve(names ...string) {
+	for _, name := range names {
+		c.used[name] = true
+	}
+}
+
+// unique returns the name, or the name with a number suffix if it is taken
+func (c *typeCollector) unique(name string) string {
+	res := name
+	for i := 2; c.used[res]; i++ {
+		res = fmt.Sprintf("%s%d", name, i)
+	}
+	c.used[res] = true
+	return res
+}
+
+// typeSyntax is how a language writes the types
+type typeSyntax struct {
+	str, integer, number, boolean, any string
+
+	named    func(name string) string
+	array    func(item string) string
+	mapOf    func(value string) string
+	union    func(items []string) string
+	nullable func(t string) string
+	literal  func(v interface{}) string
+}
+
+// typeExpr returns the type expression of the schema in the language
+func (c *typeCollector) typeExpr(ref *openapi3.SchemaRef, syntax typeSyntax) string {
+	if ref == nil || ref.Value == nil {
+		return syntax.any
+	}
+	s := ref.Value
+	res := c.baseTypeExpr(s, syntax)
+	if s.Nullable && res != syntax.any {
+		res = syntax.nullable(res)
+	}
+	return res
+}
+
+func (c *typeCollector) baseTypeExpr(s *openapi3.Schema, syntax typeSyntax) string {
+	if name, ok := c.names[s]; ok {
+		return syntax.named(name)
+	}
+	if items := append(append(openapi3.SchemaRefs{}, s.OneOf...), s.AnyOf...); len(items) > 0 {
+		var exprs []string
+		seen := map[string]bool{}
+		for _, item := range items {
+			expr := c.typeExpr(item, syntax)
+			if expr == syntax.any {
+				return syntax.any
+			}
+			if !seen[expr] {
+				seen[expr] = true
+				exprs = append(exprs, expr)
+			}
+		}
+		if len(exprs) == 1 {
+			return exprs[0]
+		}
+		return syntax.union(exprs)
+	}
+	if len(s.Enum) > 0 {
+		var exprs []string
+		for _, v := range s.Enum {
+			exprs = append(exprs, syntax.literal(v))
+		}
+		if len(exprs) == 1 {
+			return exprs[0]
+		}
+		return syntax.union(exprs)
+	}
+	switch {
+	case s.Type.Is(openapi3.TypeString):
+		return syntax.str
+	case s.Type.Is(openapi3.TypeInteger):
+		return syntax.integer
+	case s.Type.Is(openapi3.TypeNumber):
+		return syntax.number
+	case s.Type.Is(openapi3.TypeBoolean):
+		return syntax.boolean
+	case s.Type.Is(openapi3.TypeArray):
+		return syntax.array(c.typeExpr(s.Items, syntax))
+	case s.Type.Is(openapi3.TypeObject):
+		if value := s.AdditionalProperties.Schema; value != nil && !isFreeForm(value.Value) {
+			return syntax.mapOf(c.typeExpr(value, syntax))
+		}
+		return syntax.mapOf(syntax.any)
+	}
+	return syntax.any
+}
+
+// isFreeForm tells if the schema is a free form object, e.g. `{...}`, including
+// the additional properties completed by completeFreeFormSchema
+func isFreeForm(s *openapi3.Schema) bool {
+	return s == nil || (s.Type.Is(openapi3.TypeObject) && len(s.Properties) == 0 && len(s.OneOf) == 0 &&
+		(s.AdditionalProperties.Schema == nil || isFreeForm(s.AdditionalProperties.Schema.Value)))
+}
+
+// nestedTypeName returns the named type validated for the field, i.e. the
+// field is of the named type, a list or a map of it, and whether the field is
+// a list or a map
+func (c *typeCollector) nestedTypeName(ref *openapi3.SchemaRef) (name string, list bool, dict bool) {
+	if ref == nil || ref.Value == nil {
+		return "", false, false
+	}
+	s := ref.Value
+	if name, ok := c.names[s]; ok {
+		return name, false, false
+	}
+	if s.Type.Is(openapi3.TypeArray) && s.Items != nil && s.Items.Value != nil {
+		name = c.names[s.Items.Value]
+		return name, name != "", false
+	}
+	if s.Type.Is(openapi3.TypeObject) && s.AdditionalProperties.Schema != nil && s.AdditionalProperties.Schema.Value != nil {
+		name = c.names[s.AdditionalProperties.Schema.Value]
+		return name, false, name != ""
+	}
+	return "", false, false
+}
+
+// specSchema returns the schema of the definition parameter, which is renamed
+// into {name}-spec by completeOpenAPISchema
+func specSchema(meta *GenMeta, doc *openapi3.T) *openapi3.SchemaRef {
+	if doc == nil || doc.Components == nil {
+		return nil
+	}
+	return doc.Components.Schemas[meta.name+"-spec"]
+}
+
+// kindPascal returns the PascalCase definition kind, e.g. WorkflowStep
+func kindPascal(kind string) string {
+	return DefinitionKindToPascal[kind]
+}
+
+// hasName tells if the instances of the definition kind have names
+func hasName(kind string) bool {
+	return kind != v1beta1.TraitDefinitionKind
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// identPascal converts the name into a PascalCase identifier
+func identPascal(name string) string {
+	res := strcase.ToPascal(nonAlphanumeric.ReplaceAllString(name, "-"))
+	if res == "" || (res[0] >= '0' && res[0] <= '9') {
+		res = "P" + res
+	}
+	return res
+}
+
+// identSnake converts the name into a snake_case identifier
+func identSnake(name string) string {
+	res := strcase.ToSnake(nonAlphanumeric.ReplaceAllString(name, "-"))
+	if res == "" || (res[0] >= '0' && res[0] <= '9') {
+		res = "p_" + res
+	}
+	return res
+}
+
+// setterNames returns the setter name of each property, the names taken by
+// the builder methods are suffixed with Property
+func setterNames(props []typeField, setter func(string) string, reserved map[string]bool) map[string]string {
+	res := map[string]string{}
+	used := map[string]bool{}
+	for _, p := range props {
+		name := setter(p.name)
+		if reserved[name] {
+			name = setter(p.name + "-property")
+		}
+		unique := name
+		for i := 2; used[unique] || reserved[unique]; i++ {
+			unique = fmt.Sprintf("%s%d", name, i)
+		}
+		used[unique] = true
+		res[p.name] = unique
+	}
+	return res
+}
+
+// commentLines splits the description into lines for comments
+func commentLines(desc string) []string {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return nil
+	}
+	return strings.Split(desc, "\n")
+}
+
+func sortedKeys(schemas openapi3.Schemas) []string {
+	keys := make([]string, 0, len(schemas))
+	for k := range schemas {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// codeWriter writes the code line by line with indentation
+type codeWriter struct {
+	buf    bytes.Buffer
+	indent string
+	level  int
+}
+
+// line writes a line, an empty format writes an empty line
+func (w *codeWriter) line(format string, args ...interface{}) {
+	if format == "" {
+		w.buf.WriteString("\n")
+		return
+	}
+	w.buf.WriteString(strings.Repeat(w.indent, w.level))
+	w.buf.WriteString(fmt.Sprintf(format, args...))
+	w.buf.WriteString("\n")
+}
+
+func (w *codeWriter) in()  { w.level++ }
+func (w *codeWriter) out() { w.level-- }
+
+func (w *codeWriter) bytes() []byte {
+	return w.buf.Bytes()
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gen_sdk
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+var _ = Describe("Native renderers", func() {
+	schemaOf := func(typ string) *openapi3.SchemaRef {
+		return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{typ}}}
+	}
+	docOf := func(name string, spec *openapi3.SchemaRef) *openapi3.T {
+		return &openapi3.T{Components: &openapi3.Components{Schemas: openapi3.Schemas{name + "-spec": spec}}}
+	}
+
+	// ports is a list of objects, cpu is oneOf number and string, protocol is an enum,
+	// traits takes the name of a builder method and env is a free form object.
+	spec := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:     &openapi3.Types{openapi3.TypeObject},
+		Required: []string{"image"},
+		Properties: openapi3.Schemas{
+			"image": schemaOf(openapi3.TypeString),
+			"ports": {Value: &openapi3.Schema{
+				Type: &openapi3.Types{openapi3.TypeArray},
+				Items: &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:     &openapi3.Types{openapi3.TypeObject},
+					Required: []string{"port"},
+					Properties: openapi3.Schemas{
+						"port":     schemaOf(openapi3.TypeInteger),
+						"protocol": {Value: &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}, Enum: []interface{}{"TCP", "UDP"}}},
+					},
+				}},
+			}},
+			"cpu":    {Value: &openapi3.Schema{OneOf: openapi3.SchemaRefs{schemaOf(openapi3.TypeNumber), schemaOf(openapi3.TypeString)}}},
+			"traits": schemaOf(openapi3.TypeString),
+			"env":    schemaOf(openapi3.TypeObject),
+		},
+	}}
+	completeFreeFormSchema(spec.Value.Properties["env"])
+
+	It("should render the TypeScript API of a component", func() {
+		meta := &GenMeta{name: "web", kind: v1beta1.ComponentDefinitionKind, description: "Web service.", APIDirectory: defaultAPIDir["typescript"]}
+		file, content, err := renderTypeScript(meta, docOf("web", spec))
+		Expect(err).Should(BeNil())
+		Expect(file).Should(Equal("component/web.ts"))
+		code := string(content)
+		Expect(code).Should(ContainSubstring(`import { ApplicationComponent, Component, ComponentBase, StepInput, StepOutput, Trait } from "../types";`))
+		Expect(code).Should(ContainSubstring("export interface WebSpecPorts {\n  port: number;\n  protocol?: \"TCP\" | \"UDP\";\n}"))
+		Expect(code).Should(ContainSubstring("  cpu?: number | string;\n"))
+		Expect(code).Should(ContainSubstring("  env?: Record<string, any>;\n"))
+		Expect(code).Should(ContainSubstring("  image: string;\n"))
+		Expect(code).Should(ContainSubstring("v.ports.forEach((item, i) => validateWebSpecPorts(item, `${path}.ports[${i}]`, errs));"))
+		Expect(code).Should(ContainSubstring("/** Web service. */\nexport class WebComponent implements Component {"))
+		Expect(code).Should(ContainSubstring("setTraitsProperty(value: string): this {"))
+		Expect(code).Should(ContainSubstring("export function web(name: string): WebComponent {"))
+	})
+
+	It("should render the Python API of a component", func() {
+		meta := &GenMeta{name: "web", kind: v1beta1.ComponentDefinitionKind, Package: "vela_sdk"}
+		file, content, err := renderPython(meta, docOf("web", spec))
+		Expect(err).Should(BeNil())
+		Expect(file).Should(Equal("component/web.py"))
+		code := string(content)
+		Expect(code).Should(ContainSubstring("WebSpecPorts = TypedDict(\"WebSpecPorts\", {\n    # Required.\n    \"port\": int,\n    \"protocol\": Union[Literal[\"TCP\"], Literal[\"UDP\"]],\n}, total=False)"))
+		Expect(code).Should(ContainSubstring(`    "cpu": Union[float, str],`))
+		Expect(code).Should(ContainSubstring(`    "env": Dict[str, Any],`))
+		Expect(code).Should(ContainSubstring(`    "ports": List["WebSpecPorts"],`))
+		Expect(code).Should(ContainSubstring("def set_traits_property(self, value: str) -> WebComponent:"))
+		Expect(code).Should(ContainSubstring("_validate_web_spec_ports(item, path + \".ports[\" + str(i) + \"]\", errs)"))
+	})
+
+	It("should avoid the reserved words and the taken names", func() {
+		// the referred type Type would be named after the type constant of the policy
+		typ := &openapi3.SchemaRef{Ref: "#/components/schemas/Type", Value: &openapi3.Schema{
+			Type:       &openapi3.Types{openapi3.TypeObject},
+			Properties: openapi3.Schemas{"name": schemaOf(openapi3.TypeString)},
+		}}
+		doc := docOf("import", &openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type:       &openapi3.Types{openapi3.TypeObject},
+			Properties: openapi3.Schemas{"type": typ},
+		}})
+		meta := &GenMeta{name: "import", kind: v1beta1.PolicyDefinitionKind, Package: "vela_sdk", APIDirectory: defaultAPIDir["typescript"]}
+
+		file, content, err := renderTypeScript(meta, doc)
+		Expect(err).Should(BeNil())
+		Expect(file).Should(Equal("policy/import.ts"))
+		Expect(string(content)).Should(ContainSubstring("export interface ImportType2 {"))
+		Expect(string(content)).Should(ContainSubstring("export function importPolicy(name: string): ImportPolicy {"))
+
+		file, content, err = renderPython(meta, doc)
+		Expect(err).Should(BeNil())
+		Expect(file).Should(Equal("policy/import_policy.py"))
+		Expect(string(content)).Should(ContainSubstring("def import_policy(name: str) -> ImportPolicy:"))
+	})
+
+	It("should render the empty spec if the definition has no parameter", func() {
+		meta := &GenMeta{name: "step-group", kind: v1beta1.WorkflowStepDefinitionKind, Package: "vela_sdk", APIDirectory: defaultAPIDir["typescript"]}
+		doc := &openapi3.T{Components: &openapi3.Components{Schemas: openapi3.Schemas{}}}
+
+		_, content, err := renderTypeScript(meta, doc)
+		Expect(err).Should(BeNil())
+		Expect(string(content)).Should(ContainSubstring("export type StepGroupSpec = Record<string, any>;"))
+		Expect(string(content)).Should(ContainSubstring("addSubStep(subStep: WorkflowStep): this {"))
+
+		_, content, err = renderPython(meta, doc)
+		Expect(err).Should(BeNil())
+		Expect(string(content)).Should(ContainSubstring("StepGroupSpec = Dict[str, Any]"))
+		Expect(string(content)).Should(ContainSubstring("def add_sub_step(self, sub_step: WorkflowStep) -> StepGroupWorkflowStep:"))
+	})
+})
@@ -0,0 +1,548 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gen_sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/definition"
+)
+
+const (
+	// PyPackagePlaceHolder is the package name placeholder of the Python SDK
+	PyPackagePlaceHolder = "vela_sdk"
+)
+
+var (
+	pyIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// pyKeywords can't be used as function names
+	pyKeywords = map[string]bool{
+		"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true,
+		"await": true, "break": true, "class": true, "continue": true, "def": true, "del": true,
+		"elif": true, "else": true, "except": true, "finally": true, "for": true, "from": true,
+		"global": true, "if": true, "import": true, "in": true, "is": true, "lambda": true,
+		"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true, "return": true,
+		"try": true, "while": true, "with": true, "yield": true,
+	}
+	// pyBuilderMethods are the methods of the builders which the setters must not override
+	pyBuilderMethods = map[string]bool{
+		"set_properties": true, "set_traits": true,
+	}
+
+	pySyntax = typeSyntax{
+		str: "str", integer: "int", number: "float", boolean: "bool", any: "Any",
+		// the named types are quoted, so they can be referred before being declared
+		named:    func(name string) string { return pyStr(name) },
+		array:    func(item string) string { return "List[" + item + "]" },
+		mapOf:    func(value string) string { return "Dict[str, " + value + "]" },
+		union:    func(items []string) string { return "Union[" + strings.Join(items, ", ") + "]" },
+		nullable: func(t string) string { return "Optional[" + t + "]" },
+		literal:  func(v interface{}) string { return "Literal[" + pyLiteral(v) + "]" },
+	}
+)
+
+// pyContext is the names used in the Python code of a definition
+type pyContext struct {
+	pkg         string
+	kind        string
+	kindSnake   string
+	typeConst   string
+	className   string
+	specName    string
+	specNamed   bool
+	appType     string
+	types       *typeCollector
+	setterNames map[string]string
+}
+
+// renderPython renders the Python API of the definition, which contains the
+// TypedDicts of the parameter and the builder class of the definition
+func renderPython(meta *GenMeta, doc *openapi3.T) (string, []byte, error) {
+	kindDir := definition.DefinitionKindToType[meta.kind]
+	if _, ok := DefinitionKindToPascal[meta.kind]; !ok {
+		return "", nil, errors.Errorf("unsupported definition kind %s", meta.kind)
+	}
+	pascal := identPascal(meta.name)
+	c := &pyContext{
+		pkg:       meta.Package,
+		kind:      meta.kind,
+		kindSnake: identSnake(kindDir),
+		typeConst: strings.ToUpper(identSnake(meta.name)) + "_TYPE",
+		className: pascal + kindPascal(meta.kind),
+		specName:  pascal + "Spec",
+		appType:   "Application" + kindPascal(meta.kind),
+		types:     newTypeCollector(pascal),
+	}
+	spec := specSchema(meta, doc)
+	c.types.reserve(c.className, c.typeConst)
+	var props []typeField
+	c.specName, c.specNamed, props = c.types.collectSpec(c.specName, spec)
+	c.setterNames = setterNames(props, func(name string) string { return "set_" + identSnake(name) }, pyBuilderMethods)
+	factory := identSnake(meta.name)
+	if pyKeywords[factory] {
+		factory += "_" + c.kindSnake
+	}
+
+	w := &codeWriter{indent: "    "}
+	w.line("# Code generated by vela def gen-api. DO NOT EDIT.")
+	w.line("")
+	w.line("from __future__ import annotations")
+	w.line("")
+	w.line("from typing import Any, Dict, List, Literal, Optional, TypedDict, Union, cast  # noqa: F401")
+	w.line("")
+	c.writeImports(w)
+	w.line("")
+	exports := []string{c.typeConst}
+	for _, t := range c.types.types {
+		exports = append(exports, t.name)
+	}
+	if !c.specNamed {
+		exports = append(exports, c.specName)
+	}
+	exports = append(exports, c.className, factory)
+	quoted := make([]string, 0, len(exports))
+	for _, e := range exports {
+		quoted = append(quoted, pyStr(e))
+	}
+	w.line("__all__ = [%s]", strings.Join(quoted, ", "))
+	w.line("")
+	w.line("%s = %s", c.typeConst, pyStr(meta.name))
+	w.line("")
+	c.writeTypes(w, spec)
+	c.writeValidators(w)
+	c.writeClass(w, meta, props)
+	w.line("")
+	w.line("")
+	if hasName(c.kind) {
+		w.line("def %s(name: str) -> %s:", factory, c.className)
+		w.in()
+		w.line("return %s(name)", c.className)
+	} else {
+		w.line("def %s() -> %s:", factory, c.className)
+		w.in()
+		w.line("return %s()", c.className)
+	}
+	w.out()
+	w.line("")
+	w.line("")
+	w.line("register_%s(%s, %s.from_%s)", c.kindSnake, c.typeConst, c.className, c.kindSnake)
+	// the module is named after the factory, as the keywords can't be imported
+	return path.Join(c.kindSnake, factory+".py"), w.bytes(), nil
+}
+
+func (c *pyContext) writeImports(w *codeWriter) {
+	catalog := []string{"register_" + c.kindSnake}
+	types := []string{c.appType, kindPascal(c.kind), kindPascal(c.kind) + "Base"}
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		catalog = append(catalog, "from_trait")
+		types = append(types, "StepInput", "StepOutput", "Trait")
+	case v1beta1.WorkflowStepDefinitionKind:
+		catalog = append(catalog, "from_workflow_step")
+		types = append(types, "StepInput", "StepOutput")
+	}
+	sort.Strings(catalog)
+	sort.Strings(types)
+	w.line("from %s.apis.common.catalog import %s", c.pkg, strings.Join(catalog, ", "))
+	w.line("from %s.apis.types import %s", c.pkg, strings.Join(types, ", "))
+}
+
+// writeTypes declares the named types as TypedDicts, and the spec type
+func (c *pyContext) writeTypes(w *codeWriter, spec *openapi3.SchemaRef) {
+	for _, t := range c.types.types {
+		for _, l := range commentLines(t.description) {
+			w.line("# %s", l)
+		}
+		if len(t.fields) == 0 {
+			w.line("%s = TypedDict(%s, {}, total=False)", t.name, pyStr(t.name))
+			w.line("")
+			continue
+		}
+		w.line("%s = TypedDict(%s, {", t.name, pyStr(t.name))
+		w.in()
+		for _, f := range t.fields {
+			desc := commentLines(f.description)
+			if f.required {
+				desc = append([]string{"Required."}, desc...)
+			}
+			for _, l := range desc {
+				w.line("# %s", l)
+			}
+			w.line("%s: %s,", pyStr(f.name), c.types.typeExpr(f.schema, pySyntax))
+		}
+		w.out()
+		w.line("}, total=False)")
+		w.line("")
+	}
+	if !c.specNamed {
+		expr := "Dict[str, Any]"
+		if spec != nil && !isFreeForm(spec.Value) {
+			expr = c.types.typeExpr(spec, pySyntax)
+		}
+		w.line("%s = %s", c.specName, expr)
+		w.line("")
+	}
+}
+
+// writeValidators declares a function for each named type, which checks the
+// required fields recursively
+func (c *pyContext) writeValidators(w *codeWriter) {
+	for _, t := range c.types.types {
+		w.line("")
+		w.line("def %s(v: %s, path: str, errs: List[str]) -> None:", pyValidatorName(t.name), t.name)
+		w.in()
+		written := false
+		for _, f := range t.fields {
+			key := pyStr(f.name)
+			if f.required {
+				w.line("if %s not in v:", key)
+				w.line("    errs.append(path + %s)", pyStr("."+f.name+" is required"))
+				written = true
+			}
+			name, list, dict := c.types.nestedTypeName(f.schema)
+			if name == "" {
+				continue
+			}
+			written = true
+			w.line("if v.get(%s) is not None:", key)
+			w.in()
+			switch {
+			case list:
+				w.line("for i, item in enumerate(v[%s]):", key)
+				w.line("    %s(item, path + %s + str(i) + \"]\", errs)", pyValidatorName(name), pyStr("."+f.name+"["))
+			case dict:
+				w.line("for k, item in v[%s].items():", key)
+				w.line("    %s(item, path + %s + k, errs)", pyValidatorName(name), pyStr("."+f.name+"."))
+			default:
+				w.line("%s(v[%s], path + %s, errs)", pyValidatorName(name), key, pyStr("."+f.name))
+			}
+			w.out()
+		}
+		if !written {
+			w.line("pass")
+		}
+		w.out()
+		w.line("")
+	}
+}
+
+// writeClass declares the builder class of the definition
+func (c *pyContext) writeClass(w *codeWriter, meta *GenMeta, props []typeField) {
+	kind := kindPascal(c.kind)
+	w.line("")
+	w.line("class %s(%s):", c.className, kind)
+	w.in()
+	if lines := commentLines(strings.ReplaceAll(strings.ReplaceAll(meta.description, `\`, `\\`), `"""`, `\"\"\"`)); len(lines) > 0 {
+		if len(lines) == 1 {
+			w.line(`"""%s"""`, lines[0])
+		} else {
+			w.line(`"""%s`, lines[0])
+			for _, l := range lines[1:] {
+				w.line("%s", l)
+			}
+			w.line(`"""`)
+		}
+		w.line("")
+	}
+	if hasName(c.kind) {
+		w.line("def __init__(self, name: str) -> None:")
+		w.line("    self.base = %sBase(name=name, type=%s)", kind, c.typeConst)
+	} else {
+		w.line("def __init__(self) -> None:")
+		w.line("    self.base = %sBase(type=%s)", kind, c.typeConst)
+	}
+	w.line("    self.properties: %s = cast(%s, {})", c.specName, c.specName)
+
+	for _, p := range props {
+		w.line("")
+		w.line("def %s(self, value: %s) -> %s:", c.setterNames[p.name], c.types.typeExpr(p.schema, pySyntax), c.className)
+		w.in()
+		for _, l := range commentLines(p.description) {
+			w.line("# %s", l)
+		}
+		w.line("self.properties[%s] = value", pyStr(p.name))
+		w.line("return self")
+		w.out()
+	}
+	w.line("")
+	writePyMethod(w, "set_properties(self, properties: "+c.specName+") -> "+c.className, "self.properties = properties", "return self")
+	if hasName(c.kind) {
+		w.line("")
+		writePyMethod(w, c.kindSnake+"_name(self) -> str", "return self.base.name")
+	}
+	w.line("")
+	writePyMethod(w, "def_type(self) -> str", "return "+c.typeConst)
+
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		w.line("")
+		writePyMethod(w, "set_traits(self, *traits: Trait) -> "+c.className,
+			"for trait in traits:",
+			"    for i, t in enumerate(self.base.traits):",
+			"        if t.def_type() == trait.def_type():",
+			"            self.base.traits[i] = trait",
+			"            break",
+			"    else:",
+			"        self.base.traits.append(trait)",
+			"return self")
+		w.line("")
+		writePyMethod(w, "get_trait(self, type_: str) -> Optional[Trait]",
+			"return next((t for t in self.base.traits if t.def_type() == type_), None)")
+		w.line("")
+		writePyMethod(w, "get_all_traits(self) -> List[Trait]", "return self.base.traits")
+		c.writeBaseSetters(w, "depends_on", "add_depends_on", "inputs", "outputs")
+	case v1beta1.WorkflowStepDefinitionKind:
+		c.writeBaseSetters(w, "if_", "alias", "timeout", "depends_on", "inputs", "outputs")
+		if meta.name == "step-group" {
+			w.line("")
+			writePyMethod(w, "add_sub_step(self, sub_step: WorkflowStep) -> "+c.className,
+				"self.base.sub_steps.append(sub_step)", "return self")
+		}
+	}
+
+	c.writeValidate(w)
+	c.writeBuild(w)
+	c.writeFrom(w)
+	w.out()
+}
+
+// writeBaseSetters writes the setters of the base fields
+func (c *pyContext) writeBaseSetters(w *codeWriter, names ...string) {
+	setters := map[string][]string{
+		"depends_on":     {"depends_on(self, depends_on: List[str])", "self.base.depends_on = depends_on"},
+		"add_depends_on": {"add_depends_on(self, depends_on: str)", "self.base.depends_on = (self.base.depends_on or []) + [depends_on]"},
+		"inputs":         {"inputs(self, inputs: List[StepInput])", "self.base.inputs = inputs"},
+		"outputs":        {"outputs(self, outputs: List[StepOutput])", "self.base.outputs = outputs"},
+		"if_":            {"if_(self, condition: str)", "self.base.if_ = condition"},
+		"alias":          {"alias(self, alias: str)", "self.base.meta = {**(self.base.meta or {}), \"alias\": alias}"},
+		"timeout":        {"timeout(self, timeout: str)", "self.base.timeout = timeout"},
+	}
+	for _, name := range names {
+		w.line("")
+		writePyMethod(w, setters[name][0]+" -> "+c.className, setters[name][1], "return self")
+	}
+}
+
+func (c *pyContext) writeValidate(w *codeWriter) {
+	w.line("")
+	w.line("def validate(self) -> None:")
+	w.in()
+	written := false
+	if c.specNamed {
+		written = true
+		w.line("errs: List[str] = []")
+		w.line("%s(self.properties, \"properties\", errs)", pyValidatorName(c.specName))
+		w.line("if errs:")
+		kind := definition.DefinitionKindToType[c.kind]
+		if hasName(c.kind) {
+			w.line("    raise ValueError(f\"{%s} %s {self.base.name} is invalid: {', '.join(errs)}\")", c.typeConst, kind)
+		} else {
+			w.line("    raise ValueError(f\"{%s} %s is invalid: {', '.join(errs)}\")", c.typeConst, kind)
+		}
+	}
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		written = true
+		w.line("for i, trait in enumerate(self.base.traits):")
+		w.line("    try:")
+		w.line("        trait.validate()")
+		w.line("    except ValueError as e:")
+		w.line("        raise ValueError(f\"traits[{i}] {trait.def_type()} in {%s} component is invalid: {e}\") from e", c.typeConst)
+	case v1beta1.WorkflowStepDefinitionKind:
+		written = true
+		w.line("for sub_step in self.base.sub_steps:")
+		w.line("    sub_step.validate()")
+	}
+	if !written {
+		w.line("pass")
+	}
+	w.out()
+}
+
+func (c *pyContext) writeBuild(w *codeWriter) {
+	w.line("")
+	w.line("def build(self) -> %s:", c.appType)
+	w.in()
+	switch c.kind {
+	case v1beta1.TraitDefinitionKind:
+		w.line("return {\"type\": %s, \"properties\": dict(self.properties)}", c.typeConst)
+	case v1beta1.PolicyDefinitionKind:
+		w.line("return {\"name\": self.base.name, \"type\": %s, \"properties\": dict(self.properties)}", c.typeConst)
+	case v1beta1.ComponentDefinitionKind:
+		w.line("res: %s = {\"name\": self.base.name, \"type\": %s, \"properties\": dict(self.properties)}", c.appType, c.typeConst)
+		w.line("if self.base.traits:")
+		w.line("    res[\"traits\"] = [trait.build() for trait in self.base.traits]")
+		c.writeOptionalBase(w, [][2]string{{"dependsOn", "depends_on"}, {"inputs", "inputs"}, {"outputs", "outputs"}})
+		w.line("return res")
+	case v1beta1.WorkflowStepDefinitionKind:
+		w.line("res: %s = {\"name\": self.base.name, \"type\": %s, \"properties\": dict(self.properties)}", c.appType, c.typeConst)
+		c.writeOptionalBase(w, [][2]string{{"meta", "meta"}, {"if", "if_"}, {"timeout", "timeout"},
+			{"dependsOn", "depends_on"}, {"inputs", "inputs"}, {"outputs", "outputs"}})
+		w.line("if self.base.sub_steps:")
+		w.line("    res[\"subSteps\"] = []")
+		w.line("    for sub_step in self.base.sub_steps:")
+		w.line("        sub = sub_step.build()")
+		w.line("        sub.pop(\"subSteps\", None)")
+		w.line("        res[\"subSteps\"].append(sub)")
+		w.line("return res")
+	}
+	w.out()
+}
+
+// writeOptionalBase sets the base fields into res if they are set, the fields
+// are pairs of the key and the attribute name
+func (c *pyContext) writeOptionalBase(w *codeWriter, fields [][2]string) {
+	for _, f := range fields {
+		w.line("if self.base.%s is not None:", f[1])
+		w.line("    res[%s] = self.base.%s", pyStr(f[0]), f[1])
+	}
+}
+
+func (c *pyContext) writeFrom(w *codeWriter) {
+	w.line("")
+	w.line("@staticmethod")
+	w.line("def from_%s(from_: %s) -> %s:", c.kindSnake, c.appType, c.className)
+	w.in()
+	if hasName(c.kind) {
+		w.line("res = %s(from_[\"name\"])", c.className)
+	} else {
+		w.line("res = %s()", c.className)
+	}
+	w.line("res.properties = cast(%s, dict(from_.get(\"properties\") or {}))", c.specName)
+	var fields [][2]string
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		w.line("res.base.traits = [from_trait(trait) for trait in from_.get(\"traits\") or []]")
+		fields = [][2]string{{"dependsOn", "depends_on"}, {"inputs", "inputs"}, {"outputs", "outputs"}}
+	case v1beta1.WorkflowStepDefinitionKind:
+		w.line("res.base.sub_steps = [from_workflow_step(sub_step) for sub_step in from_.get(\"subSteps\") or []]")
+		fields = [][2]string{{"meta", "meta"}, {"if", "if_"}, {"timeout", "timeout"},
+			{"dependsOn", "depends_on"}, {"inputs", "inputs"}, {"outputs", "outputs"}}
+	}
+	for _, f := range fields {
+		w.line("res.base.%s = from_.get(%s)", f[1], pyStr(f[0]))
+	}
+	w.line("return res")
+	w.out()
+}
+
+// writePyMethod writes a method with the body lines
+func writePyMethod(w *codeWriter, signature string, body ...string) {
+	w.line("def %s:", signature)
+	w.in()
+	for _, l := range body {
+		w.line("%s", l)
+	}
+	w.out()
+}
+
+// pyValidatorName returns the name of the validator function of the named type
+func pyValidatorName(name string) string {
+	return "_validate_" + identSnake(name)
+}
+
+// pyStr returns the Python string literal of s
+func pyStr(s string) string {
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// pyLiteral returns the Python literal of the enum value
+func pyLiteral(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if val {
+			return "True"
+		}
+		return "False"
+	case string:
+		return pyStr(val)
+	default:
+		bs, _ := json.Marshal(v)
+		return string(bs)
+	}
+}
+
+// PyModuleModifier is the Modifier for Python, it imports the definitions
+// of the module in __init__.py files
+type PyModuleModifier struct {
+	*GenMeta
+}
+
+// Name the name of modifier
+func (m *PyModuleModifier) Name() string {
+	return "PyModuleModifier"
+}
+
+// Modify writes __init__.py of each definition kind package, which imports the
+// definitions so that they are registered, and __init__.py of the API package
+// which imports the kinds
+func (m *PyModuleModifier) Modify() error {
+	apiDir := path.Join(m.Output, m.APIDirectory)
+	var kinds []string
+	for _, kind := range []string{v1beta1.ComponentDefinitionKind, v1beta1.TraitDefinitionKind, v1beta1.WorkflowStepDefinitionKind, v1beta1.PolicyDefinitionKind} {
+		dir := identSnake(definition.DefinitionKindToType[kind])
+		files, err := os.ReadDir(path.Join(apiDir, dir))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		var imports []string
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".py") || f.Name() == "__init__.py" {
+				continue
+			}
+			imports = append(imports, fmt.Sprintf("from .%s import *  # noqa: F401,F403", strings.TrimSuffix(f.Name(), ".py")))
+		}
+		if len(imports) == 0 {
+			continue
+		}
+		if err := writePyInit(path.Join(apiDir, dir, "__init__.py"), imports); err != nil {
+			return err
+		}
+		kinds = append(kinds, dir)
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+	return writePyInit(path.Join(apiDir, "__init__.py"), []string{fmt.Sprintf("from . import %s  # noqa: F401", strings.Join(kinds, ", "))})
+}
+
+func writePyInit(file string, imports []string) error {
+	content := "# Code generated by vela def gen-api. DO NOT EDIT.\n\n" + strings.Join(imports, "\n") + "\n"
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		return errors.Wrapf(err, "write %s", file)
+	}
+	return nil
+}
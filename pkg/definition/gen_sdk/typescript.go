@@ -0,0 +1,537 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gen_sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ettle/strcase"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/definition"
+)
+
+const (
+	// TSPackagePlaceHolder is the npm package name placeholder of the TypeScript SDK
+	TSPackagePlaceHolder = "vela-sdk"
+	// tsRuntimeDir is the directory of the TypeScript SDK runtime, i.e. types.ts and common/
+	tsRuntimeDir = "src/apis"
+)
+
+var (
+	tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	// tsReservedWords can't be used as function names
+	tsReservedWords = map[string]bool{
+		"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+		"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+		"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+		"if": true, "import": true, "in": true, "instanceof": true, "new": true, "null": true,
+		"return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+		"try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+		"let": true, "static": true, "yield": true, "await": true, "implements": true,
+		"interface": true, "package": true, "private": true, "protected": true, "public": true,
+	}
+	// tsBuilderMethods are the methods of the builders which the setters must not override
+	tsBuilderMethods = map[string]bool{
+		"setProperties": true, "setTraits": true,
+	}
+
+	tsSyntax = typeSyntax{
+		str: "string", integer: "number", number: "number", boolean: "boolean", any: "any",
+		named: func(name string) string { return name },
+		array: func(item string) string { return "Array<" + item + ">" },
+		mapOf: func(value string) string { return "Record<string, " + value + ">" },
+		union: func(items []string) string { return strings.Join(items, " | ") },
+		nullable: func(t string) string {
+			return t + " | null"
+		},
+		literal: func(v interface{}) string {
+			bs, _ := json.Marshal(v)
+			return string(bs)
+		},
+	}
+)
+
+// tsContext is the names used in the TypeScript code of a definition
+type tsContext struct {
+	kind        string
+	kindPascal  string
+	typeConst   string
+	className   string
+	specName    string
+	specNamed   bool
+	appType     string
+	types       *typeCollector
+	setterNames map[string]string
+}
+
+// renderTypeScript renders the TypeScript API of the definition, which contains
+// the types of the parameter and the builder class of the definition
+func renderTypeScript(meta *GenMeta, doc *openapi3.T) (string, []byte, error) {
+	kindDir := definition.DefinitionKindToType[meta.kind]
+	if _, ok := DefinitionKindToPascal[meta.kind]; !ok {
+		return "", nil, errors.Errorf("unsupported definition kind %s", meta.kind)
+	}
+	pascal := identPascal(meta.name)
+	c := &tsContext{
+		kind:       meta.kind,
+		kindPascal: kindPascal(meta.kind),
+		typeConst:  pascal + "Type",
+		className:  pascal + kindPascal(meta.kind),
+		specName:   pascal + "Spec",
+		appType:    "Application" + kindPascal(meta.kind),
+		types:      newTypeCollector(pascal),
+	}
+	if meta.kind == v1beta1.PolicyDefinitionKind {
+		c.appType = "ApplicationPolicy"
+	}
+	spec := specSchema(meta, doc)
+	c.types.reserve(c.className, c.typeConst)
+	var props []typeField
+	c.specName, c.specNamed, props = c.types.collectSpec(c.specName, spec)
+	c.setterNames = setterNames(props, func(name string) string { return "set" + identPascal(name) }, tsBuilderMethods)
+
+	runtimeDir, err := filepath.Rel(path.Join(meta.APIDirectory, kindDir), tsRuntimeDir)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "locate the SDK runtime")
+	}
+	runtimeDir = filepath.ToSlash(runtimeDir)
+
+	w := &codeWriter{indent: "  "}
+	w.line("// Code generated by vela def gen-api. DO NOT EDIT.")
+	w.line("")
+	c.writeImports(w, runtimeDir)
+	w.line("")
+	w.line("export const %s = %q;", c.typeConst, meta.name)
+	w.line("")
+	c.writeTypes(w, spec)
+	c.writeValidators(w)
+	c.writeClass(w, meta, props)
+	w.line("")
+	factory := strcase.ToCamel(identPascal(meta.name))
+	if tsReservedWords[factory] {
+		factory += c.kindPascal
+	}
+	if hasName(c.kind) {
+		w.line("export function %s(name: string): %s {", factory, c.className)
+		w.in()
+		w.line("return new %s(name);", c.className)
+	} else {
+		w.line("export function %s(): %s {", factory, c.className)
+		w.in()
+		w.line("return new %s();", c.className)
+	}
+	w.out()
+	w.line("}")
+	w.line("")
+	w.line("register%s(%s, %s.from%s);", c.kindPascal, c.typeConst, c.className, c.kindPascal)
+	return path.Join(kindDir, meta.name+".ts"), w.bytes(), nil
+}
+
+func (c *tsContext) writeImports(w *codeWriter, runtimeDir string) {
+	catalog := []string{"register" + c.kindPascal}
+	types := []string{c.appType, c.kindPascal, c.kindPascal + "Base"}
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		catalog = append(catalog, "fromTrait")
+		types = append(types, "StepInput", "StepOutput", "Trait")
+	case v1beta1.WorkflowStepDefinitionKind:
+		catalog = append(catalog, "fromWorkflowStep")
+		types = append(types, "StepInput", "StepOutput")
+	}
+	sort.Strings(catalog)
+	sort.Strings(types)
+	w.line("import { %s } from %q;", strings.Join(catalog, ", "), runtimeDir+"/common/catalog")
+	w.line("import { %s } from %q;", strings.Join(types, ", "), runtimeDir+"/types")
+}
+
+// writeTypes declares the named types and the spec type
+func (c *tsContext) writeTypes(w *codeWriter, spec *openapi3.SchemaRef) {
+	for _, t := range c.types.types {
+		writeTSDoc(w, t.description)
+		w.line("export interface %s {", t.name)
+		w.in()
+		for _, f := range t.fields {
+			writeTSDoc(w, f.description)
+			optional := "?"
+			if f.required {
+				optional = ""
+			}
+			w.line("%s%s: %s;", tsKey(f.name), optional, c.types.typeExpr(f.schema, tsSyntax))
+		}
+		w.out()
+		w.line("}")
+		w.line("")
+	}
+	if !c.specNamed {
+		expr := "Record<string, any>"
+		if spec != nil && !isFreeForm(spec.Value) {
+			expr = c.types.typeExpr(spec, tsSyntax)
+		}
+		w.line("export type %s = %s;", c.specName, expr)
+		w.line("")
+	}
+}
+
+// writeValidators declares a function for each named type, which checks the
+// required fields recursively
+func (c *tsContext) writeValidators(w *codeWriter) {
+	for _, t := range c.types.types {
+		w.line("function validate%s(v: %s, path: string, errs: string[]): void {", t.name, t.name)
+		w.in()
+		for _, f := range t.fields {
+			access := "v" + tsAccess(f.name)
+			fieldPath := "${path}." + strings.ReplaceAll(strings.ReplaceAll(f.name, "`", "\\`"), "${", "\\${")
+			if f.required {
+				w.line("if (%s === undefined) {", access)
+				w.in()
+				w.line("errs.push(`%s is required`);", fieldPath)
+				w.out()
+				w.line("}")
+			}
+			name, list, dict := c.types.nestedTypeName(f.schema)
+			if name == "" {
+				continue
+			}
+			w.line("if (%s != null) {", access)
+			w.in()
+			switch {
+			case list:
+				w.line("%s.forEach((item, i) => validate%s(item, `%s[${i}]`, errs));", access, name, fieldPath)
+			case dict:
+				w.line("Object.entries(%s).forEach(([key, item]) => validate%s(item, `%s.${key}`, errs));", access, name, fieldPath)
+			default:
+				w.line("validate%s(%s, `%s`, errs);", name, access, fieldPath)
+			}
+			w.out()
+			w.line("}")
+		}
+		w.out()
+		w.line("}")
+		w.line("")
+	}
+}
+
+// writeClass declares the builder class of the definition
+func (c *tsContext) writeClass(w *codeWriter, meta *GenMeta, props []typeField) {
+	writeTSDoc(w, meta.description)
+	w.line("export class %s implements %s {", c.className, c.kindPascal)
+	w.in()
+	w.line("base: %sBase;", c.kindPascal)
+	w.line("properties: %s;", c.specName)
+	w.line("")
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		w.line("constructor(name: string) {")
+		w.in()
+		w.line("this.base = { name, type: %s, traits: [] };", c.typeConst)
+	case v1beta1.WorkflowStepDefinitionKind:
+		w.line("constructor(name: string) {")
+		w.in()
+		w.line("this.base = { name, type: %s, subSteps: [] };", c.typeConst)
+	case v1beta1.PolicyDefinitionKind:
+		w.line("constructor(name: string) {")
+		w.in()
+		w.line("this.base = { name, type: %s };", c.typeConst)
+	default:
+		w.line("constructor() {")
+		w.in()
+		w.line("this.base = { type: %s };", c.typeConst)
+	}
+	w.line("this.properties = {} as %s;", c.specName)
+	w.out()
+	w.line("}")
+
+	for _, p := range props {
+		w.line("")
+		writeTSDoc(w, p.description)
+		w.line("%s(value: %s): this {", c.setterNames[p.name], c.types.typeExpr(p.schema, tsSyntax))
+		w.in()
+		w.line("this.properties%s = value;", tsAccess(p.name))
+		w.line("return this;")
+		w.out()
+		w.line("}")
+	}
+	w.line("")
+	writeTSMethod(w, "setProperties(properties: "+c.specName+"): this", "this.properties = properties;", "return this;")
+	if hasName(c.kind) {
+		w.line("")
+		writeTSMethod(w, strcase.ToCamel(c.kindPascal)+"Name(): string", "return this.base.name;")
+	}
+	w.line("")
+	writeTSMethod(w, "defType(): string", "return "+c.typeConst+";")
+
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		w.line("")
+		writeTSMethod(w, "setTraits(...traits: Trait[]): this",
+			"for (const trait of traits) {",
+			"  const i = this.base.traits.findIndex((t) => t.defType() === trait.defType());",
+			"  if (i >= 0) {",
+			"    this.base.traits[i] = trait;",
+			"  } else {",
+			"    this.base.traits.push(trait);",
+			"  }",
+			"}",
+			"return this;")
+		w.line("")
+		writeTSMethod(w, "getTrait(type: string): Trait | undefined", "return this.base.traits.find((t) => t.defType() === type);")
+		w.line("")
+		writeTSMethod(w, "getAllTraits(): Trait[]", "return this.base.traits;")
+		c.writeBaseSetters(w, "dependsOn", "addDependsOn", "inputs", "outputs")
+	case v1beta1.WorkflowStepDefinitionKind:
+		c.writeBaseSetters(w, "if", "alias", "timeout", "dependsOn", "inputs", "outputs")
+		if meta.name == "step-group" {
+			w.line("")
+			writeTSMethod(w, "addSubStep(subStep: WorkflowStep): this", "this.base.subSteps.push(subStep);", "return this;")
+		}
+	}
+
+	c.writeValidate(w)
+	c.writeBuild(w)
+	c.writeFrom(w)
+	w.out()
+	w.line("}")
+}
+
+// writeBaseSetters writes the setters of the base fields
+func (c *tsContext) writeBaseSetters(w *codeWriter, names ...string) {
+	setters := map[string][]string{
+		"dependsOn":    {"dependsOn(dependsOn: string[]): this", "this.base.dependsOn = dependsOn;"},
+		"addDependsOn": {"addDependsOn(dependsOn: string): this", "this.base.dependsOn = [...(this.base.dependsOn ?? []), dependsOn];"},
+		"inputs":       {"inputs(inputs: StepInput[]): this", "this.base.inputs = inputs;"},
+		"outputs":      {"outputs(outputs: StepOutput[]): this", "this.base.outputs = outputs;"},
+		"if":           {"if(condition: string): this", "this.base.if = condition;"},
+		"alias":        {"alias(alias: string): this", "this.base.meta = { ...this.base.meta, alias };"},
+		"timeout":      {"timeout(timeout: string): this", "this.base.timeout = timeout;"},
+	}
+	for _, name := range names {
+		w.line("")
+		writeTSMethod(w, setters[name][0], setters[name][1], "return this;")
+	}
+}
+
+func (c *tsContext) writeValidate(w *codeWriter) {
+	w.line("")
+	w.line("validate(): void {")
+	w.in()
+	if c.specNamed {
+		w.line("const errs: string[] = [];")
+		w.line("validate%s(this.properties, \"properties\", errs);", c.specName)
+		w.line("if (errs.length > 0) {")
+		w.in()
+		if hasName(c.kind) {
+			w.line("throw new Error(`${%s} %s ${this.base.name} is invalid: ${errs.join(\", \")}`);", c.typeConst, definition.DefinitionKindToType[c.kind])
+		} else {
+			w.line("throw new Error(`${%s} %s is invalid: ${errs.join(\", \")}`);", c.typeConst, definition.DefinitionKindToType[c.kind])
+		}
+		w.out()
+		w.line("}")
+	}
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		w.line("this.base.traits.forEach((trait, i) => {")
+		w.in()
+		w.line("try {")
+		w.line("  trait.validate();")
+		w.line("} catch (e) {")
+		w.line("  throw new Error(`traits[${i}] ${trait.defType()} in ${%s} component is invalid: ${(e as Error).message}`);", c.typeConst)
+		w.line("}")
+		w.out()
+		w.line("});")
+	case v1beta1.WorkflowStepDefinitionKind:
+		w.line("this.base.subSteps.forEach((subStep) => subStep.validate());")
+	}
+	w.out()
+	w.line("}")
+}
+
+func (c *tsContext) writeBuild(w *codeWriter) {
+	w.line("")
+	w.line("build(): %s {", c.appType)
+	w.in()
+	switch c.kind {
+	case v1beta1.TraitDefinitionKind:
+		w.line("return { type: %s, properties: { ...this.properties } };", c.typeConst)
+	case v1beta1.PolicyDefinitionKind:
+		w.line("return { name: this.base.name, type: %s, properties: { ...this.properties } };", c.typeConst)
+	case v1beta1.ComponentDefinitionKind:
+		w.line("const res: %s = { name: this.base.name, type: %s, properties: { ...this.properties } };", c.appType, c.typeConst)
+		w.line("if (this.base.traits.length > 0) {")
+		w.line("  res.traits = this.base.traits.map((trait) => trait.build());")
+		w.line("}")
+		c.writeOptionalBase(w, "dependsOn", "inputs", "outputs")
+		w.line("return res;")
+	case v1beta1.WorkflowStepDefinitionKind:
+		w.line("const res: %s = { name: this.base.name, type: %s, properties: { ...this.properties } };", c.appType, c.typeConst)
+		c.writeOptionalBase(w, "meta", "if", "timeout", "dependsOn", "inputs", "outputs")
+		w.line("if (this.base.subSteps.length > 0) {")
+		w.in()
+		w.line("res.subSteps = this.base.subSteps.map((subStep) => {")
+		w.line("  const sub = subStep.build();")
+		w.line("  delete sub.subSteps;")
+		w.line("  return sub;")
+		w.line("});")
+		w.out()
+		w.line("}")
+		w.line("return res;")
+	}
+	w.out()
+	w.line("}")
+}
+
+func (c *tsContext) writeOptionalBase(w *codeWriter, fields ...string) {
+	for _, f := range fields {
+		w.line("if (this.base.%s !== undefined) {", f)
+		w.line("  res.%s = this.base.%s;", f, f)
+		w.line("}")
+	}
+}
+
+func (c *tsContext) writeFrom(w *codeWriter) {
+	w.line("")
+	w.line("static from%s(from: %s): %s {", c.kindPascal, c.appType, c.className)
+	w.in()
+	if hasName(c.kind) {
+		w.line("const res = new %s(from.name);", c.className)
+	} else {
+		w.line("const res = new %s();", c.className)
+	}
+	w.line("res.properties = { ...from.properties } as %s;", c.specName)
+	switch c.kind {
+	case v1beta1.ComponentDefinitionKind:
+		w.line("res.base.traits = (from.traits ?? []).map(fromTrait);")
+		w.line("res.base.dependsOn = from.dependsOn;")
+		w.line("res.base.inputs = from.inputs;")
+		w.line("res.base.outputs = from.outputs;")
+	case v1beta1.WorkflowStepDefinitionKind:
+		w.line("res.base.subSteps = (from.subSteps ?? []).map(fromWorkflowStep);")
+		for _, f := range []string{"meta", "if", "timeout", "dependsOn", "inputs", "outputs"} {
+			w.line("res.base.%s = from.%s;", f, f)
+		}
+	}
+	w.line("return res;")
+	w.out()
+	w.line("}")
+}
+
+// writeTSMethod writes a method with the body lines
+func writeTSMethod(w *codeWriter, signature string, body ...string) {
+	w.line("%s {", signature)
+	w.in()
+	for _, l := range body {
+		w.line("%s", l)
+	}
+	w.out()
+	w.line("}")
+}
+
+// writeTSDoc writes the description as a doc comment
+func writeTSDoc(w *codeWriter, desc string) {
+	lines := commentLines(strings.ReplaceAll(desc, "*/", "* /"))
+	switch len(lines) {
+	case 0:
+	case 1:
+		w.line("/** %s */", lines[0])
+	default:
+		w.line("/**")
+		for _, l := range lines {
+			w.line(" * %s", l)
+		}
+		w.line(" */")
+	}
+}
+
+// tsKey returns the property key, quoted if it is not an identifier
+func tsKey(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	bs, _ := json.Marshal(name)
+	return string(bs)
+}
+
+// tsAccess returns the property accessor, e.g. .name or ["app.oam.dev/name"]
+func tsAccess(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return "." + name
+	}
+	return "[" + tsKey(name) + "]"
+}
+
+// TSModuleModifier is the Modifier for TypeScript, it exports the definitions
+// of the module from index files
+type TSModuleModifier struct {
+	*GenMeta
+}
+
+// Name the name of modifier
+func (m *TSModuleModifier) Name() string {
+	return "TSModuleModifier"
+}
+
+// Modify writes index.ts of each definition kind directory, and index.ts of the
+// API directory which exports the kinds as namespaces, e.g. component
+func (m *TSModuleModifier) Modify() error {
+	apiDir := path.Join(m.Output, m.APIDirectory)
+	var kinds []string
+	for _, kind := range []string{v1beta1.ComponentDefinitionKind, v1beta1.TraitDefinitionKind, v1beta1.WorkflowStepDefinitionKind, v1beta1.PolicyDefinitionKind} {
+		dir := definition.DefinitionKindToType[kind]
+		files, err := os.ReadDir(path.Join(apiDir, dir))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		var exports []string
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".ts") || f.Name() == "index.ts" {
+				continue
+			}
+			exports = append(exports, fmt.Sprintf("export * from \"./%s\";", strings.TrimSuffix(f.Name(), ".ts")))
+		}
+		if len(exports) == 0 {
+			continue
+		}
+		if err := writeIndex(path.Join(apiDir, dir, "index.ts"), exports); err != nil {
+			return err
+		}
+		kinds = append(kinds, fmt.Sprintf("export * as %s from \"./%s\";", strcase.ToCamel(dir), dir))
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+	return writeIndex(path.Join(apiDir, "index.ts"), kinds)
+}
+
+func writeIndex(file string, exports []string) error {
+	content := "// Code generated by vela def gen-api. DO NOT EDIT.\n\n" + strings.Join(exports, "\n") + "\n"
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		return errors.Wrapf(err, "write %s", file)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package goloader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+)
+
+const (
+	// moduleRecordPrefix is the name prefix of the ConfigMaps recording the installed modules
+	moduleRecordPrefix = "definition-module-"
+	// moduleRecordKey is the data key of the install record in the ConfigMap
+	moduleRecordKey = "module.json"
+)
+
+// InstalledModule records a definition module installed in the cluster from a registry
+type InstalledModule struct {
+	// Name is the module name
+	Name string `json:"name"`
+	// Version is the installed version
+	Version string `json:"version"`
+	// Repository is the OCI repository the module is installed from
+	Repository string `json:"repository"`
+	// Digest is the manifest digest of the installed artifact
+	Digest string `json:"digest,omitempty"`
+	// InstalledAt is the time the module is installed or upgraded
+	InstalledAt time.Time `json:"installedAt"`
+	// Dependencies are the dependencies declared by the installed version
+	Dependencies []ModuleDependency `json:"dependencies,omitempty"`
+	// Definitions are the definitions installed by the module
+	Definitions []InstalledDefinition `json:"definitions"`
+}
+
+// InstalledDefinition is a definition installed by a module
+type InstalledDefinition struct {
+	// Name is the definition name
+	Name string `json:"name"`
+	// Kind is the definition kind, e.g. ComponentDefinition
+	Kind string `json:"kind"`
+	// SpecHash is the hash of the definition spec at install time, used to detect drift
+	SpecHash string `json:"specHash"`
+}
+
+// DriftStatus is the state of an installed definition compared with its install record
+type DriftStatus string
+
+const (
+	// DriftStatusInSync means the definition is unchanged since it was installed
+	DriftStatusInSync DriftStatus = "InSync"
+	// DriftStatusModified means the spec of the definition was changed after it was installed
+	DriftStatusModified DriftStatus = "Modified"
+	// DriftStatusMissing means the definition was deleted from the cluster
+	DriftStatusMissing DriftStatus = "Missing"
+	// DriftStatusTakenOver means the definition was overwritten by another module or version
+	DriftStatusTakenOver DriftStatus = "TakenOver"
+)
+
+// DefinitionDrift is the drift status of an installed definition
+type DefinitionDrift struct {
+	Name   string
+	Kind   string
+	Status DriftStatus
+}
+
+// ModuleRecordName returns the name of the ConfigMap recording the installed module
+func ModuleRecordName(module string) string {
+	return moduleRecordPrefix + module
+}
+
+// DefinitionSpecHash returns the hash of the spec of a definition object
+func DefinitionSpecHash(obj map[string]interface{}) (string, error) {
+	spec, err := json.Marshal(obj["spec"])
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the definition spec: %w", err)
+	}
+	sum := sha256.Sum256(spec)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// GetModuleRecord returns the install record of the module, or nil if the module is not installed
+func GetModuleRecord(ctx context.Context, cli client.Client, namespace, module string) (*InstalledModule, error) {
+	cm := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ModuleRecordName(module)}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the install record of module %s: %w", module, err)
+	}
+	return parseModuleRecord(cm)
+}
+
+// ListModuleRecords returns the install records of all the modules in the namespace, sorted by name
+func ListModuleRecords(ctx context.Context, cli client.Client, namespace string) ([]InstalledModule, error) {
+	cms := &corev1.ConfigMapList{}
+	if err := cli.List(ctx, cms, client.InNamespace(namespace), client.HasLabels{types.LabelDefinitionModuleRecord}); err != nil {
+		return nil, fmt.Errorf("failed to list the installed modules: %w", err)
+	}
+	records := make([]InstalledModule, 0, len(cms.Items))
+	for i := range cms.Items {
+		record, err := parseModuleRecord(&cms.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Name < records[j].Name })
+	return records, nil
+}
+
+// SaveModuleRecord creates or updates the install record of the module
+func SaveModuleRecord(ctx context.Context, cli client.Client, namespace string, record *InstalledModule) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the install record of module %s: %w", record.Name, err)
+	}
+	cm := &corev1.ConfigMap{}
+	err = cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ModuleRecordName(record.Name)}, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get the install record of module %s: %w", record.Name, err)
+	}
+	exists := err == nil
+	if !exists {
+		cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: ModuleRecordName(record.Name)}}
+	}
+	if cm.Labels == nil {
+		cm.Labels = map[string]string{}
+	}
+	cm.Labels[types.LabelDefinitionModuleRecord] = record.Name
+	cm.Data = map[string]string{moduleRecordKey: string(data)}
+	if exists {
+		err = cli.Update(ctx, cm)
+	} else {
+		err = cli.Create(ctx, cm)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save the install record of module %s: %w", record.Name, err)
+	}
+	return nil
+}
+
+// CheckModuleDrift compares the definitions in the cluster with the install record of the module
+func CheckModuleDrift(ctx context.Context, cli client.Client, namespace string, record *InstalledModule) ([]DefinitionDrift, error) {
+	drifts := make([]DefinitionDrift, 0, len(record.Definitions))
+	for _, def := range record.Definitions {
+		drift := DefinitionDrift{Name: def.Name, Kind: def.Kind, Status: DriftStatusInSync}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(def.Kind))
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: def.Name}, obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get %s %s: %w", def.Kind, def.Name, err)
+			}
+			drift.Status = DriftStatusMissing
+			drifts = append(drifts, drift)
+			continue
+		}
+		if obj.GetLabels()[types.LabelDefinitionModule] != record.Name ||
+			obj.GetAnnotations()[types.AnnoDefinitionModuleVersion] != record.Version {
+			drift.Status = DriftStatusTakenOver
+		} else {
+			hash, err := DefinitionSpecHash(obj.Object)
+			if err != nil {
+				return nil, err
+			}
+			if hash != def.SpecHash {
+				drift.Status = DriftStatusModified
+			}
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// parseModuleRecord reads the install record from the ConfigMap
+func parseModuleRecord(cm *corev1.ConfigMap) (*InstalledModule, error) {
+	record := &InstalledModule{}
+	if err := json.Unmarshal([]byte(cm.Data[moduleRecordKey]), record); err != nil {
+		return nil, fmt.Errorf("invalid install record %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	return record, nil
+}
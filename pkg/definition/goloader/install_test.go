@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package goloader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+)
+
+func TestModuleRecords(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	record, err := GetModuleRecord(ctx, cli, "vela-system", "my-defs")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	installedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, r := range []*InstalledModule{
+		{Name: "my-defs", Version: "1.0.0", Repository: "ghcr.io/myorg/defs", InstalledAt: installedAt},
+		{Name: "base", Version: "2.0.0", Repository: "ghcr.io/myorg/base", InstalledAt: installedAt},
+		{Name: "my-defs", Version: "1.1.0", Repository: "ghcr.io/myorg/defs", InstalledAt: installedAt,
+			Definitions: []InstalledDefinition{{Name: "web", Kind: "ComponentDefinition", SpecHash: "abc"}}},
+	} {
+		require.NoError(t, SaveModuleRecord(ctx, cli, "vela-system", r))
+	}
+
+	record, err = GetModuleRecord(ctx, cli, "vela-system", "my-defs")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, "1.1.0", record.Version)
+	assert.Equal(t, installedAt, record.InstalledAt)
+	assert.Len(t, record.Definitions, 1)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "vela-system", Name: ModuleRecordName("my-defs")}, cm))
+	assert.Equal(t, "my-defs", cm.Labels[types.LabelDefinitionModuleRecord])
+
+	// ConfigMaps without the record label are ignored
+	require.NoError(t, cli.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "vela-system", Name: "other"}}))
+	records, err := ListModuleRecords(ctx, cli, "vela-system")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "base", records[0].Name)
+	assert.Equal(t, "my-defs", records[1].Name)
+
+	records, err = ListModuleRecords(ctx, cli, "default")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestCheckModuleDrift(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	trait := func(name, module, version, template string) *v1beta1.TraitDefinition {
+		return &v1beta1.TraitDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "vela-system",
+				Name:        name,
+				Labels:      map[string]string{types.LabelDefinitionModule: module},
+				Annotations: map[string]string{types.AnnoDefinitionModuleVersion: version},
+			},
+			Spec: v1beta1.TraitDefinitionSpec{Schematic: &common.Schematic{CUE: &common.CUE{Template: template}}},
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		trait("scaler", "my-defs", "1.0.0", "parameter: {}"),
+		trait("expose", "my-defs", "1.0.0", "parameter: {port: int}"),
+		trait("gateway", "other-defs", "3.0.0", "parameter: {}"),
+	).Build()
+
+	hashOf := func(name string) string {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(v1beta1.TraitDefinitionGroupVersionKind)
+		require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "vela-system", Name: name}, obj))
+		hash, err := DefinitionSpecHash(obj.Object)
+		require.NoError(t, err)
+		return hash
+	}
+	record := &InstalledModule{
+		Name:    "my-defs",
+		Version: "1.0.0",
+		Definitions: []InstalledDefinition{
+			{Name: "scaler", Kind: "TraitDefinition", SpecHash: hashOf("scaler")},
+			{Name: "expose", Kind: "TraitDefinition", SpecHash: "changed-since"},
+			{Name: "gateway", Kind: "TraitDefinition", SpecHash: hashOf("gateway")},
+			{Name: "deleted", Kind: "TraitDefinition", SpecHash: "abc"},
+		},
+	}
+
+	drifts, err := CheckModuleDrift(ctx, cli, "vela-system", record)
+	require.NoError(t, err)
+	assert.Equal(t, []DefinitionDrift{
+		{Name: "scaler", Kind: "TraitDefinition", Status: DriftStatusInSync},
+		{Name: "expose", Kind: "TraitDefinition", Status: DriftStatusModified},
+		{Name: "gateway", Kind: "TraitDefinition", Status: DriftStatusTakenOver},
+		{Name: "deleted", Kind: "TraitDefinition", Status: DriftStatusMissing},
+	}, drifts)
+}
@@ -200,7 +200,8 @@ type Maintainer struct {
 
 // ModuleDependency represents a dependency on another module
 type ModuleDependency struct {
-	// Module is the Go module path
+	// Module is the Go module path, or the OCI repository (e.g., ghcr.io/myorg/defs)
+	// for modules installed from a registry
 	Module string `yaml:"module" json:"module"`
 	// Version is the required version (supports semver constraints)
 	Version string `yaml:"version" json:"version"`
@@ -770,18 +771,8 @@ func ValidateModule(module *LoadedModule, velaVersion string) []error {
 	}
 
 	// Check minimum Vela version using semver comparison
-	if module.Metadata.Spec.MinVelaVersion != "" && velaVersion != "" {
-		minVersion, minErr := semver.NewVersion(module.Metadata.Spec.MinVelaVersion)
-		currentVersion, curErr := semver.NewVersion(velaVersion)
-		if minErr == nil && curErr == nil {
-			if minVersion.GreaterThan(currentVersion) {
-				errs = append(errs, fmt.Errorf(
-					"module requires KubeVela %s or later, but cluster has %s",
-					module.Metadata.Spec.MinVelaVersion,
-					velaVersion,
-				))
-			}
-		}
+	if err := CheckMinVelaVersion(module.Metadata.Spec.MinVelaVersion, velaVersion); err != nil {
+		errs = append(errs, err)
 	}
 
 	// Validate hooks
@@ -793,6 +784,23 @@ func ValidateModule(module *LoadedModule, velaVersion string) []error {
 	return errs
 }
 
+// CheckMinVelaVersion returns an error if velaVersion is lower than the minimum version
+// required by a module. Versions that are empty or not valid semver are not checked.
+func CheckMinVelaVersion(minVelaVersion, velaVersion string) error {
+	if minVelaVersion == "" || velaVersion == "" {
+		return nil
+	}
+	minVersion, minErr := semver.NewVersion(minVelaVersion)
+	currentVersion, curErr := semver.NewVersion(velaVersion)
+	if minErr != nil || curErr != nil {
+		return nil
+	}
+	if minVersion.GreaterThan(currentVersion) {
+		return fmt.Errorf("module requires KubeVela %s or later, but cluster has %s", minVelaVersion, velaVersion)
+	}
+	return nil
+}
+
 // validateHooks validates a list of hooks
 func validateHooks(phase string, hooks []Hook, modulePath string) []error {
 	var errs []error
@@ -0,0 +1,530 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package goloader
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// ModuleArtifactType is the media type of the config of a definition module artifact
+	ModuleArtifactType = "application/vnd.oam.dev.definition-module.config.v1+json"
+	// ModuleLayerMediaType is the media type of the layer holding the module content
+	ModuleLayerMediaType = "application/vnd.oam.dev.definition-module.content.v1.tar+gzip"
+
+	// moduleMetadataFile is the file of the module metadata in the artifact
+	moduleMetadataFile = "module.yaml"
+	// maxModuleArtifactSize limits the size of the extracted module content
+	maxModuleArtifactSize = 32 << 20
+)
+
+// definitionTypeDirs maps the definition types to the directories of the generated CUE files
+var definitionTypeDirs = map[string]string{
+	"component":     "components",
+	"trait":         "traits",
+	"policy":        "policies",
+	"workflow-step": "workflowsteps",
+}
+
+// ModuleArtifact is a definition module packaged for a registry. It carries the module
+// metadata and the generated CUE of the definitions, so it can be installed without the
+// Go toolchain.
+type ModuleArtifact struct {
+	// Metadata is the module metadata
+	Metadata ModuleMetadata
+	// Version is the semver version of the module
+	Version string
+	// Repository is the OCI repository the artifact is pulled from or pushed to
+	Repository string
+	// Digest is the manifest digest of the artifact in the registry
+	Digest string
+	// Definitions are the generated definitions of the module
+	Definitions []ArtifactDefinition
+}
+
+// ArtifactDefinition is a generated definition in a module artifact
+type ArtifactDefinition struct {
+	// Name is the definition name
+	Name string
+	// Type is the definition type (e.g., "component", "trait", "policy", "workflow-step")
+	Type string
+	// CUE is the generated CUE of the definition
+	CUE string
+}
+
+// Name returns the name of the module
+func (a *ModuleArtifact) Name() string {
+	return a.Metadata.Metadata.Name
+}
+
+// ValidateModuleName checks that the module name is a DNS-1123 label, as it names the
+// install record of the module and labels the definitions installed by the module
+func ValidateModuleName(name string) error {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("invalid module name %q in metadata.name: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// PackageModule packages the generated CUE and metadata of a loaded module into an artifact
+func PackageModule(module *LoadedModule, version string) (*ModuleArtifact, error) {
+	if module.Metadata.Metadata.Name == "" {
+		return nil, errors.New("module.yaml with metadata.name is required to package a module")
+	}
+	if err := ValidateModuleName(module.Metadata.Metadata.Name); err != nil {
+		return nil, err
+	}
+	if _, err := semver.NewVersion(version); err != nil {
+		return nil, fmt.Errorf("module version %q is not a valid semver version: %w", version, err)
+	}
+	for _, dep := range module.Metadata.Spec.Dependencies {
+		if _, err := semver.NewConstraint(dependencyConstraint(dep.Version)); err != nil {
+			return nil, fmt.Errorf("invalid version range %q of dependency %s: %w", dep.Version, dep.Module, err)
+		}
+	}
+
+	artifact := &ModuleArtifact{Metadata: module.Metadata, Version: version}
+	seen := map[string]bool{}
+	for _, result := range module.Definitions {
+		if result.Error != nil {
+			return nil, fmt.Errorf("definition %s failed to load: %w", result.Definition.FilePath, result.Error)
+		}
+		if _, ok := definitionTypeDirs[result.Definition.Type]; !ok {
+			return nil, fmt.Errorf("definition %s has unknown type %q", result.Definition.Name, result.Definition.Type)
+		}
+		key := result.Definition.Type + "/" + result.Definition.Name
+		if seen[key] {
+			return nil, fmt.Errorf("duplicated %s definition %s", result.Definition.Type, result.Definition.Name)
+		}
+		seen[key] = true
+		artifact.Definitions = append(artifact.Definitions, ArtifactDefinition{
+			Name: result.Definition.Name,
+			Type: result.Definition.Type,
+			CUE:  result.CUE,
+		})
+	}
+	if len(artifact.Definitions) == 0 {
+		return nil, errors.New("module has no definitions to package")
+	}
+	sort.Slice(artifact.Definitions, func(i, j int) bool {
+		if artifact.Definitions[i].Type != artifact.Definitions[j].Type {
+			return artifact.Definitions[i].Type < artifact.Definitions[j].Type
+		}
+		return artifact.Definitions[i].Name < artifact.Definitions[j].Name
+	})
+	return artifact, nil
+}
+
+// Archive returns the module content as a tar.gz archive. The archive has the same layout
+// as the output of `vela def gen-module`, plus the module.yaml. It is reproducible, so the
+// same module content always results in the same digest.
+func (a *ModuleArtifact) Archive() ([]byte, error) {
+	metadata, err := yaml.Marshal(a.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal module metadata: %w", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	write := func(name string, content []byte) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+	if err := write(moduleMetadataFile, metadata); err != nil {
+		return nil, fmt.Errorf("failed to archive %s: %w", moduleMetadataFile, err)
+	}
+	for _, def := range a.Definitions {
+		name := path.Join(definitionTypeDirs[def.Type], def.Name+".cue")
+		if err := write(name, []byte(def.CUE)); err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseModuleArchive parses the module content archived by ModuleArtifact.Archive
+func ParseModuleArchive(data []byte, version string) (*ModuleArtifact, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("module content is not a gzip archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	dirTypes := map[string]string{}
+	for typ, dir := range definitionTypeDirs {
+		dirTypes[dir] = typ
+	}
+
+	artifact := &ModuleArtifact{Version: version}
+	var hasMetadata bool
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read module content: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		total += header.Size
+		if total > maxModuleArtifactSize {
+			return nil, fmt.Errorf("module content exceeds the limit of %d bytes", maxModuleArtifactSize)
+		}
+		content, err := io.ReadAll(io.LimitReader(tr, header.Size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+
+		name := path.Clean(header.Name)
+		if name == moduleMetadataFile {
+			if err := yaml.Unmarshal(content, &artifact.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", moduleMetadataFile, err)
+			}
+			hasMetadata = true
+			continue
+		}
+		dir, file := path.Split(name)
+		typ, ok := dirTypes[strings.TrimSuffix(dir, "/")]
+		if !ok || path.Ext(file) != ".cue" {
+			continue
+		}
+		artifact.Definitions = append(artifact.Definitions, ArtifactDefinition{
+			Name: strings.TrimSuffix(file, ".cue"),
+			Type: typ,
+			CUE:  string(content),
+		})
+	}
+	if !hasMetadata {
+		return nil, fmt.Errorf("module content has no %s", moduleMetadataFile)
+	}
+	return artifact, nil
+}
+
+// ModuleRegistry stores the versions of the definition module artifacts
+type ModuleRegistry interface {
+	// ListVersions returns the versions of the module in the repository
+	ListVersions(ctx context.Context, repository string) ([]string, error)
+	// Pull fetches the module artifact of the given version
+	Pull(ctx context.Context, repository, version string) (*ModuleArtifact, error)
+}
+
+// ResolveVersion returns the highest version satisfying the constraint. An empty
+// constraint resolves to the latest stable version. Versions that are not valid semver
+// are ignored.
+func ResolveVersion(versions []string, constraint string) (string, error) {
+	c, err := semver.NewConstraint(dependencyConstraint(constraint))
+	if err != nil {
+		return "", fmt.Errorf("invalid version range %q: %w", constraint, err)
+	}
+	var best *semver.Version
+	var resolved string
+	for _, v := range versions {
+		sv, err := semver.NewVersion(v)
+		if err != nil || !c.Check(sv) {
+			continue
+		}
+		if best == nil || sv.GreaterThan(best) {
+			best, resolved = sv, v
+		}
+	}
+	if best == nil {
+		if constraint == "" {
+			return "", fmt.Errorf("no released version in %v", versions)
+		}
+		return "", fmt.Errorf("no version satisfies %q in %v", constraint, versions)
+	}
+	return resolved, nil
+}
+
+// dependencyConstraint returns the constraint matching any version if the range is empty
+func dependencyConstraint(constraint string) string {
+	if strings.TrimSpace(constraint) == "" {
+		return "*"
+	}
+	return constraint
+}
+
+// maxResolveRounds bounds the rounds of ResolveModuleDependencies before giving up
+const maxResolveRounds = 100
+
+// ResolveModuleDependencies resolves the dependencies of the root module, recursively,
+// from the registry. Each dependency is resolved to the highest version satisfying the
+// version ranges of all the modules requiring it, including the installed modules which
+// are not reinstalled. The result lists the dependencies in install order: a module
+// always comes after the modules it depends on.
+func ResolveModuleDependencies(ctx context.Context, registry ModuleRegistry, root *ModuleArtifact, installed []InstalledModule) ([]*ModuleArtifact, error) {
+	rootRepo := NormalizeRepository(root.Repository)
+	// requirements maps a repository to the version ranges required by each dependent
+	requirements := map[string]map[string]string{}
+	selected := map[string]*ModuleArtifact{}
+	versions := map[string][]string{}
+	installedRequirements := installedDependencies(installed)
+	// constraint combines the ranges of the modules to install and of the installed
+	// modules, the installed versions of the modules to install no longer apply
+	constraint := func(repo string) (string, []string) {
+		ranges := map[string]string{}
+		for from, version := range requirements[repo] {
+			ranges[from] = version
+		}
+		for from, version := range installedRequirements[repo] {
+			if from != rootRepo && selected[from] == nil {
+				ranges[installedDependent(from)] = version
+			}
+		}
+		return combineConstraints(ranges), sortedKeys(ranges)
+	}
+
+	require := func(from string, deps []ModuleDependency) error {
+		for _, dep := range deps {
+			repo := NormalizeRepository(dep.Module)
+			if repo == rootRepo {
+				return fmt.Errorf("dependency cycle: %s depends on %s", from, root.Repository)
+			}
+			if requirements[repo] == nil {
+				requirements[repo] = map[string]string{}
+			}
+			requirements[repo][from] = dep.Version
+		}
+		return nil
+	}
+	if err := require(rootRepo, root.Metadata.Spec.Dependencies); err != nil {
+		return nil, err
+	}
+
+	for round := 0; ; round++ {
+		if round >= maxResolveRounds {
+			return nil, errors.New("module dependencies cannot be resolved: the version ranges keep changing")
+		}
+		reachable := reachableModules(rootRepo, root, selected)
+		changed := false
+		for _, repo := range sortedKeys(requirements) {
+			// drop the requirements of the modules that are no longer selected
+			for from := range requirements[repo] {
+				if !reachable[from] {
+					delete(requirements[repo], from)
+				}
+			}
+			if len(requirements[repo]) == 0 {
+				continue
+			}
+			if versions[repo] == nil {
+				vs, err := registry.ListVersions(ctx, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list versions of %s: %w", repo, err)
+				}
+				versions[repo] = vs
+			}
+			ranges, dependents := constraint(repo)
+			version, err := ResolveVersion(versions[repo], ranges)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve %s required by %s: %w", repo, strings.Join(dependents, ", "), err)
+			}
+			if current := selected[repo]; current != nil && current.Version == version {
+				continue
+			}
+			artifact, err := registry.Pull(ctx, repo, version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to pull %s:%s: %w", repo, version, err)
+			}
+			artifact.Repository = repo
+			selected[repo] = artifact
+			for _, deps := range requirements {
+				delete(deps, repo)
+			}
+			if err := require(repo, artifact.Metadata.Spec.Dependencies); err != nil {
+				return nil, err
+			}
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+
+	return installOrder(rootRepo, root, selected)
+}
+
+// CheckInstalledDependents checks that the versions of the modules to install satisfy
+// the version ranges required by the installed modules which are not reinstalled
+func CheckInstalledDependents(artifacts []*ModuleArtifact, installed []InstalledModule) error {
+	planned := map[string]bool{}
+	for _, artifact := range artifacts {
+		planned[NormalizeRepository(artifact.Repository)] = true
+	}
+	installedRequirements := installedDependencies(installed)
+	for _, artifact := range artifacts {
+		repo := NormalizeRepository(artifact.Repository)
+		version, err := semver.NewVersion(artifact.Version)
+		if err != nil {
+			return fmt.Errorf("invalid version %q of module %s: %w", artifact.Version, repo, err)
+		}
+		for _, from := range sortedKeys(installedRequirements[repo]) {
+			if planned[from] {
+				continue
+			}
+			required := installedRequirements[repo][from]
+			c, err := semver.NewConstraint(dependencyConstraint(required))
+			if err != nil {
+				return fmt.Errorf("invalid version range %q of %s required by %s: %w", required, repo, installedDependent(from), err)
+			}
+			if !c.Check(version) {
+				return fmt.Errorf("%s %s conflicts with %s requiring %q", repo, artifact.Version, installedDependent(from), required)
+			}
+		}
+	}
+	return nil
+}
+
+// installedDependencies maps a repository to the version ranges required by each installed module
+func installedDependencies(installed []InstalledModule) map[string]map[string]string {
+	requirements := map[string]map[string]string{}
+	for _, record := range installed {
+		from := NormalizeRepository(record.Repository)
+		for _, dep := range record.Dependencies {
+			repo := NormalizeRepository(dep.Module)
+			if requirements[repo] == nil {
+				requirements[repo] = map[string]string{}
+			}
+			requirements[repo][from] = dep.Version
+		}
+	}
+	return requirements
+}
+
+// installedDependent describes an installed module requiring a dependency
+func installedDependent(repo string) string {
+	return repo + " (installed)"
+}
+
+// NormalizeRepository strips the oci:// scheme and the tag or digest from a repository reference
+func NormalizeRepository(ref string) string {
+	repo, _ := SplitModuleReference(ref)
+	return repo
+}
+
+// SplitModuleReference splits an OCI reference like ghcr.io/myorg/defs:1.2.0 into the
+// repository and the tag. The tag is empty if the reference has none.
+func SplitModuleReference(ref string) (repository, tag string) {
+	ref = strings.TrimPrefix(ref, "oci://")
+	if idx := strings.Index(ref, "@"); idx != -1 {
+		ref = ref[:idx]
+	}
+	if idx := strings.LastIndex(ref, ":"); idx != -1 && !strings.Contains(ref[idx+1:], "/") {
+		return ref[:idx], ref[idx+1:]
+	}
+	return ref, ""
+}
+
+// combineConstraints joins the version ranges required by all the dependents
+func combineConstraints(ranges map[string]string) string {
+	var parts []string
+	for _, from := range sortedKeys(ranges) {
+		parts = append(parts, dependencyConstraint(ranges[from]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// reachableModules returns the repositories reachable from the root through the selected modules
+func reachableModules(rootRepo string, root *ModuleArtifact, selected map[string]*ModuleArtifact) map[string]bool {
+	reachable := map[string]bool{rootRepo: true}
+	var visit func(deps []ModuleDependency)
+	visit = func(deps []ModuleDependency) {
+		for _, dep := range deps {
+			repo := NormalizeRepository(dep.Module)
+			if reachable[repo] {
+				continue
+			}
+			reachable[repo] = true
+			if artifact := selected[repo]; artifact != nil {
+				visit(artifact.Metadata.Spec.Dependencies)
+			}
+		}
+	}
+	visit(root.Metadata.Spec.Dependencies)
+	return reachable
+}
+
+// installOrder sorts the selected modules so that every module comes after its dependencies
+func installOrder(rootRepo string, root *ModuleArtifact, selected map[string]*ModuleArtifact) ([]*ModuleArtifact, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{rootRepo: visiting}
+	var order []*ModuleArtifact
+	var visit func(from string, deps []ModuleDependency) error
+	visit = func(from string, deps []ModuleDependency) error {
+		for _, dep := range deps {
+			repo := NormalizeRepository(dep.Module)
+			switch state[repo] {
+			case visited:
+				continue
+			case visiting:
+				return fmt.Errorf("dependency cycle: %s depends on %s", from, repo)
+			}
+			state[repo] = visiting
+			artifact := selected[repo]
+			if err := visit(repo, artifact.Metadata.Spec.Dependencies); err != nil {
+				return err
+			}
+			state[repo] = visited
+			order = append(order, artifact)
+		}
+		return nil
+	}
+	if err := visit(rootRepo, root.Metadata.Spec.Dependencies); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// sortedKeys returns the keys of the map in order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
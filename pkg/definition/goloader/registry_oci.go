@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package goloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	ocitypes "github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// annotationModuleName is the manifest annotation of the module name
+	annotationModuleName = "dev.oam.definition-module.name"
+	// annotationVersion is the manifest annotation of the module version
+	annotationVersion = "org.opencontainers.image.version"
+	// annotationDescription is the manifest annotation of the module description
+	annotationDescription = "org.opencontainers.image.description"
+)
+
+// RegistryOptions configures the access to an OCI registry
+type RegistryOptions struct {
+	// Insecure allows the registry to be accessed over plain HTTP
+	Insecure bool
+	// Username and Password are the basic auth credentials. The docker credentials
+	// of the local machine are used if they are not set.
+	Username string
+	Password string
+}
+
+// OCIModuleRegistry stores definition modules as OCI artifacts. Every version of a module
+// is a tag of the repository.
+type OCIModuleRegistry struct {
+	opts RegistryOptions
+}
+
+// NewOCIModuleRegistry creates a module registry accessing OCI registries
+func NewOCIModuleRegistry(opts RegistryOptions) *OCIModuleRegistry {
+	return &OCIModuleRegistry{opts: opts}
+}
+
+// Push uploads the module artifact as the version tag of the repository and returns the manifest digest
+func (r *OCIModuleRegistry) Push(ctx context.Context, artifact *ModuleArtifact, repository string) (string, error) {
+	ref, err := r.reference(repository, artifact.Version)
+	if err != nil {
+		return "", err
+	}
+	content, err := artifact.Archive()
+	if err != nil {
+		return "", err
+	}
+
+	img, err := mutate.AppendLayers(empty.Image, static.NewLayer(content, ModuleLayerMediaType))
+	if err != nil {
+		return "", fmt.Errorf("failed to build the module artifact: %w", err)
+	}
+	img = mutate.MediaType(img, ocitypes.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, ModuleArtifactType)
+	annotations := map[string]string{
+		annotationModuleName: artifact.Name(),
+		annotationVersion:    artifact.Version,
+	}
+	if artifact.Metadata.Spec.Description != "" {
+		annotations[annotationDescription] = artifact.Metadata.Spec.Description
+	}
+	img, ok := mutate.Annotations(img, annotations).(v1.Image)
+	if !ok {
+		return "", errors.New("failed to annotate the module artifact")
+	}
+
+	if err := remote.Write(ref, img, r.remoteOptions(ctx)...); err != nil {
+		return "", fmt.Errorf("failed to push %s: %w", ref, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the digest of %s: %w", ref, err)
+	}
+	artifact.Repository = NormalizeRepository(repository)
+	artifact.Digest = digest.String()
+	return artifact.Digest, nil
+}
+
+// ListVersions returns the tags of the repository which are valid semver versions
+func (r *OCIModuleRegistry) ListVersions(ctx context.Context, repository string) ([]string, error) {
+	repo, err := name.NewRepository(NormalizeRepository(repository), r.nameOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository %s: %w", repository, err)
+	}
+	tags, err := remote.List(repo, r.remoteOptions(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, tag := range tags {
+		version := tagToVersion(tag)
+		if _, err := semver.NewVersion(version); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// Pull fetches the module artifact of the given version
+func (r *OCIModuleRegistry) Pull(ctx context.Context, repository, version string) (*ModuleArtifact, error) {
+	ref, err := r.reference(repository, version)
+	if err != nil {
+		return nil, err
+	}
+	img, err := remote.Image(ref, r.remoteOptions(ctx)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %s: %w", ref, err)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the manifest of %s: %w", ref, err)
+	}
+	if manifest.Config.MediaType != ModuleArtifactType {
+		return nil, fmt.Errorf("%s is not a definition module artifact (config media type %s)", ref, manifest.Config.MediaType)
+	}
+
+	var content []byte
+	for _, desc := range manifest.Layers {
+		if desc.MediaType != ModuleLayerMediaType {
+			continue
+		}
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the module content of %s: %w", ref, err)
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the module content of %s: %w", ref, err)
+		}
+		content, err = io.ReadAll(io.LimitReader(rc, maxModuleArtifactSize))
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the module content of %s: %w", ref, err)
+		}
+		break
+	}
+	if content == nil {
+		return nil, fmt.Errorf("%s has no module content layer", ref)
+	}
+
+	if v := manifest.Annotations[annotationVersion]; v != "" {
+		version = v
+	}
+	artifact, err := ParseModuleArchive(content, version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module artifact %s: %w", ref, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the digest of %s: %w", ref, err)
+	}
+	artifact.Repository = NormalizeRepository(repository)
+	artifact.Digest = digest.String()
+	return artifact, nil
+}
+
+// reference returns the tag reference of the module version in the repository
+func (r *OCIModuleRegistry) reference(repository, version string) (name.Reference, error) {
+	ref := NormalizeRepository(repository) + ":" + versionToTag(version)
+	tag, err := name.NewTag(ref, r.nameOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module reference %s: %w", ref, err)
+	}
+	return tag, nil
+}
+
+func (r *OCIModuleRegistry) nameOptions() []name.Option {
+	if r.opts.Insecure {
+		return []name.Option{name.Insecure}
+	}
+	return nil
+}
+
+func (r *OCIModuleRegistry) remoteOptions(ctx context.Context) []remote.Option {
+	opts := []remote.Option{remote.WithContext(ctx)}
+	if r.opts.Username != "" || r.opts.Password != "" {
+		return append(opts, remote.WithAuth(&authn.Basic{Username: r.opts.Username, Password: r.opts.Password}))
+	}
+	return append(opts, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+}
+
+// versionToTag converts a semver version to an OCI tag, which doesn't allow the "+" of the build metadata
+func versionToTag(version string) string {
+	return strings.ReplaceAll(version, "+", "_")
+}
+
+// tagToVersion converts an OCI tag back to the semver version
+func tagToVersion(tag string) string {
+	return strings.ReplaceAll(tag, "_", "+")
+}
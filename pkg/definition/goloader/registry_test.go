@@ -0,0 +1,327 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package goloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeModuleRegistry serves module artifacts from memory, keyed by repository and version
+type fakeModuleRegistry struct {
+	modules map[string]map[string]*ModuleArtifact
+}
+
+func (r *fakeModuleRegistry) add(repo, name, version string, deps ...ModuleDependency) {
+	if r.modules == nil {
+		r.modules = map[string]map[string]*ModuleArtifact{}
+	}
+	if r.modules[repo] == nil {
+		r.modules[repo] = map[string]*ModuleArtifact{}
+	}
+	artifact := &ModuleArtifact{Version: version}
+	artifact.Metadata.Metadata.Name = name
+	artifact.Metadata.Spec.Dependencies = deps
+	r.modules[repo][version] = artifact
+}
+
+func (r *fakeModuleRegistry) ListVersions(_ context.Context, repo string) ([]string, error) {
+	var versions []string
+	for v := range r.modules[repo] {
+		versions = append(versions, v)
+	}
+	if versions == nil {
+		return nil, errors.New("repository not found")
+	}
+	return versions, nil
+}
+
+func (r *fakeModuleRegistry) Pull(_ context.Context, repo, version string) (*ModuleArtifact, error) {
+	artifact, ok := r.modules[repo][version]
+	if !ok {
+		return nil, errors.New("manifest unknown")
+	}
+	copied := *artifact
+	return &copied, nil
+}
+
+func testLoadedModule() *LoadedModule {
+	module := &LoadedModule{
+		Metadata: ModuleMetadata{
+			APIVersion: "defkit.oam.dev/v1",
+			Kind:       "DefinitionModule",
+			Metadata:   ModuleObjectMeta{Name: "my-defs"},
+			Spec: ModuleSpec{
+				Description:  "My definitions",
+				Dependencies: []ModuleDependency{{Module: "ghcr.io/myorg/base", Version: "^1.0.0"}},
+			},
+		},
+		Definitions: []LoadResult{
+			{CUE: "scaler: {}", Definition: DefinitionInfo{Name: "scaler", Type: "trait"}},
+			{CUE: "web: {}", Definition: DefinitionInfo{Name: "web", Type: "component"}},
+			{CUE: "deploy: {}", Definition: DefinitionInfo{Name: "deploy", Type: "workflow-step"}},
+		},
+	}
+	return module
+}
+
+func TestPackageModule(t *testing.T) {
+	artifact, err := PackageModule(testLoadedModule(), "1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "my-defs", artifact.Name())
+	assert.Equal(t, "1.2.0", artifact.Version)
+	require.Len(t, artifact.Definitions, 3)
+	assert.Equal(t, "web", artifact.Definitions[0].Name)
+	assert.Equal(t, "scaler", artifact.Definitions[1].Name)
+	assert.Equal(t, "deploy", artifact.Definitions[2].Name)
+
+	data, err := artifact.Archive()
+	require.NoError(t, err)
+	again, err := artifact.Archive()
+	require.NoError(t, err)
+	assert.Equal(t, data, again, "archive should be reproducible")
+
+	parsed, err := ParseModuleArchive(data, "1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, artifact.Metadata, parsed.Metadata)
+	assert.ElementsMatch(t, artifact.Definitions, parsed.Definitions)
+}
+
+func TestPackageModuleErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(m *LoadedModule)
+		version string
+		wantErr string
+	}{
+		{name: "no name", modify: func(m *LoadedModule) { m.Metadata.Metadata.Name = "" }, version: "1.0.0", wantErr: "metadata.name is required"},
+		{name: "invalid name", modify: func(m *LoadedModule) { m.Metadata.Metadata.Name = "My_Defs" }, version: "1.0.0", wantErr: `invalid module name "My_Defs"`},
+		{name: "invalid version", modify: func(m *LoadedModule) {}, version: "latest", wantErr: "not a valid semver version"},
+		{name: "invalid dependency range", modify: func(m *LoadedModule) { m.Metadata.Spec.Dependencies[0].Version = "not a range" }, version: "1.0.0", wantErr: "invalid version range"},
+		{name: "failed definition", modify: func(m *LoadedModule) { m.Definitions[0].Error = errors.New("boom") }, version: "1.0.0", wantErr: "failed to load"},
+		{name: "duplicated definition", modify: func(m *LoadedModule) { m.Definitions = append(m.Definitions, m.Definitions[0]) }, version: "1.0.0", wantErr: "duplicated trait definition scaler"},
+		{name: "no definitions", modify: func(m *LoadedModule) { m.Definitions = nil }, version: "1.0.0", wantErr: "no definitions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			module := testLoadedModule()
+			tt.modify(module)
+			_, err := PackageModule(module, tt.version)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseModuleArchiveRequiresMetadata(t *testing.T) {
+	_, err := ParseModuleArchive([]byte("not gzip"), "1.0.0")
+	assert.Error(t, err)
+
+	artifact := &ModuleArtifact{Definitions: []ArtifactDefinition{{Name: "web", Type: "component", CUE: "web: {}"}}}
+	data, err := artifact.Archive()
+	require.NoError(t, err)
+	parsed, err := ParseModuleArchive(data, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "", parsed.Name())
+	assert.Len(t, parsed.Definitions, 1)
+}
+
+func TestResolveVersion(t *testing.T) {
+	versions := []string{"1.0.0", "1.2.0", "v1.3.0", "2.0.0", "2.1.0-rc.1", "latest"}
+	tests := []struct {
+		constraint string
+		want       string
+		wantErr    bool
+	}{
+		{constraint: "", want: "2.0.0"},
+		{constraint: "^1.0.0", want: "v1.3.0"},
+		{constraint: ">=1.0.0, <1.3.0", want: "1.2.0"},
+		{constraint: "1.0.0", want: "1.0.0"},
+		{constraint: ">=2.1.0-0", want: "2.1.0-rc.1"},
+		{constraint: "^3.0.0", wantErr: true},
+		{constraint: "not a range", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got, err := ResolveVersion(versions, tt.constraint)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitModuleReference(t *testing.T) {
+	tests := []struct {
+		ref, repo, tag string
+	}{
+		{ref: "ghcr.io/myorg/defs", repo: "ghcr.io/myorg/defs"},
+		{ref: "ghcr.io/myorg/defs:1.2.0", repo: "ghcr.io/myorg/defs", tag: "1.2.0"},
+		{ref: "oci://localhost:5000/defs:1.2.0", repo: "localhost:5000/defs", tag: "1.2.0"},
+		{ref: "localhost:5000/defs", repo: "localhost:5000/defs"},
+		{ref: "ghcr.io/myorg/defs@sha256:abc", repo: "ghcr.io/myorg/defs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			repo, tag := SplitModuleReference(tt.ref)
+			assert.Equal(t, tt.repo, repo)
+			assert.Equal(t, tt.tag, tag)
+		})
+	}
+}
+
+func TestResolveModuleDependencies(t *testing.T) {
+	dep := func(repo, version string) ModuleDependency {
+		return ModuleDependency{Module: repo, Version: version}
+	}
+	root := func(deps ...ModuleDependency) *ModuleArtifact {
+		artifact := &ModuleArtifact{Repository: "oci://registry/root:1.0.0", Version: "1.0.0"}
+		artifact.Metadata.Metadata.Name = "root"
+		artifact.Metadata.Spec.Dependencies = deps
+		return artifact
+	}
+	names := func(artifacts []*ModuleArtifact) []string {
+		var res []string
+		for _, a := range artifacts {
+			res = append(res, fmt.Sprintf("%s@%s", a.Name(), a.Version))
+		}
+		return res
+	}
+
+	t.Run("diamond resolves to the highest common version", func(t *testing.T) {
+		reg := &fakeModuleRegistry{}
+		reg.add("registry/a", "a", "1.0.0", dep("registry/base", "^1.0.0"))
+		reg.add("registry/b", "b", "1.0.0", dep("registry/base", "<1.3.0"))
+		reg.add("registry/base", "base", "1.1.0")
+		reg.add("registry/base", "base", "1.2.0")
+		reg.add("registry/base", "base", "1.3.0")
+		reg.add("registry/base", "base", "2.0.0")
+
+		order, err := ResolveModuleDependencies(context.Background(), reg, root(dep("registry/a", ""), dep("oci://registry/b", "1.x")), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"base@1.2.0", "a@1.0.0", "b@1.0.0"}, names(order))
+		assert.Equal(t, "registry/base", order[0].Repository)
+	})
+
+	t.Run("requirements of replaced versions are dropped", func(t *testing.T) {
+		reg := &fakeModuleRegistry{}
+		// a 2.0.0 requires an old base, but b only works with a 1.x
+		reg.add("registry/a", "a", "1.0.0", dep("registry/base", "^2.0.0"))
+		reg.add("registry/a", "a", "2.0.0", dep("registry/base", "^1.0.0"))
+		reg.add("registry/b", "b", "1.0.0", dep("registry/a", "^1.0.0"))
+		reg.add("registry/base", "base", "1.0.0")
+		reg.add("registry/base", "base", "2.0.0")
+
+		order, err := ResolveModuleDependencies(context.Background(), reg, root(dep("registry/a", ""), dep("registry/b", "")), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"base@2.0.0", "a@1.0.0", "b@1.0.0"}, names(order))
+	})
+
+	t.Run("conflicting ranges", func(t *testing.T) {
+		reg := &fakeModuleRegistry{}
+		reg.add("registry/a", "a", "1.0.0", dep("registry/base", "^1.0.0"))
+		reg.add("registry/base", "base", "1.0.0")
+		reg.add("registry/base", "base", "2.0.0")
+
+		_, err := ResolveModuleDependencies(context.Background(), reg, root(dep("registry/a", ""), dep("registry/base", "^2.0.0")), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "registry/base required by registry/a, registry/root")
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		reg := &fakeModuleRegistry{}
+		reg.add("registry/a", "a", "1.0.0", dep("registry/b", ""))
+		reg.add("registry/b", "b", "1.0.0", dep("registry/a", ""))
+
+		_, err := ResolveModuleDependencies(context.Background(), reg, root(dep("registry/a", "")), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dependency cycle")
+
+		reg.add("registry/c", "c", "1.0.0", dep("registry/root", ""))
+		_, err = ResolveModuleDependencies(context.Background(), reg, root(dep("registry/c", "")), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dependency cycle")
+	})
+
+	t.Run("ranges of the installed modules", func(t *testing.T) {
+		reg := &fakeModuleRegistry{}
+		reg.add("registry/a", "a", "1.0.0", dep("registry/base", ""))
+		reg.add("registry/a", "a", "2.0.0", dep("registry/base", ""))
+		reg.add("registry/base", "base", "1.0.0")
+		reg.add("registry/base", "base", "2.0.0")
+		installed := []InstalledModule{
+			{Name: "other", Repository: "registry/other", Version: "1.0.0", Dependencies: []ModuleDependency{dep("registry/base", "^1.0.0")}},
+			// the requirements of the installed version of a module to install no longer apply
+			{Name: "a", Repository: "registry/a", Version: "1.0.0", Dependencies: []ModuleDependency{dep("registry/base", "<1.0.0")}},
+		}
+
+		order, err := ResolveModuleDependencies(context.Background(), reg, root(dep("registry/a", "")), installed)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"base@1.0.0", "a@2.0.0"}, names(order))
+
+		_, err = ResolveModuleDependencies(context.Background(), reg, root(dep("registry/a", ""), dep("registry/base", "^2.0.0")), installed)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "registry/base required by registry/a, registry/other (installed), registry/root")
+	})
+
+	t.Run("missing repository", func(t *testing.T) {
+		_, err := ResolveModuleDependencies(context.Background(), &fakeModuleRegistry{}, root(dep("registry/a", "")), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list versions of registry/a")
+	})
+}
+
+func TestCheckInstalledDependents(t *testing.T) {
+	artifact := func(repo, version string) *ModuleArtifact {
+		return &ModuleArtifact{Repository: repo, Version: version}
+	}
+	installed := []InstalledModule{
+		{Name: "other", Repository: "registry/other", Version: "1.0.0", Dependencies: []ModuleDependency{{Module: "oci://registry/base", Version: "^1.0.0"}}},
+		{Name: "a", Repository: "registry/a", Version: "1.0.0", Dependencies: []ModuleDependency{{Module: "registry/base", Version: "<1.0.0"}}},
+	}
+
+	assert.NoError(t, CheckInstalledDependents([]*ModuleArtifact{artifact("registry/base", "1.2.0"), artifact("registry/a", "2.0.0")}, installed))
+	assert.NoError(t, CheckInstalledDependents([]*ModuleArtifact{artifact("registry/base", "2.0.0"), artifact("registry/other", "2.0.0"), artifact("registry/a", "2.0.0")}, installed))
+
+	err := CheckInstalledDependents([]*ModuleArtifact{artifact("registry/base", "2.0.0"), artifact("registry/a", "2.0.0")}, installed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `registry/base 2.0.0 conflicts with registry/other (installed) requiring "^1.0.0"`)
+}
+
+func TestValidateModuleName(t *testing.T) {
+	assert.NoError(t, ValidateModuleName("my-defs"))
+	assert.Error(t, ValidateModuleName("My_Defs"))
+	assert.Error(t, ValidateModuleName("my.defs"))
+	assert.Error(t, ValidateModuleName(strings.Repeat("a", 64)))
+}
+
+func TestCheckMinVelaVersion(t *testing.T) {
+	assert.NoError(t, CheckMinVelaVersion("", "v1.9.0"))
+	assert.NoError(t, CheckMinVelaVersion("v1.9.0", ""))
+	assert.NoError(t, CheckMinVelaVersion("v1.9.0", "UNKNOWN"))
+	assert.NoError(t, CheckMinVelaVersion("v1.9.0", "v1.10.0"))
+	assert.Error(t, CheckMinVelaVersion("v1.11.0", "v1.10.0"))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defkitImport is the import path of defkit
+const defkitImport = "github.com/oam-dev/kubevela/pkg/definition/defkit"
+
+// definitionTypes are the Go types of the definitions
+var definitionTypes = map[string]string{
+	typeComponent:    "ComponentDefinition",
+	typeTrait:        "TraitDefinition",
+	typePolicy:       "PolicyDefinition",
+	typeWorkflowStep: "WorkflowStepDefinition",
+}
+
+// renderFile renders the Go file of the definition function with the body
+func renderFile(opts Options, src *source, body string) ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("package %s\n\n", opts.Package))
+	sb.WriteString(fmt.Sprintf("import (\n\t%q\n)\n\n", defkitImport))
+	sb.WriteString(fmt.Sprintf("func init() {\n\tdefkit.Register(%s())\n}\n\n", opts.FuncName))
+	sb.WriteString(fmt.Sprintf("// %s creates the %s %s definition.\n", opts.FuncName, src.name, src.typ))
+	sb.WriteString(fmt.Sprintf("func %s() *defkit.%s {\n", opts.FuncName, definitionTypes[src.typ]))
+	sb.WriteString(body)
+	sb.WriteString("}\n")
+	return format.Source([]byte(sb.String()))
+}
+
+// goString returns the Go literal of the string, a raw string literal for multi-line strings
+func goString(s string) string {
+	if strings.Contains(s, "\n") && !strings.Contains(s, "`") && !strings.Contains(s, "\r") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// goLiteral returns the Go literal of the scalar value
+func goLiteral(v any) string {
+	switch val := v.(type) {
+	case string:
+		return goString(val)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// goStrings returns the Go literals of the strings joined with commas
+func goStrings(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		quoted = append(quoted, strconv.Quote(item))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// goStringMap returns the Go literal of the string map
+func goStringMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString("map[string]string{\n")
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("%q: %s,\n", k, goString(m[k])))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// reservedIdents are the identifiers that cannot be used as the variables of the parameters
+var reservedIdents = map[string]bool{
+	"defkit": true, "tpl": true, "vela": true,
+	"any": true, "append": true, "bool": true, "cap": true, "copy": true, "error": true, "false": true,
+	"float64": true, "int": true, "len": true, "make": true, "new": true, "nil": true, "string": true, "true": true,
+}
+
+// goIdent returns a lowerCamelCase Go identifier of the name that is not used yet
+func goIdent(name string, used map[string]bool) string {
+	var sb strings.Builder
+	upper := false
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = sb.Len() > 0
+			continue
+		}
+		switch {
+		case sb.Len() == 0:
+			if unicode.IsDigit(r) {
+				sb.WriteString("p")
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		case upper:
+			sb.WriteRune(unicode.ToUpper(r))
+		default:
+			sb.WriteRune(r)
+		}
+		upper = false
+	}
+	ident := sb.String()
+	if ident == "" {
+		ident = "param"
+	}
+	if token.IsKeyword(ident) || reservedIdents[ident] {
+		ident += "Param"
+	}
+	candidate := ident
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", ident, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// chain renders the method chain on the receiver, one call per line
+func chain(receiver string, calls []string) string {
+	if len(calls) == 0 {
+		return receiver
+	}
+	return receiver + ".\n" + strings.Join(calls, ".\n")
+}
+
+// args renders the call arguments, one per line if there are many
+func args(items []string) string {
+	if len(items) <= 3 && !strings.Contains(strings.Join(items, ""), "\n") {
+		return strings.Join(items, ", ")
+	}
+	return "\n" + strings.Join(items, ",\n") + ",\n"
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"context"
+
+	"cuelang.org/go/cue"
+	"github.com/kubevela/pkg/cue/cuex"
+
+	velacue "github.com/oam-dev/kubevela/pkg/cue"
+	"github.com/oam-dev/kubevela/pkg/workflow/providers"
+)
+
+// compileTemplate compiles the template with the base template of KubeVela,
+// the provider functions are not resolved
+func compileTemplate(template string) (cue.Value, error) {
+	return providers.DefaultCompiler.Get().CompileStringWithOptions(context.Background(), template+"\n"+velacue.BaseTemplate, cuex.DisableResolveProviderFunctions{})
+}
@@ -0,0 +1,837 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"github.com/pkg/errors"
+
+	ast2 "github.com/oam-dev/kubevela/pkg/definition/ast"
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// converter converts a parsed CUE definition into a defkit definition and the Go code building it
+type converter struct {
+	src    *source
+	issues []Issue
+	// params are the translated top-level parameters by name
+	params    map[string]*goParam
+	paramList []*goParam
+	// used are the Go identifiers already used in the generated function
+	used map[string]bool
+	// usesVela tells if the template refers to the vela context
+	usesVela bool
+}
+
+func newConverter(src *source) *converter {
+	return &converter{
+		src:    src,
+		params: map[string]*goParam{},
+		used:   map[string]bool{},
+	}
+}
+
+// issue reports a part of the definition that is not translated into the fluent API
+func (c *converter) issue(path, msg string) {
+	c.issues = append(c.issues, Issue{Path: path, Message: msg})
+}
+
+// convertMark is the state of the converter before an attempt of translation
+type convertMark struct {
+	issues   int
+	usesVela bool
+}
+
+// mark records the state of the converter so that a failed attempt can be reverted
+func (c *converter) mark() convertMark {
+	return convertMark{issues: len(c.issues), usesVela: c.usesVela}
+}
+
+// reset reverts the converter to the marked state
+func (c *converter) reset(m convertMark) {
+	c.issues = c.issues[:m.issues]
+	c.usesVela = m.usesVela
+}
+
+// convert converts the definition, returning the body of the generated function
+func (c *converter) convert() (defkit.Definition, string, error) {
+	h, err := c.parseHeader()
+	if err != nil {
+		return nil, "", err
+	}
+	decls, rawParam := c.splitTemplate()
+	switch c.src.typ {
+	case typeComponent:
+		return c.convertComponent(h, decls, rawParam)
+	case typeTrait:
+		return c.convertTrait(h, decls, rawParam)
+	case typePolicy:
+		return c.convertPolicy(h, decls, rawParam)
+	default:
+		return c.convertWorkflowStep(h, decls, rawParam)
+	}
+}
+
+// splitTemplate translates the parameters of the template, returning the other
+// declarations of the template and the parameter schema kept as raw CUE
+func (c *converter) splitTemplate() ([]ast.Decl, string) {
+	var decls []ast.Decl
+	rawParam := ""
+	found := false
+	for _, elt := range c.src.template.Elts {
+		if _, ok := elt.(*ast.CommentGroup); ok {
+			continue
+		}
+		if field, ok := elt.(*ast.Field); ok && !found {
+			if label, ok := fieldLabel(field); ok && label == "parameter" {
+				found = true
+				st, ok := field.Value.(*ast.StructLit)
+				if !ok {
+					c.issue("parameter", "schema kept as raw CUE")
+					rawParam = formatNode(field)
+					continue
+				}
+				if raw := c.translateParameters(st); len(raw) > 0 {
+					rawParam = "parameter: {\n" + formatDecls(raw) + "\n}"
+				}
+				continue
+			}
+		}
+		decls = append(decls, elt)
+	}
+	return decls, rawParam
+}
+
+// header is the metadata of a definition
+type header struct {
+	description string
+	version     string
+	alias       *string
+	annotations map[string]string
+	labels      map[string]string
+	// attributes are the fields of the attributes in the order of the definition
+	attributes []*ast.Field
+	status     map[string]string
+	imports    []string
+}
+
+// statusFields are the fields of the status attribute in the order of defkit
+var statusFields = []string{"customStatus", "healthPolicy", "details"}
+
+// parseHeader parses the metadata of the definition, the unknown fields are reported as dropped
+func (c *converter) parseHeader() (*header, error) {
+	st, ok := c.src.header.Value.(*ast.StructLit)
+	if !ok {
+		return nil, errors.Errorf("the metadata of %s must be a struct", c.src.name)
+	}
+	h := &header{status: map[string]string{}}
+	for _, spec := range c.src.imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid import %s", spec.Path.Value)
+		}
+		if spec.Name != nil {
+			c.issue("import", fmt.Sprintf("alias %s of %q dropped", spec.Name.Name, path))
+		}
+		h.imports = append(h.imports, path)
+	}
+	for _, elt := range st.Elts {
+		field, ok := elt.(*ast.Field)
+		if !ok {
+			if _, ok := elt.(*ast.CommentGroup); !ok {
+				c.issue(c.src.name, fmt.Sprintf("%q dropped", firstLine(formatNode(elt))))
+			}
+			continue
+		}
+		label, _ := fieldLabel(field)
+		switch label {
+		case "type":
+		case "description", "version", "alias":
+			s, ok := stringLit(field.Value)
+			if !ok {
+				return nil, errors.Errorf("%s of %s must be a string literal", label, c.src.name)
+			}
+			switch {
+			case label == "description":
+				h.description = s
+			case label == "version":
+				h.version = s
+			case c.src.typ == typeWorkflowStep:
+				h.alias = &s
+			default:
+				c.issue("alias", fmt.Sprintf("not supported by %s definitions, dropped", c.src.typ))
+			}
+		case "annotations", "labels":
+			m, ok := stringMap(field.Value)
+			if !ok {
+				return nil, errors.Errorf("%s of %s must be a map of string literals", label, c.src.name)
+			}
+			if label == "annotations" {
+				h.annotations = m
+			} else {
+				h.labels = m
+			}
+		case "attributes":
+			attrs, ok := field.Value.(*ast.StructLit)
+			if !ok {
+				return nil, errors.Errorf("attributes of %s must be a struct", c.src.name)
+			}
+			if err := c.parseAttributes(h, attrs); err != nil {
+				return nil, err
+			}
+		default:
+			c.issue(c.src.name, fmt.Sprintf("%q dropped", firstLine(formatNode(field))))
+		}
+	}
+	return h, nil
+}
+
+// parseAttributes parses the attributes, the status is extracted and the others
+// are left to the definition types
+func (c *converter) parseAttributes(h *header, attrs *ast.StructLit) error {
+	for _, elt := range attrs.Elts {
+		field, ok := elt.(*ast.Field)
+		if !ok {
+			if _, ok := elt.(*ast.CommentGroup); !ok {
+				c.issue("attributes", fmt.Sprintf("%q dropped", firstLine(formatNode(elt))))
+			}
+			continue
+		}
+		if label, _ := fieldLabel(field); label != "status" {
+			h.attributes = append(h.attributes, field)
+			continue
+		}
+		st, ok := field.Value.(*ast.StructLit)
+		if !ok {
+			return errors.Errorf("attributes.status of %s must be a struct", c.src.name)
+		}
+		for _, elt := range st.Elts {
+			f, ok := elt.(*ast.Field)
+			if !ok {
+				continue
+			}
+			label, _ := fieldLabel(f)
+			s, isString := stringLit(f.Value)
+			switch {
+			case !isString:
+				return errors.Errorf("attributes.status.%s of %s must be a string", label, c.src.name)
+			case label == "customStatus" || label == "healthPolicy" || label == "details":
+				h.status[label] = strings.Trim(s, "\n")
+			default:
+				c.issue("attributes.status."+label, "dropped")
+			}
+		}
+	}
+	return nil
+}
+
+// attribute returns the value of the attribute
+func (h *header) attribute(name string) (ast.Expr, bool) {
+	for _, field := range h.attributes {
+		if label, _ := fieldLabel(field); label == name {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// definitionBuilder is the metadata and the parameters shared by the defkit definitions
+type definitionBuilder[T any] interface {
+	Description(desc string) T
+	Annotations(annotations map[string]string) T
+	Labels(labels map[string]string) T
+	Version(v string) T
+	WithImports(imports ...string) T
+	Params(params ...defkit.Param) T
+	CustomStatus(expr string) T
+	HealthPolicy(expr string) T
+	StatusDetails(details string) T
+}
+
+// applyHeader records the metadata on the definition
+func applyHeader[T definitionBuilder[T]](d T, h *header) (T, []string) {
+	var calls []string
+	if h.description != "" {
+		d, calls = d.Description(h.description), append(calls, fmt.Sprintf("Description(%s)", goString(h.description)))
+	}
+	if len(h.annotations) > 0 {
+		d, calls = d.Annotations(h.annotations), append(calls, fmt.Sprintf("Annotations(%s)", goStringMap(h.annotations)))
+	}
+	if len(h.labels) > 0 {
+		d, calls = d.Labels(h.labels), append(calls, fmt.Sprintf("Labels(%s)", goStringMap(h.labels)))
+	}
+	if h.version != "" {
+		d, calls = d.Version(h.version), append(calls, fmt.Sprintf("Version(%q)", h.version))
+	}
+	if len(h.imports) > 0 {
+		d, calls = d.WithImports(h.imports...), append(calls, fmt.Sprintf("WithImports(%s)", goStrings(h.imports)))
+	}
+	return d, calls
+}
+
+// applyParams records the parameters and the status on the definition, the status
+// is only kept for the definition types rendering it in the attributes
+func applyParams[T definitionBuilder[T]](c *converter, d T, h *header, status bool) (T, []string) {
+	var calls []string
+	if len(c.paramList) > 0 {
+		params := make([]defkit.Param, 0, len(c.paramList))
+		idents := make([]string, 0, len(c.paramList))
+		for _, p := range c.paramList {
+			params = append(params, p.param)
+			idents = append(idents, p.ident)
+		}
+		d, calls = d.Params(params...), append(calls, fmt.Sprintf("Params(%s)", args(idents)))
+	}
+	if !status {
+		if len(h.status) > 0 {
+			c.issue("attributes.status", fmt.Sprintf("not rendered by %s definitions, dropped", c.src.typ))
+		}
+		return d, calls
+	}
+	for _, name := range statusFields {
+		s, ok := h.status[name]
+		if !ok || s == "" {
+			continue
+		}
+		switch name {
+		case "customStatus":
+			d, calls = d.CustomStatus(s), append(calls, fmt.Sprintf("CustomStatus(%s)", goString(s)))
+		case "healthPolicy":
+			d, calls = d.HealthPolicy(s), append(calls, fmt.Sprintf("HealthPolicy(%s)", goString(s)))
+		default:
+			d, calls = d.StatusDetails(s), append(calls, fmt.Sprintf("StatusDetails(%s)", goString(s)))
+		}
+	}
+	return d, calls
+}
+
+// functionBody renders the body of the generated function, declaring the parameters
+// before returning the definition built by the calls
+func (c *converter) functionBody(constructor string, calls []string) string {
+	var sb strings.Builder
+	for _, p := range c.paramList {
+		sb.WriteString(fmt.Sprintf("%s := %s\n", p.ident, p.code))
+	}
+	if len(c.paramList) > 0 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("return %s\n", chain(constructor, calls)))
+	return sb.String()
+}
+
+// templateCall renders the Template call with the statements of the template function
+func (c *converter) templateCall(templateType string, stmts []string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Template(func(tpl *defkit.%s) {\n", templateType))
+	if c.usesVela {
+		sb.WriteString("vela := defkit.VelaCtx()\n")
+	}
+	for _, stmt := range stmts {
+		sb.WriteString(stmt + "\n")
+	}
+	sb.WriteString("})")
+	return sb.String()
+}
+
+// rawBlock keeps the declarations as a raw CUE block, reporting each of them
+func (c *converter) rawBlock(decls []ast.Decl, rawParam string) string {
+	var parts []string
+	for _, decl := range decls {
+		path := "template"
+		if field, ok := decl.(*ast.Field); ok {
+			if label, ok := fieldLabel(field); ok {
+				path += "." + label
+			}
+		}
+		c.issue(path, fmt.Sprintf("%q kept as raw CUE", firstLine(formatNode(decl))))
+		parts = append(parts, formatNode(decl))
+	}
+	if rawParam != "" {
+		parts = append(parts, rawParam)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// templateField returns the label of the template field, empty if it is not a regular field
+func templateField(decl ast.Decl) (*ast.Field, string) {
+	field, ok := decl.(*ast.Field)
+	if !ok {
+		return nil, ""
+	}
+	label, ok := fieldLabel(field)
+	if !ok || len(field.Attrs) > 0 {
+		return nil, ""
+	}
+	return field, label
+}
+
+// convertComponent converts the component definition, the output and outputs are
+// translated into defkit resources and the others are kept in the raw header block
+func (c *converter) convertComponent(h *header, decls []ast.Decl, rawParam string) (defkit.Definition, string, error) {
+	def, calls := applyHeader(defkit.NewComponent(c.src.name), h)
+	def, calls = c.applyWorkload(def, calls, h)
+
+	var output *goResource
+	var outputs []goOutput
+	var rest []ast.Decl
+	for _, decl := range decls {
+		field, label := templateField(decl)
+		switch {
+		case label == "output" && output == nil:
+			if res, ok := c.translateResource("output", field.Value); ok {
+				output = res
+				continue
+			}
+		case label == "outputs" && outputs == nil:
+			if res, ok := c.translateOutputs(field.Value); ok && len(res) > 0 {
+				outputs = res
+				continue
+			}
+		default:
+		}
+		rest = append(rest, decl)
+	}
+	rawHeader := c.rawBlock(rest, rawParam)
+
+	var stmts []string
+	if rawHeader != "" {
+		stmts = append(stmts, fmt.Sprintf("tpl.SetRawHeaderBlock(%s)", goString(rawHeader)))
+	}
+	if output != nil {
+		stmts = append(stmts, fmt.Sprintf("tpl.Output(\n%s,\n)", output.code()))
+	}
+	stmts = append(stmts, outputStmts(outputs)...)
+
+	var paramCalls []string
+	def, paramCalls = applyParams(c, def, h, true)
+	calls = append(calls, paramCalls...)
+	def = def.Template(func(tpl *defkit.Template) {
+		if rawHeader != "" {
+			tpl.SetRawHeaderBlock(rawHeader)
+		}
+		if output != nil {
+			tpl.Output(output.build())
+		}
+		addOutputs(tpl, outputs)
+	})
+	calls = append(calls, c.templateCall("Template", stmts))
+	return def, c.functionBody(fmt.Sprintf("defkit.NewComponent(%q)", c.src.name), calls), nil
+}
+
+// outputStmts renders the statements adding the auxiliary resources
+func outputStmts(outputs []goOutput) []string {
+	stmts := make([]string, 0, len(outputs))
+	for _, o := range outputs {
+		if o.cond != nil {
+			stmts = append(stmts, fmt.Sprintf("tpl.OutputsIf(%s, %q,\n%s,\n)", o.cond.code, o.name, o.res.code()))
+			continue
+		}
+		stmts = append(stmts, fmt.Sprintf("tpl.Outputs(%q,\n%s,\n)", o.name, o.res.code()))
+	}
+	return stmts
+}
+
+// addOutputs adds the auxiliary resources to the template
+func addOutputs(tpl *defkit.Template, outputs []goOutput) {
+	for _, o := range outputs {
+		if o.cond != nil {
+			tpl.OutputsIf(o.cond.cond, o.name, o.res.build())
+			continue
+		}
+		tpl.Outputs(o.name, o.res.build())
+	}
+}
+
+// applyWorkload records the workload of the component, the other attributes are reported as dropped
+func (c *converter) applyWorkload(def *defkit.ComponentDefinition, calls []string, h *header) (*defkit.ComponentDefinition, []string) {
+	for _, field := range h.attributes {
+		label, _ := fieldLabel(field)
+		if label != "workload" {
+			c.issue("attributes."+label, "not supported by defkit components, dropped")
+		}
+	}
+	expr, ok := h.attribute("workload")
+	if !ok {
+		c.issue("attributes.workload", "not set, defaulted to an empty workload")
+		return def, calls
+	}
+	typ, hasType := stringAt(expr, "type")
+	apiVersion, hasAPIVersion := stringAt(expr, "definition.apiVersion")
+	kind, hasKind := stringAt(expr, "definition.kind")
+	switch {
+	case hasAPIVersion && hasKind:
+		def, calls = def.Workload(apiVersion, kind), append(calls, fmt.Sprintf("Workload(%q, %q)", apiVersion, kind))
+		if !hasType {
+			def, calls = def.OmitWorkloadType(), append(calls, "OmitWorkloadType()")
+		} else if inferred := inferWorkloadType(apiVersion, kind); typ != inferred {
+			c.issue("attributes.workload.type", fmt.Sprintf("%q replaced by %q inferred by defkit", typ, inferred))
+		}
+	case hasType && typ == "autodetects.core.oam.dev":
+		def, calls = def.AutodetectWorkload(), append(calls, "AutodetectWorkload()")
+	default:
+		c.issue("attributes.workload", fmt.Sprintf("%q not supported by defkit, dropped", firstLine(formatNode(expr))))
+	}
+	return def, calls
+}
+
+// stringAt returns the string literal at the path of the node
+func stringAt(node ast.Node, path string) (string, bool) {
+	node, _, ok := ast2.GetNodeByPath(node, path)
+	if !ok {
+		return "", false
+	}
+	expr, ok := node.(ast.Expr)
+	if !ok {
+		return "", false
+	}
+	return stringLit(expr)
+}
+
+// inferWorkloadType returns the workload type that defkit infers from the API version and kind
+func inferWorkloadType(apiVersion, kind string) string {
+	src, err := parseDefinition(defkit.NewComponent("workload").Workload(apiVersion, kind).ToCue())
+	if err != nil {
+		return ""
+	}
+	typ, _, _ := src.headerString("attributes.workload.type")
+	return typ
+}
+
+// convertTrait converts the trait definition, the patch and the outputs are
+// translated into defkit if the whole template can be expressed by the fluent
+// API, otherwise they are kept in the raw blocks of the template
+func (c *converter) convertTrait(h *header, decls []ast.Decl, rawParam string) (defkit.Definition, string, error) {
+	def, calls := applyHeader(defkit.NewTrait(c.src.name), h)
+	def, calls = c.applyTraitAttributes(def, calls, h)
+	var paramCalls []string
+	def, paramCalls = applyParams(c, def, h, true)
+	calls = append(calls, paramCalls...)
+
+	var patchField, outputsField *ast.Field
+	var rest []ast.Decl
+	for _, decl := range decls {
+		field, label := templateField(decl)
+		switch {
+		case label == "patch" && patchField == nil:
+			patchField = field
+		case label == "outputs" && outputsField == nil:
+			outputsField = field
+		default:
+			rest = append(rest, decl)
+		}
+	}
+
+	if stmts, fn, ok := c.translateTraitTemplate(patchField, outputsField, rest, rawParam); ok {
+		if len(stmts) > 0 {
+			def = def.Template(fn)
+			calls = append(calls, c.templateCall("Template", stmts))
+		}
+		return def, c.functionBody(fmt.Sprintf("defkit.NewTrait(%q)", c.src.name), calls), nil
+	}
+
+	rawHeader := c.rawBlock(rest, "")
+	rawPatch, rawOutputs := "", ""
+	if patchField != nil {
+		rawPatch = formatNode(patchField)
+		c.issue("template.patch", "kept as raw CUE")
+	}
+	if outputsField != nil {
+		rawOutputs = formatNode(outputsField)
+		c.issue("template.outputs", "kept as raw CUE")
+	}
+	var stmts []string
+	if rawHeader != "" {
+		stmts = append(stmts, fmt.Sprintf("tpl.SetRawHeaderBlock(%s)", goString(rawHeader)))
+	}
+	if rawPatch != "" {
+		stmts = append(stmts, fmt.Sprintf("tpl.SetRawPatchBlock(%s)", goString(rawPatch)))
+	}
+	if rawOutputs != "" {
+		stmts = append(stmts, fmt.Sprintf("tpl.SetRawOutputsBlock(%s)", goString(rawOutputs)))
+	}
+	if rawParam != "" {
+		stmts = append(stmts, fmt.Sprintf("tpl.SetRawParameterBlock(%s)", goString(rawParam)))
+	}
+	def = def.Template(func(tpl *defkit.Template) {
+		tpl.SetRawHeaderBlock(rawHeader)
+		tpl.SetRawPatchBlock(rawPatch)
+		tpl.SetRawOutputsBlock(rawOutputs)
+		tpl.SetRawParameterBlock(rawParam)
+	})
+	calls = append(calls, c.templateCall("Template", stmts))
+	return def, c.functionBody(fmt.Sprintf("defkit.NewTrait(%q)", c.src.name), calls), nil
+}
+
+// translateTraitTemplate translates the patch and the outputs of the trait into
+// the fluent API, false if any part of the template has to be kept as raw CUE
+func (c *converter) translateTraitTemplate(patchField, outputsField *ast.Field, rest []ast.Decl, rawParam string) ([]string, func(tpl *defkit.Template), bool) {
+	if len(rest) > 0 || rawParam != "" {
+		return nil, nil, false
+	}
+	mark := c.mark()
+	var stmts []string
+	strategy := ""
+	var ops []fieldOp
+	if patchField != nil {
+		st, ok := patchField.Value.(*ast.StructLit)
+		if !ok || hasDirectives(patchField.Value) {
+			return nil, nil, false
+		}
+		var valid bool
+		if strategy, valid = patchStrategy(patchField); !valid {
+			return nil, nil, false
+		}
+		w := &walker{c: c, loc: "patch", patch: true}
+		if !w.walkStruct("", st, nil, nil) || !w.valid() {
+			c.reset(mark)
+			return nil, nil, false
+		}
+		ops = w.ops
+		if strategy != "" {
+			stmts = append(stmts, fmt.Sprintf("tpl.PatchStrategy(%q)", strategy))
+		}
+		_, calls := applyOps(defkit.NewPatchResource(), ops, false)
+		stmts = append(stmts, chain("tpl.Patch()", calls))
+	}
+	var outputs []goOutput
+	if outputsField != nil {
+		var ok bool
+		if outputs, ok = c.translateOutputs(outputsField.Value); !ok || len(outputs) == 0 {
+			c.reset(mark)
+			return nil, nil, false
+		}
+		stmts = append(stmts, outputStmts(outputs)...)
+	}
+	fn := func(tpl *defkit.Template) {
+		if patchField != nil {
+			if strategy != "" {
+				tpl.PatchStrategy(strategy)
+			}
+			applyOps(tpl.Patch(), ops, false)
+		}
+		addOutputs(tpl, outputs)
+	}
+	return stmts, fn, true
+}
+
+// hasDirectives tells if the comments in the node have directives like +patchKey,
+// which the fluent patch API cannot render
+func hasDirectives(node ast.Node) bool {
+	found := false
+	ast.Walk(node, func(n ast.Node) bool {
+		for _, cg := range ast.Comments(n) {
+			for _, comment := range cg.List {
+				if strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(comment.Text, "//")), "+") {
+					found = true
+				}
+			}
+		}
+		return !found
+	}, nil)
+	return found
+}
+
+// patchStrategy returns the +patchStrategy directive of the patch field, false
+// if the field has other directives
+func patchStrategy(field *ast.Field) (string, bool) {
+	strategy := ""
+	for _, cg := range ast.Comments(field) {
+		for _, comment := range cg.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			switch {
+			case strings.HasPrefix(text, "+patchStrategy="):
+				strategy = strings.TrimPrefix(text, "+patchStrategy=")
+			case strings.HasPrefix(text, "+"):
+				return "", false
+			default:
+			}
+		}
+	}
+	return strategy, true
+}
+
+// applyTraitAttributes records the attributes of the trait
+func (c *converter) applyTraitAttributes(def *defkit.TraitDefinition, calls []string, h *header) (*defkit.TraitDefinition, []string) {
+	for _, field := range h.attributes {
+		label, _ := fieldLabel(field)
+		path := "attributes." + label
+		b, isBool := boolLit(field.Value)
+		s, isString := stringLit(field.Value)
+		items, isList := stringList(field.Value)
+		switch {
+		case label == "podDisruptive" && isBool:
+			def, calls = def.PodDisruptive(b), append(calls, fmt.Sprintf("PodDisruptive(%t)", b))
+		case label == "manageWorkload" && isBool:
+			if b {
+				def, calls = def.ManageWorkload(), append(calls, "ManageWorkload()")
+			}
+		case label == "controlPlaneOnly" && isBool:
+			if b {
+				def, calls = def.ControlPlaneOnly(), append(calls, "ControlPlaneOnly()")
+			}
+		case label == "revisionEnabled" && isBool:
+			if b {
+				def, calls = def.RevisionEnabled(), append(calls, "RevisionEnabled()")
+			}
+		case label == "appliesToWorkloads" && isList:
+			def, calls = def.AppliesTo(items...), append(calls, fmt.Sprintf("AppliesTo(%s)", goStrings(items)))
+		case label == "conflictsWith" && isList:
+			def, calls = def.ConflictsWith(items...), append(calls, fmt.Sprintf("ConflictsWith(%s)", goStrings(items)))
+		case label == "workloadRefPath" && isString:
+			def, calls = def.WorkloadRefPath(s), append(calls, fmt.Sprintf("WorkloadRefPath(%q)", s))
+		case label == "stage" && isString:
+			def, calls = def.Stage(s), append(calls, fmt.Sprintf("Stage(%q)", s))
+		default:
+			c.issue(path, fmt.Sprintf("%q not supported by defkit traits, dropped", firstLine(formatNode(field.Value))))
+		}
+	}
+	return def, calls
+}
+
+// convertPolicy converts the policy definition, the fields of the template are
+// translated into computed fields, the whole definition is kept as raw CUE if the
+// template has other constructs
+func (c *converter) convertPolicy(h *header, decls []ast.Decl, rawParam string) (defkit.Definition, string, error) {
+	if len(h.attributes) > 0 && !onlyManageHealthCheck(h) {
+		return c.rawPolicy("attributes not supported by defkit policies")
+	}
+	if rawParam != "" {
+		return c.rawPolicy("parameter schema not expressible by defkit")
+	}
+	type computed struct {
+		name  string
+		value goValue
+	}
+	mark := c.mark()
+	var fields []computed
+	names := map[string]bool{}
+	for _, decl := range decls {
+		field, label := templateField(decl)
+		if field == nil || names[label] || !ast.IsValidIdent(label) || label == "status" {
+			c.reset(mark)
+			return c.rawPolicy(fmt.Sprintf("%q not expressible by defkit", firstLine(formatNode(decl))))
+		}
+		names[label] = true
+		fields = append(fields, computed{name: label, value: c.translateValue("template."+label, field.Value)})
+	}
+
+	def, calls := applyHeader(defkit.NewPolicy(c.src.name), h)
+	if _, ok := h.attribute("manageHealthCheck"); ok {
+		def, calls = def.ManageHealthCheck(), append(calls, "ManageHealthCheck()")
+		c.issue("attributes.manageHealthCheck", "only rendered in the YAML of the definition")
+	}
+	var paramCalls []string
+	def, paramCalls = applyParams(c, def, h, false)
+	calls = append(calls, paramCalls...)
+	if len(fields) > 0 {
+		stmts := make([]string, 0, len(fields))
+		for _, f := range fields {
+			stmts = append(stmts, fmt.Sprintf("tpl.Set(%q, %s)", f.name, f.value.code))
+		}
+		def = def.Template(func(tpl *defkit.PolicyTemplate) {
+			for _, f := range fields {
+				tpl.Set(f.name, f.value.val)
+			}
+		})
+		calls = append(calls, c.templateCall("PolicyTemplate", stmts))
+	}
+	return def, c.functionBody(fmt.Sprintf("defkit.NewPolicy(%q)", c.src.name), calls), nil
+}
+
+// onlyManageHealthCheck tells if the only attribute of the policy is manageHealthCheck: true
+func onlyManageHealthCheck(h *header) bool {
+	if len(h.attributes) != 1 {
+		return false
+	}
+	expr, ok := h.attribute("manageHealthCheck")
+	if !ok {
+		return false
+	}
+	b, ok := boolLit(expr)
+	return ok && b
+}
+
+// rawPolicy keeps the whole policy definition as raw CUE
+func (c *converter) rawPolicy(reason string) (defkit.Definition, string, error) {
+	c.issues = nil
+	c.params = map[string]*goParam{}
+	c.paramList = nil
+	c.issue(c.src.name, fmt.Sprintf("definition kept as raw CUE: %s", reason))
+	cue := strings.TrimSpace(c.src.cue)
+	def := defkit.NewPolicy(c.src.name).RawCUE(cue)
+	calls := []string{fmt.Sprintf("RawCUE(%s)", goString(cue))}
+	return def, c.functionBody(fmt.Sprintf("defkit.NewPolicy(%q)", c.src.name), calls), nil
+}
+
+// convertWorkflowStep converts the workflow step definition, the parameters are
+// translated into defkit and the steps of the template are kept as the raw template
+// body since they are made of workflow providers rather than resources
+func (c *converter) convertWorkflowStep(h *header, decls []ast.Decl, rawParam string) (defkit.Definition, string, error) {
+	category, hasCategory := h.annotations["category"]
+	if hasCategory {
+		h.annotations = withoutKey(h.annotations, "category")
+	}
+	scope, hasScope := h.labels["scope"]
+	if hasScope {
+		h.labels = withoutKey(h.labels, "scope")
+	}
+	for _, field := range h.attributes {
+		label, _ := fieldLabel(field)
+		c.issue("attributes."+label, "not supported by defkit workflow steps, dropped")
+	}
+	def, calls := applyHeader(defkit.NewWorkflowStep(c.src.name), h)
+	if hasCategory {
+		def, calls = def.Category(category), append(calls, fmt.Sprintf("Category(%q)", category))
+	}
+	if hasScope {
+		def, calls = def.Scope(scope), append(calls, fmt.Sprintf("Scope(%q)", scope))
+	}
+	if h.alias != nil {
+		def, calls = def.Alias(*h.alias), append(calls, fmt.Sprintf("Alias(%q)", *h.alias))
+	}
+	var paramCalls []string
+	def, paramCalls = applyParams(c, def, h, false)
+	calls = append(calls, paramCalls...)
+
+	var parts []string
+	for _, decl := range decls {
+		parts = append(parts, formatNode(decl))
+	}
+	if rawParam != "" {
+		parts = append(parts, rawParam)
+	}
+	if body := strings.Join(parts, "\n"); body != "" {
+		c.issue("template", "steps kept as raw CUE in the template body")
+		def, calls = def.TemplateBody(body), append(calls, fmt.Sprintf("TemplateBody(%s)", goString(body)))
+	}
+	return def, c.functionBody(fmt.Sprintf("defkit.NewWorkflowStep(%q)", c.src.name), calls), nil
+}
+
+// withoutKey returns a copy of the map without the key
+func withoutKey(m map[string]string, key string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if k != key {
+			out[k] = v
+		}
+	}
+	return out
+}
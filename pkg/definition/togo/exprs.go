@@ -0,0 +1,453 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/token"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// goValue is a value translated into defkit along with the Go code building it
+type goValue struct {
+	val  defkit.Value
+	code string
+}
+
+// goCond is a condition translated into defkit along with the Go code building it
+type goCond struct {
+	cond defkit.Condition
+	code string
+	// or tells if the condition is a disjunction, which defkit does not
+	// parenthesize when it is combined with other conditions
+	or bool
+}
+
+// contextMethods are the methods of defkit.VelaContext for the context fields
+var contextMethods = map[string]func(*defkit.VelaContext) *defkit.ContextRef{
+	"name":           (*defkit.VelaContext).Name,
+	"namespace":      (*defkit.VelaContext).Namespace,
+	"appName":        (*defkit.VelaContext).AppName,
+	"appRevision":    (*defkit.VelaContext).AppRevision,
+	"appRevisionNum": (*defkit.VelaContext).AppRevisionNum,
+	"revision":       (*defkit.VelaContext).Revision,
+	"output":         (*defkit.VelaContext).Output,
+}
+
+// refPath returns the selectors of a reference like parameter.a["b"], false if
+// the expression is not a reference or uses a dynamic or numeric index
+func refPath(expr ast.Expr) ([]string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return []string{e.Name}, true
+	case *ast.SelectorExpr:
+		path, ok := refPath(e.X)
+		if !ok {
+			return nil, false
+		}
+		sel := ""
+		switch l := e.Sel.(type) {
+		case *ast.Ident:
+			sel = l.Name
+		case *ast.BasicLit:
+			if sel, ok = stringLit(l); !ok {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+		return append(path, sel), true
+	case *ast.IndexExpr:
+		path, ok := refPath(e.X)
+		if !ok {
+			return nil, false
+		}
+		key, ok := stringLit(e.Index)
+		if !ok {
+			return nil, false
+		}
+		return append(path, key), true
+	default:
+		return nil, false
+	}
+}
+
+// isIdentPath tells if all the selectors are identifiers, which can be joined with dots
+func isIdentPath(path []string) bool {
+	for _, sel := range path {
+		if !ast.IsValidIdent(sel) || strings.HasPrefix(sel, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// tryLiteral translates the scalar literal into a defkit literal
+func tryLiteral(expr ast.Expr) (goValue, bool) {
+	if s, ok := stringLit(expr); ok {
+		return goValue{val: defkit.Lit(s), code: fmt.Sprintf("defkit.Lit(%s)", goString(s))}, true
+	}
+	if i, ok := intLit(expr); ok {
+		return goValue{val: defkit.Lit(i), code: fmt.Sprintf("defkit.Lit(%d)", i)}, true
+	}
+	if f, ok := floatLit(expr); ok {
+		return goValue{val: defkit.Lit(f), code: fmt.Sprintf("defkit.Lit(%s)", goLiteral(f))}, true
+	}
+	if b, ok := boolLit(expr); ok {
+		return goValue{val: defkit.Lit(b), code: fmt.Sprintf("defkit.Lit(%t)", b)}, true
+	}
+	return goValue{}, false
+}
+
+// tryRef translates the reference to a parameter, the context or a local field
+func (c *converter) tryRef(expr ast.Expr) (goValue, bool) {
+	path, ok := refPath(expr)
+	if !ok || !isIdentPath(path) {
+		return goValue{}, false
+	}
+	switch path[0] {
+	case "parameter":
+		if len(path) == 1 {
+			return goValue{val: defkit.Parameter(), code: "defkit.Parameter()"}, true
+		}
+		if p, ok := c.params[path[1]]; ok && len(path) == 2 {
+			return goValue{val: p.param, code: p.ident}, true
+		}
+		sub := strings.Join(path[1:], ".")
+		return goValue{val: defkit.ParamPath(sub), code: fmt.Sprintf("defkit.ParamPath(%q)", sub)}, true
+	case "context":
+		if len(path) == 2 {
+			if method, ok := contextMethods[path[1]]; ok {
+				c.usesVela = true
+				return goValue{val: method(defkit.VelaCtx()), code: fmt.Sprintf("vela.%s()", toPascalCase(path[1]))}, true
+			}
+		}
+	default:
+	}
+	ref := strings.Join(path, ".")
+	return goValue{val: defkit.Reference(ref), code: fmt.Sprintf("defkit.Reference(%q)", ref)}, true
+}
+
+// tryInterpolation translates the single-line string interpolation
+func (c *converter) tryInterpolation(interp *ast.Interpolation) (goValue, bool) {
+	var vals []defkit.Value
+	var codes []string
+	for i, elt := range interp.Elts {
+		if i%2 == 1 {
+			v, ok := c.tryValue(elt)
+			if !ok {
+				return goValue{}, false
+			}
+			vals, codes = append(vals, v.val), append(codes, v.code)
+			continue
+		}
+		lit, ok := elt.(*ast.BasicLit)
+		if !ok {
+			return goValue{}, false
+		}
+		frag := lit.Value
+		switch {
+		case i == 0 && strings.HasPrefix(frag, `"`) && !strings.HasPrefix(frag, `"""`):
+			frag = frag[1:]
+		case i > 0 && strings.HasPrefix(frag, ")"):
+			frag = frag[1:]
+		default:
+			return goValue{}, false
+		}
+		switch {
+		case i == len(interp.Elts)-1 && strings.HasSuffix(frag, `"`):
+			frag = frag[:len(frag)-1]
+		case i < len(interp.Elts)-1 && strings.HasSuffix(frag, `\(`):
+			frag = frag[:len(frag)-2]
+		default:
+			return goValue{}, false
+		}
+		// defkit writes the fragments verbatim, so escaped fragments are not translated
+		if strings.ContainsAny(frag, `\"`) {
+			return goValue{}, false
+		}
+		if frag != "" {
+			vals, codes = append(vals, defkit.Lit(frag)), append(codes, fmt.Sprintf("defkit.Lit(%q)", frag))
+		}
+	}
+	return goValue{val: defkit.Interpolation(vals...), code: fmt.Sprintf("defkit.Interpolation(%s)", args(codes))}, true
+}
+
+// plusOperands returns the operands of the chained + expression
+func plusOperands(expr ast.Expr) []ast.Expr {
+	if b, ok := expr.(*ast.BinaryExpr); ok && b.Op == token.ADD {
+		return append(plusOperands(b.X), plusOperands(b.Y)...)
+	}
+	return []ast.Expr{expr}
+}
+
+// tryValue translates the CUE expression into a defkit value
+func (c *converter) tryValue(expr ast.Expr) (goValue, bool) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return c.tryValue(e.X)
+	case *ast.BasicLit:
+		return tryLiteral(e)
+	case *ast.UnaryExpr:
+		return tryLiteral(e)
+	case *ast.Ident:
+		if v, ok := tryLiteral(e); ok {
+			return v, true
+		}
+		return c.tryRef(e)
+	case *ast.SelectorExpr, *ast.IndexExpr:
+		return c.tryRef(e)
+	case *ast.Interpolation:
+		return c.tryInterpolation(e)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return goValue{}, false
+		}
+		var vals []defkit.Value
+		var codes []string
+		for _, operand := range plusOperands(e) {
+			v, ok := c.tryValue(operand)
+			if !ok {
+				return goValue{}, false
+			}
+			vals, codes = append(vals, v.val), append(codes, v.code)
+		}
+		return goValue{val: defkit.Plus(vals...), code: fmt.Sprintf("defkit.Plus(%s)", args(codes))}, true
+	default:
+		return goValue{}, false
+	}
+}
+
+// rawValue keeps the CUE expression as a raw reference
+func rawValue(expr ast.Expr) goValue {
+	raw := formatNode(expr)
+	return goValue{val: defkit.Reference(raw), code: fmt.Sprintf("defkit.Reference(%s)", goString(raw))}
+}
+
+// translateValue translates the CUE expression at the path into a defkit value,
+// the expression is kept as raw CUE if it cannot be translated
+func (c *converter) translateValue(path string, expr ast.Expr) goValue {
+	if v, ok := c.tryValue(expr); ok {
+		return v
+	}
+	c.issue(path, "expression kept as raw CUE")
+	return rawValue(expr)
+}
+
+// isBottom tells if the expression is _|_
+func isBottom(expr ast.Expr) bool {
+	_, ok := expr.(*ast.BottomLit)
+	return ok
+}
+
+// compareMethods are the methods of the parameters for the comparison operators
+var compareMethods = map[token.Token]string{
+	token.EQL: "Eq",
+	token.NEQ: "Ne",
+	token.GTR: "Gt",
+	token.GEQ: "Gte",
+	token.LSS: "Lt",
+	token.LEQ: "Lte",
+}
+
+// tryExists translates the existence check of the reference, i.e. ref != _|_ or ref == _|_
+func (c *converter) tryExists(ref ast.Expr, exists bool) (goCond, bool) {
+	path, ok := refPath(ref)
+	if !ok || !isIdentPath(path) || len(path) < 2 {
+		return goCond{}, false
+	}
+	var cond goCond
+	switch {
+	case path[0] == "parameter" && len(path) == 2 && c.params[path[1]] != nil:
+		p := c.params[path[1]]
+		if !exists {
+			return goCond{cond: p.param.(paramCondition).NotSet(), code: p.ident + ".NotSet()"}, true
+		}
+		return goCond{cond: p.param.(paramCondition).IsSet(), code: p.ident + ".IsSet()"}, true
+	case path[0] == "parameter":
+		sub := strings.Join(path[1:], ".")
+		cond = goCond{cond: defkit.ParamPath(sub).IsSet(), code: fmt.Sprintf("defkit.ParamPath(%q).IsSet()", sub)}
+	default:
+		ref := strings.Join(path, ".")
+		cond = goCond{cond: defkit.PathExists(ref), code: fmt.Sprintf("defkit.PathExists(%q)", ref)}
+	}
+	if !exists {
+		cond = goCond{cond: defkit.Not(cond.cond), code: fmt.Sprintf("defkit.Not(%s)", cond.code)}
+	}
+	return cond, true
+}
+
+// tryCompare translates the comparison between a parameter and a literal
+func (c *converter) tryCompare(op token.Token, x, y ast.Expr) (goCond, bool) {
+	method, ok := compareMethods[op]
+	if !ok {
+		return goCond{}, false
+	}
+	path, ok := refPath(x)
+	if !ok || len(path) != 2 || path[0] != "parameter" || c.params[path[1]] == nil {
+		return goCond{}, false
+	}
+	lit, ok := tryLiteral(y)
+	if !ok {
+		return goCond{}, false
+	}
+	p := c.params[path[1]]
+	pc, ok := p.param.(paramCondition)
+	if !ok {
+		return goCond{}, false
+	}
+	val := lit.val.(*defkit.Literal).Val()
+	var cond defkit.Condition
+	switch op {
+	case token.EQL:
+		cond = pc.Eq(val)
+	case token.NEQ:
+		cond = pc.Ne(val)
+	case token.GTR:
+		cond = pc.Gt(val)
+	case token.GEQ:
+		cond = pc.Gte(val)
+	case token.LSS:
+		cond = pc.Lt(val)
+	default:
+		cond = pc.Lte(val)
+	}
+	return goCond{cond: cond, code: fmt.Sprintf("%s.%s(%s)", p.ident, method, goLiteral(val))}, true
+}
+
+// tryBoolParam translates the reference to a bool parameter into IsTrue or IsFalse
+func (c *converter) tryBoolParam(expr ast.Expr, truthy bool) (goCond, bool) {
+	path, ok := refPath(expr)
+	if !ok || len(path) != 2 || path[0] != "parameter" || c.params[path[1]] == nil {
+		return goCond{}, false
+	}
+	p := c.params[path[1]]
+	b, ok := p.param.(*defkit.BoolParam)
+	if !ok {
+		return goCond{}, false
+	}
+	if truthy {
+		return goCond{cond: b.IsTrue(), code: p.ident + ".IsTrue()"}, true
+	}
+	return goCond{cond: b.IsFalse(), code: p.ident + ".IsFalse()"}, true
+}
+
+// tryLogical translates the chained && or || expression
+func (c *converter) tryLogical(op token.Token, expr ast.Expr) (goCond, bool) {
+	var operands func(ast.Expr) []ast.Expr
+	operands = func(e ast.Expr) []ast.Expr {
+		if b, ok := e.(*ast.BinaryExpr); ok && b.Op == op {
+			return append(operands(b.X), operands(b.Y)...)
+		}
+		return []ast.Expr{e}
+	}
+	var conds []defkit.Condition
+	var codes []string
+	for _, operand := range operands(expr) {
+		cond, ok := c.tryCond(operand)
+		if !ok || (op == token.LAND && cond.or) {
+			return goCond{}, false
+		}
+		conds, codes = append(conds, cond.cond), append(codes, cond.code)
+	}
+	if op == token.LAND {
+		return goCond{cond: defkit.And(conds...), code: fmt.Sprintf("defkit.And(%s)", args(codes))}, true
+	}
+	return goCond{cond: defkit.Or(conds...), code: fmt.Sprintf("defkit.Or(%s)", args(codes)), or: true}, true
+}
+
+// tryCond translates the CUE expression into a defkit condition
+func (c *converter) tryCond(expr ast.Expr) (goCond, bool) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return c.tryCond(e.X)
+	case *ast.UnaryExpr:
+		if e.Op != token.NOT {
+			return goCond{}, false
+		}
+		if cond, ok := c.tryBoolParam(e.X, false); ok {
+			return cond, true
+		}
+		inner, ok := c.tryCond(e.X)
+		if !ok {
+			return goCond{}, false
+		}
+		return goCond{cond: defkit.Not(inner.cond), code: fmt.Sprintf("defkit.Not(%s)", inner.code)}, true
+	case *ast.BinaryExpr:
+		switch {
+		case e.Op == token.LAND || e.Op == token.LOR:
+			return c.tryLogical(e.Op, e)
+		case (e.Op == token.NEQ || e.Op == token.EQL) && isBottom(e.Y):
+			return c.tryExists(e.X, e.Op == token.NEQ)
+		default:
+			return c.tryCompare(e.Op, e.X, e.Y)
+		}
+	case *ast.SelectorExpr, *ast.IndexExpr:
+		return c.tryBoolParam(e, true)
+	default:
+		return goCond{}, false
+	}
+}
+
+// translateCond translates the CUE condition at the path into a defkit condition,
+// the condition is kept as raw CUE if it cannot be translated
+func (c *converter) translateCond(path string, expr ast.Expr) goCond {
+	if cond, ok := c.tryCond(expr); ok {
+		return cond
+	}
+	c.issue(path, fmt.Sprintf("condition %q kept as raw CUE", formatNode(expr)))
+	raw := formatNode(expr)
+	cond := goCond{cond: defkit.CUEExpr(raw), code: fmt.Sprintf("defkit.CUEExpr(%s)", goString(raw))}
+	if b, ok := expr.(*ast.BinaryExpr); ok && b.Op == token.LOR {
+		cond.or = true
+	}
+	return cond
+}
+
+// andConds combines the conditions with &&, the disjunctions are kept as raw
+// parenthesized CUE since defkit does not parenthesize them
+func andConds(conds []goCond, srcs []ast.Expr) goCond {
+	if len(conds) == 1 {
+		return conds[0]
+	}
+	var items []defkit.Condition
+	var codes []string
+	for i, cond := range conds {
+		if cond.or {
+			raw := "(" + formatNode(srcs[i]) + ")"
+			cond = goCond{cond: defkit.CUEExpr(raw), code: fmt.Sprintf("defkit.CUEExpr(%s)", goString(raw))}
+		}
+		items, codes = append(items, cond.cond), append(codes, cond.code)
+	}
+	return goCond{cond: defkit.And(items...), code: fmt.Sprintf("defkit.And(%s)", args(codes))}
+}
+
+// paramCondition is the conditions supported by all the parameters
+type paramCondition interface {
+	IsSet() defkit.Condition
+	NotSet() defkit.Condition
+	Eq(val any) defkit.Condition
+	Ne(val any) defkit.Condition
+	Gt(val any) defkit.Condition
+	Gte(val any) defkit.Condition
+	Lt(val any) defkit.Condition
+	Lte(val any) defkit.Condition
+}
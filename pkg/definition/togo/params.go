@@ -0,0 +1,556 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/token"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// goParam is a parameter translated into defkit
+type goParam struct {
+	param defkit.Param
+	// ident is the Go variable of a top-level parameter
+	ident string
+	// code is the Go expression building the parameter
+	code string
+}
+
+// paramMeta is the marker and the directives of a parameter field
+type paramMeta struct {
+	marker token.Token
+	usage  string
+	short  string
+	ignore bool
+}
+
+// readParamMeta reads the marker and the +usage, +short and +ignore directives
+// of the parameter field, the other directives are reported as dropped
+func (c *converter) readParamMeta(path string, field *ast.Field) paramMeta {
+	meta := paramMeta{marker: field.Constraint}
+	for _, cg := range ast.Comments(field) {
+		if cg.Line {
+			continue
+		}
+		for _, comment := range cg.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			switch {
+			case strings.HasPrefix(text, "+usage="):
+				meta.usage = strings.TrimPrefix(text, "+usage=")
+			case strings.HasPrefix(text, "+short="):
+				meta.short = strings.TrimPrefix(text, "+short=")
+			case text == "+ignore":
+				meta.ignore = true
+			case strings.HasPrefix(text, "+"):
+				c.issue(path, fmt.Sprintf("directive %q dropped", text))
+			default:
+			}
+		}
+	}
+	if len(field.Attrs) > 0 {
+		c.issue(path, "attributes dropped")
+	}
+	return meta
+}
+
+// metaBuilder is the modifiers shared by all the parameters
+type metaBuilder[T any] interface {
+	Required() T
+	Optional() T
+	Description(desc string) T
+}
+
+// withMeta applies the marker and the description to the parameter
+func withMeta[T metaBuilder[T]](p T, calls []string, meta paramMeta) (T, []string) {
+	switch meta.marker {
+	case token.NOT:
+		p, calls = p.Required(), append(calls, "Required()")
+	case token.OPTION:
+		p, calls = p.Optional(), append(calls, "Optional()")
+	default:
+	}
+	if meta.usage != "" {
+		p, calls = p.Description(meta.usage), append(calls, fmt.Sprintf("Description(%s)", goString(meta.usage)))
+	}
+	return p, calls
+}
+
+// flagBuilder is the CLI flag modifiers of the scalar parameters
+type flagBuilder[T any] interface {
+	Short(s string) T
+	Ignore() T
+}
+
+// withFlags applies the +short and +ignore directives to the parameter
+func withFlags[T flagBuilder[T]](p T, calls []string, meta paramMeta) (T, []string) {
+	if meta.short != "" {
+		p, calls = p.Short(meta.short), append(calls, fmt.Sprintf("Short(%q)", meta.short))
+	}
+	if meta.ignore {
+		p, calls = p.Ignore(), append(calls, "Ignore()")
+	}
+	return p, calls
+}
+
+// scalarTypes are the CUE types of the scalar parameters
+var scalarTypes = map[string]bool{"string": true, "int": true, "bool": true, "float": true}
+
+// elementTypes are the defkit parameter types of the scalar CUE types
+var elementTypes = map[string]string{
+	"string": "ParamTypeString",
+	"int":    "ParamTypeInt",
+	"bool":   "ParamTypeBool",
+	"float":  "ParamTypeFloat",
+}
+
+// scalarSchema is the schema of a scalar parameter, e.g. *"a" | "b" or int & >=0
+type scalarSchema struct {
+	base        string
+	def         ast.Expr
+	values      []string
+	open        bool
+	constraints []ast.Expr
+}
+
+// disjuncts returns the operands of the chained | expression
+func disjuncts(expr ast.Expr) []ast.Expr {
+	if b, ok := expr.(*ast.BinaryExpr); ok && b.Op == token.OR {
+		return append(disjuncts(b.X), disjuncts(b.Y)...)
+	}
+	return []ast.Expr{expr}
+}
+
+// conjuncts returns the operands of the chained & expression
+func conjuncts(expr ast.Expr) []ast.Expr {
+	if b, ok := expr.(*ast.BinaryExpr); ok && b.Op == token.AND {
+		return append(conjuncts(b.X), conjuncts(b.Y)...)
+	}
+	return []ast.Expr{expr}
+}
+
+// typeIdent returns the scalar type of the identifier
+func typeIdent(expr ast.Expr) (string, bool) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || !scalarTypes[ident.Name] {
+		return "", false
+	}
+	return ident.Name, true
+}
+
+// parseScalar parses the schema of a scalar parameter
+func parseScalar(expr ast.Expr) (*scalarSchema, bool) {
+	s := &scalarSchema{}
+	hasType := false
+	for _, d := range disjuncts(expr) {
+		if u, ok := d.(*ast.UnaryExpr); ok && u.Op == token.MUL {
+			if s.def != nil {
+				return nil, false
+			}
+			s.def = u.X
+			continue
+		}
+		if v, ok := stringLit(d); ok {
+			s.values = append(s.values, v)
+			continue
+		}
+		ops := conjuncts(d)
+		typ, ok := typeIdent(ops[0])
+		if !ok || hasType {
+			return nil, false
+		}
+		s.base, s.constraints, hasType = typ, ops[1:], true
+	}
+	switch {
+	case !hasType && len(s.values) > 0:
+		s.base = "string"
+	case !hasType:
+		return nil, false
+	case len(s.values) > 0:
+		if s.base != "string" || len(s.constraints) > 0 {
+			return nil, false
+		}
+		s.open = true
+	default:
+	}
+	if s.def != nil && len(s.values) > 0 {
+		def, ok := stringLit(s.def)
+		// defkit does not render an empty default of an enum
+		if !ok || def == "" {
+			return nil, false
+		}
+		found := false
+		for _, v := range s.values {
+			found = found || v == def
+		}
+		if !found {
+			s.values = append([]string{def}, s.values...)
+		}
+	}
+	return s, true
+}
+
+// stringParam translates the schema into a string parameter
+func stringParam(name string, s *scalarSchema) (*defkit.StringParam, []string, bool) {
+	p, calls := defkit.String(name), []string(nil)
+	if len(s.values) > 0 {
+		p, calls = p.Values(s.values...), append(calls, fmt.Sprintf("Values(%s)", goStrings(s.values)))
+		if s.open {
+			p, calls = p.OpenEnum(), append(calls, "OpenEnum()")
+		}
+	}
+	for _, constraint := range s.constraints {
+		switch e := constraint.(type) {
+		case *ast.UnaryExpr:
+			v, ok := stringLit(e.X)
+			switch {
+			case ok && e.Op == token.NEQ && v == "":
+				p, calls = p.NotEmpty(), append(calls, "NotEmpty()")
+			case ok && e.Op == token.MAT:
+				p, calls = p.Pattern(v), append(calls, fmt.Sprintf("Pattern(%s)", goString(v)))
+			default:
+				return nil, nil, false
+			}
+		case *ast.CallExpr:
+			fn := formatNode(e.Fun)
+			if len(e.Args) != 1 {
+				return nil, nil, false
+			}
+			n, ok := intLit(e.Args[0])
+			switch {
+			case ok && fn == "strings.MinRunes":
+				p, calls = p.MinLen(n), append(calls, fmt.Sprintf("MinLen(%d)", n))
+			case ok && fn == "strings.MaxRunes":
+				p, calls = p.MaxLen(n), append(calls, fmt.Sprintf("MaxLen(%d)", n))
+			default:
+				return nil, nil, false
+			}
+		default:
+			return nil, nil, false
+		}
+	}
+	if s.def != nil {
+		def, ok := stringLit(s.def)
+		if !ok {
+			return nil, nil, false
+		}
+		p, calls = p.Default(def), append(calls, fmt.Sprintf("Default(%s)", goString(def)))
+	}
+	return p, calls, true
+}
+
+// intParam translates the schema into an int parameter
+func intParam(name string, s *scalarSchema) (*defkit.IntParam, []string, bool) {
+	p, calls := defkit.Int(name), []string(nil)
+	for _, constraint := range s.constraints {
+		e, ok := constraint.(*ast.UnaryExpr)
+		if !ok {
+			return nil, nil, false
+		}
+		n, ok := intLit(e.X)
+		switch {
+		case ok && e.Op == token.GEQ:
+			p, calls = p.Min(n), append(calls, fmt.Sprintf("Min(%d)", n))
+		case ok && e.Op == token.LEQ:
+			p, calls = p.Max(n), append(calls, fmt.Sprintf("Max(%d)", n))
+		default:
+			return nil, nil, false
+		}
+	}
+	if s.def != nil {
+		def, ok := intLit(s.def)
+		if !ok {
+			return nil, nil, false
+		}
+		p, calls = p.Default(def), append(calls, fmt.Sprintf("Default(%d)", def))
+	}
+	return p, calls, true
+}
+
+// boolParam translates the schema into a bool parameter
+func boolParam(name string, s *scalarSchema) (*defkit.BoolParam, []string, bool) {
+	p, calls := defkit.Bool(name), []string(nil)
+	if len(s.constraints) > 0 {
+		return nil, nil, false
+	}
+	if s.def != nil {
+		def, ok := boolLit(s.def)
+		if !ok {
+			return nil, nil, false
+		}
+		p, calls = p.Default(def), append(calls, fmt.Sprintf("Default(%t)", def))
+	}
+	return p, calls, true
+}
+
+// floatParam translates the schema into a float parameter, the bounds are not
+// translated since defkit renders the bounded floats as numbers
+func floatParam(name string, s *scalarSchema) (*defkit.FloatParam, []string, bool) {
+	p, calls := defkit.Float(name), []string(nil)
+	if len(s.constraints) > 0 {
+		return nil, nil, false
+	}
+	if s.def != nil {
+		def, ok := floatLit(s.def)
+		if !ok {
+			return nil, nil, false
+		}
+		p, calls = p.Default(def), append(calls, fmt.Sprintf("Default(%s)", goLiteral(def)))
+	}
+	return p, calls, true
+}
+
+// scalarParam translates the scalar schema into a parameter
+func scalarParam(name string, expr ast.Expr, meta paramMeta) (defkit.Param, string, bool) {
+	s, ok := parseScalar(expr)
+	if !ok {
+		return nil, "", false
+	}
+	ctor := fmt.Sprintf("defkit.%s(%q)", toPascalCase(s.base), name)
+	switch s.base {
+	case "string":
+		p, calls, ok := stringParam(name, s)
+		if !ok {
+			return nil, "", false
+		}
+		p, calls = withMeta(p, calls, meta)
+		p, calls = withFlags(p, calls, meta)
+		return p, chain(ctor, calls), true
+	case "int":
+		p, calls, ok := intParam(name, s)
+		if !ok {
+			return nil, "", false
+		}
+		p, calls = withMeta(p, calls, meta)
+		p, calls = withFlags(p, calls, meta)
+		return p, chain(ctor, calls), true
+	case "bool":
+		p, calls, ok := boolParam(name, s)
+		if !ok {
+			return nil, "", false
+		}
+		p, calls = withMeta(p, calls, meta)
+		p, calls = withFlags(p, calls, meta)
+		return p, chain(ctor, calls), true
+	default:
+		p, calls, ok := floatParam(name, s)
+		if !ok {
+			return nil, "", false
+		}
+		p, calls = withMeta(p, calls, meta)
+		p, calls = withFlags(p, calls, meta)
+		return p, chain(ctor, calls), true
+	}
+}
+
+// listParam translates the list schema, e.g. [...string] or [...{name: string}], into a parameter
+func (c *converter) listParam(path, name string, list *ast.ListLit, meta paramMeta) (defkit.Param, string, bool) {
+	if len(list.Elts) != 1 {
+		return nil, "", false
+	}
+	ellipsis, ok := list.Elts[0].(*ast.Ellipsis)
+	if !ok {
+		return nil, "", false
+	}
+	var p *defkit.ArrayParam
+	var ctor string
+	var calls []string
+	switch typ := ellipsis.Type.(type) {
+	case nil:
+		p, ctor = defkit.List(name), fmt.Sprintf("defkit.List(%q)", name)
+	case *ast.Ident:
+		switch typ.Name {
+		case "string":
+			p, ctor = defkit.StringList(name), fmt.Sprintf("defkit.StringList(%q)", name)
+		case "int":
+			p, ctor = defkit.IntList(name), fmt.Sprintf("defkit.IntList(%q)", name)
+		case "bool", "float":
+			p, ctor = defkit.Array(name).Of(defkit.ParamType(typ.Name)), fmt.Sprintf("defkit.Array(%q)", name)
+			calls = append(calls, fmt.Sprintf("Of(defkit.%s)", elementTypes[typ.Name]))
+		default:
+			return nil, "", false
+		}
+	case *ast.StructLit:
+		fields, codes, ok := c.structFields(path, typ)
+		if !ok || len(fields) == 0 {
+			return nil, "", false
+		}
+		p, ctor = defkit.Array(name).WithFields(fields...), fmt.Sprintf("defkit.Array(%q)", name)
+		calls = append(calls, fmt.Sprintf("WithFields(%s)", args(codes)))
+	default:
+		return nil, "", false
+	}
+	p, calls = withMeta(p, calls, meta)
+	return p, chain(ctor, calls), true
+}
+
+// structParam translates the struct schema, e.g. {...}, [string]: int or {name: string}, into a parameter
+func (c *converter) structParam(path, name string, st *ast.StructLit, meta paramMeta) (defkit.Param, string, bool) {
+	if len(st.Elts) == 0 {
+		p, calls := withMeta(defkit.Object(name), nil, meta)
+		return p, chain(fmt.Sprintf("defkit.Object(%q)", name), calls), true
+	}
+	if len(st.Elts) == 1 {
+		if e, ok := st.Elts[0].(*ast.Ellipsis); ok && e.Type == nil {
+			p, calls := withMeta(defkit.Object(name), nil, meta)
+			return p, chain(fmt.Sprintf("defkit.Object(%q)", name), calls), true
+		}
+		if f, ok := st.Elts[0].(*ast.Field); ok {
+			if key, ok := f.Label.(*ast.ListLit); ok && len(key.Elts) == 1 && formatNode(key.Elts[0]) == "string" {
+				typ, ok := typeIdent(f.Value)
+				switch {
+				case !ok:
+					return nil, "", false
+				case typ == "string":
+					p, calls := withMeta(defkit.StringKeyMap(name), nil, meta)
+					return p, chain(fmt.Sprintf("defkit.StringKeyMap(%q)", name), calls), true
+				default:
+					p, calls := withMeta(defkit.Map(name).Of(defkit.ParamType(typ)), []string{fmt.Sprintf("Of(defkit.%s)", elementTypes[typ])}, meta)
+					return p, chain(fmt.Sprintf("defkit.Map(%q)", name), calls), true
+				}
+			}
+		}
+	}
+	fields, codes, ok := c.structFields(path, st)
+	if !ok {
+		return nil, "", false
+	}
+	p, calls := withMeta(defkit.Map(name).WithFields(fields...), []string{fmt.Sprintf("WithFields(%s)", args(codes))}, meta)
+	return p, chain(fmt.Sprintf("defkit.Map(%q)", name), calls), true
+}
+
+// structFields translates the fields of the struct schema into parameters,
+// false if the struct has other elements than regular fields
+func (c *converter) structFields(path string, st *ast.StructLit) ([]defkit.Param, []string, bool) {
+	var params []defkit.Param
+	var codes []string
+	for _, elt := range st.Elts {
+		field, ok := elt.(*ast.Field)
+		if !ok {
+			if _, ok := elt.(*ast.CommentGroup); ok {
+				continue
+			}
+			return nil, nil, false
+		}
+		p, ok := c.translateParam(path, field)
+		if !ok {
+			return nil, nil, false
+		}
+		params, codes = append(params, p.param), append(codes, p.code)
+	}
+	return params, codes, true
+}
+
+// isScalarParam tells if the parameter supports the +short and +ignore directives
+func isScalarParam(p defkit.Param) bool {
+	switch p.(type) {
+	case *defkit.StringParam, *defkit.IntParam, *defkit.BoolParam, *defkit.FloatParam:
+		return true
+	default:
+		return false
+	}
+}
+
+// paramName returns the name of the parameter field, false if the field is
+// not a regular field with an identifier label
+func paramName(field *ast.Field) (string, bool) {
+	ident, ok := field.Label.(*ast.Ident)
+	if !ok || strings.HasPrefix(ident.Name, "#") || strings.HasPrefix(ident.Name, "_") {
+		return "", false
+	}
+	return ident.Name, true
+}
+
+// translateParam translates the parameter field, the schemas that have no
+// defkit equivalent are kept as raw CUE. It returns false if the field label
+// cannot be translated.
+func (c *converter) translateParam(prefix string, field *ast.Field) (goParam, bool) {
+	name, ok := paramName(field)
+	if !ok {
+		return goParam{}, false
+	}
+	path := prefix + "." + name
+	meta := c.readParamMeta(path, field)
+	var p defkit.Param
+	var code string
+	switch e := field.Value.(type) {
+	case *ast.ListLit:
+		p, code, ok = c.listParam(path, name, e, meta)
+	case *ast.StructLit:
+		p, code, ok = c.structParam(path, name, e, meta)
+	default:
+		p, code, ok = scalarParam(name, e, meta)
+	}
+	if ok {
+		if !isScalarParam(p) && (meta.short != "" || meta.ignore) {
+			c.issue(path, "+short and +ignore are only supported on scalar parameters, dropped")
+		}
+		return goParam{param: p, code: code}, true
+	}
+	raw := formatNode(field.Value)
+	c.issue(path, "schema kept as raw CUE")
+	if meta.short != "" || meta.ignore {
+		c.issue(path, "+short and +ignore are only supported on scalar parameters, dropped")
+	}
+	if _, isList := field.Value.(*ast.ListLit); isList {
+		p, calls := withMeta(defkit.Array(name).WithSchema(raw), []string{fmt.Sprintf("WithSchema(%s)", goString(raw))}, meta)
+		return goParam{param: p, code: chain(fmt.Sprintf("defkit.Array(%q)", name), calls)}, true
+	}
+	p, calls := withMeta(defkit.Object(name).WithSchema(raw), []string{fmt.Sprintf("WithSchema(%s)", goString(raw))}, meta)
+	return goParam{param: p, code: chain(fmt.Sprintf("defkit.Object(%q)", name), calls)}, true
+}
+
+// translateParameters translates the fields of the parameter struct into the
+// top-level parameters, the other elements are returned to be kept as raw CUE
+func (c *converter) translateParameters(st *ast.StructLit) []ast.Decl {
+	var raw []ast.Decl
+	for _, elt := range st.Elts {
+		switch e := elt.(type) {
+		case *ast.CommentGroup:
+			continue
+		case *ast.Field:
+			if p, ok := c.translateParam("parameter", e); ok {
+				name := p.param.Name()
+				if c.params[name] != nil {
+					c.issue("parameter."+name, "duplicated parameter kept as raw CUE")
+					raw = append(raw, e)
+					continue
+				}
+				p.ident = goIdent(name, c.used)
+				c.params[name] = &p
+				c.paramList = append(c.paramList, &p)
+				continue
+			}
+		default:
+		}
+		c.issue("parameter", fmt.Sprintf("%q kept as raw CUE", firstLine(formatNode(elt))))
+		raw = append(raw, elt)
+	}
+	return raw
+}
+
+// firstLine returns the first line of the text, used to identify a CUE node in the issues
+func firstLine(s string) string {
+	if i := strings.Index(s, "\n"); i >= 0 {
+		return s[:i] + " ..."
+	}
+	return s
+}
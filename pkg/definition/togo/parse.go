@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/literal"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+	"github.com/pkg/errors"
+
+	ast2 "github.com/oam-dev/kubevela/pkg/definition/ast"
+)
+
+// the types of the definitions
+const (
+	typeComponent    = "component"
+	typeTrait        = "trait"
+	typePolicy       = "policy"
+	typeWorkflowStep = "workflow-step"
+)
+
+// source is a parsed CUE definition
+type source struct {
+	name string
+	typ  string
+	// cue is the original CUE of the definition
+	cue string
+	// imports are the import specs of the definition
+	imports []*ast.ImportSpec
+	// header is the metadata field of the definition, with the status encoded as strings
+	header *ast.Field
+	// template is the body of the template field
+	template *ast.StructLit
+}
+
+// parseDefinition parses the CUE definition into the metadata and the template
+func parseDefinition(cueString string) (*source, error) {
+	f, err := parser.ParseFile("-", cueString, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the definition")
+	}
+	src := &source{cue: cueString}
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.ImportDecl:
+			src.imports = append(src.imports, d.Specs...)
+		case *ast.Field:
+			label := ast2.GetFieldLabel(d.Label)
+			if label == "template" {
+				st, ok := d.Value.(*ast.StructLit)
+				if !ok {
+					return nil, errors.Errorf("the template of the definition must be a struct")
+				}
+				src.template = st
+				continue
+			}
+			if src.header != nil {
+				return nil, errors.Errorf("found more than one metadata field: %s and %s", src.name, label)
+			}
+			if label == "" {
+				return nil, errors.Errorf("invalid name of the definition: %s", formatNode(d.Label))
+			}
+			src.name, src.header = label, d
+		case *ast.Package, *ast.CommentGroup, *ast.Attribute:
+		default:
+			return nil, errors.Errorf("unexpected declaration in the definition: %s", formatNode(decl))
+		}
+	}
+	if src.header == nil {
+		return nil, errors.Errorf("no metadata found in the definition")
+	}
+	if src.template == nil {
+		return nil, errors.Errorf("no template found in the definition")
+	}
+	if err := ast2.EncodeMetadata(src.header); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the metadata of %s", src.name)
+	}
+	typ, ok, err := src.headerString("type")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("the type of %s is not set", src.name)
+	}
+	switch typ {
+	case typeComponent, typeTrait, typePolicy, typeWorkflowStep:
+		src.typ = typ
+	default:
+		return nil, errors.Errorf("unsupported definition type %q of %s", typ, src.name)
+	}
+	return src, nil
+}
+
+// headerNode returns the value of the metadata at the path
+func (src *source) headerNode(path string) (ast.Expr, bool) {
+	node, _, ok := ast2.GetNodeByPath(src.header, path)
+	if !ok {
+		return nil, false
+	}
+	expr, ok := node.(ast.Expr)
+	return expr, ok
+}
+
+// headerString returns the string value of the metadata at the path
+func (src *source) headerString(path string) (string, bool, error) {
+	expr, ok := src.headerNode(path)
+	if !ok {
+		return "", false, nil
+	}
+	s, ok := stringLit(expr)
+	if !ok {
+		return "", false, errors.Errorf("%s of %s must be a string literal", path, src.name)
+	}
+	return s, true, nil
+}
+
+// stringLit returns the value of the string literal
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := literal.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// boolLit returns the value of the bool literal
+func boolLit(expr ast.Expr) (bool, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.TRUE:
+			return true, true
+		case token.FALSE:
+			return false, true
+		default:
+		}
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		default:
+		}
+	}
+	return false, false
+}
+
+// intLit returns the value of the int literal
+func intLit(expr ast.Expr) (int, bool) {
+	neg := false
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.SUB {
+		neg, expr = true, u.X
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, false
+	}
+	i, err := strconv.Atoi(lit.Value)
+	if err != nil {
+		return 0, false
+	}
+	if neg {
+		i = -i
+	}
+	return i, true
+}
+
+// floatLit returns the value of the float literal, which is only accepted if
+// it is still formatted as a float by defkit
+func floatLit(expr ast.Expr) (float64, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.FLOAT {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(lit.Value, 64)
+	if err != nil || !strings.ContainsAny(strconv.FormatFloat(f, 'g', -1, 64), ".e") {
+		return 0, false
+	}
+	return f, true
+}
+
+// stringMap returns the string map of the struct literal
+func stringMap(expr ast.Expr) (map[string]string, bool) {
+	st, ok := expr.(*ast.StructLit)
+	if !ok {
+		return nil, false
+	}
+	m := map[string]string{}
+	for _, elt := range st.Elts {
+		field, ok := elt.(*ast.Field)
+		if !ok {
+			return nil, false
+		}
+		key, ok := fieldLabel(field)
+		if !ok {
+			return nil, false
+		}
+		if m[key], ok = stringLit(field.Value); !ok {
+			return nil, false
+		}
+	}
+	return m, true
+}
+
+// stringList returns the strings of the list literal
+func stringList(expr ast.Expr) ([]string, bool) {
+	list, ok := expr.(*ast.ListLit)
+	if !ok {
+		return nil, false
+	}
+	items := make([]string, 0, len(list.Elts))
+	for _, elt := range list.Elts {
+		s, ok := stringLit(elt)
+		if !ok {
+			return nil, false
+		}
+		items = append(items, s)
+	}
+	return items, true
+}
+
+// fieldLabel returns the label of a regular field, false if the label is
+// dynamic, a pattern constraint or the field is not a regular field
+func fieldLabel(field *ast.Field) (string, bool) {
+	if field.Constraint != token.ILLEGAL {
+		return "", false
+	}
+	switch l := field.Label.(type) {
+	case *ast.Ident:
+		if strings.HasPrefix(l.Name, "#") || strings.HasPrefix(l.Name, "_") {
+			return "", false
+		}
+		return l.Name, true
+	case *ast.BasicLit:
+		return stringLit(l)
+	default:
+		return "", false
+	}
+}
+
+// formatNode formats the CUE node, the declarations that are not fields are
+// formatted in a file since they cannot be formatted alone
+func formatNode(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.Comprehension, *ast.EmbedDecl, *ast.LetClause:
+		node = &ast.File{Decls: []ast.Decl{n.(ast.Decl)}}
+	default:
+	}
+	bs, err := format.Node(node, format.Simplify())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(bs))
+}
+
+// formatDecls formats the CUE declarations
+func formatDecls(decls []ast.Decl) string {
+	parts := make([]string, 0, len(decls))
+	for _, decl := range decls {
+		parts = append(parts, formatNode(decl))
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,493 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"github.com/pkg/errors"
+)
+
+// RoundTrip is the result of comparing the CUE regenerated from defkit with the original CUE
+type RoundTrip struct {
+	// Equivalent tells if no difference is found
+	Equivalent bool
+	// Differences lists the paths rendered differently
+	Differences []string
+	// Unverified lists the paths that cannot be compared, e.g. the fields
+	// depending on the runtime context like context.output
+	Unverified []string
+}
+
+// sampleMode is the way of building a sample of the parameters
+type sampleMode string
+
+const (
+	// sampleMinimal only sets the required parameters without defaults
+	sampleMinimal sampleMode = "minimal"
+	// sampleFull sets all the parameters, keeping the defaults
+	sampleFull sampleMode = "full"
+	// sampleAlternate sets all the parameters to values other than the defaults
+	sampleAlternate sampleMode = "alternate"
+)
+
+// sampleContext is the context filled into the templates when comparing them
+var sampleContext = map[string]any{
+	"name":           "sample",
+	"namespace":      "default",
+	"appName":        "sample-app",
+	"appRevision":    "sample-app-v1",
+	"appRevisionNum": 1,
+}
+
+// VerifyRoundTrip compares the generated CUE definition with the original one.
+// The metadata is compared after normalizing the fields defkit always renders,
+// the parameter schemas are compared by subsumption and the other fields of the
+// templates are compared on samples of the parameters.
+func VerifyRoundTrip(original, generated string) (*RoundTrip, error) {
+	orig, err := parseDefinition(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the original definition")
+	}
+	gen, err := parseDefinition(generated)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the generated definition")
+	}
+	rt := &roundTripper{differences: map[string]bool{}, unverified: map[string]bool{}}
+	if orig.name != gen.name {
+		rt.differ("name", fmt.Sprintf("%q != %q", orig.name, gen.name))
+	}
+	rt.compareMetadata(flattenMetadata(orig), flattenMetadata(gen))
+
+	if _, err := compileTemplate(templateSource(orig)); err != nil {
+		return nil, errors.Wrap(err, "failed to compile the original template")
+	}
+	if _, err := compileTemplate(templateSource(gen)); err != nil {
+		rt.differ("template", fmt.Sprintf("failed to compile: %v", err))
+		return rt.result(), nil
+	}
+	// the templates are compared in the same CUE runtime, which is required by subsumption
+	pair, err := compileTemplate(pairSource(orig, gen))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compile the templates together")
+	}
+	rt.compareTemplates(pair)
+	return rt.result(), nil
+}
+
+// roundTripper collects the differences and the unverified paths
+type roundTripper struct {
+	differences map[string]bool
+	unverified  map[string]bool
+}
+
+func (rt *roundTripper) differ(path, msg string) {
+	rt.differences[fmt.Sprintf("%s: %s", path, msg)] = true
+}
+
+func (rt *roundTripper) unverify(path, msg string) {
+	rt.unverified[fmt.Sprintf("%s: %s", path, msg)] = true
+}
+
+func (rt *roundTripper) result() *RoundTrip {
+	res := &RoundTrip{Differences: sortedSet(rt.differences), Unverified: sortedSet(rt.unverified)}
+	res.Equivalent = len(res.Differences) == 0
+	return res
+}
+
+func sortedSet(set map[string]bool) []string {
+	items := make([]string, 0, len(set))
+	for item := range set {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return items
+}
+
+// the labels of the original and the generated templates when they are compiled
+// together, which cannot be referred to by the templates
+const (
+	originalLabel  = "togo-original"
+	generatedLabel = "togo-generated"
+)
+
+// templateSource returns the CUE source of the template with the imports of the definition
+func templateSource(src *source) string {
+	return importsSource(src.imports) + formatDecls(src.template.Elts)
+}
+
+// pairSource returns the CUE source of both templates, they share the imports and the context
+func pairSource(orig, gen *source) string {
+	return importsSource(append(append([]*ast.ImportSpec(nil), orig.imports...), gen.imports...)) +
+		fmt.Sprintf("%q: {\n%s\n}\n%q: {\n%s\n}\n", originalLabel, formatDecls(orig.template.Elts), generatedLabel, formatDecls(gen.template.Elts))
+}
+
+// importsSource returns the deduplicated import declarations
+func importsSource(specs []*ast.ImportSpec) string {
+	var sb strings.Builder
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		decl := fmt.Sprintf("import %s\n", spec.Path.Value)
+		if spec.Name != nil {
+			decl = fmt.Sprintf("import %s %s\n", spec.Name.Name, spec.Path.Value)
+		}
+		if !seen[decl] {
+			seen[decl] = true
+			sb.WriteString(decl)
+		}
+	}
+	return sb.String()
+}
+
+// flattenMetadata flattens the metadata into the formatted values by path, the
+// values defkit renders by default are dropped, i.e. the empty descriptions and
+// maps and the false attributes, and the status scripts are normalized
+func flattenMetadata(src *source) map[string]string {
+	values := map[string]string{}
+	var flatten func(path string, expr ast.Expr)
+	flatten = func(path string, expr ast.Expr) {
+		switch e := expr.(type) {
+		case *ast.StructLit:
+			for _, elt := range e.Elts {
+				field, ok := elt.(*ast.Field)
+				if !ok {
+					continue
+				}
+				label, ok := fieldLabel(field)
+				if !ok {
+					label = formatNode(field.Label)
+				}
+				if path != "" {
+					label = path + "." + label
+				}
+				flatten(label, field.Value)
+			}
+		case *ast.ListLit:
+			if len(e.Elts) == 0 {
+				values[path] = "[]"
+			}
+			for i, elt := range e.Elts {
+				flatten(fmt.Sprintf("%s[%d]", path, i), elt)
+			}
+		default:
+			if b, ok := boolLit(expr); ok && !b && strings.HasPrefix(path, "attributes.") {
+				return
+			}
+			if s, ok := stringLit(expr); ok {
+				if strings.HasPrefix(path, "attributes.status.") {
+					s = normalizeScript(s)
+				}
+				if s == "" && path == "description" {
+					return
+				}
+				values[path] = fmt.Sprintf("%q", s)
+				return
+			}
+			values[path] = formatNode(expr)
+		}
+	}
+	flatten("", src.header.Value)
+	return values
+}
+
+// normalizeScript trims the lines of the status script and drops the blank ones
+func normalizeScript(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// compareMetadata compares the flattened metadata
+func (rt *roundTripper) compareMetadata(orig, gen map[string]string) {
+	for path, v := range orig {
+		switch w, ok := gen[path]; {
+		case !ok:
+			rt.differ(path, "missing in the generated definition")
+		case v != w:
+			rt.differ(path, fmt.Sprintf("%s != %s", v, w))
+		default:
+		}
+	}
+	for path := range gen {
+		if _, ok := orig[path]; !ok {
+			rt.differ(path, "not in the original definition")
+		}
+	}
+}
+
+// compareTemplates compares the parameter schemas and the fields of the templates on the samples
+func (rt *roundTripper) compareTemplates(pair cue.Value) {
+	origPath, genPath := cue.MakePath(cue.Str(originalLabel)), cue.MakePath(cue.Str(generatedLabel))
+	paramPath := cue.ParsePath("parameter")
+	origParam, genParam := pair.LookupPath(origPath).LookupPath(paramPath), pair.LookupPath(genPath).LookupPath(paramPath)
+	switch {
+	case origParam.Exists() != genParam.Exists():
+		rt.differ("parameter", "only exists in one of the definitions")
+	case origParam.Exists() && !sameSchema(origParam, genParam):
+		// subsumption is not complete for definitions and closed structs, the
+		// schemas are still compared on the samples of both of them
+		rt.unverify("parameter", "the schemas cannot be proven equivalent, compared on samples only")
+	default:
+	}
+	for _, mode := range []sampleMode{sampleMinimal, sampleFull, sampleAlternate} {
+		if genParam.Exists() {
+			if sample, ok := sampleOf(genParam, mode).(map[string]any); ok {
+				filled := fillSample(pair, sample)
+				if filled.LookupPath(genPath).Err() == nil && filled.LookupPath(origPath).Err() != nil {
+					rt.differ("parameter", fmt.Sprintf("the %s sample of the generated schema is rejected by the original schema", mode))
+				}
+			}
+		}
+		sample := map[string]any{}
+		if origParam.Exists() {
+			if s, ok := sampleOf(origParam, mode).(map[string]any); ok {
+				sample = s
+			}
+		}
+		filled := fillSample(pair, sample)
+		origVal, genVal := filled.LookupPath(origPath), filled.LookupPath(genPath)
+		if origVal.Err() != nil {
+			rt.unverify("parameter", fmt.Sprintf("the %s sample is rejected by the original schema", mode))
+			continue
+		}
+		if err := genVal.Err(); err != nil {
+			rt.differ("parameter", fmt.Sprintf("the %s sample is rejected by the generated schema: %v", mode, err))
+			continue
+		}
+		for _, label := range fieldLabels(origVal, genVal) {
+			if label == "parameter" || label == "context" {
+				continue
+			}
+			path := cue.ParsePath(label)
+			rt.compareValues(label, mode, origVal.LookupPath(path), genVal.LookupPath(path))
+		}
+	}
+}
+
+// sameSchema tells if the schemas subsume each other or are formatted the same
+func sameSchema(a, b cue.Value) bool {
+	if a.Subsume(b) == nil && b.Subsume(a) == nil {
+		return true
+	}
+	opts := []cue.Option{cue.Docs(false), cue.Optional(true), cue.Definitions(true), cue.ResolveReferences(true)}
+	return formatNode(a.Syntax(opts...)) == formatNode(b.Syntax(opts...))
+}
+
+// fillSample fills the sample of the parameters into both templates and the sample context
+func fillSample(pair cue.Value, sample map[string]any) cue.Value {
+	for _, label := range []string{originalLabel, generatedLabel} {
+		pair = pair.FillPath(cue.MakePath(cue.Str(label), cue.Str("parameter")), sample)
+	}
+	for k, val := range sampleContext {
+		pair = pair.FillPath(cue.MakePath(cue.Str("context"), cue.Str(k)), val)
+	}
+	return pair
+}
+
+// fieldLabels returns the sorted labels of the regular fields of both structs
+func fieldLabels(a, b cue.Value) []string {
+	set := map[string]bool{}
+	for _, v := range []cue.Value{a, b} {
+		it, err := v.Fields()
+		if err != nil {
+			continue
+		}
+		for it.Next() {
+			set[it.Selector().String()] = true
+		}
+	}
+	return sortedSet(set)
+}
+
+// compareValues compares the values at the path recursively
+func (rt *roundTripper) compareValues(path string, mode sampleMode, a, b cue.Value) {
+	switch {
+	case !a.Exists() && !b.Exists():
+		return
+	case !a.Exists():
+		rt.differ(path, fmt.Sprintf("not in the original definition (%s sample)", mode))
+		return
+	case !b.Exists():
+		rt.differ(path, fmt.Sprintf("missing in the generated definition (%s sample)", mode))
+		return
+	default:
+	}
+	if a.Err() != nil || b.Err() != nil {
+		if (a.Err() == nil) != (b.Err() == nil) {
+			rt.differ(path, fmt.Sprintf("only fails in one of the definitions (%s sample)", mode))
+		}
+		return
+	}
+	if a.IncompleteKind() == cue.StructKind && b.IncompleteKind() == cue.StructKind {
+		for _, label := range fieldLabels(a, b) {
+			sel := cue.ParsePath(label)
+			rt.compareValues(path+"."+label, mode, a.LookupPath(sel), b.LookupPath(sel))
+		}
+		return
+	}
+	if a.IncompleteKind() == cue.ListKind && b.IncompleteKind() == cue.ListKind {
+		la, errA := a.Len().Int64()
+		lb, errB := b.Len().Int64()
+		if errA == nil && errB == nil {
+			if la != lb {
+				rt.differ(path, fmt.Sprintf("%d items != %d items (%s sample)", la, lb, mode))
+				return
+			}
+			for i := 0; i < int(la); i++ {
+				sel := cue.MakePath(cue.Index(i))
+				rt.compareValues(fmt.Sprintf("%s[%d]", path, i), mode, a.LookupPath(sel), b.LookupPath(sel))
+			}
+			return
+		}
+	}
+	concreteA, concreteB := a.Validate(cue.Concrete(true)) == nil, b.Validate(cue.Concrete(true)) == nil
+	switch {
+	case concreteA && concreteB:
+		ja, _ := a.MarshalJSON()
+		jb, _ := b.MarshalJSON()
+		if string(ja) != string(jb) {
+			rt.differ(path, fmt.Sprintf("%s != %s (%s sample)", ja, jb, mode))
+		}
+	case concreteA != concreteB:
+		rt.differ(path, fmt.Sprintf("only concrete in one of the definitions (%s sample)", mode))
+	case a.Subsume(b) != nil || b.Subsume(a) != nil:
+		rt.unverify(path, "not concrete in both definitions")
+	default:
+	}
+}
+
+// sampleOf builds a sample of the schema, nil if the field can be omitted
+func sampleOf(v cue.Value, mode sampleMode) any {
+	def, hasDefault := v.Default()
+	kind := v.IncompleteKind()
+	switch {
+	case kind&cue.StructKind != 0:
+		return sampleStruct(v, mode)
+	case kind&cue.ListKind != 0:
+		if mode == sampleMinimal {
+			if hasDefault {
+				return nil
+			}
+			return []any{}
+		}
+		if elem := v.LookupPath(cue.MakePath(cue.AnyIndex)); elem.Exists() {
+			if item := sampleOf(elem, mode); item != nil {
+				return []any{item}
+			}
+		}
+		return []any{}
+	default:
+	}
+	if v.IsConcrete() || (hasDefault && mode != sampleAlternate) {
+		return nil
+	}
+	if values := enumValues(v); len(values) > 0 {
+		for _, val := range values {
+			if !hasDefault || !val.Equals(def) {
+				var out any
+				if err := val.Decode(&out); err == nil {
+					return out
+				}
+			}
+		}
+	}
+	var candidates []any
+	switch {
+	case kind&cue.BoolKind != 0:
+		if hasDefault {
+			b, _ := def.Bool()
+			return !b
+		}
+		return true
+	case kind&cue.StringKind != 0:
+		candidates = sampleStrings
+	case kind&cue.IntKind != 0:
+		if hasDefault {
+			if i, err := def.Int64(); err == nil {
+				candidates = append(candidates, i+1, i-1)
+			}
+		}
+		candidates = append(candidates, sampleInts...)
+	case kind&cue.FloatKind != 0:
+		candidates = []any{1.5, 0.5}
+	default:
+		candidates = sampleStrings
+	}
+	return firstValid(v, candidates)
+}
+
+// the candidates of the string and int samples, the first one accepted by the schema is used
+var (
+	sampleStrings = []any{"sample", "1Gi", "100m", "1", "sample.example.com", "https://sample.example.com"}
+	sampleInts    = []any{1, 2, 0, 80, 8080}
+)
+
+// firstValid returns the first candidate accepted by the schema, the first candidate if none is
+func firstValid(v cue.Value, candidates []any) any {
+	for _, candidate := range candidates {
+		if v.Unify(v.Context().Encode(candidate)).Validate(cue.Concrete(true)) == nil {
+			return candidate
+		}
+	}
+	return candidates[0]
+}
+
+// sampleStruct builds a sample of the struct schema, the optional fields are only set in full samples
+func sampleStruct(v cue.Value, mode sampleMode) any {
+	out := map[string]any{}
+	it, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return out
+	}
+	for it.Next() {
+		sel := it.Selector()
+		if sel.IsDefinition() {
+			continue
+		}
+		optional := sel.ConstraintType() == cue.OptionalConstraint
+		if optional && mode == sampleMinimal {
+			continue
+		}
+		if item := sampleOf(it.Value(), mode); item != nil {
+			out[sel.Unquoted()] = item
+		}
+	}
+	return out
+}
+
+// enumValues returns the concrete values of the disjunction
+func enumValues(v cue.Value) []cue.Value {
+	op, args := v.Expr()
+	if op != cue.OrOp {
+		return nil
+	}
+	var values []cue.Value
+	for _, arg := range args {
+		if arg.IsConcrete() && arg.Kind() != cue.StructKind && arg.Kind() != cue.ListKind {
+			values = append(values, arg)
+		}
+	}
+	return values
+}
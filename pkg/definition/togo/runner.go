@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+)
+
+// runnerDirPattern is the pattern of the temporary directory holding the
+// generated code in the module, the leading underscore keeps it out of ./...
+const runnerDirPattern = "_togo-roundtrip-*"
+
+// Runner runs the generated Go code and prints the CUE of the definition it
+// builds, so that the round trip is verified against the code actually emitted.
+// The code is built with `go run` inside the Go module of the directory, like
+// the registry of a definition module is, so the module must require kubevela,
+// e.g. a definition module. No go.mod is written and `go mod tidy` is not run.
+type Runner struct {
+	moduleRoot string
+	modulePath string
+}
+
+// NewRunner creates a Runner building the generated code in the Go module of
+// the directory. It fails if the directory is not in a Go module or the go
+// command is not available.
+func NewRunner(dir string) (*Runner, error) {
+	if _, err := exec.LookPath("go"); err != nil {
+		return nil, errors.Wrap(err, "the go command is required to run the generated code")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the absolute path of %s", dir)
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		bs, err := os.ReadFile(filepath.Join(root, "go.mod")) //nolint:gosec // G304: go.mod of the output module
+		if err == nil {
+			modulePath := modfile.ModulePath(bs)
+			if modulePath == "" {
+				return nil, errors.Errorf("no module path found in %s", filepath.Join(root, "go.mod"))
+			}
+			return &Runner{moduleRoot: root, modulePath: modulePath}, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "failed to read the go.mod of %s", root)
+		}
+		if filepath.Dir(root) == root {
+			return nil, errors.Errorf("%s is not in a Go module", abs)
+		}
+	}
+}
+
+// Run builds and runs the generated Go source, the CUE rendered by the function
+// of the source is returned. The source is written into a temporary package of
+// the module which is removed afterwards.
+func (r *Runner) Run(source []byte, funcName string) (string, error) {
+	dir, err := os.MkdirTemp(r.moduleRoot, runnerDirPattern)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create a temporary directory in %s", r.moduleRoot)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	defDir := filepath.Join(dir, "def")
+	if err := os.Mkdir(defDir, 0750); err != nil {
+		return "", errors.Wrapf(err, "failed to create %s", defDir)
+	}
+	if err := os.WriteFile(filepath.Join(defDir, generatedFile), source, 0600); err != nil {
+		return "", errors.Wrap(err, "failed to write the generated code")
+	}
+	importPath := path.Join(r.modulePath, filepath.Base(dir), "def")
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(runnerProgram(importPath, funcName)), 0600); err != nil {
+		return "", errors.Wrap(err, "failed to write the runner program")
+	}
+
+	// GOWORK=off builds the code with the module alone, as the registry of a definition module is
+	cmd := exec.Command("go", "run", "./"+filepath.Base(dir)) //nolint:gosec // G204: the package is generated above
+	cmd.Dir = r.moduleRoot
+	cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOWORK=off")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "failed to run the generated code: %s", stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// runnerProgram is the program printing the CUE of the generated definition
+func runnerProgram(importPath, funcName string) string {
+	return fmt.Sprintf(`package main
+
+import (
+	"fmt"
+
+	def %q
+)
+
+func main() {
+	fmt.Print(def.%s().ToCue())
+}
+`, importPath, funcName)
+}
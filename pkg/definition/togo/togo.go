@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package togo converts CUE X-Definitions into defkit Go code.
+//
+// Parameters, output resources, conditionals, trait patches and status/health
+// policies are translated into the fluent defkit API. The parts that have no
+// defkit equivalent are kept as raw CUE through the escape hatches of defkit
+// (raw header and patch blocks, raw schemas and expressions) and reported as
+// issues, so that the generated definition always renders the same CUE. When a
+// Runner is given, the conversion is verified by running the generated Go code
+// and comparing the CUE it prints with the original one semantically. The
+// generated code is type-checked against defkit when a TypeChecker is given.
+package togo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// Options is the options of converting a CUE definition
+type Options struct {
+	// Package is the package of the generated Go file, default to the package
+	// of the definition type in a definition module, e.g. components
+	Package string
+	// FuncName is the name of the generated function, default to the PascalCase
+	// of the definition name with the type suffix, e.g. WebserviceComponent
+	FuncName string
+	// SkipVerify skips the round trip verification
+	SkipVerify bool
+	// TypeChecker type-checks the generated Go code against defkit, the code
+	// is not type-checked if not set
+	TypeChecker *TypeChecker
+	// Runner runs the generated Go code to verify the round trip, the round
+	// trip is not verified if not set
+	Runner *Runner
+}
+
+// Issue is a part of the definition that is not translated into the fluent defkit API
+type Issue struct {
+	// Path is the path of the part in the definition, e.g. template.output.spec
+	Path string
+	// Message describes how the part is handled
+	Message string
+}
+
+// String returns the issue in the format of path: message
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// Result is the result of converting a CUE definition
+type Result struct {
+	// Name is the name of the definition
+	Name string
+	// Type is the type of the definition, e.g. component
+	Type string
+	// Source is the formatted Go source of the defkit definition
+	Source []byte
+	// Definition is the defkit definition built by the converter in process.
+	// It mirrors the generated Go code and is meant for debugging the converter
+	// only, the round trip is verified by running Source.
+	Definition defkit.Definition
+	// CUE is the CUE printed by running the generated Go code, empty if it is not run
+	CUE string
+	// Issues lists the parts kept as raw CUE or dropped in the conversion
+	Issues []Issue
+	// RoundTrip is the result of comparing CUE with the original, nil if the
+	// verification is skipped or no Runner is given
+	RoundTrip *RoundTrip
+	// TypeChecked tells if the generated Go code is type-checked against defkit
+	TypeChecked bool
+}
+
+// Convert converts the CUE definition into defkit Go code
+func Convert(cueString string, opts Options) (*Result, error) {
+	src, err := parseDefinition(cueString)
+	if err != nil {
+		return nil, err
+	}
+	c := newConverter(src)
+	def, body, err := c.convert()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s %s", src.typ, src.name)
+	}
+	if opts.Package == "" {
+		opts.Package = packageNames[src.typ]
+	}
+	if opts.FuncName == "" {
+		opts.FuncName = toPascalCase(src.name) + funcSuffixes[src.typ]
+	}
+	source, err := renderFile(opts, src, body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to format the generated code of %s %s", src.typ, src.name)
+	}
+	if opts.TypeChecker != nil {
+		if err := opts.TypeChecker.Check(source); err != nil {
+			return nil, errors.Wrapf(err, "failed to type-check the generated code of %s %s", src.typ, src.name)
+		}
+	}
+	res := &Result{
+		Name:        src.name,
+		Type:        src.typ,
+		Source:      source,
+		Definition:  def,
+		Issues:      c.issues,
+		TypeChecked: opts.TypeChecker != nil,
+	}
+	if !opts.SkipVerify && opts.Runner != nil {
+		if res.CUE, err = opts.Runner.Run(source, opts.FuncName); err != nil {
+			return nil, errors.Wrapf(err, "failed to run the generated code of %s %s", src.typ, src.name)
+		}
+		if res.RoundTrip, err = VerifyRoundTrip(cueString, res.CUE); err != nil {
+			return nil, errors.Wrapf(err, "failed to verify the round trip of %s %s", src.typ, src.name)
+		}
+	}
+	return res, nil
+}
+
+// packageNames are the packages of the definition types in a definition module
+var packageNames = map[string]string{
+	typeComponent:    "components",
+	typeTrait:        "traits",
+	typePolicy:       "policies",
+	typeWorkflowStep: "workflowsteps",
+}
+
+// funcSuffixes are the suffixes of the generated functions of the definition types
+var funcSuffixes = map[string]string{
+	typeComponent:    "Component",
+	typeTrait:        "Trait",
+	typePolicy:       "Policy",
+	typeWorkflowStep: "WorkflowStep",
+}
+
+// toPascalCase converts a kebab-case or snake_case string to PascalCase
+func toPascalCase(s string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range s {
+		switch {
+		case r == '-' || r == '_' || r == '.' || r == ' ':
+			upper = true
+		case upper:
+			sb.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,518 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const componentCUE = `
+"my-web": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "A simple web service"
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "apps/v1"
+				kind:       "Deployment"
+			}
+			type: "deployments.apps"
+		}
+		status: {
+			healthPolicy: #"""
+				isHealth: context.output.status.readyReplicas == context.output.status.replicas
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		metadata: {
+			name: context.name
+			labels: "app.oam.dev/component": context.name
+		}
+		spec: {
+			replicas: parameter.replicas
+			selector: matchLabels: "app.oam.dev/component": context.name
+			template: {
+				metadata: labels: "app.oam.dev/component": context.name
+				spec: containers: [{
+					name:  context.name
+					image: parameter.image
+					if parameter.cmd != _|_ {
+						command: parameter.cmd
+					}
+					env: [{name: "APP", value: "\(context.appName)-\(parameter.image)"}]
+				}]
+			}
+		}
+	}
+	outputs: {
+		if parameter.port != _|_ && parameter.expose {
+			service: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: name: context.name
+				spec: {
+					selector: "app.oam.dev/component": context.name
+					ports: [{port: parameter.port}]
+					if parameter.serviceType == "LoadBalancer" {
+						externalTrafficPolicy: "Local"
+					}
+					type: parameter.serviceType
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=Which image would you like to use for your service
+		// +short=i
+		image: string
+		// +usage=Number of replicas
+		replicas: *1 | int & >=1 & <=10
+		cmd?: [...string]
+		port?: int
+		expose: *false | bool
+		serviceType: *"ClusterIP" | "NodePort" | "LoadBalancer"
+		labels?: [string]: string
+	}
+}
+`
+
+const traitCUE = `
+"pod-labels": {
+	type: "trait"
+	annotations: {}
+	labels: {}
+	description: "Add labels to the pod"
+	attributes: {
+		podDisruptive: true
+		appliesToWorkloads: ["deployments.apps"]
+	}
+}
+template: {
+	// +patchStrategy=retainKeys
+	patch: spec: template: {
+		metadata: labels: {
+			if parameter.version != _|_ {
+				version: parameter.version
+			}
+			team: parameter.team
+		}
+		if context.namespace == "prod" {
+			spec: priorityClassName: "high"
+		}
+	}
+	parameter: {
+		version?: string
+		team: *"core" | string
+	}
+}
+`
+
+const rawTraitCUE = `
+"pod-annotations": {
+	type: "trait"
+	annotations: {}
+	labels: {}
+	description: "Add annotations to the pod"
+	attributes: podDisruptive: true
+}
+template: {
+	patch: spec: template: metadata: annotations: {
+		for k, v in parameter {
+			(k): v
+		}
+	}
+	parameter: [string]: string
+}
+`
+
+const policyCUE = `
+"my-topology": {
+	type: "policy"
+	annotations: {}
+	labels: {}
+	description: "Select the clusters to deploy to"
+	attributes: {}
+}
+template: {
+	clusters: parameter.clusters
+	parameter: {
+		// +usage=The names of the clusters
+		clusters?: [...string]
+		namespace?: string
+	}
+}
+`
+
+const rawPolicyCUE = `
+"my-override": {
+	type: "policy"
+	annotations: {}
+	labels: {}
+	description: "Override the components"
+	attributes: {}
+}
+template: {
+	#Patch: {
+		name?: string
+		properties?: {...}
+	}
+	parameter: {
+		components: [...#Patch]
+	}
+}
+`
+
+const stepCUE = `
+import (
+	"vela/kube"
+)
+
+"apply-object": {
+	type: "workflow-step"
+	annotations: {
+		"category": "Resource Management"
+	}
+	labels: {
+		"scope": "Application"
+	}
+	description: "Apply a raw object"
+}
+template: {
+	apply: kube.#Apply & {
+		$params: {
+			value:   parameter.value
+			cluster: parameter.cluster
+		}
+	}
+	parameter: {
+		value: {...}
+		cluster: *"" | string
+	}
+}
+`
+
+func TestConvertComponent(t *testing.T) {
+	res, err := Convert(componentCUE, Options{})
+	require.NoError(t, err)
+	require.Equal(t, "my-web", res.Name)
+	require.Equal(t, typeComponent, res.Type)
+	require.Nil(t, res.RoundTrip)
+	requireInProcessEquivalent(t, componentCUE, res)
+	require.Empty(t, res.Issues)
+
+	src := string(res.Source)
+	for _, snippet := range []string{
+		"package components",
+		"defkit.Register(MyWebComponent())",
+		"func MyWebComponent() *defkit.ComponentDefinition {",
+		`image := defkit.String("image").`,
+		`Description("Which image would you like to use for your service").`,
+		`Short("i")`,
+		`Min(1).`,
+		`Values("ClusterIP", "NodePort", "LoadBalancer").`,
+		`labels := defkit.StringKeyMap("labels").`,
+		`Workload("apps/v1", "Deployment").`,
+		`HealthPolicy("isHealth: context.output.status.readyReplicas == context.output.status.replicas").`,
+		"vela := defkit.VelaCtx()",
+		`Set("metadata.labels[app.oam.dev/component]", vela.Name()).`,
+		`SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).`,
+		`defkit.Interpolation(vela.AppName(), defkit.Lit("-"), image)`,
+		`tpl.OutputsIf(defkit.And(port.IsSet(), expose.IsTrue()), "service",`,
+		`SetIf(serviceType.Eq("LoadBalancer"), "spec.externalTrafficPolicy", defkit.Lit("Local")).`,
+	} {
+		require.Contains(t, src, snippet)
+	}
+}
+
+func TestConvertTrait(t *testing.T) {
+	res, err := Convert(traitCUE, Options{Package: "mytraits", FuncName: "PodLabels"})
+	require.NoError(t, err)
+	requireInProcessEquivalent(t, traitCUE, res)
+
+	src := string(res.Source)
+	for _, snippet := range []string{
+		"package mytraits",
+		"func PodLabels() *defkit.TraitDefinition {",
+		`AppliesTo("deployments.apps").`,
+		`tpl.PatchStrategy("retainKeys")`,
+		`SetIf(version.IsSet(), "spec.template.metadata.labels.version", version).`,
+		`Set("spec.template.metadata.labels.team", team).`,
+	} {
+		require.Contains(t, src, snippet)
+	}
+	require.Equal(t, []Issue{{
+		Path:    "patch.spec.template",
+		Message: `condition "context.namespace == \"prod\"" kept as raw CUE`,
+	}}, res.Issues)
+}
+
+func TestConvertTraitRawPatch(t *testing.T) {
+	res, err := Convert(rawTraitCUE, Options{})
+	require.NoError(t, err)
+	requireInProcessEquivalent(t, rawTraitCUE, res)
+	require.Contains(t, string(res.Source), "tpl.SetRawPatchBlock(`patch: spec: template: metadata: annotations: {")
+	require.Contains(t, string(res.Source), "tpl.SetRawParameterBlock(")
+
+	var paths []string
+	for _, issue := range res.Issues {
+		paths = append(paths, issue.Path)
+	}
+	require.Equal(t, []string{"parameter", "template.patch"}, paths)
+}
+
+func TestConvertPolicy(t *testing.T) {
+	res, err := Convert(policyCUE, Options{})
+	require.NoError(t, err)
+	requireInProcessEquivalent(t, policyCUE, res)
+	require.Empty(t, res.Issues)
+	require.Contains(t, string(res.Source), "Template(func(tpl *defkit.PolicyTemplate) {")
+	require.Contains(t, string(res.Source), `tpl.Set("clusters", clusters)`)
+
+	res, err = Convert(rawPolicyCUE, Options{})
+	require.NoError(t, err)
+	requireInProcessEquivalent(t, rawPolicyCUE, res)
+	require.Len(t, res.Issues, 1)
+	require.Contains(t, res.Issues[0].Message, "definition kept as raw CUE")
+	require.Contains(t, string(res.Source), "RawCUE(`")
+}
+
+func TestConvertWorkflowStep(t *testing.T) {
+	res, err := Convert(stepCUE, Options{})
+	require.NoError(t, err)
+	requireInProcessEquivalent(t, stepCUE, res)
+
+	src := string(res.Source)
+	for _, snippet := range []string{
+		"package workflowsteps",
+		"func ApplyObjectWorkflowStep() *defkit.WorkflowStepDefinition {",
+		`Category("Resource Management").`,
+		`Scope("Application").`,
+		`WithImports("vela/kube").`,
+		`value := defkit.Object("value")`,
+		"TemplateBody(`apply: kube.#Apply & {",
+	} {
+		require.Contains(t, src, snippet)
+	}
+	require.Equal(t, []Issue{{Path: "template", Message: "steps kept as raw CUE in the template body"}}, res.Issues)
+}
+
+func TestConvertErrors(t *testing.T) {
+	testCases := map[string]struct {
+		cue string
+		err string
+	}{
+		"invalid cue": {
+			cue: `a: {`,
+			err: "failed to parse the definition",
+		},
+		"no template": {
+			cue: `a: {type: "trait"}`,
+			err: "no template found",
+		},
+		"unsupported type": {
+			cue: `a: {type: "scope"}
+template: {}`,
+			err: `unsupported definition type "scope"`,
+		},
+		"non-literal description": {
+			cue: `a: {type: "trait", description: "a" + "b"}
+template: {}`,
+			err: "description of a must be a string literal",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Convert(tc.cue, Options{})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	res, err := VerifyRoundTrip(componentCUE, componentCUE)
+	require.NoError(t, err)
+	require.True(t, res.Equivalent)
+
+	testCases := map[string]struct {
+		old, new string
+		diff     string
+	}{
+		"changed literal": {
+			old:  `"\(context.appName)-\(parameter.image)"`,
+			new:  `"\(context.appName):\(parameter.image)"`,
+			diff: "output.spec.template.spec.containers[0].env[0].value",
+		},
+		"changed default": {
+			old:  `replicas: *1 | int`,
+			new:  `replicas: *2 | int`,
+			diff: "output.spec.replicas",
+		},
+		"changed condition": {
+			old:  `if parameter.cmd != _|_ {`,
+			new:  `if parameter.cmd == _|_ {`,
+			diff: "output.spec.template.spec.containers[0]",
+		},
+		"changed description": {
+			old:  `description: "A simple web service"`,
+			new:  `description: "Another web service"`,
+			diff: "description",
+		},
+		"changed parameter type": {
+			old:  `cmd?: [...string]`,
+			new:  `cmd?: [...int]`,
+			diff: "parameter",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			generated := strings.Replace(componentCUE, tc.old, tc.new, 1)
+			require.NotEqual(t, componentCUE, generated)
+			res, err := VerifyRoundTrip(componentCUE, generated)
+			require.NoError(t, err)
+			require.False(t, res.Equivalent)
+			require.Contains(t, strings.Join(res.Differences, "\n"), tc.diff)
+		})
+	}
+}
+
+// builtinDefinitionsDir is the directory of the built-in definitions of KubeVela
+const builtinDefinitionsDir = "../../../vela-templates/definitions/internal"
+
+// typeChecker resolves defkit in the module of kubevela, shared by the tests to
+// import defkit from source only once
+var typeChecker = sync.OnceValues(func() (*TypeChecker, error) {
+	return NewTypeChecker(".")
+})
+
+func TestTypeCheck(t *testing.T) {
+	tc, err := typeChecker()
+	require.NoError(t, err)
+
+	res, err := Convert(componentCUE, Options{TypeChecker: tc})
+	require.NoError(t, err)
+	require.True(t, res.TypeChecked)
+
+	broken := strings.Replace(string(res.Source), "defkit.String(", "defkit.Strings(", 1)
+	err = tc.Check([]byte(broken))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the generated code does not compile")
+	require.Contains(t, err.Error(), "Strings")
+}
+
+func TestConvertBuiltinDefinitions(t *testing.T) {
+	tc, err := typeChecker()
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(builtinDefinitionsDir, "*", "*.cue"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		name, _ := filepath.Rel(builtinDefinitionsDir, file)
+		t.Run(name, func(t *testing.T) {
+			bs, err := os.ReadFile(file)
+			require.NoError(t, err)
+			res, err := Convert(string(bs), Options{TypeChecker: tc})
+			require.NoError(t, err)
+			require.True(t, res.TypeChecked)
+			requireInProcessEquivalent(t, string(bs), res)
+		})
+	}
+}
+
+func TestRunner(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skip building the generated code in short mode")
+	}
+	runner, err := NewRunner(".")
+	require.NoError(t, err)
+
+	for name, cue := range map[string]string{
+		"component": componentCUE,
+		"trait":     traitCUE,
+		"step":      stepCUE,
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := Convert(cue, Options{Runner: runner})
+			require.NoError(t, err)
+			require.NotEmpty(t, res.CUE)
+			require.NotNil(t, res.RoundTrip)
+			require.True(t, res.RoundTrip.Equivalent, "differences: %v", res.RoundTrip.Differences)
+		})
+	}
+
+	t.Run("the emitted code is verified", func(t *testing.T) {
+		res, err := Convert(componentCUE, Options{})
+		require.NoError(t, err)
+		broken := strings.Replace(string(res.Source), `Description("A simple web service")`, `Description("Another web service")`, 1)
+		require.NotEqual(t, string(res.Source), broken)
+		generated, err := runner.Run([]byte(broken), "MyWebComponent")
+		require.NoError(t, err)
+		rt, err := VerifyRoundTrip(componentCUE, generated)
+		require.NoError(t, err)
+		require.False(t, rt.Equivalent)
+		require.Contains(t, strings.Join(rt.Differences, "\n"), "description")
+	})
+
+	t.Run("broken code", func(t *testing.T) {
+		_, err := runner.Run([]byte("package components\n\nfunc MyWebComponent() {}\n"), "MyWebComponent")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to run the generated code")
+	})
+
+	_, err = NewRunner(t.TempDir())
+	require.Error(t, err)
+}
+
+// requireInProcessEquivalent verifies the round trip of the definition built by
+// the converter in process, a quick check of the converter without building the
+// generated code which is run in TestRunner
+func requireInProcessEquivalent(t *testing.T, original string, res *Result) {
+	t.Helper()
+	rt, err := VerifyRoundTrip(original, res.Definition.ToCue())
+	require.NoError(t, err)
+	require.True(t, rt.Equivalent, "differences: %v", rt.Differences)
+}
+
+func TestGoIdent(t *testing.T) {
+	used := map[string]bool{}
+	require.Equal(t, "image", goIdent("image", used))
+	require.Equal(t, "image2", goIdent("image", used))
+	require.Equal(t, "serviceType", goIdent("service-type", used))
+	require.Equal(t, "typeParam", goIdent("type", used))
+	require.Equal(t, "p3replicas", goIdent("3replicas", used))
+	require.Equal(t, "velaParam", goIdent("vela", used))
+}
+
+func TestToPascalCase(t *testing.T) {
+	require.Equal(t, "MyWeb", toPascalCase("my-web"))
+	require.Equal(t, "ApplyObject", toPascalCase("apply_object"))
+	require.Equal(t, "K8sObjects", toPascalCase("k8s-objects"))
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// generatedFile is the name of the generated Go file when type-checking it
+const generatedFile = "zz_generated_definition.go"
+
+// TypeChecker type-checks the generated Go code against the defkit package.
+// defkit is imported from source through the Go module of the directory, so
+// the directory must be in a module requiring kubevela, e.g. a definition module.
+// The imported packages are cached, a TypeChecker should be reused for
+// checking multiple definitions.
+type TypeChecker struct {
+	dir      string
+	fset     *token.FileSet
+	importer types.ImporterFrom
+}
+
+// NewTypeChecker creates a TypeChecker resolving defkit in the Go module of the
+// directory. It fails if defkit cannot be imported from the directory.
+func NewTypeChecker(dir string) (*TypeChecker, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the absolute path of %s", dir)
+	}
+	fset := token.NewFileSet()
+	imp, ok := importer.ForCompiler(fset, "source", nil).(types.ImporterFrom)
+	if !ok {
+		return nil, errors.New("the source importer does not support importing from a directory")
+	}
+	if _, err := imp.ImportFrom(defkitImport, abs, 0); err != nil {
+		return nil, errors.Wrapf(err, "failed to import %s from %s", defkitImport, abs)
+	}
+	return &TypeChecker{dir: abs, fset: fset, importer: imp}, nil
+}
+
+// Check parses and type-checks the generated Go source, all the type errors are returned
+func (tc *TypeChecker) Check(source []byte) error {
+	file, err := parser.ParseFile(tc.fset, filepath.Join(tc.dir, generatedFile), source, parser.SkipObjectResolution)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the generated code")
+	}
+	var typeErrors []string
+	conf := types.Config{
+		Importer: tc.importer,
+		Error: func(err error) {
+			typeErrors = append(typeErrors, err.Error())
+		},
+	}
+	// the errors are collected by the error handler
+	_, _ = conf.Check(file.Name.Name, tc.fset, []*ast.File{file}, nil)
+	if len(typeErrors) > 0 {
+		return errors.Errorf("the generated code does not compile:\n%s", strings.Join(typeErrors, "\n"))
+	}
+	return nil
+}
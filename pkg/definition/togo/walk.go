@@ -0,0 +1,370 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package togo
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// fieldOp is a field assignment of a resource or a patch
+type fieldOp struct {
+	path   string
+	value  goValue
+	spread bool
+	conds  []goCond
+	// srcs are the CUE expressions of the conditions
+	srcs []ast.Expr
+}
+
+// walker collects the field assignments of a resource or a patch
+type walker struct {
+	c *converter
+	// loc is the location of the walked struct in the template, e.g. output
+	loc string
+	// patch tells if the walked struct is a trait patch, which defkit renders
+	// without map keys, spreads and list elements
+	patch bool
+	ops   []fieldOp
+}
+
+// joinPath appends the field label to the defkit path, the labels that are not
+// identifiers are written as map keys, e.g. labels[app.oam.dev/name]
+func joinPath(prefix, label string) (string, bool) {
+	if ast.IsValidIdent(label) && !strings.HasPrefix(label, "#") {
+		if prefix == "" {
+			return label, true
+		}
+		return prefix + "." + label, true
+	}
+	if prefix == "" || label == "" || strings.ContainsAny(label, "[]") || strings.Trim(label, "0123456789") == "" {
+		return "", false
+	}
+	return prefix + "[" + label + "]", true
+}
+
+// appendCond returns a copy of the conditions with the condition appended
+func appendCond(conds []goCond, srcs []ast.Expr, cond goCond, src ast.Expr) ([]goCond, []ast.Expr) {
+	conds = append(append([]goCond(nil), conds...), cond)
+	srcs = append(append([]ast.Expr(nil), srcs...), src)
+	return conds, srcs
+}
+
+// walkStruct collects the assignments of the struct literal under the path, false
+// if the struct has constructs that cannot be expressed by the defkit assignments
+func (w *walker) walkStruct(prefix string, st *ast.StructLit, conds []goCond, srcs []ast.Expr) bool {
+	for _, elt := range st.Elts {
+		switch e := elt.(type) {
+		case *ast.CommentGroup:
+		case *ast.Field:
+			if !w.walkField(prefix, e, conds, srcs) {
+				return false
+			}
+		case *ast.Comprehension:
+			ifConds, ifSrcs := conds, srcs
+			for _, clause := range e.Clauses {
+				ic, ok := clause.(*ast.IfClause)
+				if !ok {
+					return false
+				}
+				ifConds, ifSrcs = appendCond(ifConds, ifSrcs, w.c.translateCond(w.location(prefix), ic.Condition), ic.Condition)
+			}
+			body, ok := e.Value.(*ast.StructLit)
+			if !ok || !w.walkStruct(prefix, body, ifConds, ifSrcs) {
+				return false
+			}
+		case *ast.EmbedDecl:
+			if w.patch || len(conds) == 0 || prefix == "" {
+				return false
+			}
+			w.ops = append(w.ops, fieldOp{path: prefix, value: w.c.translateValue(w.location(prefix), e.Expr), spread: true, conds: conds, srcs: srcs})
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// walkField collects the assignments of the field under the path
+func (w *walker) walkField(prefix string, field *ast.Field, conds []goCond, srcs []ast.Expr) bool {
+	label, ok := fieldLabel(field)
+	if !ok || len(field.Attrs) > 0 {
+		return false
+	}
+	path, ok := joinPath(prefix, label)
+	if !ok || (w.patch && strings.HasSuffix(path, "]")) {
+		return false
+	}
+	// the map keys are always leaves in defkit paths
+	if !strings.HasSuffix(path, "]") {
+		switch v := field.Value.(type) {
+		case *ast.StructLit:
+			if len(v.Elts) > 0 && w.walkNested(path, v, conds, srcs) {
+				return true
+			}
+		case *ast.ListLit:
+			// defkit writes the conditional list element fields inside the element,
+			// so only the unconditional lists are walked into
+			if st, ok := singleStruct(v); ok && !w.patch && len(conds) == 0 && w.walkNested(path+"[0]", st, conds, srcs) {
+				return true
+			}
+		default:
+		}
+	}
+	w.ops = append(w.ops, fieldOp{path: path, value: w.c.translateValue(w.location(path), field.Value), conds: conds, srcs: srcs})
+	return true
+}
+
+// walkNested walks into the nested struct, nothing is collected if it fails
+func (w *walker) walkNested(path string, st *ast.StructLit, conds []goCond, srcs []ast.Expr) bool {
+	mark, ops := w.c.mark(), len(w.ops)
+	if w.walkStruct(path, st, conds, srcs) {
+		return true
+	}
+	w.c.reset(mark)
+	w.ops = w.ops[:ops]
+	return false
+}
+
+// singleStruct returns the struct of a list with a single struct element
+func singleStruct(list *ast.ListLit) (*ast.StructLit, bool) {
+	if len(list.Elts) != 1 {
+		return nil, false
+	}
+	st, ok := list.Elts[0].(*ast.StructLit)
+	return st, ok
+}
+
+// location returns the location of the path in the template
+func (w *walker) location(path string) string {
+	if path == "" {
+		return w.loc
+	}
+	return w.loc + "." + path
+}
+
+// valid tells if defkit renders the collected assignments as they are, i.e. no
+// path is assigned twice or is both assigned and the parent of another path
+func (w *walker) valid() bool {
+	leaves := map[string]bool{}
+	for _, op := range w.ops {
+		if op.spread {
+			continue
+		}
+		if leaves[op.path] {
+			return false
+		}
+		leaves[op.path] = true
+	}
+	for _, op := range w.ops {
+		for leaf := range leaves {
+			if op.path != leaf && (strings.HasPrefix(op.path, leaf+".") || strings.HasPrefix(op.path, leaf+"[")) {
+				return false
+			}
+		}
+		if op.spread && leaves[op.path] {
+			return false
+		}
+	}
+	return true
+}
+
+// opsBuilder is the assignments shared by defkit resources and patches
+type opsBuilder[T any] interface {
+	Set(path string, value defkit.Value) T
+	SetIf(cond defkit.Condition, path string, value defkit.Value) T
+	SpreadIf(cond defkit.Condition, path string, value defkit.Value) T
+	If(cond defkit.Condition) T
+	EndIf() T
+}
+
+// applyOps records the assignments on the builder, the consecutive assignments
+// sharing the same outermost condition are grouped in an If block if group is set
+func applyOps[T opsBuilder[T]](b T, ops []fieldOp, group bool) (T, []string) {
+	var calls []string
+	for i := 0; i < len(ops); {
+		j := i + 1
+		if group && len(ops[i].conds) > 0 && !ops[i].spread {
+			for j < len(ops) && !ops[j].spread && len(ops[j].conds) > 0 && ops[j].conds[0].code == ops[i].conds[0].code {
+				j++
+			}
+		}
+		if j-i == 1 {
+			b, calls = applyOp(b, calls, ops[i], 0)
+			i = j
+			continue
+		}
+		b, calls = b.If(ops[i].conds[0].cond), append(calls, fmt.Sprintf("If(%s)", ops[i].conds[0].code))
+		for _, op := range ops[i:j] {
+			b, calls = applyOp(b, calls, op, 1)
+		}
+		b, calls = b.EndIf(), append(calls, "EndIf()")
+		i = j
+	}
+	return b, calls
+}
+
+// applyOp records the assignment on the builder, skipping the conditions of the enclosing If block
+func applyOp[T opsBuilder[T]](b T, calls []string, op fieldOp, skip int) (T, []string) {
+	if len(op.conds) == skip {
+		return b.Set(op.path, op.value.val), append(calls, fmt.Sprintf("Set(%q, %s)", op.path, op.value.code))
+	}
+	cond := andConds(op.conds[skip:], op.srcs[skip:])
+	if op.spread {
+		return b.SpreadIf(cond.cond, op.path, op.value.val), append(calls, fmt.Sprintf("SpreadIf(%s, %q, %s)", cond.code, op.path, op.value.code))
+	}
+	return b.SetIf(cond.cond, op.path, op.value.val), append(calls, fmt.Sprintf("SetIf(%s, %q, %s)", cond.code, op.path, op.value.code))
+}
+
+// goResource is a resource translated into defkit
+type goResource struct {
+	apiVersion string
+	kind       string
+	ops        []fieldOp
+}
+
+// build builds a new defkit resource, the template functions of defkit are
+// invoked on every rendering so the resources are built for each of them
+func (r *goResource) build() *defkit.Resource {
+	res, _ := applyOps(defkit.NewResource(r.apiVersion, r.kind), r.ops, true)
+	return res
+}
+
+// code returns the Go code building the resource
+func (r *goResource) code() string {
+	_, calls := applyOps(defkit.NewResource(r.apiVersion, r.kind), r.ops, true)
+	return chain(fmt.Sprintf("defkit.NewResource(%q, %q)", r.apiVersion, r.kind), calls)
+}
+
+// translateResource translates the resource at the location, false if it has
+// constructs that defkit cannot express
+func (c *converter) translateResource(loc string, expr ast.Expr) (*goResource, bool) {
+	st, ok := expr.(*ast.StructLit)
+	if !ok {
+		return nil, false
+	}
+	r := &goResource{}
+	body := &ast.StructLit{}
+	for _, elt := range st.Elts {
+		if field, ok := elt.(*ast.Field); ok {
+			label, _ := fieldLabel(field)
+			s, isString := stringLit(field.Value)
+			switch {
+			case label == "apiVersion" && isString && r.apiVersion == "":
+				r.apiVersion = s
+				continue
+			case label == "kind" && isString && r.kind == "":
+				r.kind = s
+				continue
+			default:
+			}
+		}
+		body.Elts = append(body.Elts, elt)
+	}
+	if r.apiVersion == "" || r.kind == "" {
+		return nil, false
+	}
+	mark := c.mark()
+	w := &walker{c: c, loc: loc}
+	if !w.walkStruct("", body, nil, nil) || !w.valid() {
+		c.reset(mark)
+		return nil, false
+	}
+	r.ops = w.ops
+	return r, true
+}
+
+// goOutput is an auxiliary resource of the outputs
+type goOutput struct {
+	name string
+	cond *goCond
+	res  *goResource
+}
+
+// translateOutputs translates the auxiliary resources, false if any of them
+// cannot be translated
+func (c *converter) translateOutputs(expr ast.Expr) ([]goOutput, bool) {
+	st, ok := expr.(*ast.StructLit)
+	if !ok {
+		return nil, false
+	}
+	mark := c.mark()
+	var outputs []goOutput
+	names := map[string]bool{}
+	add := func(field *ast.Field, cond *goCond) bool {
+		name, ok := fieldLabel(field)
+		if !ok || names[name] {
+			return false
+		}
+		res, ok := c.translateResource("outputs."+name, field.Value)
+		if !ok {
+			return false
+		}
+		names[name] = true
+		outputs = append(outputs, goOutput{name: name, cond: cond, res: res})
+		return true
+	}
+	for _, elt := range st.Elts {
+		ok := false
+		switch e := elt.(type) {
+		case *ast.CommentGroup:
+			ok = true
+		case *ast.Field:
+			ok = add(e, nil)
+		case *ast.Comprehension:
+			ok = c.translateConditionalOutputs(e, add)
+		default:
+		}
+		if !ok {
+			c.reset(mark)
+			return nil, false
+		}
+	}
+	return outputs, true
+}
+
+// translateConditionalOutputs translates the auxiliary resources under the if clauses
+func (c *converter) translateConditionalOutputs(comp *ast.Comprehension, add func(*ast.Field, *goCond) bool) bool {
+	var conds []goCond
+	var srcs []ast.Expr
+	for _, clause := range comp.Clauses {
+		ic, ok := clause.(*ast.IfClause)
+		if !ok {
+			return false
+		}
+		conds, srcs = appendCond(conds, srcs, c.translateCond("outputs", ic.Condition), ic.Condition)
+	}
+	body, ok := comp.Value.(*ast.StructLit)
+	if !ok {
+		return false
+	}
+	cond := andConds(conds, srcs)
+	for _, elt := range body.Elts {
+		if _, ok := elt.(*ast.CommentGroup); ok {
+			continue
+		}
+		field, ok := elt.(*ast.Field)
+		if !ok || !add(field, &cond) {
+			return false
+		}
+	}
+	return true
+}
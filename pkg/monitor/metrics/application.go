@@ -87,6 +87,12 @@ var (
 		Help: "Workflow phase as numeric value (0=initializing, 1=succeeded, 2=executing, 3=suspending, 4=terminated, " +
 			"5=failed, 6=skipped, -1=unknown)",
 	}, []string{"app_name", "namespace"})
+
+	// ApplicationDriftCounter report the number of drifted resources detected in state-keep of each application
+	ApplicationDriftCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubevela_application_drift_total",
+		Help: "number of drifted resources detected in state-keep.",
+	}, []string{"app_name", "namespace", "mode"})
 )
 
 var (
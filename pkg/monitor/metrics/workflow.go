@@ -38,6 +38,7 @@ var collectorGroup = []prometheus.Collector{
 	AppReconcileStageDurationHistogram,
 	StepDurationHistogram,
 	ListResourceTrackerCounter,
+	ApplicationDriftCounter,
 	ApplicationReconcileTimeHistogram,
 	ApplyComponentTimeHistogram,
 	WorkflowFinishedTimeHistogram,
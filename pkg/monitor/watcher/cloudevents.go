@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+
+	workflowv1alpha1 "github.com/kubevela/workflow/api/v1alpha1"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/cloudevents"
+)
+
+// applicationEventQueueSize is the number of events buffered before delivered, the events beyond are dropped
+const applicationEventQueueSize = 1024
+
+// ApplicationEventData is the data of the lifecycle events of the application
+type ApplicationEventData struct {
+	Application string                  `json:"application"`
+	Namespace   string                  `json:"namespace"`
+	Phase       common.ApplicationPhase `json:"phase,omitempty"`
+	AppRevision string                  `json:"appRevision,omitempty"`
+	Step        string                  `json:"step,omitempty"`
+	StepType    string                  `json:"stepType,omitempty"`
+	Message     string                  `json:"message,omitempty"`
+	Rollback    *common.RollbackRecord  `json:"rollback,omitempty"`
+}
+
+type applicationEventsWatcher struct {
+	sender *cloudevents.Sender
+	queue  chan *cloudevents.Event
+}
+
+func (watcher *applicationEventsWatcher) getApp(obj interface{}) *v1beta1.Application {
+	app := &v1beta1.Application{}
+	bs, _ := json.Marshal(obj)
+	_ = json.Unmarshal(bs, app)
+	return app
+}
+
+func getStepPhases(status *common.WorkflowStatus) map[string]workflowv1alpha1.WorkflowStepPhase {
+	phases := map[string]workflowv1alpha1.WorkflowStepPhase{}
+	if status == nil {
+		return phases
+	}
+	for _, step := range status.Steps {
+		phases[step.Name] = step.Phase
+		for _, sub := range step.SubStepsStatus {
+			phases[sub.Name] = sub.Phase
+		}
+	}
+	return phases
+}
+
+func isNewWorkflowRun(oldApp, app *v1beta1.Application) bool {
+	if app.Status.Workflow == nil || app.Status.Workflow.StartTime.IsZero() {
+		return false
+	}
+	return oldApp.Status.Workflow == nil ||
+		oldApp.Status.Workflow.AppRevision != app.Status.Workflow.AppRevision ||
+		!oldApp.Status.Workflow.StartTime.Equal(&app.Status.Workflow.StartTime)
+}
+
+// getEvents returns the events of the lifecycle transitions between the old and the new application
+func (watcher *applicationEventsWatcher) getEvents(oldApp, app *v1beta1.Application) []*cloudevents.Event {
+	var events []*cloudevents.Event
+	newData := func() ApplicationEventData {
+		data := ApplicationEventData{Application: app.Name, Namespace: app.Namespace, Phase: app.Status.Phase}
+		if app.Status.Workflow != nil {
+			data.AppRevision = app.Status.Workflow.AppRevision
+		}
+		return data
+	}
+	add := func(eventType string, data ApplicationEventData) {
+		event, err := cloudevents.NewEvent(cloudevents.ApplicationSource(app.Namespace, app.Name), eventType, app.Name, data)
+		if err != nil {
+			klog.ErrorS(err, "failed to create cloud event", "application", klog.KObj(app), "type", eventType)
+			return
+		}
+		events = append(events, event)
+	}
+
+	newRun := isNewWorkflowRun(oldApp, app)
+	if newRun {
+		add(cloudevents.EventTypeWorkflowStarted, newData())
+	}
+	if app.Status.Workflow != nil {
+		oldPhases := map[string]workflowv1alpha1.WorkflowStepPhase{}
+		if !newRun {
+			oldPhases = getStepPhases(oldApp.Status.Workflow)
+		}
+		for _, step := range app.Status.Workflow.Steps {
+			for _, s := range append([]workflowv1alpha1.StepStatus{step.StepStatus}, step.SubStepsStatus...) {
+				if s.Phase == workflowv1alpha1.WorkflowStepPhaseFailed && oldPhases[s.Name] != workflowv1alpha1.WorkflowStepPhaseFailed {
+					data := newData()
+					data.Step, data.StepType, data.Message = s.Name, s.Type, s.Message
+					add(cloudevents.EventTypeWorkflowStepFailed, data)
+				}
+			}
+		}
+	}
+	if app.Status.Phase != oldApp.Status.Phase {
+		switch app.Status.Phase {
+		case common.ApplicationWorkflowFailed:
+			data := newData()
+			if app.Status.Workflow != nil {
+				data.Message = app.Status.Workflow.Message
+			}
+			add(cloudevents.EventTypeWorkflowFailed, data)
+		case common.ApplicationRunning:
+			add(cloudevents.EventTypeApplicationHealthy, newData())
+		default:
+		}
+	}
+	if rollback := app.Status.LastRollback; rollback != nil &&
+		(oldApp.Status.LastRollback == nil || !oldApp.Status.LastRollback.Time.Equal(&rollback.Time) || oldApp.Status.LastRollback.ToRevision != rollback.ToRevision) {
+		data := newData()
+		data.Message, data.Rollback = rollback.Reason, rollback
+		add(cloudevents.EventTypeApplicationRollback, data)
+	}
+	return events
+}
+
+func (watcher *applicationEventsWatcher) enqueue(events []*cloudevents.Event) {
+	for _, event := range events {
+		select {
+		case watcher.queue <- event:
+		default:
+			klog.InfoS("Cloud event dropped as the queue is full", "type", event.Type, "subject", event.Subject)
+		}
+	}
+}
+
+func (watcher *applicationEventsWatcher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-watcher.queue:
+			if err := watcher.sender.Send(ctx, event); err != nil {
+				klog.ErrorS(err, "failed to send cloud event", "type", event.Type, "subject", event.Subject)
+			}
+		}
+	}
+}
+
+// RunApplicationEventsWatcher emits the CloudEvents of the lifecycle transitions of the applications
+// to the sink, such as workflow started, step failed, workflow failed, app healthy and rollback. The
+// existing applications are not reported when the watcher starts. It blocks until the context is done.
+func RunApplicationEventsWatcher(ctx context.Context, informer ctrlcache.Informer, sender *cloudevents.Sender) error {
+	watcher := &applicationEventsWatcher{
+		sender: sender,
+		queue:  make(chan *cloudevents.Event, applicationEventQueueSize),
+	}
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, obj interface{}) {
+			watcher.enqueue(watcher.getEvents(watcher.getApp(oldObj), watcher.getApp(obj)))
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := informer.RemoveEventHandler(registration); err != nil {
+			klog.ErrorS(err, "failed to remove event handler for application events watcher")
+		}
+	}()
+	watcher.run(ctx)
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowv1alpha1 "github.com/kubevela/workflow/api/v1alpha1"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/cloudevents"
+)
+
+func newEventsTestApp(phase common.ApplicationPhase, rev string, start time.Time, steps ...workflowv1alpha1.WorkflowStepStatus) *v1beta1.Application {
+	return &v1beta1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Status: common.AppStatus{
+			Phase:    phase,
+			Workflow: &common.WorkflowStatus{AppRevision: rev, StartTime: metav1.NewTime(start), Steps: steps},
+		},
+	}
+}
+
+func newEventsTestStep(name string, phase workflowv1alpha1.WorkflowStepPhase) workflowv1alpha1.WorkflowStepStatus {
+	return workflowv1alpha1.WorkflowStepStatus{StepStatus: workflowv1alpha1.StepStatus{Name: name, Type: "apply-component", Phase: phase, Message: "failed to apply"}}
+}
+
+func TestApplicationEventsWatcherGetEvents(t *testing.T) {
+	start := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	running := newEventsTestApp(common.ApplicationRunning, "app-v1", start)
+	rollback := running.DeepCopy()
+	rollback.Status.LastRollback = &common.RollbackRecord{FromRevision: "app-v2", ToRevision: "app-v1", Reason: "unhealthy", Time: metav1.NewTime(start)}
+
+	testCases := map[string]struct {
+		oldApp *v1beta1.Application
+		app    *v1beta1.Application
+		types  []string
+	}{
+		"no-change": {
+			oldApp: running,
+			app:    running,
+		},
+		"workflow-started": {
+			oldApp: running,
+			app:    newEventsTestApp(common.ApplicationRunningWorkflow, "app-v2", start.Add(time.Hour)),
+			types:  []string{cloudevents.EventTypeWorkflowStarted},
+		},
+		"step-failed": {
+			oldApp: newEventsTestApp(common.ApplicationRunningWorkflow, "app-v2", start, newEventsTestStep("deploy", workflowv1alpha1.WorkflowStepPhaseRunning)),
+			app:    newEventsTestApp(common.ApplicationWorkflowFailed, "app-v2", start, newEventsTestStep("deploy", workflowv1alpha1.WorkflowStepPhaseFailed)),
+			types:  []string{cloudevents.EventTypeWorkflowStepFailed, cloudevents.EventTypeWorkflowFailed},
+		},
+		"step-failed-already": {
+			oldApp: newEventsTestApp(common.ApplicationWorkflowFailed, "app-v2", start, newEventsTestStep("deploy", workflowv1alpha1.WorkflowStepPhaseFailed)),
+			app:    newEventsTestApp(common.ApplicationWorkflowFailed, "app-v2", start, newEventsTestStep("deploy", workflowv1alpha1.WorkflowStepPhaseFailed)),
+		},
+		"healthy": {
+			oldApp: newEventsTestApp(common.ApplicationRunningWorkflow, "app-v1", start),
+			app:    running,
+			types:  []string{cloudevents.EventTypeApplicationHealthy},
+		},
+		"rollback": {
+			oldApp: running,
+			app:    rollback,
+			types:  []string{cloudevents.EventTypeApplicationRollback},
+		},
+	}
+	watcher := &applicationEventsWatcher{}
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var types []string
+			for _, event := range watcher.getEvents(tt.oldApp, tt.app) {
+				assert.Equal(t, "/namespaces/default/applications/app", event.Source)
+				assert.Equal(t, "app", event.Subject)
+				types = append(types, event.Type)
+			}
+			assert.Equal(t, tt.types, types)
+		})
+	}
+
+	events := watcher.getEvents(newEventsTestApp(common.ApplicationRunningWorkflow, "app-v2", start, newEventsTestStep("deploy", workflowv1alpha1.WorkflowStepPhaseRunning)),
+		newEventsTestApp(common.ApplicationRunningWorkflow, "app-v2", start, newEventsTestStep("deploy", workflowv1alpha1.WorkflowStepPhaseFailed)))
+	data := &ApplicationEventData{}
+	assert.NoError(t, json.Unmarshal(events[0].Data, data))
+	assert.Equal(t, ApplicationEventData{Application: "app", Namespace: "default", Phase: common.ApplicationRunningWorkflow, AppRevision: "app-v2",
+		Step: "deploy", StepType: "apply-component", Message: "failed to apply"}, *data)
+}
+
+func TestApplicationEventsWatcherRun(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("ce-type")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := &applicationEventsWatcher{
+		sender: &cloudevents.Sender{URL: server.URL, Mode: cloudevents.ModeBinary},
+		queue:  make(chan *cloudevents.Event, 1),
+	}
+	go watcher.run(ctx)
+	event, err := cloudevents.NewEvent("/namespaces/default/applications/app", cloudevents.EventTypeApplicationHealthy, "app", nil)
+	assert.NoError(t, err)
+	watcher.enqueue([]*cloudevents.Event{event})
+	select {
+	case eventType := <-received:
+		assert.Equal(t, cloudevents.EventTypeApplicationHealthy, eventType)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the event is not delivered")
+	}
+}
@@ -40,12 +40,21 @@ type ClusterMetricsHelper interface {
 	Refresh() error
 }
 
+// clusterMetricsEnabled records whether the cluster metrics manager is started
+var clusterMetricsEnabled bool
+
+// IsClusterMetricsEnabled checks if the cluster metrics manager is started to probe the clusters
+func IsClusterMetricsEnabled() bool {
+	return clusterMetricsEnabled
+}
+
 // NewClusterMetricsMgr will create a cluster metrics manager
 func NewClusterMetricsMgr(ctx context.Context, kubeClient client.Client, refreshPeriod time.Duration) (*ClusterMetricsMgr, error) {
 	mgr := &ClusterMetricsMgr{
 		kubeClient:    kubeClient,
 		refreshPeriod: refreshPeriod,
 	}
+	clusterMetricsEnabled = true
 	go mgr.Start(ctx)
 	return mgr, nil
 }
@@ -72,6 +81,12 @@ func (cmm *ClusterMetricsMgr) Refresh() ([]VirtualCluster, error) {
 			ClusterInfo:         clusterInfo,
 			ClusterUsageMetrics: clusterUsageMetrics,
 		}
+		if !isConnected {
+			cm.ProbeFailures = 1
+			if last, found := metricsMap[cluster.Name]; found && last != nil {
+				cm.ProbeFailures += last.ProbeFailures
+			}
+		}
 		m[cluster.Name] = cm
 		cluster.Metrics = cm
 	}
@@ -79,6 +94,15 @@ func (cmm *ClusterMetricsMgr) Refresh() ([]VirtualCluster, error) {
 	return clusters, nil
 }
 
+// GetClusterProbeFailures returns the number of consecutive failed probes of the cluster
+// recorded by the cluster metrics manager
+func GetClusterProbeFailures(clusterName string) int {
+	if m, found := metricsMap[clusterName]; found && m != nil {
+		return m.ProbeFailures
+	}
+	return 0
+}
+
 // Start will start polling cluster api to collect metrics
 func (cmm *ClusterMetricsMgr) Start(ctx context.Context) {
 	for {
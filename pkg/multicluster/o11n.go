@@ -33,6 +33,8 @@ type ClusterMetrics struct {
 	IsConnected         bool
 	ClusterInfo         *ClusterInfo
 	ClusterUsageMetrics *ClusterUsageMetrics
+	// ProbeFailures is the number of consecutive failed probes
+	ProbeFailures int
 }
 
 // ClusterInfo describes the basic information of a cluster
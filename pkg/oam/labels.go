@@ -133,6 +133,11 @@ const (
 	// the name of the workload instance should not changing along with the revision
 	AnnotationInplaceUpgrade = "app.oam.dev/inplace-upgrade"
 
+	// AnnotationProgressiveRollout marks the workload whose replicas are shifted to a canary by
+	// an ongoing progressive rollout, the value is the name of the canary. State-keep keeps the
+	// live replicas of the marked workload.
+	AnnotationProgressiveRollout = "app.oam.dev/progressive-rollout"
+
 	// AnnotationAppRevision indicates that the object is an application revision
 	//	its controller should not try to reconcile it
 	AnnotationAppRevision = "app.oam.dev/app-revision"
@@ -249,6 +254,10 @@ type policyContextKeyType string
 // PolicyAdditionalContextKey is the Go context key for storing policy output.ctx data.
 const PolicyAdditionalContextKey policyContextKeyType = "kubevela.oam.dev/policy-additional-context"
 
+// AppDependenciesContextKey is the Go context key for storing the outputs of the Applications
+// depended on through the app-dependency policy.
+const AppDependenciesContextKey policyContextKeyType = "kubevela.oam.dev/app-dependencies"
+
 // internalMetadataPrefixes lists key prefixes that are stripped when exposing Application
 // labels/annotations to policy CUE templates. Add prefixes here to prevent policies from
 // reading internal platform metadata.
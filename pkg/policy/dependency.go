@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+// GetAppDependencies returns the Applications that the app depends on through the app-dependency policy.
+// The outputs of the dependencies are exposed by name in context.dependencies, so the Applications
+// depended on must have different names even if they are in different namespaces.
+func GetAppDependencies(app *v1beta1.Application) ([]types.NamespacedName, error) {
+	spec, err := ParsePolicy[v1alpha1.AppDependencyPolicySpec](app)
+	if err != nil || spec == nil {
+		return nil, err
+	}
+	var deps []types.NamespacedName
+	seen := map[string]types.NamespacedName{}
+	for _, dep := range spec.DependsOn {
+		key := types.NamespacedName{Namespace: dep.Namespace, Name: dep.Name}
+		if key.Namespace == "" {
+			key.Namespace = app.Namespace
+		}
+		if key.Name == "" {
+			continue
+		}
+		if prev, found := seen[key.Name]; found {
+			if prev != key {
+				return nil, errors.Errorf("the applications %s and %s depended on have the same name", prev, key)
+			}
+			continue
+		}
+		seen[key.Name] = key
+		deps = append(deps, key)
+	}
+	return deps, nil
+}
+
+// AppDependsOnIndex is the field index of the Applications by the Applications they depend on,
+// in the format of <namespace>/<name>
+const AppDependsOnIndex = "appDependsOn"
+
+// IndexAppDependsOn is the indexer of AppDependsOnIndex
+func IndexAppDependsOn(obj client.Object) []string {
+	app, ok := obj.(*v1beta1.Application)
+	if !ok {
+		return nil
+	}
+	deps, err := GetAppDependencies(app)
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		keys = append(keys, dep.String())
+	}
+	return keys
+}
+
+// AllowsDependant returns whether the Applications in the namespace can depend on the app. The
+// Applications in other namespaces must be allowed by the exportTo of the app-dependency policy.
+func AllowsDependant(app *v1beta1.Application, namespace string) bool {
+	if namespace == app.Namespace {
+		return true
+	}
+	spec, err := ParsePolicy[v1alpha1.AppDependencyPolicySpec](app)
+	if err != nil || spec == nil {
+		return false
+	}
+	for _, ns := range spec.ExportTo {
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ListAppDependants lists the Applications that depend on the given Application through the
+// AppDependsOnIndex, which must be registered in the cache of the client. The ones being deleted,
+// and the ones in the namespaces not allowed to depend on the app, are skipped.
+func ListAppDependants(ctx context.Context, cli client.Client, app *v1beta1.Application) ([]types.NamespacedName, error) {
+	key := types.NamespacedName{Namespace: app.Namespace, Name: app.Name}
+	apps := &v1beta1.ApplicationList{}
+	if err := cli.List(ctx, apps, client.MatchingFields{AppDependsOnIndex: key.String()}); err != nil {
+		return nil, err
+	}
+	var dependants []types.NamespacedName
+	for i := range apps.Items {
+		item := &apps.Items[i]
+		if item.DeletionTimestamp != nil || !AllowsDependant(app, item.Namespace) {
+			continue
+		}
+		dependants = append(dependants, types.NamespacedName{Namespace: item.Namespace, Name: item.Name})
+	}
+	sort.Slice(dependants, func(i, j int) bool { return dependants[i].String() < dependants[j].String() })
+	return dependants, nil
+}
+
+// FindDependencyCycle returns the Applications in a dependency cycle going through the
+// start Application, or nil if there is no such cycle. The cycle starts and ends with start.
+func FindDependencyCycle(start types.NamespacedName, dependencies func(types.NamespacedName) []types.NamespacedName) []types.NamespacedName {
+	visited := map[types.NamespacedName]bool{}
+	var path []types.NamespacedName
+	var visit func(types.NamespacedName) bool
+	visit = func(app types.NamespacedName) bool {
+		path = append(path, app)
+		for _, dep := range dependencies(app) {
+			if dep == start {
+				path = append(path, dep)
+				return true
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			if visit(dep) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if visit(start) {
+		return path
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newDependantApp(namespace, name string, deps string) *v1beta1.Application {
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if deps != "" {
+		app.Spec.Policies = []v1beta1.AppPolicy{{
+			Name:       "deps",
+			Type:       v1alpha1.AppDependencyPolicyType,
+			Properties: &runtime.RawExtension{Raw: []byte(deps)},
+		}}
+	}
+	return app
+}
+
+func TestGetAppDependencies(t *testing.T) {
+	app := newDependantApp("ns", "web", `{"dependsOn":[{"name":"db"},{"name":"mq","namespace":"infra"},{"name":"db"}]}`)
+	deps, err := GetAppDependencies(app)
+	require.NoError(t, err)
+	require.Equal(t, []types.NamespacedName{{Namespace: "ns", Name: "db"}, {Namespace: "infra", Name: "mq"}}, deps)
+
+	deps, err = GetAppDependencies(newDependantApp("ns", "web", ""))
+	require.NoError(t, err)
+	require.Empty(t, deps)
+
+	_, err = GetAppDependencies(newDependantApp("ns", "web", `{"dependsOn":[{"name":"db"},{"name":"db","namespace":"infra"}]}`))
+	require.ErrorContains(t, err, "the applications ns/db and infra/db depended on have the same name")
+}
+
+func TestListAppDependants(t *testing.T) {
+	deleting := newDependantApp("ns", "old", `{"dependsOn":[{"name":"db"}]}`)
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	deleting.Finalizers = []string{"test"}
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).
+		WithIndex(&v1beta1.Application{}, AppDependsOnIndex, IndexAppDependsOn).
+		WithObjects(
+			newDependantApp("ns", "web", `{"dependsOn":[{"name":"db"}]}`),
+			newDependantApp("other", "api", `{"dependsOn":[{"name":"db","namespace":"ns"}]}`),
+			newDependantApp("other", "db", ""),
+			deleting,
+		).Build()
+	db := newDependantApp("ns", "db", "")
+	dependants, err := ListAppDependants(context.Background(), cli, db)
+	require.NoError(t, err)
+	// other/api is not allowed to depend on ns/db
+	require.Equal(t, []types.NamespacedName{{Namespace: "ns", Name: "web"}}, dependants)
+
+	db = newDependantApp("ns", "db", `{"exportTo":["other"]}`)
+	dependants, err = ListAppDependants(context.Background(), cli, db)
+	require.NoError(t, err)
+	require.Equal(t, []types.NamespacedName{{Namespace: "ns", Name: "web"}, {Namespace: "other", Name: "api"}}, dependants)
+}
+
+func TestAllowsDependant(t *testing.T) {
+	require.True(t, AllowsDependant(newDependantApp("ns", "db", ""), "ns"))
+	require.False(t, AllowsDependant(newDependantApp("ns", "db", ""), "other"))
+	require.False(t, AllowsDependant(newDependantApp("ns", "db", `{"exportTo":["team-a"]}`), "other"))
+	require.True(t, AllowsDependant(newDependantApp("ns", "db", `{"exportTo":["team-a","other"]}`), "other"))
+	require.True(t, AllowsDependant(newDependantApp("ns", "db", `{"exportTo":["*"]}`), "other"))
+}
+
+func TestFindDependencyCycle(t *testing.T) {
+	a, b, c, d := types.NamespacedName{Name: "a"}, types.NamespacedName{Name: "b"}, types.NamespacedName{Name: "c"}, types.NamespacedName{Name: "d"}
+	graph := map[types.NamespacedName][]types.NamespacedName{a: {b}, b: {c}, c: {a}, d: {c}}
+	lookup := func(app types.NamespacedName) []types.NamespacedName { return graph[app] }
+	require.Equal(t, []types.NamespacedName{a, b, c, a}, FindDependencyCycle(a, lookup))
+	// d reaches the cycle but is not part of it
+	require.Nil(t, FindDependencyCycle(d, lookup))
+
+	graph[c] = nil
+	require.Nil(t, FindDependencyCycle(a, lookup))
+}
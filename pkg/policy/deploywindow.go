@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+)
+
+const (
+	// deployWindowHorizon is how far NextDeployTime looks ahead for an allowed time
+	deployWindowHorizon = 366 * 24 * time.Hour
+	// deployWindowMaxSteps bounds the jumps between the windows when looking for an allowed time
+	deployWindowMaxSteps = 10000
+	// deployFreezeDateLayout is the layout of the freeze dates
+	deployFreezeDateLayout = "2006-01-02"
+)
+
+type deployWindow struct {
+	name     string
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// end returns the end of the latest window run covering t
+func (w deployWindow) end(t time.Time) (time.Time, bool) {
+	var end time.Time
+	found := false
+	for i, start := 0, w.schedule.Next(t.Add(-w.duration)); i < deployWindowMaxSteps && !start.IsZero() && !start.After(t); i, start = i+1, w.schedule.Next(start) {
+		end, found = start.Add(w.duration), true
+	}
+	return end, found
+}
+
+type deployFreeze struct {
+	name       string
+	start, end time.Time
+}
+
+func parseDeployWindows(windows []v1alpha1.DeployWindow, loc *time.Location) ([]deployWindow, error) {
+	var parsed []deployWindow
+	for _, w := range windows {
+		name := w.Name
+		if name == "" {
+			name = w.Schedule
+		}
+		schedule, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid schedule of window %q", name)
+		}
+		if s, ok := schedule.(*cron.SpecSchedule); ok && s.Location == time.Local {
+			s.Location = loc
+		}
+		duration, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid duration of window %q", name)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("the duration of window %q must be positive", name)
+		}
+		parsed = append(parsed, deployWindow{name: name, schedule: schedule, duration: duration})
+	}
+	return parsed, nil
+}
+
+func parseDeployFreezeTime(value string, loc *time.Location, end bool) (time.Time, error) {
+	if t, err := time.ParseInLocation(deployFreezeDateLayout, value, loc); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func parseDeployFreezes(freezes []v1alpha1.DeployFreeze, loc *time.Location) ([]deployFreeze, error) {
+	var parsed []deployFreeze
+	for _, f := range freezes {
+		name := f.Name
+		if name == "" {
+			name = f.Start + " ~ " + f.End
+		}
+		start, err := parseDeployFreezeTime(f.Start, loc, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid start of freeze %q", name)
+		}
+		end, err := parseDeployFreezeTime(f.End, loc, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid end of freeze %q", name)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("the end of freeze %q must be after its start", name)
+		}
+		parsed = append(parsed, deployFreeze{name: name, start: start, end: end})
+	}
+	return parsed, nil
+}
+
+// NextDeployTime returns the earliest time from now on that the deploy-window policy allows the
+// deployment, together with the reason why the deployment is postponed. If the deployment is
+// allowed now, now is returned with an empty reason.
+func NextDeployTime(spec *v1alpha1.DeployWindowPolicySpec, now time.Time) (time.Time, string, error) {
+	if spec == nil {
+		return now, "", nil
+	}
+	loc, err := spec.GetLocation()
+	if err != nil {
+		return now, "", errors.Wrapf(err, "invalid time zone %q", spec.TimeZone)
+	}
+	allowed, err := parseDeployWindows(spec.Allowed, loc)
+	if err != nil {
+		return now, "", err
+	}
+	blocked, err := parseDeployWindows(spec.Blocked, loc)
+	if err != nil {
+		return now, "", err
+	}
+	freezes, err := parseDeployFreezes(spec.Freezes, loc)
+	if err != nil {
+		return now, "", err
+	}
+
+	t, reason := now.In(loc), ""
+	postpone := func(to time.Time, why string) {
+		t = to
+		if reason == "" {
+			reason = why
+		}
+	}
+	for i := 0; i < deployWindowMaxSteps && t.Sub(now) <= deployWindowHorizon; i++ {
+		var blockedUntil time.Time
+		var blockedBy string
+		for _, f := range freezes {
+			if !t.Before(f.start) && t.Before(f.end) && f.end.After(blockedUntil) {
+				blockedUntil, blockedBy = f.end, fmt.Sprintf("in freeze %q", f.name)
+			}
+		}
+		for _, w := range blocked {
+			if end, ok := w.end(t); ok && end.After(blockedUntil) {
+				blockedUntil, blockedBy = end, fmt.Sprintf("in blocked window %q", w.name)
+			}
+		}
+		if !blockedUntil.IsZero() {
+			postpone(blockedUntil, blockedBy)
+			continue
+		}
+		if len(allowed) == 0 {
+			return t, reason, nil
+		}
+		var next time.Time
+		for _, w := range allowed {
+			if _, ok := w.end(t); ok {
+				return t, reason, nil
+			}
+			if n := w.schedule.Next(t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+				next = n
+			}
+		}
+		if next.IsZero() {
+			break
+		}
+		postpone(next, "outside of the allowed windows")
+	}
+	return now, "", errors.New("no time allowed for deployment within a year")
+}
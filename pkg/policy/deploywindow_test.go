@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+)
+
+func TestNextDeployTime(t *testing.T) {
+	nightly := v1alpha1.DeployWindow{Name: "nightly", Schedule: "0 22 * * *", Duration: "2h"}
+	businessHours := v1alpha1.DeployWindow{Name: "business-hours", Schedule: "0 9 * * 1-5", Duration: "8h"}
+	christmas := v1alpha1.DeployFreeze{Name: "christmas", Start: "2026-12-24", End: "2026-12-26"}
+	testCases := map[string]struct {
+		spec   *v1alpha1.DeployWindowPolicySpec
+		now    string
+		next   string
+		reason string
+		err    string
+	}{
+		"no-policy": {
+			now:  "2026-10-16T10:00:00Z",
+			next: "2026-10-16T10:00:00Z",
+		},
+		"inside-allowed-window": {
+			spec: &v1alpha1.DeployWindowPolicySpec{TimeZone: "Asia/Shanghai", Allowed: []v1alpha1.DeployWindow{nightly}},
+			now:  "2026-10-16T14:30:00Z",
+			next: "2026-10-16T14:30:00Z",
+		},
+		"outside-allowed-window": {
+			spec:   &v1alpha1.DeployWindowPolicySpec{TimeZone: "Asia/Shanghai", Allowed: []v1alpha1.DeployWindow{nightly}},
+			now:    "2026-10-16T10:00:00Z",
+			next:   "2026-10-16T14:00:00Z",
+			reason: "outside of the allowed windows",
+		},
+		"in-blocked-window": {
+			spec:   &v1alpha1.DeployWindowPolicySpec{Blocked: []v1alpha1.DeployWindow{businessHours}},
+			now:    "2026-10-16T10:00:00Z",
+			next:   "2026-10-16T17:00:00Z",
+			reason: `in blocked window "business-hours"`,
+		},
+		"blocked-window-not-running": {
+			spec: &v1alpha1.DeployWindowPolicySpec{Blocked: []v1alpha1.DeployWindow{businessHours}},
+			now:  "2026-10-17T10:00:00Z",
+			next: "2026-10-17T10:00:00Z",
+		},
+		"in-freeze": {
+			spec:   &v1alpha1.DeployWindowPolicySpec{Freezes: []v1alpha1.DeployFreeze{christmas}},
+			now:    "2026-12-25T12:00:00Z",
+			next:   "2026-12-27T00:00:00Z",
+			reason: `in freeze "christmas"`,
+		},
+		"freeze-with-rfc3339-time": {
+			spec:   &v1alpha1.DeployWindowPolicySpec{Freezes: []v1alpha1.DeployFreeze{{Start: "2026-10-16T08:00:00Z", End: "2026-10-16T12:00:00Z"}}},
+			now:    "2026-10-16T10:00:00Z",
+			next:   "2026-10-16T12:00:00Z",
+			reason: `in freeze "2026-10-16T08:00:00Z ~ 2026-10-16T12:00:00Z"`,
+		},
+		"allowed-window-in-freeze": {
+			spec:   &v1alpha1.DeployWindowPolicySpec{Allowed: []v1alpha1.DeployWindow{nightly}, Freezes: []v1alpha1.DeployFreeze{christmas}},
+			now:    "2026-12-24T10:00:00Z",
+			next:   "2026-12-27T22:00:00Z",
+			reason: `in freeze "christmas"`,
+		},
+		"blocked-window-overlaps-allowed-window": {
+			spec: &v1alpha1.DeployWindowPolicySpec{
+				Allowed: []v1alpha1.DeployWindow{{Schedule: "0 8 * * *", Duration: "4h"}},
+				Blocked: []v1alpha1.DeployWindow{businessHours},
+			},
+			now:    "2026-10-16T06:00:00Z",
+			next:   "2026-10-16T08:00:00Z",
+			reason: "outside of the allowed windows",
+		},
+		"skip-blocked-part-of-allowed-window": {
+			spec: &v1alpha1.DeployWindowPolicySpec{
+				Allowed: []v1alpha1.DeployWindow{{Schedule: "0 8 * * *", Duration: "4h"}},
+				Blocked: []v1alpha1.DeployWindow{{Name: "standup", Schedule: "0 8 * * *", Duration: "1h"}},
+			},
+			now:    "2026-10-16T08:30:00Z",
+			next:   "2026-10-16T09:00:00Z",
+			reason: `in blocked window "standup"`,
+		},
+		"invalid-time-zone": {
+			spec: &v1alpha1.DeployWindowPolicySpec{TimeZone: "Mars/Olympus"},
+			now:  "2026-10-16T10:00:00Z",
+			err:  `invalid time zone "Mars/Olympus"`,
+		},
+		"invalid-schedule": {
+			spec: &v1alpha1.DeployWindowPolicySpec{Allowed: []v1alpha1.DeployWindow{{Name: "bad", Schedule: "every day", Duration: "1h"}}},
+			now:  "2026-10-16T10:00:00Z",
+			err:  `invalid schedule of window "bad"`,
+		},
+		"invalid-duration": {
+			spec: &v1alpha1.DeployWindowPolicySpec{Blocked: []v1alpha1.DeployWindow{{Schedule: "0 9 * * *", Duration: "-1h"}}},
+			now:  "2026-10-16T10:00:00Z",
+			err:  `the duration of window "0 9 * * *" must be positive`,
+		},
+		"invalid-freeze": {
+			spec: &v1alpha1.DeployWindowPolicySpec{Freezes: []v1alpha1.DeployFreeze{{Name: "bad", Start: "2026-12-26", End: "2026-12-24"}}},
+			now:  "2026-10-16T10:00:00Z",
+			err:  `the end of freeze "bad" must be after its start`,
+		},
+		"never-allowed": {
+			spec: &v1alpha1.DeployWindowPolicySpec{Allowed: []v1alpha1.DeployWindow{{Schedule: "0 0 30 2 *", Duration: "1h"}}},
+			now:  "2026-10-16T10:00:00Z",
+			err:  "no time allowed for deployment within a year",
+		},
+	}
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			now, err := time.Parse(time.RFC3339, tt.now)
+			r.NoError(err)
+			next, reason, err := NextDeployTime(tt.spec, now)
+			if tt.err != "" {
+				r.ErrorContains(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.next, next.UTC().Format(time.RFC3339))
+			r.Equal(tt.reason, reason)
+		})
+	}
+}
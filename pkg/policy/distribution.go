@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubectl/pkg/util/slice"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	pkgutils "github.com/oam-dev/kubevela/pkg/utils"
+)
+
+// GetReplicaDistributionPatches splits the replicas of the components selected by the replication
+// policies with distribution across the clusters of the placements. The result is the override
+// patches of each cluster, which rewrite the replicas in the component properties. It returns nil
+// if no replication policy has distribution.
+func GetReplicaDistributionPatches(ctx context.Context, cli client.Client, policies []v1beta1.AppPolicy, components []common.ApplicationComponent, placements []v1alpha1.PlacementDecision) (map[string][]v1alpha1.EnvComponentPatch, error) {
+	var clusters []string
+	for _, pl := range placements {
+		if !slice.ContainsString(clusters, pl.Cluster, nil) {
+			clusters = append(clusters, pl.Cluster)
+		}
+	}
+	var patches map[string][]v1alpha1.EnvComponentPatch
+	for _, policy := range policies {
+		if policy.Type != v1alpha1.ReplicationPolicyType || policy.Properties == nil {
+			continue
+		}
+		spec := &v1alpha1.ReplicationPolicySpec{}
+		if err := pkgutils.StrictUnmarshal(policy.Properties.Raw, spec); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse replicate policy %s", policy.Name)
+		}
+		if spec.Distribution == nil || len(clusters) == 0 {
+			continue
+		}
+		replicas, err := distributeReplicas(ctx, cli, spec.Distribution, clusters)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to distribute replicas in replicate policy %s", policy.Name)
+		}
+		field := spec.Distribution.ReplicasField
+		if field == "" {
+			field = v1alpha1.DefaultReplicasField
+		}
+		if patches == nil {
+			patches = map[string][]v1alpha1.EnvComponentPatch{}
+		}
+		for _, comp := range components {
+			if len(spec.Selector) > 0 && !slice.ContainsString(spec.Selector, comp.Name, nil) {
+				continue
+			}
+			for i, cluster := range clusters {
+				props, err := json.Marshal(fieldPathToMap(field, replicas[i]))
+				if err != nil {
+					return nil, err
+				}
+				patches[cluster] = append(patches[cluster], v1alpha1.EnvComponentPatch{
+					// match the component name exactly, the patch name is used as a pattern
+					Name:       "^" + regexp.QuoteMeta(comp.Name) + "$",
+					Properties: &runtime.RawExtension{Raw: props},
+				})
+			}
+		}
+	}
+	return patches, nil
+}
+
+func distributeReplicas(ctx context.Context, cli client.Client, distribution *v1alpha1.ReplicaDistribution, clusters []string) ([]int, error) {
+	if distribution.Replicas < 0 {
+		return nil, errors.Errorf("replicas cannot be negative")
+	}
+	if distribution.ByCapacity && len(distribution.Weights) > 0 {
+		return nil, errors.Errorf("byCapacity and weights cannot be used together")
+	}
+	weights := make([]int64, len(clusters))
+	switch {
+	case distribution.ByCapacity:
+		for i, cluster := range clusters {
+			vc, err := multicluster.GetVirtualCluster(ctx, cli, cluster)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get cluster %s", cluster)
+			}
+			if vc.Metrics == nil || vc.Metrics.ClusterInfo == nil {
+				return nil, errors.Errorf("the capacity of cluster %s is unknown, the cluster metrics should be enabled", cluster)
+			}
+			weights[i] = vc.Metrics.ClusterInfo.CPUAllocatable.MilliValue()
+		}
+	case len(distribution.Weights) > 0:
+		// the clusters are grouped by the first matched weight, the replicas of each group are split evenly
+		groups := make([][]int, len(distribution.Weights))
+		for i, cluster := range clusters {
+			for j, weight := range distribution.Weights {
+				matched, err := matchClusterWeight(ctx, cli, weight, cluster)
+				if err != nil {
+					return nil, err
+				}
+				if matched {
+					groups[j] = append(groups[j], i)
+					break
+				}
+			}
+		}
+		groupWeights := make([]int64, len(groups))
+		for j, group := range groups {
+			if len(group) > 0 {
+				groupWeights[j] = int64(distribution.Weights[j].Weight)
+			}
+		}
+		groupReplicas := splitByWeights(distribution.Replicas, groupWeights)
+		replicas := make([]int, len(clusters))
+		for j, group := range groups {
+			evenWeights := make([]int64, len(group))
+			for k := range evenWeights {
+				evenWeights[k] = 1
+			}
+			for k, n := range splitByWeights(groupReplicas[j], evenWeights) {
+				replicas[group[k]] = n
+			}
+		}
+		return replicas, nil
+	default:
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	return splitByWeights(distribution.Replicas, weights), nil
+}
+
+func matchClusterWeight(ctx context.Context, cli client.Client, weight v1alpha1.ClusterWeight, cluster string) (bool, error) {
+	if weight.Weight < 0 {
+		return false, errors.Errorf("weight cannot be negative")
+	}
+	if slice.ContainsString(weight.Clusters, cluster, nil) {
+		return true, nil
+	}
+	if len(weight.ClusterLabelSelector) == 0 {
+		return false, nil
+	}
+	vc, err := multicluster.GetVirtualCluster(ctx, cli, cluster)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get cluster %s", cluster)
+	}
+	return labels.SelectorFromSet(weight.ClusterLabelSelector).Matches(labels.Set(vc.Labels)), nil
+}
+
+// splitByWeights splits the total by the weights with the largest remainder method, the
+// remainders are given to the former ones if equal
+func splitByWeights(total int, weights []int64) []int {
+	result := make([]int, len(weights))
+	var sum int64
+	for _, w := range weights {
+		sum += w
+	}
+	if sum == 0 || total == 0 {
+		return result
+	}
+	remainders := make([]int64, len(weights))
+	assigned := 0
+	for i, w := range weights {
+		result[i] = int(int64(total) * w / sum)
+		remainders[i] = int64(total) * w % sum
+		assigned += result[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for i := 0; assigned < total; i++ {
+		if weights[order[i%len(order)]] == 0 {
+			continue
+		}
+		result[order[i%len(order)]]++
+		assigned++
+	}
+	return result
+}
+
+func fieldPathToMap(path string, val interface{}) map[string]interface{} {
+	fields := strings.Split(path, ".")
+	m := map[string]interface{}{fields[len(fields)-1]: val}
+	for i := len(fields) - 2; i >= 0; i-- {
+		m = map[string]interface{}{fields[i]: m}
+	}
+	return m
+}
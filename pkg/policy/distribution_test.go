@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	clustercommon "github.com/oam-dev/cluster-gateway/pkg/common"
+
+	apicommon "github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	"github.com/oam-dev/kubevela/pkg/policy/envbinding"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestSplitByWeights(t *testing.T) {
+	testCases := map[string]struct {
+		Total   int
+		Weights []int64
+		Result  []int
+	}{
+		"60-40":          {Total: 10, Weights: []int64{60, 40}, Result: []int{6, 4}},
+		"even":           {Total: 10, Weights: []int64{1, 1, 1}, Result: []int{4, 3, 3}},
+		"largest-remain": {Total: 5, Weights: []int64{1, 2, 7}, Result: []int{1, 1, 3}},
+		"zero-weight":    {Total: 3, Weights: []int64{0, 1, 1}, Result: []int{0, 2, 1}},
+		"all-zero":       {Total: 3, Weights: []int64{0, 0}, Result: []int{0, 0}},
+		"zero-total":     {Total: 0, Weights: []int64{1, 1}, Result: []int{0, 0}},
+	}
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.Result, splitByWeights(tt.Total, tt.Weights))
+		})
+	}
+}
+
+func TestGetReplicaDistributionPatches(t *testing.T) {
+	multicluster.ClusterGatewaySecretNamespace = types.DefaultKubeVelaNS
+	builder := fake.NewClientBuilder().WithScheme(common.Scheme)
+	for name, region := range map[string]string{"hz-1": "hangzhou", "hz-2": "hangzhou", "bj-1": "beijing"} {
+		builder = builder.WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: multicluster.ClusterGatewaySecretNamespace,
+				Labels: map[string]string{
+					clustercommon.LabelKeyClusterEndpointType:   string(clusterv1alpha1.ClusterEndpointTypeConst),
+					clustercommon.LabelKeyClusterCredentialType: string(clusterv1alpha1.CredentialTypeX509Certificate),
+					"region": region,
+				},
+			},
+		})
+	}
+	cli := builder.Build()
+	components := []apicommon.ApplicationComponent{
+		{Name: "web", Type: "webservice", Properties: &runtime.RawExtension{Raw: []byte(`{"image":"nginx","replicas":1}`)}},
+		{Name: "web-worker", Type: "worker", Properties: &runtime.RawExtension{Raw: []byte(`{"image":"busybox"}`)}},
+	}
+	placements := []v1alpha1.PlacementDecision{{Cluster: "hz-1"}, {Cluster: "hz-2"}, {Cluster: "bj-1"}, {Cluster: "bj-1", Namespace: "test"}}
+	testCases := map[string]struct {
+		Properties string
+		Replicas   map[string]string
+		Error      string
+	}{
+		"no-distribution": {
+			Properties: `{"keys":["a","b"],"selector":["web"]}`,
+		},
+		"even": {
+			Properties: `{"selector":["web"],"distribution":{"replicas":10}}`,
+			Replicas:   map[string]string{"hz-1": `{"image":"nginx","replicas":4}`, "hz-2": `{"image":"nginx","replicas":3}`, "bj-1": `{"image":"nginx","replicas":3}`},
+		},
+		"weights-by-region": {
+			Properties: `{"selector":["web"],"distribution":{"replicas":10,"weights":[{"clusterLabelSelector":{"region":"hangzhou"},"weight":60},{"clusters":["bj-1"],"weight":40}]}}`,
+			Replicas:   map[string]string{"hz-1": `{"image":"nginx","replicas":3}`, "hz-2": `{"image":"nginx","replicas":3}`, "bj-1": `{"image":"nginx","replicas":4}`},
+		},
+		"unmatched-cluster": {
+			Properties: `{"selector":["web"],"distribution":{"replicas":5,"weights":[{"clusters":["hz-1"],"weight":1},{"clusters":["not-used"],"weight":9}]}}`,
+			Replicas:   map[string]string{"hz-1": `{"image":"nginx","replicas":5}`, "hz-2": `{"image":"nginx","replicas":0}`, "bj-1": `{"image":"nginx","replicas":0}`},
+		},
+		"nested-field": {
+			Properties: `{"selector":["web"],"distribution":{"replicas":2,"weights":[{"clusters":["bj-1"],"weight":1}],"replicasField":"spec.replicas"}}`,
+			Replicas:   map[string]string{"hz-1": `{"image":"nginx","replicas":1,"spec":{"replicas":0}}`, "hz-2": `{"image":"nginx","replicas":1,"spec":{"replicas":0}}`, "bj-1": `{"image":"nginx","replicas":1,"spec":{"replicas":2}}`},
+		},
+		"capacity-and-weights": {
+			Properties: `{"selector":["web"],"distribution":{"replicas":2,"byCapacity":true,"weights":[{"clusters":["bj-1"],"weight":1}]}}`,
+			Error:      "cannot be used together",
+		},
+		"capacity-unknown": {
+			Properties: `{"selector":["web"],"distribution":{"replicas":2,"byCapacity":true}}`,
+			Error:      "capacity of cluster hz-1 is unknown",
+		},
+	}
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			policies := []v1beta1.AppPolicy{{Name: "replication", Type: v1alpha1.ReplicationPolicyType, Properties: &runtime.RawExtension{Raw: []byte(tt.Properties)}}}
+			patches, err := GetReplicaDistributionPatches(context.Background(), cli, policies, components, placements)
+			if tt.Error != "" {
+				r.Error(err)
+				r.Contains(err.Error(), tt.Error)
+				return
+			}
+			r.NoError(err)
+			if tt.Replicas == nil {
+				r.Nil(patches)
+				return
+			}
+			r.Equal(len(tt.Replicas), len(patches))
+			for cluster, props := range tt.Replicas {
+				comps, err := envbinding.PatchComponents(components, patches[cluster], nil)
+				r.NoError(err)
+				r.Equal(2, len(comps))
+				for _, comp := range comps {
+					if comp.Name == "web" {
+						r.JSONEq(props, string(comp.Properties.Raw), cluster)
+					} else {
+						r.JSONEq(`{"image":"busybox"}`, string(comp.Properties.Raw))
+					}
+				}
+			}
+		})
+	}
+}
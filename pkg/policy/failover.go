@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+)
+
+// MaxPlacementChangesRecorded is the max number of placement changes kept in the failover status
+const MaxPlacementChangesRecorded = 10
+
+// IsClusterHealthProbed checks if the cluster health is probed by the cluster metrics manager,
+// which is started by the controller flag --enable-cluster-metrics
+var IsClusterHealthProbed = multicluster.IsClusterMetricsEnabled
+
+// IsFailoverEnabled checks if the failover of the topology policy takes effect. Failover is only
+// supported with clusterLabelSelector, it is ignored in the topology policies with clusters.
+func IsFailoverEnabled(topologySpec *v1alpha1.TopologyPolicySpec) bool {
+	return topologySpec.Failover != nil && topologySpec.Clusters == nil && GetClusterLabelSelectorInTopology(topologySpec) != nil
+}
+
+// IsClusterUnhealthy checks if the cluster fails the probes of the cluster metrics manager for
+// no less than threshold times in a row
+var IsClusterUnhealthy = func(cluster string, threshold int) bool {
+	if cluster == multicluster.ClusterLocalName {
+		return false
+	}
+	return multicluster.GetClusterProbeFailures(cluster) >= threshold
+}
+
+// FindPlacementFailoverStatus finds the failover status of the topology policy
+func FindPlacementFailoverStatus(failovers []common.PlacementFailoverStatus, policyName string) *common.PlacementFailoverStatus {
+	for i := range failovers {
+		if failovers[i].Policy == policyName {
+			return &failovers[i]
+		}
+	}
+	return nil
+}
+
+// ResolveFailoverPlacement decides the clusters to place the components for the topology policy
+// with failover. The candidates are the clusters matching the selector sorted by name, the first
+// replicas of them are the original clusters and the others are spare. Each original cluster
+// holds a slot, if the cluster in the slot becomes unhealthy, it is replaced by the first healthy
+// cluster not in use. With failback, the slot goes back to the original cluster once it recovers.
+// The last status keeps the slots stable across the resolutions. It returns the new status and
+// the placement changes made in this resolution.
+func ResolveFailoverPlacement(policyName string, failover *v1alpha1.TopologyFailover, candidates []string, last *common.PlacementFailoverStatus) (*common.PlacementFailoverStatus, []common.PlacementChange) {
+	threshold := failover.GetFailureThreshold()
+	n := len(candidates)
+	if failover.Replicas != nil && *failover.Replicas >= 0 && *failover.Replicas < n {
+		n = *failover.Replicas
+	}
+	isCandidate := map[string]bool{}
+	unhealthy := map[string]bool{}
+	status := &common.PlacementFailoverStatus{Policy: policyName}
+	for _, cluster := range candidates {
+		isCandidate[cluster] = true
+		if IsClusterUnhealthy(cluster, threshold) {
+			unhealthy[cluster] = true
+			status.UnhealthyClusters = append(status.UnhealthyClusters, cluster)
+		}
+	}
+	if last != nil {
+		status.Changes = append(status.Changes, last.Changes...)
+	}
+
+	slots := make([]string, n)
+	used := map[string]bool{}
+	for i := range slots {
+		if last != nil && i < len(last.Clusters) && isCandidate[last.Clusters[i]] && !used[last.Clusters[i]] {
+			slots[i] = last.Clusters[i]
+		} else if !used[candidates[i]] {
+			slots[i] = candidates[i]
+		}
+		if slots[i] != "" {
+			used[slots[i]] = true
+		}
+	}
+
+	var changes []common.PlacementChange
+	move := func(i int, to string, reason string) {
+		if slots[i] != "" {
+			changes = append(changes, common.PlacementChange{From: slots[i], To: to, Reason: reason, Time: metav1.Now()})
+			delete(used, slots[i])
+		}
+		slots[i] = to
+		used[to] = true
+	}
+	if failover.Failback {
+		for i, original := range candidates[:n] {
+			if slots[i] != original && slots[i] != "" && !unhealthy[original] && !used[original] {
+				move(i, original, fmt.Sprintf("cluster %s recovers", original))
+			}
+		}
+	}
+	for i := range slots {
+		if slots[i] != "" && !unhealthy[slots[i]] {
+			continue
+		}
+		for _, cluster := range candidates {
+			if !used[cluster] && !unhealthy[cluster] {
+				move(i, cluster, fmt.Sprintf("cluster %s is unhealthy", slots[i]))
+				break
+			}
+		}
+	}
+
+	for _, cluster := range slots {
+		if cluster != "" {
+			status.Clusters = append(status.Clusters, cluster)
+		}
+	}
+	status.Changes = append(status.Changes, changes...)
+	if len(status.Changes) > MaxPlacementChangesRecorded {
+		status.Changes = status.Changes[len(status.Changes)-MaxPlacementChangesRecorded:]
+	}
+	return status, changes
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	clustercommon "github.com/oam-dev/cluster-gateway/pkg/common"
+
+	apicommon "github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func mockUnhealthyClusters(t *testing.T, clusters ...string) {
+	unhealthy := map[string]bool{}
+	for _, cluster := range clusters {
+		unhealthy[cluster] = true
+	}
+	original := IsClusterUnhealthy
+	IsClusterUnhealthy = func(cluster string, _ int) bool { return unhealthy[cluster] }
+	t.Cleanup(func() { IsClusterUnhealthy = original })
+}
+
+func TestResolveFailoverPlacement(t *testing.T) {
+	candidates := []string{"c1", "c2", "c3", "c4"}
+	testCases := map[string]struct {
+		Failover  *v1alpha1.TopologyFailover
+		Last      *apicommon.PlacementFailoverStatus
+		Unhealthy []string
+		Clusters  []string
+		Changes   []string
+	}{
+		"all-healthy": {
+			Failover: &v1alpha1.TopologyFailover{Replicas: ptr.To(2)},
+			Clusters: []string{"c1", "c2"},
+		},
+		"all-clusters-without-replicas": {
+			Failover:  &v1alpha1.TopologyFailover{},
+			Unhealthy: []string{"c2"},
+			Clusters:  []string{"c1", "c2", "c3", "c4"},
+		},
+		"failover-to-spare": {
+			Failover:  &v1alpha1.TopologyFailover{Replicas: ptr.To(2)},
+			Last:      &apicommon.PlacementFailoverStatus{Clusters: []string{"c1", "c2"}},
+			Unhealthy: []string{"c1"},
+			Clusters:  []string{"c3", "c2"},
+			Changes:   []string{"c1->c3"},
+		},
+		"failover-skips-unhealthy-spare": {
+			Failover:  &v1alpha1.TopologyFailover{Replicas: ptr.To(2)},
+			Last:      &apicommon.PlacementFailoverStatus{Clusters: []string{"c1", "c2"}},
+			Unhealthy: []string{"c2", "c3"},
+			Clusters:  []string{"c1", "c4"},
+			Changes:   []string{"c2->c4"},
+		},
+		"no-spare-available": {
+			Failover:  &v1alpha1.TopologyFailover{Replicas: ptr.To(3)},
+			Last:      &apicommon.PlacementFailoverStatus{Clusters: []string{"c1", "c2", "c4"}},
+			Unhealthy: []string{"c1", "c3"},
+			Clusters:  []string{"c1", "c2", "c4"},
+		},
+		"stay-on-spare-without-failback": {
+			Failover: &v1alpha1.TopologyFailover{Replicas: ptr.To(2)},
+			Last:     &apicommon.PlacementFailoverStatus{Clusters: []string{"c3", "c2"}},
+			Clusters: []string{"c3", "c2"},
+		},
+		"failback": {
+			Failover: &v1alpha1.TopologyFailover{Replicas: ptr.To(2), Failback: true},
+			Last:     &apicommon.PlacementFailoverStatus{Clusters: []string{"c3", "c2"}},
+			Clusters: []string{"c1", "c2"},
+			Changes:  []string{"c3->c1"},
+		},
+		"removed-cluster-replaced": {
+			Failover: &v1alpha1.TopologyFailover{Replicas: ptr.To(2)},
+			Last:     &apicommon.PlacementFailoverStatus{Clusters: []string{"c1", "c0"}},
+			Clusters: []string{"c1", "c2"},
+		},
+	}
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			mockUnhealthyClusters(t, tt.Unhealthy...)
+			status, changes := ResolveFailoverPlacement("topology", tt.Failover, candidates, tt.Last)
+			r.Equal("topology", status.Policy)
+			r.Equal(tt.Clusters, status.Clusters)
+			var moves []string
+			for _, change := range changes {
+				moves = append(moves, change.From+"->"+change.To)
+			}
+			r.Equal(tt.Changes, moves)
+			r.Equal(len(tt.Changes), len(status.Changes))
+		})
+	}
+}
+
+func TestResolveFailoverPlacementKeepsRecentChanges(t *testing.T) {
+	r := require.New(t)
+	mockUnhealthyClusters(t, "c1")
+	last := &apicommon.PlacementFailoverStatus{Clusters: []string{"c1"}}
+	for i := 0; i < MaxPlacementChangesRecorded; i++ {
+		last.Changes = append(last.Changes, apicommon.PlacementChange{From: "a", To: "b"})
+	}
+	status, changes := ResolveFailoverPlacement("topology", &v1alpha1.TopologyFailover{Replicas: ptr.To(1)}, []string{"c1", "c2"}, last)
+	r.Equal(1, len(changes))
+	r.Equal(MaxPlacementChangesRecorded, len(status.Changes))
+	r.Equal("c1", status.Changes[MaxPlacementChangesRecorded-1].From)
+	r.Equal([]string{"c1"}, status.UnhealthyClusters)
+}
+
+func TestGetPlacementsFromTopologyPoliciesWithFailover(t *testing.T) {
+	r := require.New(t)
+	multicluster.ClusterGatewaySecretNamespace = types.DefaultKubeVelaNS
+	builder := fake.NewClientBuilder().WithScheme(common.Scheme)
+	for _, name := range []string{"hz-3", "hz-1", "hz-2", "bj-1"} {
+		region := "hangzhou"
+		if name == "bj-1" {
+			region = "beijing"
+		}
+		builder = builder.WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: multicluster.ClusterGatewaySecretNamespace,
+				Labels: map[string]string{
+					clustercommon.LabelKeyClusterEndpointType:   string(clusterv1alpha1.ClusterEndpointTypeConst),
+					clustercommon.LabelKeyClusterCredentialType: string(clusterv1alpha1.CredentialTypeX509Certificate),
+					"region": region,
+				},
+			},
+		})
+	}
+	cli := builder.Build()
+	policies := []v1beta1.AppPolicy{{
+		Name:       "topology-hangzhou",
+		Type:       v1alpha1.TopologyPolicyType,
+		Properties: &runtime.RawExtension{Raw: []byte(`{"clusterLabelSelector":{"region":"hangzhou"},"failover":{"replicas":2}}`)},
+	}}
+	ctx := context.Background()
+
+	mockUnhealthyClusters(t, "hz-1")
+	pds, err := GetPlacementsFromTopologyPoliciesWithFailover(ctx, cli, "default", policies, nil, false)
+	r.NoError(err)
+	r.Equal([]v1alpha1.PlacementDecision{{Cluster: "hz-3"}, {Cluster: "hz-2"}}, pds)
+
+	failovers := []apicommon.PlacementFailoverStatus{{Policy: "topology-hangzhou", Clusters: []string{"hz-2", "hz-removed", "hz-1"}}}
+	pds, err = GetPlacementsFromTopologyPoliciesWithFailover(ctx, cli, "default", policies, failovers, false)
+	r.NoError(err)
+	r.Equal([]v1alpha1.PlacementDecision{{Cluster: "hz-2"}, {Cluster: "hz-1"}}, pds)
+
+	policies[0].Properties = &runtime.RawExtension{Raw: []byte(`{"clusters":["hz-1"],"failover":{}}`)}
+	_, err = GetPlacementsFromTopologyPoliciesWithFailover(ctx, cli, "default", policies, nil, false)
+	r.Error(err)
+}
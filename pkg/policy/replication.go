@@ -57,6 +57,10 @@ func ReplicateComponents(policies []v1beta1.AppPolicy, components []common.Appli
 			if err := pkgutils.StrictUnmarshal(policy.Properties.Raw, replicateSpec); err != nil {
 				return nil, errors.Wrapf(err, "failed to parse replicate policy %s", policy.Name)
 			}
+			if len(replicateSpec.Keys) == 0 && replicateSpec.Distribution != nil {
+				// only distribute the replicas across clusters, see GetReplicaDistributionPatches
+				continue
+			}
 			compToRep, err := selectReplicateComponents(components, replicateSpec.Selector)
 			if err != nil {
 				return nil, errors.Wrapf(err, "failed to apply replicate policy %s", policy.Name)
@@ -19,12 +19,14 @@ package policy
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	pkgmulticluster "github.com/kubevela/pkg/multicluster"
 	"github.com/pkg/errors"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/pkg/features"
@@ -45,6 +47,12 @@ func GetClusterLabelSelectorInTopology(topology *v1alpha1.TopologyPolicySpec) ma
 
 // GetPlacementsFromTopologyPolicies get placements from topology policies with provided client
 func GetPlacementsFromTopologyPolicies(ctx context.Context, cli client.Client, appNs string, policies []v1beta1.AppPolicy, allowCrossNamespace bool) ([]v1alpha1.PlacementDecision, error) {
+	return GetPlacementsFromTopologyPoliciesWithFailover(ctx, cli, appNs, policies, nil, allowCrossNamespace)
+}
+
+// GetPlacementsFromTopologyPoliciesWithFailover get placements from topology policies with provided client,
+// the clusters of the topology policies with failover are taken from the recorded failover status
+func GetPlacementsFromTopologyPoliciesWithFailover(ctx context.Context, cli client.Client, appNs string, policies []v1beta1.AppPolicy, failovers []common.PlacementFailoverStatus, allowCrossNamespace bool) ([]v1alpha1.PlacementDecision, error) {
 	placements := make([]v1alpha1.PlacementDecision, 0)
 	placementMap := map[string]struct{}{}
 	addCluster := func(cluster string, ns string, validateCluster bool) error {
@@ -76,6 +84,18 @@ func GetPlacementsFromTopologyPolicies(ctx context.Context, cli client.Client, a
 				return nil, errors.Wrapf(err, "failed to parse topology policy %s", policy.Name)
 			}
 			clusterLabelSelector := GetClusterLabelSelectorInTopology(topologySpec)
+			if IsFailoverEnabled(topologySpec) {
+				clusters, err := getFailoverClusters(ctx, cli, policy.Name, topologySpec, failovers)
+				if err != nil {
+					return nil, err
+				}
+				for _, cluster := range clusters {
+					if err = addCluster(cluster, topologySpec.Namespace, false); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
 			switch {
 			case topologySpec.Clusters != nil:
 				for _, cluster := range topologySpec.Clusters {
@@ -108,3 +128,49 @@ func GetPlacementsFromTopologyPolicies(ctx context.Context, cli client.Client, a
 	}
 	return placements, nil
 }
+
+// ListFailoverCandidates lists the names of the clusters matching the selector of the topology
+// policy with failover, sorted by name
+func ListFailoverCandidates(ctx context.Context, cli client.Client, policyName string, topologySpec *v1alpha1.TopologyPolicySpec) ([]string, error) {
+	if !IsFailoverEnabled(topologySpec) {
+		return nil, errors.Errorf("failover in topology %s requires clusterLabelSelector instead of clusters", policyName)
+	}
+	clusterLabelSelector := GetClusterLabelSelectorInTopology(topologySpec)
+	clusterList, err := multicluster.NewClusterClient(cli).List(ctx, client.MatchingLabels(clusterLabelSelector))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find clusters in topology %s", policyName)
+	}
+	var candidates []string
+	for _, cluster := range clusterList.Items {
+		candidates = append(candidates, cluster.Name)
+	}
+	sort.Strings(candidates)
+	return candidates, nil
+}
+
+func getFailoverClusters(ctx context.Context, cli client.Client, policyName string, topologySpec *v1alpha1.TopologyPolicySpec, failovers []common.PlacementFailoverStatus) ([]string, error) {
+	candidates, err := ListFailoverCandidates(ctx, cli, policyName, topologySpec)
+	if err != nil {
+		return nil, err
+	}
+	var clusters []string
+	if last := FindPlacementFailoverStatus(failovers, policyName); last != nil {
+		isCandidate := map[string]bool{}
+		for _, cluster := range candidates {
+			isCandidate[cluster] = true
+		}
+		for _, cluster := range last.Clusters {
+			if isCandidate[cluster] {
+				clusters = append(clusters, cluster)
+			}
+		}
+	}
+	if len(clusters) == 0 {
+		status, _ := ResolveFailoverPlacement(policyName, topologySpec.Failover, candidates, nil)
+		clusters = status.Clusters
+	}
+	if len(clusters) == 0 && !topologySpec.AllowEmpty {
+		return nil, errors.New("failed to find any cluster matches given labels")
+	}
+	return clusters, nil
+}
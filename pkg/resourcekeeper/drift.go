@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcekeeper
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// driftIgnoredAnnotations are the annotations maintained by the applicator, which
+// are not part of the desired state
+var driftIgnoredAnnotations = map[string]bool{
+	oam.AnnotationLastAppliedConfig:    true,
+	oam.AnnotationLastAppliedTime:      true,
+	corev1.LastAppliedConfigAnnotation: true,
+}
+
+// detectDrift compares the desired manifest with the live object and returns the
+// paths of the drifted fields. Only the fields set in the desired manifest are
+// compared, so fields defaulted by the server or added by other controllers are
+// not treated as drift.
+func detectDrift(desired, live *unstructured.Unstructured) []string {
+	var fields []string
+	for key, val := range desired.Object {
+		switch key {
+		case "apiVersion", "kind", "status":
+		case "metadata":
+			metadata, _ := val.(map[string]interface{})
+			liveMetadata, _ := live.Object["metadata"].(map[string]interface{})
+			for _, k := range []string{"labels", "annotations"} {
+				desiredMap, _ := metadata[k].(map[string]interface{})
+				liveMap, _ := liveMetadata[k].(map[string]interface{})
+				for _k, _v := range desiredMap {
+					if k == "annotations" && driftIgnoredAnnotations[_k] {
+						continue
+					}
+					fields = compareDriftValue(fmt.Sprintf("metadata.%s[%s]", k, _k), _v, liveMap[_k], fields)
+				}
+			}
+		default:
+			fields = compareDriftValue(key, val, live.Object[key], fields)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func compareDriftValue(path string, desired, live interface{}, fields []string) []string {
+	switch d := desired.(type) {
+	case nil:
+		return fields
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return append(fields, path)
+		}
+		for k, v := range d {
+			fields = compareDriftValue(path+"."+k, v, l[k], fields)
+		}
+		return fields
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return append(fields, path)
+		}
+		for i := range d {
+			fields = compareDriftValue(fmt.Sprintf("%s[%d]", path, i), d[i], l[i], fields)
+		}
+		return fields
+	default:
+		if df, ok := toFloat64(desired); ok {
+			if lf, ok := toFloat64(live); ok && df == lf {
+				return fields
+			}
+			return append(fields, path)
+		}
+		if !reflect.DeepEqual(desired, live) {
+			return append(fields, path)
+		}
+		return fields
+	}
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
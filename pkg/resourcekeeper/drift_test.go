@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcekeeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+func TestDetectDrift(t *testing.T) {
+	desired := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":        "web",
+			"labels":      map[string]interface{}{"app": "web"},
+			"annotations": map[string]interface{}{oam.AnnotationLastAppliedTime: "now"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"name": "web", "image": "nginx"}},
+				},
+			},
+		},
+	}}
+	testCases := map[string]struct {
+		live   map[string]interface{}
+		fields []string
+	}{
+		"no drift with server defaults": {
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":            "web",
+					"resourceVersion": "1",
+					"labels":          map[string]interface{}{"app": "web", "extra": "label"},
+				},
+				"spec": map[string]interface{}{
+					"replicas":             float64(2),
+					"revisionHistoryLimit": int64(10),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{map[string]interface{}{"name": "web", "image": "nginx", "imagePullPolicy": "Always"}},
+						},
+					},
+				},
+				"status": map[string]interface{}{"replicas": int64(1)},
+			},
+		},
+		"drifted fields": {
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "web"},
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{map[string]interface{}{"name": "web", "image": "busybox"}},
+						},
+					},
+				},
+			},
+			fields: []string{"metadata.labels[app]", "spec.replicas", "spec.template.spec.containers[0].image"},
+		},
+		"drifted list length": {
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "web", "labels": map[string]interface{}{"app": "web"}},
+				"spec": map[string]interface{}{
+					"replicas": int64(2),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{"containers": []interface{}{}},
+					},
+				},
+			},
+			fields: []string{"spec.template.spec.containers"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.fields, detectDrift(desired, &unstructured.Unstructured{Object: tc.live}))
+		})
+	}
+}
+
+func TestDriftDetectionPolicyFindMode(t *testing.T) {
+	r := require.New(t)
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	deploy := &unstructured.Unstructured{}
+	deploy.SetAPIVersion("apps/v1")
+	deploy.SetKind("Deployment")
+
+	var spec *v1alpha1.DriftDetectionPolicySpec
+	r.Equal(v1alpha1.DriftDetectionModeAutoCorrect, spec.FindMode(cm))
+	spec = &v1alpha1.DriftDetectionPolicySpec{
+		Mode: v1alpha1.DriftDetectionModeReportOnly,
+		Rules: []v1alpha1.DriftDetectionPolicyRule{{
+			Selector: v1alpha1.ResourcePolicyRuleSelector{ResourceTypes: []string{"ConfigMap"}},
+			Mode:     v1alpha1.DriftDetectionModeAutoCorrect,
+		}},
+	}
+	r.Equal(v1alpha1.DriftDetectionModeAutoCorrect, spec.FindMode(cm))
+	r.Equal(v1alpha1.DriftDetectionModeReportOnly, spec.FindMode(deploy))
+}
+
+func TestKeepProgressiveRolloutReplicas(t *testing.T) {
+	manifest := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"spec":       map[string]interface{}{"replicas": int64(4)},
+		}}
+	}
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"spec":       map[string]interface{}{"replicas": int64(3)},
+	}}
+
+	desired := manifest()
+	keepProgressiveRolloutReplicas(desired, live)
+	replicas, _, _ := unstructured.NestedInt64(desired.Object, "spec", "replicas")
+	require.Equal(t, int64(4), replicas)
+
+	live.SetAnnotations(map[string]string{oam.AnnotationProgressiveRollout: "web-canary"})
+	desired = manifest()
+	keepProgressiveRolloutReplicas(desired, live)
+	replicas, _, _ = unstructured.NestedInt64(desired.Object, "spec", "replicas")
+	require.Equal(t, int64(3), replicas)
+
+	keepProgressiveRolloutReplicas(desired, nil)
+	replicas, _, _ = unstructured.NestedInt64(desired.Object, "spec", "replicas")
+	require.Equal(t, int64(3), replicas)
+}
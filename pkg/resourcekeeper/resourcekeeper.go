@@ -49,6 +49,8 @@ type ResourceKeeper interface {
 
 	// GetAppliedResources returns the current applied resources from the ResourceTracker.
 	GetAppliedResources() []common.ClusterObjectReference
+	// GetDriftedResources returns the drifted resources detected in the last StateKeep.
+	GetDriftedResources() []common.DriftedResource
 }
 
 type resourceKeeper struct {
@@ -68,8 +70,10 @@ type resourceKeeper struct {
 	takeOverPolicy       *v1alpha1.TakeOverPolicySpec
 	readOnlyPolicy       *v1alpha1.ReadOnlyPolicySpec
 	resourceUpdatePolicy *v1alpha1.ResourceUpdatePolicySpec
+	driftDetectionPolicy *v1alpha1.DriftDetectionPolicySpec
 
-	cache *resourceCache
+	cache            *resourceCache
+	driftedResources []common.DriftedResource
 }
 
 func (h *resourceKeeper) getRootRT(ctx context.Context) (rootRT *v1beta1.ResourceTracker, err error) {
@@ -121,6 +125,9 @@ func (h *resourceKeeper) parseApplicationResourcePolicy() (err error) {
 	if h.resourceUpdatePolicy, err = policy.ParsePolicy[v1alpha1.ResourceUpdatePolicySpec](h.app); err != nil {
 		return errors.Wrapf(err, "failed to parse resource-update policy")
 	}
+	if h.driftDetectionPolicy, err = policy.ParsePolicy[v1alpha1.DriftDetectionPolicySpec](h.app); err != nil {
+		return errors.Wrapf(err, "failed to parse drift-detection policy")
+	}
 	return nil
 }
 
@@ -143,6 +150,11 @@ func (h *resourceKeeper) GetAppliedResources() []common.ClusterObjectReference {
 	return refs
 }
 
+// GetDriftedResources returns the drifted resources detected in the last StateKeep.
+func (h *resourceKeeper) GetDriftedResources() []common.DriftedResource {
+	return h.driftedResources
+}
+
 // NewResourceKeeper create a handler for dispatching and deleting resources
 func NewResourceKeeper(ctx context.Context, cli client.Client, app *v1beta1.Application) (_ ResourceKeeper, err error) {
 	h := &resourceKeeper{
@@ -18,6 +18,7 @@ package resourcekeeper
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
@@ -29,10 +30,12 @@ import (
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/pkg/auth"
 	"github.com/oam-dev/kubevela/pkg/multicluster"
+	"github.com/oam-dev/kubevela/pkg/oam"
 	"github.com/oam-dev/kubevela/pkg/utils/apply"
 	velaerrors "github.com/oam-dev/kubevela/pkg/utils/errors"
 )
@@ -54,8 +57,9 @@ func (h *resourceKeeper) StateKeep(ctx context.Context) error {
 			}
 		}
 	}
-	var stalesMu sync.Mutex
+	var stalesMu, driftMu sync.Mutex
 	var staleEntries []staleEntry
+	var drifted []common.DriftedResource
 	errs := slices.ParMap(maps.Values(mrs), func(mr v1beta1.ManagedResource) error {
 		rt := belongs[mr.ResourceKey()]
 		entry := h.cache.get(ctx, mr)
@@ -89,6 +93,19 @@ func (h *resourceKeeper) StateKeep(ctx context.Context) error {
 				stalesMu.Unlock()
 				return nil
 			}
+			keepProgressiveRolloutReplicas(manifest, entry.obj)
+			if !h.isReadOnly(manifest) {
+				mode := h.driftDetectionPolicy.FindMode(manifest)
+				if d := h.detectDriftedResource(mr, entry, manifest); d != nil {
+					d.Corrected = mode != v1alpha1.DriftDetectionModeReportOnly
+					driftMu.Lock()
+					drifted = append(drifted, *d)
+					driftMu.Unlock()
+				}
+				if mode == v1alpha1.DriftDetectionModeReportOnly {
+					return nil
+				}
+			}
 			ao := []apply.ApplyOption{apply.MustBeControlledByApp(h.app)}
 			if h.isShared(manifest) {
 				ao = append([]apply.ApplyOption{apply.SharedByApp(h.app)}, ao...)
@@ -108,12 +125,44 @@ func (h *resourceKeeper) StateKeep(ctx context.Context) error {
 		}
 		return nil
 	}, slices.Parallelism(MaxDispatchConcurrent))
+	sort.Slice(drifted, func(i, j int) bool {
+		return (&v1beta1.ManagedResource{ClusterObjectReference: drifted[i].ClusterObjectReference}).ResourceKey() <
+			(&v1beta1.ManagedResource{ClusterObjectReference: drifted[j].ClusterObjectReference}).ResourceKey()
+	})
+	h.driftedResources = drifted
 	if err := h.cleanupStaleEntries(ctx, staleEntries); err != nil {
 		errs = append(errs, err)
 	}
 	return velaerrors.AggregateErrors(errs)
 }
 
+// keepProgressiveRolloutReplicas keeps the live replicas of the workload in the manifest if
+// its replicas are being shifted to a canary by a progressive rollout
+func keepProgressiveRolloutReplicas(manifest *unstructured.Unstructured, live *unstructured.Unstructured) {
+	if live == nil || live.GetAnnotations()[oam.AnnotationProgressiveRollout] == "" {
+		return
+	}
+	replicas, found, err := unstructured.NestedFieldCopy(live.Object, "spec", "replicas")
+	if err != nil || !found {
+		return
+	}
+	_ = unstructured.SetNestedField(manifest.Object, replicas, "spec", "replicas")
+}
+
+// detectDriftedResource compares the desired manifest with the live object of the
+// managed resource, returns nil if the resource has not drifted
+func (h *resourceKeeper) detectDriftedResource(mr v1beta1.ManagedResource, entry *resourceCacheEntry, manifest *unstructured.Unstructured) *common.DriftedResource {
+	switch {
+	case entry.obj == nil || entry.obj.GetResourceVersion() == "":
+		return &common.DriftedResource{ClusterObjectReference: mr.ClusterObjectReference, Component: mr.Component, Missing: true}
+	case entry.exists:
+		if fields := detectDrift(manifest, entry.obj); len(fields) > 0 {
+			return &common.DriftedResource{ClusterObjectReference: mr.ClusterObjectReference, Component: mr.Component, Fields: fields}
+		}
+	}
+	return nil
+}
+
 // staleEntry records a managed resource whose backing object no longer exists
 // and should be removed from its ResourceTracker.
 type staleEntry struct {
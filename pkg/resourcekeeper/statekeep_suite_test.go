@@ -160,6 +160,15 @@ var _ = Describe("Test ResourceKeeper StateKeep", func() {
 		Expect(cms.Items[2].GetName()).Should(Equal("cm5"))
 		Expect(cms.Items[2].Object["data"].(map[string]interface{})["key"].(string)).Should(Equal("value"))
 
+		drifted := h.GetDriftedResources()
+		Expect(len(drifted)).Should(Equal(2))
+		Expect(drifted[0].Name).Should(Equal("cm1"))
+		Expect(drifted[0].Missing).Should(BeTrue())
+		Expect(drifted[0].Corrected).Should(BeTrue())
+		Expect(drifted[1].Name).Should(Equal("cm5"))
+		Expect(drifted[1].Fields).Should(Equal([]string{"data.key"}))
+		Expect(drifted[1].Corrected).Should(BeTrue())
+
 		Expect(cli.Get(context.Background(), client.ObjectKeyFromObject(cm1), cm1)).Should(Succeed())
 		cm1.SetLabels(map[string]string{
 			oam.LabelAppName:      "app-2",
@@ -171,6 +180,43 @@ var _ = Describe("Test ResourceKeeper StateKeep", func() {
 		Expect(err.Error()).Should(ContainSubstring("failed to re-apply"))
 	})
 
+	It("Test StateKeep with report-only drift-detection policy", func() {
+		cli := testClient
+		cm := createConfigMap("cm-drift-report", "value")
+		cm.SetLabels(map[string]string{
+			oam.LabelAppName:      "app-drift-report",
+			oam.LabelAppNamespace: "default",
+		})
+		cmRaw, err := json.Marshal(cm)
+		Expect(err).Should(Succeed())
+		cm.Object["data"].(map[string]interface{})["key"] = "changed"
+		Expect(cli.Create(context.Background(), cm)).Should(Succeed())
+
+		app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app-drift-report", Namespace: "default"}}
+		h := &resourceKeeper{
+			Client:               cli,
+			app:                  app,
+			applicator:           apply.NewAPIApplicator(cli),
+			cache:                newResourceCache(cli, app),
+			driftDetectionPolicy: &v1alpha1.DriftDetectionPolicySpec{Mode: v1alpha1.DriftDetectionModeReportOnly},
+		}
+		h._currentRT = &v1beta1.ResourceTracker{
+			Spec: v1beta1.ResourceTrackerSpec{
+				ManagedResources: []v1beta1.ManagedResource{{
+					ClusterObjectReference: createConfigMapClusterObjectReference("cm-drift-report"),
+					Data:                   &runtime.RawExtension{Raw: cmRaw},
+				}},
+			},
+		}
+		Expect(h.StateKeep(context.Background())).Should(Succeed())
+		drifted := h.GetDriftedResources()
+		Expect(len(drifted)).Should(Equal(1))
+		Expect(drifted[0].Fields).Should(Equal([]string{"data.key"}))
+		Expect(drifted[0].Corrected).Should(BeFalse())
+		Expect(cli.Get(context.Background(), client.ObjectKeyFromObject(cm), cm)).Should(Succeed())
+		Expect(cm.Object["data"].(map[string]interface{})["key"]).Should(Equal("changed"))
+	})
+
 	It("Test StateKeep apply-once does not re-create externally deleted resource and cleans up stale RT entry", func() {
 		cli := testClient
 
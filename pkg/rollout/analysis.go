@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+)
+
+const (
+	// PrometheusAnalysisType judges the canary by the result of a Prometheus query
+	PrometheusAnalysisType = "prometheus"
+	// CUEAnalysisType judges the canary by a CUE expression on the canary and stable workloads
+	CUEAnalysisType = "cue"
+)
+
+// AnalysisTarget is the workloads to analyze in one canary step
+type AnalysisTarget struct {
+	// Step the index of the current canary step
+	Step int
+	// Canary the workload running the new version
+	Canary *unstructured.Unstructured
+	// Stable the workload running the old version
+	Stable *unstructured.Unstructured
+}
+
+// Analyzer judges whether the canary is successful with the given metric
+type Analyzer interface {
+	Analyze(ctx context.Context, metric v1alpha1.RolloutAnalysisMetric, target *AnalysisTarget) (success bool, message string, err error)
+}
+
+var (
+	analyzersMu sync.RWMutex
+	analyzers   = map[string]Analyzer{
+		PrometheusAnalysisType: &prometheusAnalyzer{client: &http.Client{Timeout: 30 * time.Second}},
+		CUEAnalysisType:        &cueAnalyzer{},
+	}
+)
+
+// RegisterAnalyzer registers the analysis backend for the given metric type
+func RegisterAnalyzer(typ string, analyzer Analyzer) {
+	analyzersMu.Lock()
+	defer analyzersMu.Unlock()
+	analyzers[typ] = analyzer
+}
+
+// Analyze runs all the analysis metrics against the target, the canary is successful
+// only if all the metrics succeed
+func Analyze(ctx context.Context, metrics []v1alpha1.RolloutAnalysisMetric, target *AnalysisTarget) (bool, string, error) {
+	for _, metric := range metrics {
+		analyzersMu.RLock()
+		analyzer, found := analyzers[metric.Type]
+		analyzersMu.RUnlock()
+		if !found {
+			return false, "", errors.Errorf("unsupported analysis type %s in metric %s", metric.Type, metric.Name)
+		}
+		success, msg, err := analyzer.Analyze(ctx, metric, target)
+		if err != nil {
+			return false, "", errors.WithMessagef(err, "failed to analyze metric %s", metric.Name)
+		}
+		if !success {
+			return false, fmt.Sprintf("metric %s failed: %s", metric.Name, msg), nil
+		}
+	}
+	return true, "", nil
+}
+
+func decodeAnalysisProperties(metric v1alpha1.RolloutAnalysisMetric, props interface{}) error {
+	if metric.Properties == nil || metric.Properties.Raw == nil {
+		return errors.Errorf("properties of metric %s is empty", metric.Name)
+	}
+	return json.Unmarshal(metric.Properties.Raw, props)
+}
+
+// PrometheusAnalysisProperties the properties of prometheus analysis
+type PrometheusAnalysisProperties struct {
+	// Address the address of the Prometheus server
+	Address string `json:"address"`
+	// Query the PromQL to run, the query should return a scalar or a vector
+	Query string `json:"query"`
+	// Min the lower bound of every returned value
+	Min *float64 `json:"min,omitempty"`
+	// Max the upper bound of every returned value
+	Max *float64 `json:"max,omitempty"`
+}
+
+// maxPrometheusResponseSize limits the size of the query response read from Prometheus
+const maxPrometheusResponseSize = 10 << 20
+
+type prometheusAnalyzer struct {
+	client *http.Client
+}
+
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// Analyze runs the query against the Prometheus server and checks if every returned value
+// is in the range of [min, max]
+func (p *prometheusAnalyzer) Analyze(ctx context.Context, metric v1alpha1.RolloutAnalysisMetric, _ *AnalysisTarget) (bool, string, error) {
+	props := &PrometheusAnalysisProperties{}
+	if err := decodeAnalysisProperties(metric, props); err != nil {
+		return false, "", err
+	}
+	if props.Address == "" || props.Query == "" {
+		return false, "", errors.New("address and query must be set for prometheus analysis")
+	}
+	u := strings.TrimSuffix(props.Address, "/") + "/api/v1/query?" + url.Values{"query": []string{props.Query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, "", err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to query prometheus")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPrometheusResponseSize))
+	if err != nil {
+		return false, "", err
+	}
+	// Prometheus answers bad queries with 400/422 and a json error body, anything else
+	// than a json body is a failure of the server or of a proxy in front of it
+	result := &prometheusQueryResponse{}
+	if resp.StatusCode != http.StatusOK {
+		if json.Unmarshal(body, result) == nil && result.Error != "" {
+			return false, "", errors.Errorf("prometheus query failed with status %d: %s", resp.StatusCode, result.Error)
+		}
+		return false, "", errors.Errorf("prometheus query failed with status %d", resp.StatusCode)
+	}
+	if err = json.Unmarshal(body, result); err != nil {
+		return false, "", errors.Wrapf(err, "failed to decode prometheus response")
+	}
+	if result.Status != "success" {
+		return false, "", errors.Errorf("prometheus query failed: %s", result.Error)
+	}
+	values, err := parsePrometheusValues(result.Data.ResultType, result.Data.Result)
+	if err != nil {
+		return false, "", err
+	}
+	if len(values) == 0 {
+		return false, "query returns no data", nil
+	}
+	for _, val := range values {
+		if props.Min != nil && val < *props.Min {
+			return false, fmt.Sprintf("value %v is less than %v", val, *props.Min), nil
+		}
+		if props.Max != nil && val > *props.Max {
+			return false, fmt.Sprintf("value %v is greater than %v", val, *props.Max), nil
+		}
+	}
+	return true, "", nil
+}
+
+func parsePrometheusValues(resultType string, raw json.RawMessage) ([]float64, error) {
+	parseSample := func(sample []interface{}) (float64, error) {
+		if len(sample) != 2 {
+			return 0, errors.Errorf("invalid prometheus sample %v", sample)
+		}
+		s, ok := sample[1].(string)
+		if !ok {
+			return 0, errors.Errorf("invalid prometheus sample value %v", sample[1])
+		}
+		return strconv.ParseFloat(s, 64)
+	}
+	switch resultType {
+	case "scalar":
+		var sample []interface{}
+		if err := json.Unmarshal(raw, &sample); err != nil {
+			return nil, err
+		}
+		val, err := parseSample(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{val}, nil
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(raw, &vector); err != nil {
+			return nil, err
+		}
+		var values []float64
+		for _, v := range vector {
+			val, err := parseSample(v.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, val)
+		}
+		return values, nil
+	default:
+		return nil, errors.Errorf("unsupported prometheus result type %s", resultType)
+	}
+}
+
+// CUEAnalysisProperties the properties of cue analysis
+type CUEAnalysisProperties struct {
+	// Expression the CUE expression evaluated to a bool, the canary and stable workloads
+	// can be referred as `canary` and `stable`, like `canary.status.readyReplicas == canary.spec.replicas`
+	Expression string `json:"expression"`
+}
+
+type cueAnalyzer struct{}
+
+// Analyze evaluates the CUE expression with the canary and stable workloads
+func (c *cueAnalyzer) Analyze(_ context.Context, metric v1alpha1.RolloutAnalysisMetric, target *AnalysisTarget) (bool, string, error) {
+	props := &CUEAnalysisProperties{}
+	if err := decodeAnalysisProperties(metric, props); err != nil {
+		return false, "", err
+	}
+	if props.Expression == "" {
+		return false, "", errors.New("expression must be set for cue analysis")
+	}
+	v := cuecontext.New().CompileString(fmt.Sprintf("canary: {...}\nstable: {...}\nstep: int\nresult: %s", props.Expression))
+	if v.Err() != nil {
+		return false, "", errors.Wrapf(v.Err(), "invalid expression")
+	}
+	if target.Canary != nil {
+		v = v.FillPath(cue.ParsePath("canary"), target.Canary.Object)
+	}
+	if target.Stable != nil {
+		v = v.FillPath(cue.ParsePath("stable"), target.Stable.Object)
+	}
+	v = v.FillPath(cue.ParsePath("step"), target.Step)
+	result, err := v.LookupPath(cue.ParsePath("result")).Bool()
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to evaluate expression")
+	}
+	if !result {
+		return false, fmt.Sprintf("expression %q is false", props.Expression), nil
+	}
+	return true, "", nil
+}
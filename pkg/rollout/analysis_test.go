@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+)
+
+func TestPrometheusAnalysis(t *testing.T) {
+	responses := map[string]string{
+		"vector": `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.995"]}]}}`,
+		"scalar": `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.5"]}}`,
+		"empty":  `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"error":  `{"status":"error","error":"bad query"}`,
+		// a proxy in front of Prometheus may answer with a cached body
+		"unavailable": `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"1"]}}`,
+	}
+	statuses := map[string]int{"error": http.StatusBadRequest, "unavailable": http.StatusServiceUnavailable}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/query", r.URL.Path)
+		query := r.URL.Query().Get("query")
+		if status, found := statuses[query]; found {
+			w.WriteHeader(status)
+		}
+		_, _ = w.Write([]byte(responses[query]))
+	}))
+	defer server.Close()
+
+	metric := func(query string) v1alpha1.RolloutAnalysisMetric {
+		return v1alpha1.RolloutAnalysisMetric{
+			Name:       "success-rate",
+			Type:       PrometheusAnalysisType,
+			Properties: &runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"address":"%s","query":"%s","min":0.99}`, server.URL, query))},
+		}
+	}
+	testCases := map[string]struct {
+		query   string
+		success bool
+		hasErr  bool
+	}{
+		"vector-in-range":  {query: "vector", success: true},
+		"scalar-too-small": {query: "scalar", success: false},
+		"no-data":          {query: "empty", success: false},
+		"query-error":      {query: "error", hasErr: true},
+		"server-error":     {query: "unavailable", hasErr: true},
+	}
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			success, msg, err := Analyze(context.Background(), []v1alpha1.RolloutAnalysisMetric{metric(tt.query)}, &AnalysisTarget{})
+			if tt.hasErr {
+				r.Error(err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.success, success, msg)
+		})
+	}
+}
+
+func TestCUEAnalysis(t *testing.T) {
+	r := require.New(t)
+	target := &AnalysisTarget{
+		Step: 1,
+		Canary: &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": int64(2)},
+			"status": map[string]interface{}{"availableReplicas": int64(2)},
+		}},
+		Stable: &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"replicas": int64(2)},
+		}},
+	}
+	metric := func(expr string) []v1alpha1.RolloutAnalysisMetric {
+		return []v1alpha1.RolloutAnalysisMetric{{
+			Name:       "expr",
+			Type:       CUEAnalysisType,
+			Properties: &runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"expression":%q}`, expr))},
+		}}
+	}
+	success, _, err := Analyze(context.Background(), metric("canary.status.availableReplicas == canary.spec.replicas && step == 1"), target)
+	r.NoError(err)
+	r.True(success)
+	success, msg, err := Analyze(context.Background(), metric("canary.spec.replicas > stable.spec.replicas"), target)
+	r.NoError(err)
+	r.False(success)
+	r.Contains(msg, "metric expr failed")
+	_, _, err = Analyze(context.Background(), metric("canary.spec.replicas"), target)
+	r.Error(err)
+	_, _, err = Analyze(context.Background(), []v1alpha1.RolloutAnalysisMetric{{Name: "unknown", Type: "datadog"}}, target)
+	r.Error(err)
+}
@@ -0,0 +1,426 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+const (
+	// LabelCanary marks the canary workload and its pods created by the progressive rollout
+	LabelCanary = "rollout.oam.dev/canary"
+	// AnnotationCanaryStep records the current step of the canary
+	AnnotationCanaryStep = "rollout.oam.dev/canary-step"
+	// AnnotationCanaryRevision records the pod template hash of the new version in the canary
+	AnnotationCanaryRevision = "rollout.oam.dev/canary-revision"
+	// AnnotationCanaryReadyAt records the time when the canary of current step becomes ready
+	AnnotationCanaryReadyAt = "rollout.oam.dev/canary-ready-at"
+	// AnnotationStableReplicas records the replicas of the stable workload before the rollout
+	AnnotationStableReplicas = "rollout.oam.dev/stable-replicas"
+
+	canarySuffix = "-canary"
+)
+
+// ProgressPhase is the phase of the progressive rollout
+type ProgressPhase string
+
+const (
+	// ProgressPhaseProgressing means the canary steps are in progress
+	ProgressPhaseProgressing ProgressPhase = "progressing"
+	// ProgressPhasePromoting means all the canary steps succeed or no canary is needed, the
+	// new version should be applied to the stable workload
+	ProgressPhasePromoting ProgressPhase = "promoting"
+	// ProgressPhaseFailed means the analysis of the canary fails and the rollout is aborted
+	ProgressPhaseFailed ProgressPhase = "failed"
+)
+
+// ProgressStatus is the status of the progressive rollout
+type ProgressStatus struct {
+	Phase   ProgressPhase
+	Step    int
+	Message string
+}
+
+// ProgressiveRollout rolls out the new version of a Deployment with canary steps. The new
+// version runs in a canary Deployment beside the stable one, the replicas are shifted from
+// the stable Deployment to the canary step by step. The canary pods share the labels of the
+// stable pods, so the requests are split by the ratio of the replicas, there is no traffic
+// router involved. Shifting traffic weight is out of scope, the selector of the stable
+// Deployment overlaps the canary pods, which is tolerated because the ReplicaSets of the
+// two Deployments are told apart by the pod template hash. It does not rely on any rollout
+// controller like Kruise.
+//
+// While the canary steps are in progress, the stable Deployment is annotated with
+// oam.AnnotationProgressiveRollout so that state-keep does not restore its replicas.
+type ProgressiveRollout struct {
+	Client  client.Client
+	Cluster string
+	Policy  *v1alpha1.ProgressiveRolloutPolicySpec
+
+	now func() time.Time
+}
+
+// NewProgressiveRollout creates a progressive rollout for the workloads in the given cluster
+func NewProgressiveRollout(cli client.Client, cluster string, policy *v1alpha1.ProgressiveRolloutPolicySpec) *ProgressiveRollout {
+	return &ProgressiveRollout{Client: cli, Cluster: cluster, Policy: policy, now: time.Now}
+}
+
+// IsProgressiveWorkload checks if the workload can be rolled out progressively
+func IsProgressiveWorkload(workload *unstructured.Unstructured) bool {
+	return workload != nil && workload.GetAPIVersion() == appsv1.SchemeGroupVersion.String() && workload.GetKind() == "Deployment"
+}
+
+// Progress drives the canary of the desired workload forward by one action. It returns the
+// promoting phase if the desired workload should be applied as the stable one directly,
+// which happens when the workload is not rolled out before, the pod template does not
+// change or all the canary steps succeed.
+func (r *ProgressiveRollout) Progress(ctx context.Context, desired *unstructured.Unstructured) (*ProgressStatus, error) {
+	if !IsProgressiveWorkload(desired) || len(r.Policy.Steps) == 0 {
+		return &ProgressStatus{Phase: ProgressPhasePromoting}, nil
+	}
+	ctx = multicluster.ContextWithClusterName(ctx, r.Cluster)
+	stable := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, stable); err != nil {
+		if kerrors.IsNotFound(err) {
+			return &ProgressStatus{Phase: ProgressPhasePromoting}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get stable workload %s/%s", desired.GetNamespace(), desired.GetName())
+	}
+	revision, err := templateHash(desired.Object)
+	if err != nil {
+		return nil, err
+	}
+	if stableRevision := lastAppliedTemplateHash(stable); stableRevision == "" || stableRevision == revision {
+		return &ProgressStatus{Phase: ProgressPhasePromoting}, nil
+	}
+
+	canary, err := r.getCanary(ctx, stable)
+	if err != nil {
+		return nil, err
+	}
+	if canary == nil || canary.Annotations[AnnotationCanaryRevision] != revision {
+		// start a new canary, the one of the outdated version is replaced
+		if canary, err = r.createCanary(ctx, desired, stable, canary, revision); err != nil {
+			return nil, err
+		}
+	}
+	step, _ := strconv.Atoi(canary.Annotations[AnnotationCanaryStep])
+	if step >= len(r.Policy.Steps) {
+		return &ProgressStatus{Phase: ProgressPhasePromoting, Step: step}, nil
+	}
+	total, _ := strconv.Atoi(canary.Annotations[AnnotationStableReplicas])
+	canaryReplicas := CanaryReplicas(r.Policy.Steps[step], int32(total))
+	status := &ProgressStatus{Phase: ProgressPhaseProgressing, Step: step}
+
+	if ptr.Deref(canary.Spec.Replicas, 1) != canaryReplicas || ptr.Deref(stable.Spec.Replicas, 1) != int32(total)-canaryReplicas ||
+		stable.Annotations[oam.AnnotationProgressiveRollout] != canary.Name {
+		if err = r.scale(ctx, canary, canaryReplicas); err != nil {
+			return nil, err
+		}
+		if err = r.scaleStable(ctx, stable, int32(total)-canaryReplicas, canary.Name); err != nil {
+			return nil, err
+		}
+		status.Message = fmt.Sprintf("step %d: scaling canary to %d replicas", step, canaryReplicas)
+		return status, nil
+	}
+	if !isDeploymentAvailable(canary) {
+		status.Message = fmt.Sprintf("step %d: waiting for the canary to be available", step)
+		return status, nil
+	}
+	readyAt, err := time.Parse(time.RFC3339, canary.Annotations[AnnotationCanaryReadyAt])
+	if err != nil {
+		canary.Annotations[AnnotationCanaryReadyAt] = r.now().Format(time.RFC3339)
+		if err = r.Client.Update(ctx, canary); err != nil {
+			return nil, errors.Wrapf(err, "failed to update canary workload")
+		}
+		status.Message = fmt.Sprintf("step %d: canary is available", step)
+		return status, nil
+	}
+	if pause := r.Policy.Steps[step].Pause; pause != "" {
+		duration, err := time.ParseDuration(pause)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pause %s in step %d", pause, step)
+		}
+		if remaining := readyAt.Add(duration).Sub(r.now()); remaining > 0 {
+			status.Message = fmt.Sprintf("step %d: paused, %s remaining", step, remaining.Round(time.Second))
+			return status, nil
+		}
+	}
+
+	canaryObj, err := toUnstructured(canary)
+	if err != nil {
+		return nil, err
+	}
+	stableObj, err := toUnstructured(stable)
+	if err != nil {
+		return nil, err
+	}
+	success, msg, err := Analyze(ctx, r.Policy.Analysis, &AnalysisTarget{Step: step, Canary: canaryObj, Stable: stableObj})
+	if err != nil {
+		return nil, err
+	}
+	if !success {
+		if err = r.Abort(ctx, desired); err != nil {
+			return nil, err
+		}
+		return &ProgressStatus{Phase: ProgressPhaseFailed, Step: step, Message: fmt.Sprintf("step %d: analysis failed, %s", step, msg)}, nil
+	}
+	canary.Annotations[AnnotationCanaryStep] = strconv.Itoa(step + 1)
+	delete(canary.Annotations, AnnotationCanaryReadyAt)
+	if err = r.Client.Update(ctx, canary); err != nil {
+		return nil, errors.Wrapf(err, "failed to update canary workload")
+	}
+	if step+1 >= len(r.Policy.Steps) {
+		return &ProgressStatus{Phase: ProgressPhasePromoting, Step: step + 1}, nil
+	}
+	status.Step = step + 1
+	status.Message = fmt.Sprintf("step %d succeeded", step)
+	return status, nil
+}
+
+// Finalize cleans up the canary after the new version is promoted to the stable workload.
+// The replicas of the stable workload is restored if it is not managed by the desired one.
+func (r *ProgressiveRollout) Finalize(ctx context.Context, desired *unstructured.Unstructured) error {
+	if !IsProgressiveWorkload(desired) {
+		return nil
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(desired.Object, "spec", "replicas"); found {
+		return r.cleanup(ctx, desired, false)
+	}
+	return r.cleanup(ctx, desired, true)
+}
+
+// Abort deletes the canary and restores the replicas of the stable workload
+func (r *ProgressiveRollout) Abort(ctx context.Context, desired *unstructured.Unstructured) error {
+	return r.cleanup(ctx, desired, true)
+}
+
+func (r *ProgressiveRollout) cleanup(ctx context.Context, desired *unstructured.Unstructured, restore bool) error {
+	ctx = multicluster.ContextWithClusterName(ctx, r.Cluster)
+	stable := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, stable); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	canary, err := r.getCanary(ctx, stable)
+	if err != nil {
+		return err
+	}
+	replicas := ptr.Deref(stable.Spec.Replicas, 1)
+	if canary == nil {
+		return r.scaleStable(ctx, stable, replicas, "")
+	}
+	if total, err := strconv.Atoi(canary.Annotations[AnnotationStableReplicas]); err == nil && restore {
+		replicas = int32(total)
+	}
+	if err = r.scaleStable(ctx, stable, replicas, ""); err != nil {
+		return err
+	}
+	if err = r.Client.Delete(ctx, canary); err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete canary workload")
+	}
+	return nil
+}
+
+func (r *ProgressiveRollout) getCanary(ctx context.Context, stable *appsv1.Deployment) (*appsv1.Deployment, error) {
+	canary := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: stable.Namespace, Name: stable.Name + canarySuffix}, canary); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get canary workload")
+	}
+	return canary, nil
+}
+
+// createCanary creates the canary Deployment with the pod template of the desired workload.
+// The canary is owned by the stable Deployment so that it is garbage collected together.
+func (r *ProgressiveRollout) createCanary(ctx context.Context, desired *unstructured.Unstructured, stable *appsv1.Deployment, outdated *appsv1.Deployment, revision string) (*appsv1.Deployment, error) {
+	total := ptr.Deref(stable.Spec.Replicas, 1)
+	if outdated != nil {
+		// the rollout of the outdated version is not finished, keep the original replicas
+		if replicas, err := strconv.Atoi(outdated.Annotations[AnnotationStableReplicas]); err == nil {
+			total = int32(replicas)
+		}
+		if err := r.Client.Delete(ctx, outdated); err != nil && !kerrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to delete outdated canary workload")
+		}
+	}
+	deploy := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(desired.Object, deploy); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert desired workload")
+	}
+	canary := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      stable.Name + canarySuffix,
+			Namespace: stable.Namespace,
+			Labels:    map[string]string{LabelCanary: "true"},
+			Annotations: map[string]string{
+				AnnotationCanaryStep:     "0",
+				AnnotationCanaryRevision: revision,
+				AnnotationStableReplicas: strconv.Itoa(int(total)),
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(stable, appsv1.SchemeGroupVersion.WithKind("Deployment"))},
+		},
+		Spec: *deploy.Spec.DeepCopy(),
+	}
+	for k, v := range deploy.Labels {
+		canary.Labels[k] = v
+	}
+	canary.Spec.Replicas = ptr.To[int32](0)
+	canary.Spec.Selector = stable.Spec.Selector.DeepCopy()
+	if canary.Spec.Selector == nil {
+		canary.Spec.Selector = &metav1.LabelSelector{}
+	}
+	if canary.Spec.Selector.MatchLabels == nil {
+		canary.Spec.Selector.MatchLabels = map[string]string{}
+	}
+	canary.Spec.Selector.MatchLabels[LabelCanary] = "true"
+	if canary.Spec.Template.Labels == nil {
+		canary.Spec.Template.Labels = map[string]string{}
+	}
+	canary.Spec.Template.Labels[LabelCanary] = "true"
+	if err := r.Client.Create(ctx, canary); err != nil {
+		return nil, errors.Wrapf(err, "failed to create canary workload")
+	}
+	return canary, nil
+}
+
+func (r *ProgressiveRollout) scale(ctx context.Context, deploy *appsv1.Deployment, replicas int32) error {
+	if ptr.Deref(deploy.Spec.Replicas, 1) == replicas {
+		return nil
+	}
+	patch := client.MergeFrom(deploy.DeepCopy())
+	deploy.Spec.Replicas = ptr.To(replicas)
+	if err := r.Client.Patch(ctx, deploy, patch); err != nil {
+		return errors.Wrapf(err, "failed to scale %s/%s to %d replicas", deploy.Namespace, deploy.Name, replicas)
+	}
+	return nil
+}
+
+// scaleStable scales the stable workload and marks it with the canary in progress, the mark
+// is removed if the canary is empty
+func (r *ProgressiveRollout) scaleStable(ctx context.Context, stable *appsv1.Deployment, replicas int32, canary string) error {
+	if ptr.Deref(stable.Spec.Replicas, 1) == replicas && stable.Annotations[oam.AnnotationProgressiveRollout] == canary {
+		return nil
+	}
+	patch := client.MergeFrom(stable.DeepCopy())
+	stable.Spec.Replicas = ptr.To(replicas)
+	if canary != "" {
+		if stable.Annotations == nil {
+			stable.Annotations = map[string]string{}
+		}
+		stable.Annotations[oam.AnnotationProgressiveRollout] = canary
+	} else {
+		delete(stable.Annotations, oam.AnnotationProgressiveRollout)
+	}
+	if err := r.Client.Patch(ctx, stable, patch); err != nil {
+		return errors.Wrapf(err, "failed to scale %s/%s to %d replicas", stable.Namespace, stable.Name, replicas)
+	}
+	return nil
+}
+
+// CanaryReplicas calculates the replicas of the canary in the given step. The replicas
+// replicas of the step takes precedence over the canary percent, and the result is rounded up.
+func CanaryReplicas(step v1alpha1.ProgressiveRolloutStep, total int32) int32 {
+	var replicas int
+	switch {
+	case step.CanaryReplicas != nil:
+		replicas, _ = intstr.GetScaledValueFromIntOrPercent(step.CanaryReplicas, int(total), true)
+	case step.CanaryPercent != nil:
+		replicas = int(math.Ceil(float64(total) * float64(*step.CanaryPercent) / 100))
+	default:
+		replicas = int(total)
+	}
+	if replicas > int(total) {
+		replicas = int(total)
+	}
+	if replicas < 0 {
+		replicas = 0
+	}
+	return int32(replicas)
+}
+
+func isDeploymentAvailable(deploy *appsv1.Deployment) bool {
+	replicas := ptr.Deref(deploy.Spec.Replicas, 1)
+	return deploy.Status.ObservedGeneration >= deploy.Generation &&
+		deploy.Status.UpdatedReplicas >= replicas &&
+		deploy.Status.AvailableReplicas >= replicas
+}
+
+// templateHash hashes the pod template of the workload
+func templateHash(obj map[string]interface{}) (string, error) {
+	template, _, err := unstructured.NestedFieldNoCopy(obj, "spec", "template")
+	if err != nil {
+		return "", err
+	}
+	bs, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(bs))[:16], nil
+}
+
+// lastAppliedTemplateHash hashes the pod template in the last-applied configuration of the
+// workload, which is recorded when KubeVela applies it
+func lastAppliedTemplateHash(deploy *appsv1.Deployment) string {
+	raw, found := deploy.Annotations[oam.AnnotationLastAppliedConfig]
+	if !found || raw == "-" || raw == "skip" {
+		raw = deploy.Annotations[corev1.LastAppliedConfigAnnotation]
+	}
+	obj := map[string]interface{}{}
+	if raw == "" || json.Unmarshal([]byte(raw), &obj) != nil {
+		return ""
+	}
+	hash, err := templateHash(obj)
+	if err != nil {
+		return ""
+	}
+	return hash
+}
+
+func toUnstructured(deploy *appsv1.Deployment) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deploy)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetAPIVersion(appsv1.SchemeGroupVersion.String())
+	u.SetKind("Deployment")
+	return u, nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	utilcommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newTestDeployment(image string, replicas *int32) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web"}},
+				"spec": map[string]interface{}{"containers": []interface{}{
+					map[string]interface{}{"name": "web", "image": image},
+				}},
+			},
+		},
+	}}
+	if replicas != nil {
+		_ = unstructured.SetNestedField(obj.Object, int64(*replicas), "spec", "replicas")
+	}
+	return obj
+}
+
+func newTestStable(t *testing.T, image string, replicas int32) *appsv1.Deployment {
+	applied := newTestDeployment(image, nil)
+	bs, err := json.Marshal(applied.Object)
+	require.NoError(t, err)
+	deploy := &appsv1.Deployment{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(applied.Object, deploy))
+	deploy.Annotations = map[string]string{oam.AnnotationLastAppliedConfig: string(bs)}
+	deploy.Spec.Replicas = ptr.To(replicas)
+	return deploy
+}
+
+func markAvailable(t *testing.T, cli client.Client, name string) {
+	deploy := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.Background(), k8stypes.NamespacedName{Namespace: "default", Name: name}, deploy))
+	deploy.Status.ObservedGeneration = deploy.Generation
+	deploy.Status.UpdatedReplicas = *deploy.Spec.Replicas
+	deploy.Status.AvailableReplicas = *deploy.Spec.Replicas
+	require.NoError(t, cli.Update(context.Background(), deploy))
+}
+
+func getReplicas(t *testing.T, cli client.Client, name string) int32 {
+	deploy := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.Background(), k8stypes.NamespacedName{Namespace: "default", Name: name}, deploy))
+	return *deploy.Spec.Replicas
+}
+
+func TestProgressiveRollout(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).WithObjects(newTestStable(t, "nginx:1", 4)).Build()
+	policy := &v1alpha1.ProgressiveRolloutPolicySpec{
+		Steps: []v1alpha1.ProgressiveRolloutStep{
+			{CanaryReplicas: ptr.To(intstr.FromInt32(1))},
+			{CanaryPercent: ptr.To[int32](50), Pause: "1m"},
+		},
+		Analysis: []v1alpha1.RolloutAnalysisMetric{{
+			Name:       "available",
+			Type:       CUEAnalysisType,
+			Properties: &runtime.RawExtension{Raw: []byte(`{"expression":"canary.status.availableReplicas == canary.spec.replicas"}`)},
+		}},
+	}
+	ro := NewProgressiveRollout(cli, "", policy)
+	ro.now = func() time.Time { return now }
+	desired := newTestDeployment("nginx:2", nil)
+
+	// step 0: shift one replica to the canary
+	status, err := ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Equal(ProgressPhaseProgressing, status.Phase)
+	r.Equal(int32(1), getReplicas(t, cli, "web-canary"))
+	r.Equal(int32(3), getReplicas(t, cli, "web"))
+	canary := &appsv1.Deployment{}
+	r.NoError(cli.Get(ctx, k8stypes.NamespacedName{Namespace: "default", Name: "web-canary"}, canary))
+	r.Equal("true", canary.Spec.Selector.MatchLabels[LabelCanary])
+	r.Equal("true", canary.Spec.Template.Labels[LabelCanary])
+	r.Equal("nginx:2", canary.Spec.Template.Spec.Containers[0].Image)
+	r.Equal("4", canary.Annotations[AnnotationStableReplicas])
+	stable := &appsv1.Deployment{}
+	r.NoError(cli.Get(ctx, k8stypes.NamespacedName{Namespace: "default", Name: "web"}, stable))
+	r.Equal("web-canary", stable.Annotations[oam.AnnotationProgressiveRollout])
+
+	status, err = ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Contains(status.Message, "waiting for the canary to be available")
+	markAvailable(t, cli, "web-canary")
+	status, err = ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Contains(status.Message, "canary is available")
+	status, err = ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Equal(ProgressPhaseProgressing, status.Phase)
+	r.Equal(1, status.Step)
+
+	// step 1: shift half of the replicas and pause
+	_, err = ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Equal(int32(2), getReplicas(t, cli, "web-canary"))
+	r.Equal(int32(2), getReplicas(t, cli, "web"))
+	markAvailable(t, cli, "web-canary")
+	_, err = ro.Progress(ctx, desired)
+	r.NoError(err)
+	status, err = ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Contains(status.Message, "paused")
+	now = now.Add(2 * time.Minute)
+	status, err = ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Equal(ProgressPhasePromoting, status.Phase)
+
+	// promote: the canary is removed and the replicas of stable workload is restored
+	r.NoError(ro.Finalize(ctx, desired))
+	r.Equal(int32(4), getReplicas(t, cli, "web"))
+	r.NoError(cli.Get(ctx, k8stypes.NamespacedName{Namespace: "default", Name: "web"}, stable))
+	r.NotContains(stable.Annotations, oam.AnnotationProgressiveRollout)
+	err = cli.Get(ctx, k8stypes.NamespacedName{Namespace: "default", Name: "web-canary"}, canary)
+	r.True(client.IgnoreNotFound(err) == nil && err != nil)
+}
+
+func TestProgressiveRolloutAnalysisFailed(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).WithObjects(newTestStable(t, "nginx:1", 2)).Build()
+	policy := &v1alpha1.ProgressiveRolloutPolicySpec{
+		Steps: []v1alpha1.ProgressiveRolloutStep{{CanaryPercent: ptr.To[int32](10)}},
+		Analysis: []v1alpha1.RolloutAnalysisMetric{{
+			Name:       "never",
+			Type:       CUEAnalysisType,
+			Properties: &runtime.RawExtension{Raw: []byte(`{"expression":"false"}`)},
+		}},
+	}
+	ro := NewProgressiveRollout(cli, "", policy)
+	desired := newTestDeployment("nginx:2", nil)
+	_, err := ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Equal(int32(1), getReplicas(t, cli, "web-canary"))
+	r.Equal(int32(1), getReplicas(t, cli, "web"))
+	markAvailable(t, cli, "web-canary")
+	_, err = ro.Progress(ctx, desired)
+	r.NoError(err)
+	status, err := ro.Progress(ctx, desired)
+	r.NoError(err)
+	r.Equal(ProgressPhaseFailed, status.Phase)
+	r.Contains(status.Message, "metric never failed")
+	r.Equal(int32(2), getReplicas(t, cli, "web"))
+	err = cli.Get(ctx, k8stypes.NamespacedName{Namespace: "default", Name: "web-canary"}, &appsv1.Deployment{})
+	r.True(client.IgnoreNotFound(err) == nil && err != nil)
+}
+
+func TestProgressiveRolloutPromoteDirectly(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	policy := &v1alpha1.ProgressiveRolloutPolicySpec{Steps: []v1alpha1.ProgressiveRolloutStep{{CanaryPercent: ptr.To[int32](10)}}}
+	testCases := map[string]struct {
+		objs    []client.Object
+		desired *unstructured.Unstructured
+	}{
+		"first-rollout": {desired: newTestDeployment("nginx:2", nil)},
+		"same-template": {objs: []client.Object{newTestStable(t, "nginx:1", 2)}, desired: newTestDeployment("nginx:1", ptr.To[int32](3))},
+		"not-deployment": {objs: []client.Object{newTestStable(t, "nginx:1", 2)}, desired: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "metadata": map[string]interface{}{"name": "web", "namespace": "default"},
+		}}},
+	}
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).WithObjects(tt.objs...).Build()
+			status, err := NewProgressiveRollout(cli, "", policy).Progress(ctx, tt.desired)
+			r.NoError(err)
+			r.Equal(ProgressPhasePromoting, status.Phase)
+		})
+	}
+}
+
+func TestCanaryReplicas(t *testing.T) {
+	r := require.New(t)
+	r.Equal(int32(1), CanaryReplicas(v1alpha1.ProgressiveRolloutStep{CanaryReplicas: ptr.To(intstr.FromInt32(1))}, 4))
+	r.Equal(int32(2), CanaryReplicas(v1alpha1.ProgressiveRolloutStep{CanaryReplicas: ptr.To(intstr.FromString("40%"))}, 4))
+	r.Equal(int32(1), CanaryReplicas(v1alpha1.ProgressiveRolloutStep{CanaryPercent: ptr.To[int32](10)}, 4))
+	r.Equal(int32(4), CanaryReplicas(v1alpha1.ProgressiveRolloutStep{CanaryReplicas: ptr.To(intstr.FromInt32(10))}, 4))
+	r.Equal(int32(4), CanaryReplicas(v1alpha1.ProgressiveRolloutStep{}, 4))
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	pkgconfig "github.com/oam-dev/kubevela/pkg/config"
+	"github.com/oam-dev/kubevela/pkg/logging"
+)
+
+// ValidatingHandler handles validation of the config secrets. It checks the referenced configs
+// exist, and refuses to delete the configs referenced by other configs.
+type ValidatingHandler struct {
+	// Decoder decodes object
+	Decoder admission.Decoder
+	Client  client.Client
+}
+
+var _ admission.Handler = &ValidatingHandler{}
+
+// Handle validate the config secret
+func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ctx = logging.WithRequestID(ctx, string(req.UID))
+	logger := logging.NewHandlerLogger(ctx, req, "ConfigValidator")
+
+	secret := &corev1.Secret{}
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Update:
+		if err := h.Decoder.Decode(req, secret); err != nil {
+			return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		if secret.Labels[types.LabelConfigCatalog] != types.VelaCoreConfig {
+			return admission.ValidationResponse(true, "")
+		}
+		if err := pkgconfig.ValidateConfigReferences(ctx, h.Client, secret); err != nil {
+			logger.WithStep("validate-references").WithError(err).Error(err, "Config references other configs that are missing or created by unexpected templates", "config", secret.Name)
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+	case admissionv1.Delete:
+		if err := h.Decoder.DecodeRaw(req.OldObject, secret); err != nil {
+			return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		if secret.Labels[types.LabelConfigCatalog] != types.VelaCoreConfig {
+			return admission.ValidationResponse(true, "")
+		}
+		if err := pkgconfig.CheckConfigNotReferenced(ctx, h.Client, secret.Namespace, secret.Name); err != nil {
+			logger.WithStep("validate-dependants").WithError(err).Error(err, "Config is referenced by other configs and can not be deleted", "config", secret.Name)
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+// RegisterValidatingHandler will register the config validation to webhook
+func RegisterValidatingHandler(mgr manager.Manager) {
+	server := mgr.GetWebhookServer()
+	server.Register("/validating-config-oam-dev-secrets", &webhook.Admission{Handler: &ValidatingHandler{
+		Client:  mgr.GetClient(),
+		Decoder: admission.NewDecoder(mgr.GetScheme()),
+	}})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	pkgconfig "github.com/oam-dev/kubevela/pkg/config"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newConfigSecret(name, template, references string) *corev1.Secret {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{types.LabelConfigCatalog: types.VelaCoreConfig, types.LabelConfigType: template},
+		},
+	}
+	if references != "" {
+		secret.Annotations = map[string]string{types.AnnotationConfigReferences: references}
+	}
+	return secret
+}
+
+func TestValidatingHandler(t *testing.T) {
+	db := newConfigSecret("db", "database", `[{"name":"cache","namespace":"default","template":"redis"}]`)
+	db.Labels[pkgconfig.ConfigReferenceLabel("default", "cache")] = "true"
+	cache := newConfigSecret("cache", "redis", "")
+	app := newConfigSecret("app", "app-settings", `[{"name":"db","namespace":"default","template":"database"}]`)
+	app.Labels[pkgconfig.ConfigReferenceLabel("default", "db")] = "true"
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(db, cache, app).Build()
+	handler := &ValidatingHandler{Client: cli, Decoder: admission.NewDecoder(common.Scheme)}
+
+	request := func(op admissionv1.Operation, secret *corev1.Secret) admission.Request {
+		raw, err := json.Marshal(secret)
+		require.NoError(t, err)
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "secrets"},
+		}}
+		if op == admissionv1.Delete {
+			req.OldObject = runtime.RawExtension{Raw: raw}
+		} else {
+			req.Object = runtime.RawExtension{Raw: raw}
+		}
+		return req
+	}
+
+	testCases := map[string]struct {
+		op      admissionv1.Operation
+		secret  *corev1.Secret
+		allowed bool
+		reason  string
+	}{
+		"create with existing references": {
+			op:      admissionv1.Create,
+			secret:  newConfigSecret("app-2", "app-settings", `[{"name":"db","namespace":"default","template":"database"}]`),
+			allowed: true,
+		},
+		"create with missing reference": {
+			op:     admissionv1.Create,
+			secret: newConfigSecret("app-3", "app-settings", `[{"name":"missing","namespace":"default","template":"database"}]`),
+			reason: "does not exist",
+		},
+		"update forming a cycle": {
+			op:     admissionv1.Update,
+			secret: newConfigSecret("cache", "redis", `[{"name":"app","namespace":"default","template":"app-settings"}]`),
+			reason: "form a cycle: default/cache -> default/app -> default/db -> default/cache",
+		},
+		"delete referenced config": {
+			op:     admissionv1.Delete,
+			secret: db,
+			reason: "default/app",
+		},
+		"delete config not referenced": {
+			op:      admissionv1.Delete,
+			secret:  app,
+			allowed: true,
+		},
+		"secret not a config": {
+			op:      admissionv1.Delete,
+			secret:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+			allowed: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := handler.Handle(context.Background(), request(tc.op, tc.secret))
+			require.Equal(t, tc.allowed, resp.Allowed)
+			if !tc.allowed {
+				require.Contains(t, resp.Result.Message, tc.reason)
+			}
+		})
+	}
+}
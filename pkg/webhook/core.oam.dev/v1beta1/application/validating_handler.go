@@ -25,6 +25,7 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -34,6 +35,9 @@ import (
 	controller "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
 	"github.com/oam-dev/kubevela/pkg/logging"
 	"github.com/oam-dev/kubevela/pkg/oam/util"
+	"github.com/oam-dev/kubevela/pkg/policy"
+	"github.com/oam-dev/kubevela/pkg/utils"
+	"github.com/oam-dev/kubevela/pkg/workflow/approval"
 )
 
 var _ admission.Handler = &ValidatingHandler{}
@@ -43,6 +47,9 @@ type ValidatingHandler struct {
 	Client client.Client
 	// Decoder decodes objects
 	Decoder admission.Decoder
+	// ControllerUser the user of the controller, whose updates of the status are admitted
+	// without checking the approvals
+	ControllerUser string
 }
 
 func simplifyError(err error) error {
@@ -70,6 +77,27 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 
 	logger.WithStep("start").Info("Starting admission validation for Application resource", "operation", req.Operation, "applicationName", req.Name, "namespace", req.Namespace)
 
+	// The object is not set in the DELETE requests, only the dependants are checked
+	if req.Operation == admissionv1.Delete {
+		logger.WithStep("validate-delete").Info("Validating Application deletion - checking the applications depending on it")
+		if allErrs := h.ValidateDelete(ctx, req.Namespace, req.Name); len(allErrs) > 0 {
+			mergedErr := mergeErrors(allErrs)
+			if allErrs[0].Type == field.ErrorTypeInternal {
+				logger.WithStep("validate-delete").WithError(mergedErr).Error(mergedErr, "Unable to check the applications depending on it", "applicationName", req.Name)
+				return admission.Errored(http.StatusInternalServerError, fmt.Errorf("%w (requestUID=%s)", mergedErr, req.UID))
+			}
+			logger.WithStep("validate-delete").WithError(mergedErr).Error(mergedErr, "Application deletion denied - other applications depend on it", "applicationName", req.Name)
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", mergedErr, req.UID))
+		}
+		logger.WithStep("complete").WithSuccess(true, startTime).Info("Application admission validation completed successfully - resource will be admitted", "applicationName", req.Name, "operation", req.Operation, "namespace", req.Namespace)
+		return admission.ValidationResponse(true, "")
+	}
+
+	// The controller updates the status on every reconcile, it is trusted to keep the approvals
+	if req.SubResource == "status" && h.ControllerUser != "" && req.UserInfo.Username == h.ControllerUser {
+		return admission.ValidationResponse(true, "")
+	}
+
 	// Decode the application
 	app := &v1beta1.Application{}
 	if err := h.Decoder.Decode(req, app); err != nil {
@@ -117,6 +145,15 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 
 		logger = logger.WithValues("oldGeneration", oldApp.Generation)
 
+		// Only the approvals are validated in the updates of the status, against the user recording them
+		if req.SubResource == "status" {
+			if err := approval.ValidateStatusUpdate(ctx, h.Client, app, oldApp, req.UserInfo); err != nil {
+				logger.WithStep("validate-approvals").WithError(err).Error(err, "Application status update rejected - the approvals are invalid", "applicationName", app.Name, "user", req.UserInfo.Username)
+				return admission.Errored(http.StatusForbidden, fmt.Errorf("%w (requestUID=%s)", err, req.UID))
+			}
+			return admission.ValidationResponse(true, "")
+		}
+
 		if app.ObjectMeta.DeletionTimestamp.IsZero() {
 			if allErrs := h.ValidateUpdate(ctx, app, oldApp, req); len(allErrs) > 0 {
 				mergedErr := mergeErrors(allErrs)
@@ -128,9 +165,6 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 			logger.WithStep("skip-validation").Info("Skipping Application validation - resource is being deleted and validation is not required", "reason", "deletion-in-progress", "deletionTimestamp", app.DeletionTimestamp)
 		}
 
-	case admissionv1.Delete:
-		logger.WithStep("skip-validation").Info("Skipping Application validation - DELETE operations do not require content validation", "reason", "delete-operation-no-validation-needed")
-
 	default:
 		logger.WithStep("skip-validation").Info("Skipping Application validation - operation type is not supported by validator", "operation", req.Operation, "reason", "only CREATE, UPDATE, and DELETE operations are handled")
 	}
@@ -141,9 +175,17 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 
 // RegisterValidatingHandler will register application validate handler to the webhook
 func RegisterValidatingHandler(mgr manager.Manager, _ controller.Args) {
-	server := mgr.GetWebhookServer()
-	server.Register("/validating-core-oam-dev-v1beta1-applications", &webhook.Admission{Handler: &ValidatingHandler{
+	// the dependants of the deleted Application are looked up through the index
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1beta1.Application{}, policy.AppDependsOnIndex, policy.IndexAppDependsOn); err != nil {
+		klog.ErrorS(err, "Failed to index the applications by their dependencies")
+	}
+	handler := &ValidatingHandler{
 		Client:  mgr.GetClient(),
 		Decoder: admission.NewDecoder(mgr.GetScheme()),
-	}})
+	}
+	if user := utils.GetUserInfoFromConfig(mgr.GetConfig()); user != nil {
+		handler.ControllerUser = user.Username
+	}
+	server := mgr.GetWebhookServer()
+	server.Register("/validating-core-oam-dev-v1beta1-applications", &webhook.Admission{Handler: handler})
 }
@@ -17,12 +17,23 @@ limitations under the License.
 package application
 
 import (
+	"fmt"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/policy"
+	utilcommon "github.com/oam-dev/kubevela/pkg/utils/common"
 )
 
 var _ = Describe("Test Application Validator", func() {
@@ -159,6 +170,23 @@ var _ = Describe("Test Application Validator", func() {
 		Expect(resp.Allowed).Should(BeFalse())
 	})
 
+	It("Test Application Validator app dependencies with the same name [error]", func() {
+		req := admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Resource:  metav1.GroupVersionResource{Group: "core.oam.dev", Version: "v1alpha2", Resource: "applications"},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+{"apiVersion":"core.oam.dev/v1beta1","kind":"Application","metadata":{"name":"dependency-duplicate","namespace":"default"},"spec":{"components":[{"name":"comp","type":"worker","properties":{"image":"crccheck/hello-world"}}],"policies":[{"name":"deps","type":"app-dependency","properties":{"dependsOn":[{"name":"db"},{"name":"db","namespace":"infra"}]}}]}}
+`),
+				},
+			},
+		}
+		resp := handler.Handle(ctx, req)
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Message).Should(ContainSubstring("have the same name"))
+	})
+
 	It("Test Application Validator workflow step invalid timeout [error]", func() {
 		req := admission.Request{
 			AdmissionRequest: admissionv1.AdmissionRequest{
@@ -358,4 +386,102 @@ var _ = Describe("Test Application Validator", func() {
 		resp := handler.Handle(ctx, req)
 		Expect(resp.Allowed).Should(BeTrue())
 	})
+
+	It("Test Application Delete with dependants", func() {
+		newApp := func(namespace, name, properties string) *v1beta1.Application {
+			return &v1beta1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: v1beta1.ApplicationSpec{
+					Components: []common.ApplicationComponent{{
+						Name:       "comp",
+						Type:       "worker",
+						Properties: &runtime.RawExtension{Raw: []byte(`{"image":"busybox"}`)},
+					}},
+					Policies: []v1beta1.AppPolicy{{
+						Name:       "deps",
+						Type:       "app-dependency",
+						Properties: &runtime.RawExtension{Raw: []byte(properties)},
+					}},
+				},
+			}
+		}
+		terminating := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:              "terminating",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"kubernetes"},
+		}}
+		cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).
+			WithIndex(&v1beta1.Application{}, policy.AppDependsOnIndex, policy.IndexAppDependsOn).
+			WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+				terminating,
+				newApp("default", "database", `{"exports":[{"name":"endpoint","value":"db:3306"}]}`),
+				newApp("default", "dependant-app", `{"dependsOn":[{"name":"database"}]}`),
+				newApp("other", "cache", `{"exports":[]}`),
+				newApp("default", "cross-namespace-app", `{"dependsOn":[{"name":"cache","namespace":"other"}]}`),
+				newApp("terminating", "queue", `{"exports":[]}`),
+				newApp("terminating", "worker", `{"dependsOn":[{"name":"queue"}]}`),
+			).Build()
+		deleteHandler := &ValidatingHandler{Client: cli, Decoder: decoder}
+		deleteRequest := func(namespace, name string) admission.Request {
+			return admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Delete,
+					Resource:  metav1.GroupVersionResource{Group: "core.oam.dev", Version: "v1beta1", Resource: "applications"},
+					Name:      name,
+					Namespace: namespace,
+				},
+			}
+		}
+
+		resp := deleteHandler.Handle(ctx, deleteRequest("default", "database"))
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Reason).Should(Equal(metav1.StatusReasonForbidden))
+		Expect(resp.Result.Message).Should(ContainSubstring("application is depended on by default/dependant-app"))
+
+		Expect(deleteHandler.Handle(ctx, deleteRequest("default", "dependant-app")).Allowed).Should(BeTrue())
+		// the dependant in another namespace is not allowed by the exportTo of other/cache
+		Expect(deleteHandler.Handle(ctx, deleteRequest("other", "cache")).Allowed).Should(BeTrue())
+		// the namespace is being deleted
+		Expect(deleteHandler.Handle(ctx, deleteRequest("terminating", "queue")).Allowed).Should(BeTrue())
+		Expect(deleteHandler.Handle(ctx, deleteRequest("default", "missing")).Allowed).Should(BeTrue())
+	})
+
+	It("Test Application status update with approvals", func() {
+		statusRequest := func(user string, oldStatus, newStatus string) admission.Request {
+			app := `{"apiVersion":"core.oam.dev/v1beta1","kind":"Application","metadata":{"name":"app","namespace":"default"},` +
+				`"spec":{"components":[],"workflow":{"steps":[{"name":"approve","type":"approval","properties":{"users":["alice"]}}]}},"status":%s}`
+			return admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation:   admissionv1.Update,
+					Resource:    metav1.GroupVersionResource{Group: "core.oam.dev", Version: "v1beta1", Resource: "applications"},
+					SubResource: "status",
+					UserInfo:    authenticationv1.UserInfo{Username: user},
+					Object:      runtime.RawExtension{Raw: []byte(fmt.Sprintf(app, newStatus))},
+					OldObject:   runtime.RawExtension{Raw: []byte(fmt.Sprintf(app, oldStatus))},
+				},
+			}
+		}
+		suspending := `{"workflow":{"appRevision":"app-v1","suspend":true,"terminated":false,"finished":false,` +
+			`"steps":[{"name":"approve","type":"approval","phase":"suspending"}]}}`
+		resumed := `{"workflow":{"appRevision":"app-v1","suspend":false,"terminated":false,"finished":false,` +
+			`"steps":[{"name":"approve","type":"approval","phase":"running"}]%s}}`
+		approvals := `,"approvals":[{"step":"approve","revision":"app-v1","user":"alice","time":"2026-10-16T10:00:00Z"}]`
+
+		resp := handler.Handle(ctx, statusRequest("alice", suspending, fmt.Sprintf(resumed, "")))
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Message).Should(ContainSubstring("step approve requires 1 approvals but got 0"))
+
+		resp = handler.Handle(ctx, statusRequest("bob", suspending, fmt.Sprintf(resumed, approvals)))
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Message).Should(ContainSubstring("bob can not record the approval of alice"))
+
+		Expect(handler.Handle(ctx, statusRequest("alice", suspending, fmt.Sprintf(resumed, approvals))).Allowed).Should(BeTrue())
+
+		// the status updates of the controller are not checked
+		controllerHandler := &ValidatingHandler{Client: handler.Client, Decoder: decoder, ControllerUser: "system:serviceaccount:vela-system:kubevela-vela-core"}
+		Expect(controllerHandler.Handle(ctx, statusRequest("system:serviceaccount:vela-system:kubevela-vela-core", suspending, fmt.Sprintf(resumed, ""))).Allowed).Should(BeTrue())
+		Expect(controllerHandler.Handle(ctx, statusRequest("alice", suspending, fmt.Sprintf(resumed, ""))).Allowed).Should(BeFalse())
+	})
 })
@@ -20,12 +20,15 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/kubevela/pkg/controller/sharding"
 	"github.com/kubevela/pkg/util/singleton"
 	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/klog/v2"
@@ -36,6 +39,7 @@ import (
 	"github.com/oam-dev/kubevela/pkg/appfile"
 	"github.com/oam-dev/kubevela/pkg/features"
 	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/policy"
 )
 
 // ValidateWorkflow validates the Application workflow
@@ -452,6 +456,14 @@ func (h *ValidatingHandler) ValidateAnnotations(_ context.Context, app *v1beta1.
 	return annotationsErrs
 }
 
+// ValidateAppDependencies validates the dependencies declared by the app-dependency policy
+func (h *ValidatingHandler) ValidateAppDependencies(_ context.Context, app *v1beta1.Application) field.ErrorList {
+	if _, err := policy.GetAppDependencies(app); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "policies"), app.Name, err.Error())}
+	}
+	return nil
+}
+
 // ValidateCreate validates the Application on creation
 func (h *ValidatingHandler) ValidateCreate(ctx context.Context, app *v1beta1.Application, req admission.Request) field.ErrorList {
 	var errs field.ErrorList
@@ -459,6 +471,7 @@ func (h *ValidatingHandler) ValidateCreate(ctx context.Context, app *v1beta1.App
 	errs = append(errs, h.ValidateAnnotations(ctx, app)...)
 	errs = append(errs, h.ValidateDefinitionPermissions(ctx, app, req)...)
 	errs = append(errs, h.ValidateWorkflow(ctx, app)...)
+	errs = append(errs, h.ValidateAppDependencies(ctx, app)...)
 	errs = append(errs, h.ValidateComponents(ctx, app)...)
 	return errs
 }
@@ -469,3 +482,38 @@ func (h *ValidatingHandler) ValidateUpdate(ctx context.Context, newApp, oldApp *
 	errs = append(errs, h.ValidateImmutableFields(ctx, newApp, oldApp)...)
 	return errs
 }
+
+// ValidateDelete rejects deleting the Application while other Applications depend on it through the app-dependency policy.
+// The Applications in a namespace being deleted are always allowed to be deleted.
+func (h *ValidatingHandler) ValidateDelete(ctx context.Context, namespace, name string) field.ErrorList {
+	fp := field.NewPath("metadata", "name")
+	ns := &corev1.Namespace{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return field.ErrorList{field.InternalError(fp, err)}
+	}
+	if ns.DeletionTimestamp != nil {
+		return nil
+	}
+	app := &v1beta1.Application{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, app); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return field.ErrorList{field.InternalError(fp, err)}
+	}
+	dependants, err := policy.ListAppDependants(ctx, h.Client, app)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fp, err)}
+	}
+	if len(dependants) == 0 {
+		return nil
+	}
+	names := make([]string, len(dependants))
+	for i, dependant := range dependants {
+		names[i] = dependant.String()
+	}
+	return field.ErrorList{field.Forbidden(fp, fmt.Sprintf("application is depended on by %s, remove the app-dependency policies depending on it first", strings.Join(names, ", ")))}
+}
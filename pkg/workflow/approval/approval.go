@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wfTypesv1alpha1 "github.com/kubevela/pkg/apis/oam/v1alpha1"
+	workflowv1alpha1 "github.com/kubevela/workflow/api/v1alpha1"
+	wfUtils "github.com/kubevela/workflow/pkg/utils"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/auth"
+)
+
+// StepType is the type of the workflow step waiting for the approvals of the approvers
+const StepType = "approval"
+
+// Spec is the properties of the approval step
+type Spec struct {
+	// Users the users allowed to approve the step
+	Users []string `json:"users,omitempty"`
+	// Groups the groups whose members are allowed to approve the step
+	Groups []string `json:"groups,omitempty"`
+	// Quorum the number of the distinct approvers required, default to 1
+	Quorum int `json:"quorum,omitempty"`
+}
+
+// Subjects returns the rbac subjects allowed to approve the step
+func (spec *Spec) Subjects() []rbacv1.Subject {
+	var subjects []rbacv1.Subject
+	for _, user := range spec.Users {
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: user})
+	}
+	for _, group := range spec.Groups {
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: group})
+	}
+	return subjects
+}
+
+// GetQuorum returns the number of the distinct approvers required
+func (spec *Spec) GetQuorum() int {
+	if spec.Quorum < 1 {
+		return 1
+	}
+	return spec.Quorum
+}
+
+// GetSpec returns the properties of the approval step in the workflow of the application,
+// the steps of the referenced Workflow are used if the workflow is set by ref
+func GetSpec(ctx context.Context, cli client.Reader, app *v1beta1.Application, stepName string) (*Spec, error) {
+	var steps []wfTypesv1alpha1.WorkflowStep
+	if app.Spec.Workflow != nil {
+		steps = app.Spec.Workflow.Steps
+		if app.Spec.Workflow.Ref != "" {
+			wf := &wfTypesv1alpha1.Workflow{}
+			if err := cli.Get(ctx, types.NamespacedName{Namespace: app.Namespace, Name: app.Spec.Workflow.Ref}, wf); err != nil {
+				return nil, errors.Wrapf(err, "failed to get workflow %s", app.Spec.Workflow.Ref)
+			}
+			steps = wf.Steps
+		}
+	}
+	for _, step := range steps {
+		candidates := append([]wfTypesv1alpha1.WorkflowStepBase{step.WorkflowStepBase}, step.SubSteps...)
+		for _, candidate := range candidates {
+			if candidate.Name != stepName {
+				continue
+			}
+			if candidate.Type != StepType {
+				return nil, fmt.Errorf("step %s is not an approval step", stepName)
+			}
+			spec := &Spec{}
+			if candidate.Properties != nil && len(candidate.Properties.Raw) > 0 {
+				if err := json.Unmarshal(candidate.Properties.Raw, spec); err != nil {
+					return nil, errors.Wrapf(err, "invalid properties of approval step %s", stepName)
+				}
+			}
+			if len(spec.Subjects()) == 0 {
+				return nil, fmt.Errorf("approval step %s allows nobody to approve, users or groups must be specified", stepName)
+			}
+			return spec, nil
+		}
+	}
+	return nil, fmt.Errorf("can not find step %s", stepName)
+}
+
+// stepsInPhase returns the approval steps in the workflow status in one of the phases. If stepName
+// is set, only the step itself, or the sub-steps of it if it is a step group, are returned.
+func stepsInPhase(status *common.WorkflowStatus, stepName string, phases ...workflowv1alpha1.WorkflowStepPhase) []string {
+	var steps []string
+	for _, step := range status.Steps {
+		included := stepName == "" || step.Name == stepName
+		for _, sub := range step.SubStepsStatus {
+			if (included || sub.Name == stepName) && sub.Type == StepType && slices.Contains(phases, sub.Phase) {
+				steps = append(steps, sub.Name)
+			}
+		}
+		if included && step.Type == StepType && slices.Contains(phases, step.Phase) {
+			steps = append(steps, step.Name)
+		}
+	}
+	return steps
+}
+
+// WaitingSteps returns the approval steps suspending in the workflow. If stepName is set, only the
+// step itself, or the sub-steps of it if it is a step group, are returned.
+func WaitingSteps(status *common.WorkflowStatus, stepName string) []string {
+	return stepsInPhase(status, stepName, workflowv1alpha1.WorkflowStepPhaseSuspending)
+}
+
+// Count returns the number of the distinct approvers allowed by the spec who approved the step in
+// the current run of the workflow. The approvals of the previous runs are kept for audit only.
+func Count(status *common.WorkflowStatus, stepName string, spec *Spec) int {
+	subjects := spec.Subjects()
+	approvers := map[string]struct{}{}
+	for _, approval := range status.Approvals {
+		if approval.Step != stepName || approval.Revision != status.AppRevision {
+			continue
+		}
+		if identity := (&auth.Identity{User: approval.User, Groups: approval.Groups}); identity.MatchAny(subjects) {
+			approvers[approval.User] = struct{}{}
+		}
+	}
+	return len(approvers)
+}
+
+// Check checks if the approval steps to be resumed have got enough approvals. If stepName is
+// empty, all the approval steps suspending in the workflow are checked.
+func Check(ctx context.Context, cli client.Reader, app *v1beta1.Application, stepName string) error {
+	if app.Status.Workflow == nil {
+		return nil
+	}
+	for _, step := range WaitingSteps(app.Status.Workflow, stepName) {
+		if err := checkQuorum(ctx, cli, app, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkQuorum(ctx context.Context, cli client.Reader, app *v1beta1.Application, step string) error {
+	spec, err := GetSpec(ctx, cli, app, step)
+	if err != nil {
+		return err
+	}
+	if approvals := Count(app.Status.Workflow, step, spec); approvals < spec.GetQuorum() {
+		return fmt.Errorf("step %s requires %d approvals but got %d, approve it by 'vela workflow approve %s --step %s'",
+			step, spec.GetQuorum(), approvals, app.Name, step)
+	}
+	return nil
+}
+
+// Enforce suspends the approval steps resumed without enough approvals again, so the workflow does
+// not continue past them however the status is modified. The names of the suspended steps are returned.
+func Enforce(ctx context.Context, cli client.Reader, app *v1beta1.Application) ([]string, error) {
+	status := app.Status.Workflow
+	if status == nil || status.Finished || status.Terminated {
+		return nil, nil
+	}
+	approved := func(step string) (bool, error) {
+		spec, err := GetSpec(ctx, cli, app, step)
+		if err != nil {
+			return false, err
+		}
+		return Count(status, step, spec) >= spec.GetQuorum(), nil
+	}
+	var suspended []string
+	for i, step := range status.Steps {
+		for j, sub := range step.SubStepsStatus {
+			if sub.Type != StepType || !isResumed(sub.Phase) {
+				continue
+			}
+			ok, err := approved(sub.Name)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				wfUtils.OperateSteps(status.Steps, i, j, workflowv1alpha1.WorkflowStepPhaseSuspending)
+				status.Steps[i].Phase = workflowv1alpha1.WorkflowStepPhaseSuspending
+				suspended = append(suspended, sub.Name)
+			}
+		}
+		if step.Type != StepType || !isResumed(step.Phase) {
+			continue
+		}
+		ok, err := approved(step.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			wfUtils.OperateSteps(status.Steps, i, -1, workflowv1alpha1.WorkflowStepPhaseSuspending)
+			suspended = append(suspended, step.Name)
+		}
+	}
+	if len(suspended) > 0 {
+		status.Suspend = true
+	}
+	return suspended, nil
+}
+
+func isResumed(phase workflowv1alpha1.WorkflowStepPhase) bool {
+	return phase == workflowv1alpha1.WorkflowStepPhaseRunning || phase == workflowv1alpha1.WorkflowStepPhaseSucceeded
+}
+
+// ValidateStatusUpdate validates the approvals in the status of the application updated by the user.
+// The new approvals must be recorded by the approvers themselves for the steps waiting in the current
+// run, and the approval steps can only be resumed once they have got enough approvals.
+func ValidateStatusUpdate(ctx context.Context, cli client.Reader, app, oldApp *v1beta1.Application, user authenticationv1.UserInfo) error {
+	status, oldStatus := app.Status.Workflow, oldApp.Status.Workflow
+	if status == nil || oldStatus == nil {
+		return nil
+	}
+	recorded := map[approvalKey]struct{}{}
+	for _, approval := range oldStatus.Approvals {
+		recorded[keyOf(approval)] = struct{}{}
+	}
+	waiting := WaitingSteps(oldStatus, "")
+	identity := &auth.Identity{User: user.Username, Groups: user.Groups}
+	for _, approval := range status.Approvals {
+		if _, found := recorded[keyOf(approval)]; found {
+			continue
+		}
+		if approval.User != user.Username {
+			return fmt.Errorf("%s can not record the approval of %s", user.Username, approval.User)
+		}
+		for _, group := range approval.Groups {
+			if !slices.Contains(user.Groups, group) {
+				return fmt.Errorf("%s is not a member of group %s recorded in the approval", user.Username, group)
+			}
+		}
+		if approval.Revision != status.AppRevision || status.AppRevision != oldStatus.AppRevision || !slices.Contains(waiting, approval.Step) {
+			return fmt.Errorf("step %s is not an approval step waiting for approval", approval.Step)
+		}
+		spec, err := GetSpec(ctx, cli, app, approval.Step)
+		if err != nil {
+			return err
+		}
+		if !identity.MatchAny(spec.Subjects()) {
+			return fmt.Errorf("%s is not allowed to approve step %s", user.Username, approval.Step)
+		}
+	}
+	if status.AppRevision != oldStatus.AppRevision {
+		return nil
+	}
+	resumed := stepsInPhase(status, "", workflowv1alpha1.WorkflowStepPhaseRunning, workflowv1alpha1.WorkflowStepPhaseSucceeded)
+	for _, step := range waiting {
+		if !slices.Contains(resumed, step) {
+			continue
+		}
+		if err := checkQuorum(ctx, cli, app, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// approvalKey identifies the approval regardless of the groups, the comment and the time
+type approvalKey struct {
+	step, user, revision string
+}
+
+func keyOf(approval common.WorkflowStepApproval) approvalKey {
+	return approvalKey{step: approval.Step, user: approval.User, revision: approval.Revision}
+}
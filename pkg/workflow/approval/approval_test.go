@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wfTypesv1alpha1 "github.com/kubevela/pkg/apis/oam/v1alpha1"
+	workflowv1alpha1 "github.com/kubevela/workflow/api/v1alpha1"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	utilcommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newTestApp(phase workflowv1alpha1.WorkflowStepPhase, approvals ...common.WorkflowStepApproval) *v1beta1.Application {
+	return &v1beta1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: v1beta1.ApplicationSpec{
+			Workflow: &v1beta1.Workflow{Steps: []wfTypesv1alpha1.WorkflowStep{{
+				WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{Name: "approve-release", Type: StepType,
+					Properties: &runtime.RawExtension{Raw: []byte(`{"users":["alice","bob"],"groups":["sre"],"quorum":2}`)}},
+			}, {
+				WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{Name: "deploy", Type: "deploy"},
+			}}},
+		},
+		Status: common.AppStatus{Workflow: &common.WorkflowStatus{
+			AppRevision: "app-v2",
+			Suspend:     phase == workflowv1alpha1.WorkflowStepPhaseSuspending,
+			Steps: []workflowv1alpha1.WorkflowStepStatus{{
+				StepStatus: workflowv1alpha1.StepStatus{Name: "approve-release", Type: StepType, Phase: phase},
+			}},
+			Approvals: approvals,
+		}},
+	}
+}
+
+func TestCount(t *testing.T) {
+	r := require.New(t)
+	app := newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending,
+		common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v1", User: "alice"},
+		common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v2", User: "alice"},
+		common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v2", User: "alice"},
+		common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v2", User: "carol"},
+		common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v2", User: "dave", Groups: []string{"sre"}},
+	)
+	spec, err := GetSpec(context.Background(), nil, app, "approve-release")
+	r.NoError(err)
+	// the approval of the previous run, the duplicated one and the one of carol not allowed are not counted
+	r.Equal(2, Count(app.Status.Workflow, "approve-release", spec))
+}
+
+func TestGetSpecWithoutApprovers(t *testing.T) {
+	app := newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending)
+	app.Spec.Workflow.Steps[0].Properties = &runtime.RawExtension{Raw: []byte(`{"quorum":1}`)}
+	_, err := GetSpec(context.Background(), nil, app, "approve-release")
+	require.ErrorContains(t, err, "approval step approve-release allows nobody to approve")
+}
+
+func TestEnforce(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).Build()
+
+	t.Run("resumed without enough approvals", func(t *testing.T) {
+		r := require.New(t)
+		app := newTestApp(workflowv1alpha1.WorkflowStepPhaseRunning,
+			common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v1", User: "alice"},
+			common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v1", User: "bob"},
+			common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v2", User: "alice"},
+		)
+		suspended, err := Enforce(ctx, cli, app)
+		r.NoError(err)
+		r.Equal([]string{"approve-release"}, suspended)
+		r.True(app.Status.Workflow.Suspend)
+		r.Equal(workflowv1alpha1.WorkflowStepPhaseSuspending, app.Status.Workflow.Steps[0].Phase)
+	})
+
+	t.Run("resumed with enough approvals", func(t *testing.T) {
+		r := require.New(t)
+		app := newTestApp(workflowv1alpha1.WorkflowStepPhaseSucceeded,
+			common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v2", User: "alice"},
+			common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v2", User: "bob"},
+		)
+		suspended, err := Enforce(ctx, cli, app)
+		r.NoError(err)
+		r.Empty(suspended)
+		r.Equal(workflowv1alpha1.WorkflowStepPhaseSucceeded, app.Status.Workflow.Steps[0].Phase)
+	})
+
+	t.Run("sub-step resumed without approvals", func(t *testing.T) {
+		r := require.New(t)
+		app := newTestApp(workflowv1alpha1.WorkflowStepPhaseRunning)
+		app.Spec.Workflow.Steps = []wfTypesv1alpha1.WorkflowStep{{
+			WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{Name: "group", Type: "step-group"},
+			SubSteps: []wfTypesv1alpha1.WorkflowStepBase{
+				{Name: "approve-db", Type: StepType, Properties: &runtime.RawExtension{Raw: []byte(`{"groups":["dba"]}`)}},
+				{Name: "deploy-db", Type: "deploy"},
+			},
+		}}
+		app.Status.Workflow.Steps = []workflowv1alpha1.WorkflowStepStatus{{
+			StepStatus: workflowv1alpha1.StepStatus{Name: "group", Type: "step-group", Phase: workflowv1alpha1.WorkflowStepPhaseRunning},
+			SubStepsStatus: []workflowv1alpha1.StepStatus{
+				{Name: "approve-db", Type: StepType, Phase: workflowv1alpha1.WorkflowStepPhaseRunning},
+				{Name: "deploy-db", Type: "deploy", Phase: workflowv1alpha1.WorkflowStepPhaseSucceeded},
+			},
+		}}
+		suspended, err := Enforce(ctx, cli, app)
+		r.NoError(err)
+		r.Equal([]string{"approve-db"}, suspended)
+		r.Equal(workflowv1alpha1.WorkflowStepPhaseSuspending, app.Status.Workflow.Steps[0].Phase)
+		r.Equal(workflowv1alpha1.WorkflowStepPhaseSuspending, app.Status.Workflow.Steps[0].SubStepsStatus[0].Phase)
+		r.Equal(workflowv1alpha1.WorkflowStepPhaseSucceeded, app.Status.Workflow.Steps[0].SubStepsStatus[1].Phase)
+	})
+
+	t.Run("finished workflow", func(t *testing.T) {
+		r := require.New(t)
+		app := newTestApp(workflowv1alpha1.WorkflowStepPhaseSucceeded)
+		app.Status.Workflow.Finished = true
+		suspended, err := Enforce(ctx, cli, app)
+		r.NoError(err)
+		r.Empty(suspended)
+	})
+}
+
+func TestValidateStatusUpdate(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(utilcommon.Scheme).Build()
+	alice := authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev"}}
+	approve := func(user string, groups ...string) common.WorkflowStepApproval {
+		return common.WorkflowStepApproval{Step: "approve-release", Revision: "app-v2", User: user, Groups: groups, Time: metav1.Now()}
+	}
+
+	testCases := map[string]struct {
+		old    *v1beta1.Application
+		new    *v1beta1.Application
+		user   authenticationv1.UserInfo
+		reason string
+	}{
+		"record own approval": {
+			old:  newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending),
+			new:  newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending, approve("alice", "dev")),
+			user: alice,
+		},
+		"record approval of another user": {
+			old:    newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending),
+			new:    newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending, approve("bob")),
+			user:   alice,
+			reason: "alice can not record the approval of bob",
+		},
+		"record forged group": {
+			old:    newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending),
+			new:    newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending, approve("alice", "sre")),
+			user:   alice,
+			reason: "alice is not a member of group sre",
+		},
+		"record approval not allowed": {
+			old:    newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending),
+			new:    newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending, approve("carol")),
+			user:   authenticationv1.UserInfo{Username: "carol"},
+			reason: "carol is not allowed to approve step approve-release",
+		},
+		"resume without enough approvals": {
+			old:    newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending),
+			new:    newTestApp(workflowv1alpha1.WorkflowStepPhaseRunning, approve("alice")),
+			user:   alice,
+			reason: "step approve-release requires 2 approvals but got 1",
+		},
+		"resume with enough approvals": {
+			old:  newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending, approve("alice")),
+			new:  newTestApp(workflowv1alpha1.WorkflowStepPhaseRunning, approve("alice"), approve("bob")),
+			user: authenticationv1.UserInfo{Username: "bob"},
+		},
+		"terminate without approvals": {
+			old:  newTestApp(workflowv1alpha1.WorkflowStepPhaseSuspending),
+			new:  newTestApp(workflowv1alpha1.WorkflowStepPhaseFailed),
+			user: alice,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateStatusUpdate(ctx, cli, tc.new, tc.old, tc.user)
+			if tc.reason == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/auth"
+	"github.com/oam-dev/kubevela/pkg/workflow/approval"
+)
+
+// ApproveWorkflowStep records the approval of the identity to the approval step, and resumes the
+// step once the approvals from distinct approvers reach the quorum. If stepName is empty, the only
+// approval step waiting for approval is approved.
+func ApproveWorkflowStep(ctx context.Context, kubecli client.Client, w io.Writer, app *v1beta1.Application, stepName string, identity *auth.Identity, comment string) error {
+	if app.Status.Workflow == nil {
+		return fmt.Errorf("the workflow in application is not running")
+	}
+	if app.Status.Workflow.Terminated {
+		return fmt.Errorf("can not approve a terminated workflow")
+	}
+	if identity == nil || identity.User == "" {
+		return fmt.Errorf("the user to approve is unknown")
+	}
+	waiting := approval.WaitingSteps(app.Status.Workflow, "")
+	switch {
+	case stepName != "":
+		found := false
+		for _, step := range waiting {
+			found = found || step == stepName
+		}
+		if !found {
+			return fmt.Errorf("step %s is not an approval step waiting for approval", stepName)
+		}
+	case len(waiting) == 0:
+		return fmt.Errorf("no approval step is waiting for approval in application %s", app.Name)
+	case len(waiting) > 1:
+		return fmt.Errorf("more than one approval step is waiting for approval, please specify one of them: %s", strings.Join(waiting, ", "))
+	default:
+		stepName = waiting[0]
+	}
+
+	spec, err := approval.GetSpec(ctx, kubecli, app, stepName)
+	if err != nil {
+		return err
+	}
+	subjects := spec.Subjects()
+	if len(subjects) == 0 {
+		return fmt.Errorf("no approvers are declared in approval step %s", stepName)
+	}
+	if !identity.MatchAny(subjects) {
+		return fmt.Errorf("%s is not allowed to approve step %s", identity.User, stepName)
+	}
+	for _, approved := range app.Status.Workflow.Approvals {
+		if approved.Step == stepName && approved.User == identity.User && approved.Revision == app.Status.Workflow.AppRevision {
+			return fmt.Errorf("%s has already approved step %s", identity.User, stepName)
+		}
+	}
+
+	base := app.DeepCopy()
+	app.Status.Workflow.Approvals = append(app.Status.Workflow.Approvals, common.WorkflowStepApproval{
+		Step:     stepName,
+		Revision: app.Status.Workflow.AppRevision,
+		User:     identity.User,
+		Groups:   identity.Groups,
+		Comment:  comment,
+		Time:     metav1.Now(),
+	})
+	approvals, quorum := approval.Count(app.Status.Workflow, stepName, spec), spec.GetQuorum()
+	resumed := approvals >= quorum
+	if resumed {
+		if err := resumeSteps(app, stepName); err != nil {
+			return err
+		}
+	}
+	// the approvals are patched with optimistic lock, to not lose the ones approved concurrently
+	if err := kubecli.Status().Patch(ctx, app, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return errors.Wrapf(err, "failed to record the approval of step %s", stepName)
+	}
+	if resumed {
+		return writeOutputF(w, "Successfully approve step %s of workflow %s with %d/%d approvals, the step is resumed\n", stepName, app.Name, approvals, quorum)
+	}
+	return writeOutputF(w, "Successfully approve step %s of workflow %s with %d/%d approvals\n", stepName, app.Name, approvals, quorum)
+}
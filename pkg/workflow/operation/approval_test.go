@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wfTypesv1alpha1 "github.com/kubevela/pkg/apis/oam/v1alpha1"
+	workflowv1alpha1 "github.com/kubevela/workflow/api/v1alpha1"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/auth"
+	common2 "github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/workflow/approval"
+)
+
+func newApprovalTestApp(props string) *v1beta1.Application {
+	return &v1beta1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: v1beta1.ApplicationSpec{
+			Workflow: &v1beta1.Workflow{Steps: []wfTypesv1alpha1.WorkflowStep{{
+				WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{Name: "approve-release", Type: approval.StepType, Properties: &runtime.RawExtension{Raw: []byte(props)}},
+			}, {
+				WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{Name: "deploy", Type: "deploy"},
+			}}},
+		},
+		Status: common.AppStatus{Workflow: &common.WorkflowStatus{
+			Suspend: true,
+			Steps: []workflowv1alpha1.WorkflowStepStatus{{
+				StepStatus: workflowv1alpha1.StepStatus{Name: "approve-release", Type: approval.StepType, Phase: workflowv1alpha1.WorkflowStepPhaseSuspending},
+			}},
+		}},
+	}
+}
+
+func newApprovalTestClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(common2.Scheme).WithStatusSubresource(&v1beta1.Application{}).WithObjects(objs...).Build()
+}
+
+func TestApproveWorkflowStep(t *testing.T) {
+	ctx := context.Background()
+	alice := &auth.Identity{User: "alice"}
+	bob := &auth.Identity{User: "bob", Groups: []string{"sre"}}
+	carol := &auth.Identity{User: "carol", Groups: []string{"dev"}}
+
+	t.Run("resume after quorum", func(t *testing.T) {
+		r := require.New(t)
+		cli := newApprovalTestClient(newApprovalTestApp(`{"users":["alice"],"groups":["sre"],"quorum":2}`))
+		app := &v1beta1.Application{}
+		r.NoError(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "app"}, app))
+		r.ErrorContains(ResumeWorkflow(ctx, cli, app.DeepCopy(), ""), "step approve-release requires 2 approvals but got 0")
+
+		out := &bytes.Buffer{}
+		r.NoError(ApproveWorkflowStep(ctx, cli, out, app, "", alice, "lgtm"))
+		r.Equal("Successfully approve step approve-release of workflow app with 1/2 approvals\n", out.String())
+		r.ErrorContains(ApproveWorkflowStep(ctx, cli, out, app, "approve-release", alice, ""), "alice has already approved step approve-release")
+		r.ErrorContains(ApproveWorkflowStep(ctx, cli, out, app, "approve-release", carol, ""), "carol is not allowed to approve step approve-release")
+		r.ErrorContains(ResumeWorkflow(ctx, cli, app.DeepCopy(), "approve-release"), "requires 2 approvals but got 1")
+
+		r.NoError(ApproveWorkflowStep(ctx, cli, nil, app, "approve-release", bob, ""))
+		r.NoError(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "app"}, app))
+		r.False(app.Status.Workflow.Suspend)
+		r.Equal(workflowv1alpha1.WorkflowStepPhaseRunning, app.Status.Workflow.Steps[0].Phase)
+		r.Len(app.Status.Workflow.Approvals, 2)
+		r.Equal("alice", app.Status.Workflow.Approvals[0].User)
+		r.Equal("lgtm", app.Status.Workflow.Approvals[0].Comment)
+		r.Equal([]string{"sre"}, app.Status.Workflow.Approvals[1].Groups)
+		r.ErrorContains(ApproveWorkflowStep(ctx, cli, nil, app, "", alice, ""), "no approval step is waiting for approval in application app")
+	})
+
+	t.Run("approve stale application", func(t *testing.T) {
+		r := require.New(t)
+		cli := newApprovalTestClient(newApprovalTestApp(`{"users":["alice","bob"],"quorum":2}`))
+		app := &v1beta1.Application{}
+		r.NoError(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "app"}, app))
+		stale := app.DeepCopy()
+		r.NoError(ApproveWorkflowStep(ctx, cli, nil, app, "", alice, ""))
+		r.ErrorContains(ApproveWorkflowStep(ctx, cli, nil, stale, "", bob, ""), "failed to record the approval of step approve-release")
+	})
+
+	t.Run("approval step in step group of referenced workflow", func(t *testing.T) {
+		r := require.New(t)
+		app := newApprovalTestApp("")
+		app.Spec.Workflow = &v1beta1.Workflow{Ref: "release"}
+		app.Status.Workflow.Steps = []workflowv1alpha1.WorkflowStepStatus{{
+			StepStatus: workflowv1alpha1.StepStatus{Name: "group", Type: "step-group", Phase: workflowv1alpha1.WorkflowStepPhaseSuspending},
+			SubStepsStatus: []workflowv1alpha1.StepStatus{
+				{Name: "approve-db", Type: approval.StepType, Phase: workflowv1alpha1.WorkflowStepPhaseSuspending},
+				{Name: "approve-web", Type: approval.StepType, Phase: workflowv1alpha1.WorkflowStepPhaseSuspending},
+			},
+		}}
+		wf := &wfTypesv1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "default"},
+			Steps: []wfTypesv1alpha1.WorkflowStep{{
+				WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{Name: "group", Type: "step-group"},
+				SubSteps: []wfTypesv1alpha1.WorkflowStepBase{
+					{Name: "approve-db", Type: approval.StepType, Properties: &runtime.RawExtension{Raw: []byte(`{"groups":["dba"]}`)}},
+					{Name: "approve-web", Type: approval.StepType},
+				},
+			}},
+		}
+		cli := newApprovalTestClient(app, wf)
+		r.NoError(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "app"}, app))
+		r.ErrorContains(ApproveWorkflowStep(ctx, cli, nil, app, "", alice, ""), "more than one approval step is waiting for approval, please specify one of them: approve-db, approve-web")
+		r.ErrorContains(ApproveWorkflowStep(ctx, cli, nil, app, "approve-web", alice, ""), "no approvers are declared in approval step approve-web")
+		r.ErrorContains(ResumeWorkflow(ctx, cli, app.DeepCopy(), "group"), "step approve-db requires 1 approvals but got 0")
+
+		r.NoError(ApproveWorkflowStep(ctx, cli, nil, app, "approve-db", &auth.Identity{User: "dave", Groups: []string{"dba"}}, ""))
+		r.NoError(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "app"}, app))
+		r.Equal(workflowv1alpha1.WorkflowStepPhaseRunning, app.Status.Workflow.Steps[0].SubStepsStatus[0].Phase)
+		r.Equal(workflowv1alpha1.WorkflowStepPhaseSuspending, app.Status.Workflow.Steps[0].SubStepsStatus[1].Phase)
+		r.ErrorContains(ApproveWorkflowStep(ctx, cli, nil, app, "approve-db", alice, ""), "step approve-db is not an approval step waiting for approval")
+	})
+}
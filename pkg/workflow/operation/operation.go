@@ -36,12 +36,10 @@ import (
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/pkg/appfile"
 	"github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/application"
-	"github.com/oam-dev/kubevela/pkg/controller/utils"
 	"github.com/oam-dev/kubevela/pkg/oam"
-	"github.com/oam-dev/kubevela/pkg/resourcetracker"
 	"github.com/oam-dev/kubevela/pkg/rollout"
-	kubevelaapp "github.com/oam-dev/kubevela/pkg/utils/app"
 	errors3 "github.com/oam-dev/kubevela/pkg/utils/errors"
+	"github.com/oam-dev/kubevela/pkg/workflow/approval"
 )
 
 // NewApplicationWorkflowOperator get an workflow operator with k8sClient, ioWriter(optional, useful for cli) and application
@@ -160,6 +158,11 @@ func (wo appWorkflowOperator) Resume(ctx context.Context) error {
 	var rolloutResumed bool
 	var err error
 
+	if app.Status.Workflow.Suspend {
+		if err = approval.Check(ctx, wo.cli, app, ""); err != nil {
+			return err
+		}
+	}
 	if rolloutResumed, err = rollout.ResumeRollout(ctx, wo.cli, app, wo.outputWriter); err != nil {
 		return err
 	}
@@ -202,6 +205,17 @@ func (wo appWorkflowStepOperator) Resume(ctx context.Context, step string) error
 
 // ResumeWorkflow resume workflow
 func ResumeWorkflow(ctx context.Context, kubecli client.Client, app *v1beta1.Application, stepName string) error {
+	if err := approval.Check(ctx, kubecli, app, stepName); err != nil {
+		return err
+	}
+	if err := resumeSteps(app, stepName); err != nil {
+		return err
+	}
+	return kubecli.Status().Patch(ctx, app, client.Merge)
+}
+
+// resumeSteps resumes the suspending steps in the workflow status, or the given step only
+func resumeSteps(app *v1beta1.Application, stepName string) error {
 	app.Status.Workflow.Suspend = false
 	steps := app.Status.Workflow.Steps
 	found := stepName == ""
@@ -234,7 +248,7 @@ func ResumeWorkflow(ctx context.Context, kubecli client.Client, app *v1beta1.App
 	if !found {
 		return fmt.Errorf("can not find step %s", stepName)
 	}
-	return kubecli.Status().Patch(ctx, app, client.Merge)
+	return nil
 }
 
 // Rollback a running in middle state workflow.
@@ -285,94 +299,19 @@ func (wo appWorkflowOperator) Rollback(ctx context.Context) error {
 		return errors.Errorf("failed to find previous succeeded revision for application %s/%s", app.Namespace, app.Name)
 	}
 	publishVersion := oam.GetPublishVersion(rev)
-	revisionNumber, err := utils.ExtractRevision(rev.Name)
-	if err != nil {
-		return errors.Wrapf(err, "failed to extract revision number from revision %s", rev.Name)
-	}
-	_, currentRT, historyRTs, _, err := resourcetracker.ListApplicationResourceTrackers(ctx, wo.cli, app)
-	if err != nil {
-		return errors.Wrapf(err, "failed to list resource trackers for application %s/%s", app.Namespace, app.Name)
-	}
-	var matchRT *v1beta1.ResourceTracker
-	for _, rt := range append(historyRTs, currentRT) {
-		if rt == nil {
-			continue
-		}
-		labels := rt.GetLabels()
-		if labels != nil && labels[oam.LabelAppRevision] == rev.Name {
-			matchRT = rt.DeepCopy()
-		}
-	}
-	if matchRT == nil {
-		return errors.Errorf("cannot find resource tracker for previous revision %s, unable to rollback", rev.Name)
-	}
-	if matchRT.DeletionTimestamp != nil {
-		return errors.Errorf("previous revision %s is being recycled, unable to rollback", rev.Name)
-	}
-	err = wo.writeOutput("Find succeeded application revision %s (PublishVersion: %s) to rollback.\n")
+	err = writeOutputF(wo.outputWriter, "Find succeeded application revision %s (PublishVersion: %s) to rollback.\n", rev.Name, publishVersion)
 	if err != nil {
 		return err
 	}
-	appKey := client.ObjectKeyFromObject(app)
-	// rollback application spec and freeze
-	controllerRequirement, err := kubevelaapp.FreezeApplication(ctx, wo.cli, app, func() {
-		app.Spec = rev.Spec.Application.Spec
-		oam.SetPublishVersion(app, publishVersion)
+	// unlike the auto-rollback policy, the rollback fails if the resources of the revision
+	// have been recycled, instead of running the workflow again
+	rollback, err := application.RollbackToRevision(ctx, wo.cli, app, rev, application.RollbackOptions{
+		PublishVersion: publishVersion,
+		Output:         wo.outputWriter,
 	})
 	if err != nil {
-		return errors.Wrapf(err, "failed to rollback application spec to revision %s (PublishVersion: %s)", rev.Name, publishVersion)
-	}
-	err = writeOutputF(wo.outputWriter, "Application spec rollback successfully.\n")
-	if err != nil {
-		return err
-	}
-	// rollback application status
-	if err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		if err = wo.cli.Get(ctx, appKey, app); err != nil {
-			return err
-		}
-		app.Status.Workflow = rev.Status.Workflow
-		app.Status.Services = []common.ApplicationComponentStatus{}
-		app.Status.AppliedResources = []common.ClusterObjectReference{}
-		for _, rsc := range matchRT.Spec.ManagedResources {
-			app.Status.AppliedResources = append(app.Status.AppliedResources, rsc.ClusterObjectReference)
-		}
-		app.Status.LatestRevision = &common.Revision{
-			Name:         rev.Name,
-			Revision:     int64(revisionNumber),
-			RevisionHash: rev.GetLabels()[oam.LabelAppRevisionHash],
-		}
-		return wo.cli.Status().Update(ctx, app)
-	}); err != nil {
-		return errors.Wrapf(err, "failed to rollback application status to revision %s (PublishVersion: %s)", rev.Name, publishVersion)
-	}
-
-	err = writeOutputF(wo.outputWriter, "Application status rollback successfully.\n")
-	if err != nil {
-		return err
-	}
-	// update resource tracker generation
-	matchRTKey := client.ObjectKeyFromObject(matchRT)
-	if err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		if err = wo.cli.Get(ctx, matchRTKey, matchRT); err != nil {
-			return err
-		}
-		matchRT.Spec.ApplicationGeneration = app.Generation
-		return wo.cli.Update(ctx, matchRT)
-	}); err != nil {
-		return errors.Wrapf(err, "failed to update application generation in resource tracker")
+		return errors.WithMessagef(err, "failed to rollback application to revision %s (PublishVersion: %s)", rev.Name, publishVersion)
 	}
-
-	// unfreeze application
-	if err = kubevelaapp.UnfreezeApplication(ctx, wo.cli, app, nil, controllerRequirement); err != nil {
-		return errors.Wrapf(err, "failed to resume application to restart")
-	}
-
-	rollback, err := rollout.RollbackRollout(ctx, wo.cli, app, wo.outputWriter)
-	if err != nil {
-		return err
-	}
-
 	if rollback {
 		err = writeOutputF(wo.outputWriter, "Successfully rollback app.\n")
 		if err != nil {
@@ -405,8 +344,11 @@ func (wo appWorkflowOperator) Restart(ctx context.Context) error {
 	if status == nil {
 		return fmt.Errorf("the workflow in application is not running")
 	}
-	// reset the workflow status to restart the workflow
+	// reset the workflow status to restart the workflow, the approvals are kept for audit
 	app.Status.Workflow = nil
+	if len(status.Approvals) > 0 {
+		app.Status.Workflow = &common.WorkflowStatus{Approvals: status.Approvals}
+	}
 
 	if err := wo.cli.Status().Update(ctx, app); err != nil {
 		return err
@@ -506,14 +448,6 @@ func TerminateWorkflow(ctx context.Context, kubecli client.Client, app *v1beta1.
 	return kubecli.Status().Patch(ctx, app, client.Merge)
 }
 
-func (wo appWorkflowOperator) writeOutput(str string) error {
-	if wo.outputWriter == nil {
-		return nil
-	}
-	_, err := wo.outputWriter.Write([]byte(str))
-	return err
-}
-
 func writeOutputF(outputWriter io.Writer, format string, a ...interface{}) error {
 	if outputWriter == nil {
 		return nil
@@ -0,0 +1,35 @@
+// cloudevents.cue
+
+#Send: {
+	#do:       "send"
+	#provider: "cloudevents"
+
+	$params: {
+		// the address of the sink
+		url: string
+		// the content mode of the request
+		mode: *"structured" | "binary"
+		// the type of the event
+		type: string
+		// the source of the event, default to the path of the application
+		source?: string
+		// the subject of the event, default to the name of the application
+		subject?: string
+		// the id of the event, generated if not set
+		id?: string
+		// the data of the event
+		data?: _
+		// the key to sign the request body with HMAC-SHA256
+		secret?: string
+		// the extra header of the request
+		header?: [string]: string
+		// the times to retry after the first failed delivery
+		retries: *3 | int
+		// the wait before the first retry, doubled after each retry
+		backoff: *"1s" | string
+	}
+
+	$returns: {
+		id: string
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	cuexruntime "github.com/kubevela/pkg/cue/cuex/runtime"
+	wfContext "github.com/kubevela/workflow/pkg/context"
+	"github.com/kubevela/workflow/pkg/cue/model"
+	providertypes "github.com/kubevela/workflow/pkg/providers/types"
+
+	"github.com/oam-dev/kubevela/pkg/cloudevents"
+	oamprovidertypes "github.com/oam-dev/kubevela/pkg/workflow/providers/types"
+)
+
+const (
+	// ProviderName is provider name
+	ProviderName = "cloudevents"
+)
+
+// httpClient is the client to deliver the events, replaced in tests
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// now returns the current time, replaced in tests
+var now = time.Now
+
+// SendVars is the vars for sending a cloud event
+type SendVars struct {
+	URL     string            `json:"url"`
+	Mode    string            `json:"mode,omitempty"`
+	Type    string            `json:"type"`
+	Source  string            `json:"source,omitempty"`
+	Subject string            `json:"subject,omitempty"`
+	ID      string            `json:"id,omitempty"`
+	Data    json.RawMessage   `json:"data,omitempty"`
+	Secret  string            `json:"secret,omitempty"`
+	Header  map[string]string `json:"header,omitempty"`
+	Retries int               `json:"retries,omitempty"`
+	Backoff string            `json:"backoff,omitempty"`
+}
+
+// SendReturnVars is the return vars of sending a cloud event
+type SendReturnVars struct {
+	ID string `json:"id"`
+}
+
+// SendParams is the params for sending a cloud event
+type SendParams = oamprovidertypes.Params[SendVars]
+
+// SendReturns is the returns of sending a cloud event
+type SendReturns = oamprovidertypes.Returns[SendReturnVars]
+
+// deliveryState is the state of the delivery kept in the workflow context across the retries
+type deliveryState struct {
+	wfCtx wfContext.Context
+	paths []string
+}
+
+// newDeliveryState returns the state of the delivery in the step, nil if there is no workflow context
+func newDeliveryState(ctx context.Context, label string) *deliveryState {
+	rt := providertypes.RuntimeParamsFrom(ctx)
+	if rt.WorkflowContext == nil || rt.ProcessContext == nil {
+		return nil
+	}
+	stepID := fmt.Sprint(rt.ProcessContext.GetData(model.ContextStepSessionID))
+	return &deliveryState{wfCtx: rt.WorkflowContext, paths: []string{stepID, label, "cloudevent"}}
+}
+
+func (state *deliveryState) get(key string) string {
+	return state.wfCtx.GetMutableValue(append(state.paths, key)...)
+}
+
+func (state *deliveryState) set(key, value string) {
+	state.wfCtx.SetMutableValue(value, append(state.paths, key)...)
+}
+
+// Send sends a cloud event to the sink, the source and subject default to the application. A failed
+// delivery is not retried in place, the step waits and the event is sent again in the next execution
+// of the step after the backoff, with the same id and time, until the retries are exhausted.
+func Send(ctx context.Context, params *SendParams) (*SendReturns, error) {
+	vars := params.Params
+	var backoff time.Duration
+	if vars.Backoff != "" {
+		d, err := time.ParseDuration(vars.Backoff)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid backoff %q", vars.Backoff)
+		}
+		backoff = d
+	}
+	state := newDeliveryState(ctx, params.FieldLabel)
+	attempts := 0
+	if state != nil {
+		if next := state.get("nextAttempt"); next != "" {
+			if t, err := time.Parse(time.RFC3339Nano, next); err == nil && now().Before(t) {
+				params.Action.Wait(fmt.Sprintf("waiting to retry sending the event at %s", next))
+				return nil, nil
+			}
+		}
+		attempts, _ = strconv.Atoi(state.get("attempts"))
+	}
+	event, err := cloudevents.NewEvent(vars.Source, vars.Type, vars.Subject, nil)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		if id := state.get("id"); id != "" {
+			event.ID = id
+		}
+		if t, err := time.Parse(time.RFC3339Nano, state.get("time")); err == nil {
+			event.Time = &t
+		}
+	}
+	if vars.ID != "" {
+		event.ID = vars.ID
+	}
+	if app := params.App; app != nil {
+		if event.Source == "" {
+			event.Source = cloudevents.ApplicationSource(app.Namespace, app.Name)
+		}
+		if event.Subject == "" {
+			event.Subject = app.Name
+		}
+	}
+	if len(vars.Data) > 0 && string(vars.Data) != "null" {
+		event.DataContentType = cloudevents.ContentTypeJSON
+		event.Data = vars.Data
+	}
+	sender := &cloudevents.Sender{
+		URL:    vars.URL,
+		Mode:   vars.Mode,
+		Secret: vars.Secret,
+		Header: vars.Header,
+		Client: httpClient,
+	}
+	if err := sender.Send(ctx, event); err != nil {
+		if state == nil || !cloudevents.IsRetryable(err) || attempts >= vars.Retries {
+			return nil, err
+		}
+		attempts++
+		next := now().Add(backoff << (attempts - 1)).UTC().Format(time.RFC3339Nano)
+		state.set("id", event.ID)
+		state.set("time", event.Time.Format(time.RFC3339Nano))
+		state.set("attempts", strconv.Itoa(attempts))
+		state.set("nextAttempt", next)
+		params.Action.Wait(fmt.Sprintf("%s, retry %d/%d at %s", err.Error(), attempts, vars.Retries, next))
+		return nil, nil
+	}
+	return &SendReturns{Returns: SendReturnVars{ID: event.ID}}, nil
+}
+
+//go:embed cloudevents.cue
+var template string
+
+// GetTemplate returns the cue template.
+func GetTemplate() string {
+	return template
+}
+
+// GetProviders returns the cue providers.
+func GetProviders() map[string]cuexruntime.ProviderFn {
+	return map[string]cuexruntime.ProviderFn{
+		"send": oamprovidertypes.GenericProviderFn[SendVars, SendReturns](Send),
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfContext "github.com/kubevela/workflow/pkg/context"
+	"github.com/kubevela/workflow/pkg/cue/model"
+	"github.com/kubevela/workflow/pkg/cue/process"
+	providertypes "github.com/kubevela/workflow/pkg/providers/types"
+	"github.com/kubevela/workflow/pkg/types"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/cloudevents"
+	oamprovidertypes "github.com/oam-dev/kubevela/pkg/workflow/providers/types"
+)
+
+func TestSend(t *testing.T) {
+	r := require.New(t)
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		header = req.Header
+		body, _ = io.ReadAll(req.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	params := &SendParams{
+		Params: SendVars{
+			URL:    server.URL,
+			Mode:   cloudevents.ModeBinary,
+			Type:   "com.example.release",
+			Data:   []byte(`{"version":"v1.2.0"}`),
+			Secret: "s3cret",
+		},
+		RuntimeParams: oamprovidertypes.RuntimeParams{
+			App: &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "prod"}},
+		},
+	}
+	ret, err := Send(context.Background(), params)
+	r.NoError(err)
+	r.NotEmpty(ret.Returns.ID)
+	r.Equal(ret.Returns.ID, header.Get("ce-id"))
+	r.Equal("/namespaces/prod/applications/app", header.Get("ce-source"))
+	r.Equal("app", header.Get("ce-subject"))
+	r.Equal("com.example.release", header.Get("ce-type"))
+	r.NoError(cloudevents.VerifyRequest("s3cret", header, body, time.Now(), cloudevents.DefaultSignatureTolerance))
+	r.JSONEq(`{"version":"v1.2.0"}`, string(body))
+
+	params.Params.ID = "release-1"
+	params.Params.Source = "ci"
+	params.Params.Backoff = "soon"
+	_, err = Send(context.Background(), params)
+	r.ErrorContains(err, `invalid backoff "soon"`)
+
+	params.Params.Backoff = "10ms"
+	ret, err = Send(context.Background(), params)
+	r.NoError(err)
+	r.Equal("release-1", ret.Returns.ID)
+	r.Equal("ci", header.Get("ce-source"))
+}
+
+// testWorkflowContext keeps the mutable values of the workflow context in memory
+type testWorkflowContext struct {
+	wfContext.Context
+	values map[string]string
+}
+
+func (c *testWorkflowContext) GetMutableValue(paths ...string) string {
+	return c.values[strings.Join(paths, ".")]
+}
+
+func (c *testWorkflowContext) SetMutableValue(data string, paths ...string) {
+	c.values[strings.Join(paths, ".")] = data
+}
+
+type testAction struct {
+	types.Action
+	waiting string
+}
+
+func (act *testAction) Wait(message string) {
+	act.waiting = message
+}
+
+func TestSendRetryInNextExecution(t *testing.T) {
+	r := require.New(t)
+	var statuses = []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusAccepted}
+	var ids, times []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ids = append(ids, req.Header.Get("ce-id"))
+		times = append(times, req.Header.Get("ce-time"))
+		w.WriteHeader(statuses[len(ids)-1])
+	}))
+	defer server.Close()
+	current := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	original := now
+	now = func() time.Time { return current }
+	defer func() { now = original }()
+
+	pCtx := process.NewContext(process.ContextData{})
+	pCtx.PushData(model.ContextStepSessionID, "notify")
+	ctx := providertypes.WithRuntimeParams(context.Background(), providertypes.RuntimeParams{
+		WorkflowContext: &testWorkflowContext{values: map[string]string{}},
+		ProcessContext:  pCtx,
+	})
+	execute := func() (*SendReturns, *testAction) {
+		act := &testAction{}
+		ret, err := Send(ctx, &SendParams{
+			Params: SendVars{URL: server.URL, Mode: cloudevents.ModeBinary, Type: "com.example.release", Retries: 2, Backoff: "10s"},
+			RuntimeParams: oamprovidertypes.RuntimeParams{
+				App:        &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "prod"}},
+				Action:     act,
+				FieldLabel: "send",
+			},
+		})
+		r.NoError(err)
+		return ret, act
+	}
+
+	// the step waits instead of blocking during the backoff
+	ret, act := execute()
+	r.Nil(ret)
+	r.Equal("the sink responds with status 503 Service Unavailable, retry 1/2 at 2026-10-16T10:00:10Z", act.waiting)
+	current = current.Add(5 * time.Second)
+	_, act = execute()
+	r.Equal("waiting to retry sending the event at 2026-10-16T10:00:10Z", act.waiting)
+	r.Len(ids, 1)
+
+	current = current.Add(5 * time.Second)
+	_, act = execute()
+	r.Equal("the sink responds with status 502 Bad Gateway, retry 2/2 at 2026-10-16T10:00:30Z", act.waiting)
+	current = current.Add(20 * time.Second)
+	ret, act = execute()
+	r.Empty(act.waiting)
+	r.Len(ids, 3)
+	// the event is sent again with the same id and time
+	r.Equal(ids[0], ret.Returns.ID)
+	r.Equal([]string{ids[0], ids[0], ids[0]}, ids)
+	r.Equal([]string{times[0], times[0], times[0]}, times)
+}
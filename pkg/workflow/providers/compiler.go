@@ -33,6 +33,7 @@ import (
 	"github.com/kubevela/workflow/pkg/providers/time"
 	"github.com/kubevela/workflow/pkg/providers/util"
 
+	"github.com/oam-dev/kubevela/pkg/workflow/providers/cloudevents"
 	"github.com/oam-dev/kubevela/pkg/workflow/providers/config"
 	"github.com/oam-dev/kubevela/pkg/workflow/providers/helm"
 	"github.com/oam-dev/kubevela/pkg/workflow/providers/legacy"
@@ -40,6 +41,7 @@ import (
 	"github.com/oam-dev/kubevela/pkg/workflow/providers/multicluster"
 	"github.com/oam-dev/kubevela/pkg/workflow/providers/oam"
 	"github.com/oam-dev/kubevela/pkg/workflow/providers/query"
+	"github.com/oam-dev/kubevela/pkg/workflow/providers/rollout"
 	"github.com/oam-dev/kubevela/pkg/workflow/providers/terraform"
 )
 
@@ -68,10 +70,12 @@ var compiler = singleton.NewSingletonE[*cuex.Compiler](func() (*cuex.Compiler, e
 
 		// kubevela internal packages
 		runtime.Must(cuexruntime.NewInternalPackage("multicluster", multicluster.GetTemplate(), multicluster.GetProviders())),
+		runtime.Must(cuexruntime.NewInternalPackage("cloudevents", cloudevents.GetTemplate(), cloudevents.GetProviders())),
 		runtime.Must(cuexruntime.NewInternalPackage("config", config.GetTemplate(), config.GetProviders())),
 		runtime.Must(cuexruntime.NewInternalPackage("helm", helm.GetTemplate(), helm.GetProviders())),
 		runtime.Must(cuexruntime.NewInternalPackage("oam", oam.GetTemplate(), oam.GetProviders())),
 		runtime.Must(cuexruntime.NewInternalPackage("query", query.GetTemplate(), query.GetProviders())),
+		runtime.Must(cuexruntime.NewInternalPackage("rollout", rollout.GetTemplate(), rollout.GetProviders())),
 		runtime.Must(cuexruntime.NewInternalPackage("terraform", terraform.GetTemplate(), terraform.GetProviders())),
 	), nil
 })
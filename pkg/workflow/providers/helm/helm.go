@@ -37,5 +37,6 @@ func GetTemplate() string {
 func GetProviders() map[string]cuexruntime.ProviderFn {
 	return map[string]cuexruntime.ProviderFn{
 		"render": cuexruntime.GenericProviderFn[providers.Params[helmProvider.RenderParams], providers.Returns[helmProvider.RenderReturns]](helmProvider.Render),
+		"test":   cuexruntime.GenericProviderFn[providers.Params[helmProvider.TestParams], providers.Returns[helmProvider.TestReturns]](helmProvider.Test),
 	}
 }
@@ -48,6 +48,12 @@ var _ = Describe("Helm Workflow Provider", func() {
 			Expect(providers).To(HaveKey("render"))
 		})
 
+		It("should return the test provider", func() {
+			providers := GetProviders()
+			Expect(providers).To(HaveKey("test"))
+			Expect(providers["test"]).ToNot(BeNil())
+		})
+
 		It("should have a non-nil render provider function", func() {
 			providers := GetProviders()
 			Expect(providers["render"]).ToNot(BeNil())
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
@@ -58,11 +59,19 @@ type DeployParameter struct {
 	IgnoreTerraformComponent bool `json:"ignoreTerraformComponent"`
 	// The policies that embeds in the `deploy` step directly
 	InlinePolicies []v1beta1.AppPolicy `json:"inlinePolicies,omitempty"`
+	// Deploy the placements in waves one by one, the placements not selected by any wave are deployed in the last wave.
+	Waves []DeployWave `json:"waves,omitempty"`
+	// The max number of unhealthy clusters allowed in a wave before moving on to the next wave.
+	MaxUnavailableClusters int `json:"maxUnavailableClusters,omitempty"`
+	// The max duration a wave can be progressing, the clusters still unhealthy after it are counted as unavailable.
+	WaveTimeout string `json:"waveTimeout,omitempty"`
 }
 
 // DeployWorkflowStepExecutor executor to run deploy workflow step
 type DeployWorkflowStepExecutor interface {
 	Deploy(ctx context.Context) (healthy bool, reason string, err error)
+	WaveStatuses() []WaveStatus
+	RestoreWaveStatuses(statuses []WaveStatus)
 }
 
 // NewDeployWorkflowStepExecutor .
@@ -84,6 +93,7 @@ type deployWorkflowStepExecutor struct {
 	healthCheck oamprovidertypes.ComponentHealthCheck
 	renderer    oamprovidertypes.WorkloadRender
 	parameter   DeployParameter
+	waves       []WaveStatus
 }
 
 // Deploy execute deploy workflow step
@@ -99,7 +109,7 @@ func (executor *deployWorkflowStepExecutor) Deploy(ctx context.Context) (bool, s
 	}
 
 	// Dealing with topology, override and replication policies in order.
-	placements, err := pkgpolicy.GetPlacementsFromTopologyPolicies(ctx, executor.cli, executor.af.Namespace, policies, resourcekeeper.AllowCrossNamespaceResource)
+	placements, err := pkgpolicy.GetPlacementsFromTopologyPoliciesWithFailover(ctx, executor.cli, executor.af.Namespace, policies, executor.af.PlacementFailovers(), resourcekeeper.AllowCrossNamespaceResource)
 	if err != nil {
 		return false, "", err
 	}
@@ -107,11 +117,63 @@ func (executor *deployWorkflowStepExecutor) Deploy(ctx context.Context) (bool, s
 	if err != nil {
 		return false, "", err
 	}
+	patches, err := pkgpolicy.GetReplicaDistributionPatches(ctx, executor.cli, policies, components, placements)
+	if err != nil {
+		return false, "", err
+	}
+	clusterComponents := map[string][]common.ApplicationComponent{}
+	for cluster, clusterPatches := range patches {
+		comps, err := envbinding.PatchComponents(components, clusterPatches, nil)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "failed to distribute replicas to cluster %s", cluster)
+		}
+		if clusterComponents[cluster], err = pkgpolicy.ReplicateComponents(policies, comps); err != nil {
+			return false, "", err
+		}
+	}
 	components, err = pkgpolicy.ReplicateComponents(policies, components)
 	if err != nil {
 		return false, "", err
 	}
-	return applyComponents(ctx, executor.apply, executor.healthCheck, components, placements, int(executor.parameter.Parallelism))
+	componentsOf := func(cluster string) []common.ApplicationComponent {
+		if comps, found := clusterComponents[cluster]; found {
+			return comps
+		}
+		return components
+	}
+	if len(executor.parameter.Waves) == 0 {
+		if len(clusterComponents) == 0 {
+			return applyComponents(ctx, executor.apply, executor.healthCheck, components, placements, int(executor.parameter.Parallelism))
+		}
+		return aggregateClusterResults(applyComponentsByCluster(ctx, executor.apply, executor.healthCheck, componentsOf, placements, int(executor.parameter.Parallelism)))
+	}
+	if executor.parameter.MaxUnavailableClusters < 0 {
+		return false, "", errors.Errorf("maxUnavailableClusters cannot be negative")
+	}
+	var timeout time.Duration
+	if executor.parameter.WaveTimeout != "" {
+		if timeout, err = time.ParseDuration(executor.parameter.WaveTimeout); err != nil {
+			return false, "", errors.Wrapf(err, "invalid waveTimeout")
+		}
+	}
+	waves, err := partitionWaves(ctx, executor.cli, executor.parameter.Waves, placements)
+	if err != nil {
+		return false, "", err
+	}
+	statuses, healthy, err := applyWaves(ctx, executor.apply, executor.healthCheck, componentsOf, waves, executor.waves, executor.parameter.MaxUnavailableClusters, timeout, int(executor.parameter.Parallelism))
+	executor.waves = statuses
+	return healthy, formatWaveStatuses(statuses), err
+}
+
+// WaveStatuses returns the status of each wave in the last deployment
+func (executor *deployWorkflowStepExecutor) WaveStatuses() []WaveStatus {
+	return executor.waves
+}
+
+// RestoreWaveStatuses restores the status of each wave recorded in the last deployment, so that
+// the time each wave has been progressing is tracked across reconciles
+func (executor *deployWorkflowStepExecutor) RestoreWaveStatuses(statuses []WaveStatus) {
+	executor.waves = statuses
 }
 
 func selectPolicies(policies []v1beta1.AppPolicy, policyNames []string) ([]v1beta1.AppPolicy, error) {
@@ -427,6 +489,49 @@ HealthCheck:
 	return allHealthy && outputsReady && len(pendingTasks) == 0, strings.Join(reasons, ","), velaerrors.AggregateErrors(errs)
 }
 
+type clusterDeployResult struct {
+	cluster string
+	healthy bool
+	reason  string
+	err     error
+}
+
+// applyComponentsByCluster applies the components to the placements of each cluster in parallel,
+// the components to apply could differ between clusters
+func applyComponentsByCluster(ctx context.Context, apply oamprovidertypes.ComponentApply, healthCheck oamprovidertypes.ComponentHealthCheck, componentsOf func(cluster string) []common.ApplicationComponent, placements []v1alpha1.PlacementDecision, parallelism int) []*clusterDeployResult {
+	var clusters []string
+	clusterPlacements := map[string][]v1alpha1.PlacementDecision{}
+	for _, pl := range placements {
+		if _, found := clusterPlacements[pl.Cluster]; !found {
+			clusters = append(clusters, pl.Cluster)
+		}
+		clusterPlacements[pl.Cluster] = append(clusterPlacements[pl.Cluster], pl)
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	return slices.ParMap[string, *clusterDeployResult](clusters, func(cluster string) *clusterDeployResult {
+		healthy, reason, err := applyComponents(ctx, apply, healthCheck, componentsOf(cluster), clusterPlacements[cluster], parallelism)
+		return &clusterDeployResult{cluster: cluster, healthy: healthy, reason: reason, err: err}
+	}, slices.Parallelism(len(clusters)))
+}
+
+func aggregateClusterResults(results []*clusterDeployResult) (bool, string, error) {
+	allHealthy := true
+	var reasons []string
+	var errs []error
+	for _, res := range results {
+		allHealthy = allHealthy && res.healthy
+		if res.reason != "" {
+			reasons = append(reasons, res.reason)
+		}
+		if res.err != nil {
+			errs = append(errs, res.err)
+		}
+	}
+	return allHealthy, strings.Join(reasons, ","), velaerrors.AggregateErrors(errs)
+}
+
 func fieldPathToComponent(input string) string {
 	return fmt.Sprintf("properties.%s", strings.TrimSpace(input))
 }
@@ -35,6 +35,25 @@
 		parallelism:              int
 		ignoreTerraformComponent: bool
 		inlinePolicies: *[] | [...{...}]
+		waves: *[] | [...{
+			name?: string
+			clusters?: [...string]
+			clusterLabelSelector?: [string]: string
+			maxUnavailableClusters?: int
+		}]
+		maxUnavailableClusters: *0 | int
+		waveTimeout:            *"" | string
+	}
+	$returns?: {
+		waves?: [...{
+			name:  string
+			phase: string
+			clusters?: [...string]
+			healthyClusters?: [...string]
+			unavailableClusters?: [...string]
+			startTime?: string
+			message?:   string
+		}]
+		...
 	}
-	$returns?: {...}
 }
@@ -25,6 +25,7 @@ import (
 	cuexruntime "github.com/kubevela/pkg/cue/cuex/runtime"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/pkg/multicluster"
 	pkgpolicy "github.com/oam-dev/kubevela/pkg/policy"
 	oamprovidertypes "github.com/oam-dev/kubevela/pkg/workflow/providers/types"
@@ -92,20 +93,77 @@ func ListClusters(ctx context.Context, params *oamprovidertypes.Params[any]) (*C
 // DeployParams is the parameter for deploy
 type DeployParams = oamprovidertypes.Params[DeployParameter]
 
+// DeployResult is the result of deploy
+type DeployResult struct {
+	Waves []WaveStatus `json:"waves,omitempty"`
+}
+
+// DeployReturns is the return value for deploy
+type DeployReturns = oamprovidertypes.Returns[DeployResult]
+
 // Deploy deploys the application
-func Deploy(ctx context.Context, params *DeployParams) (*any, error) {
+func Deploy(ctx context.Context, params *DeployParams) (*DeployReturns, error) {
 	if params.Params.Parallelism <= 0 {
 		return nil, errors.Errorf("parallelism cannot be smaller than 1")
 	}
 	executor := NewDeployWorkflowStepExecutor(params.KubeClient, params.Appfile, params.ComponentApply, params.ComponentHealthCheck, params.WorkloadRender, params.Params)
+	var step string
+	if len(params.Params.Waves) > 0 {
+		step = params.Action.GetStatus().Name
+		executor.RestoreWaveStatuses(getDeployWaveStatuses(params.App, step))
+	}
 	healthy, reason, err := executor.Deploy(ctx)
+	waves := executor.WaveStatuses()
+	if len(params.Params.Waves) > 0 {
+		setDeployWaveStatuses(params.App, step, waves)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if len(waves) == 0 {
+		if !healthy {
+			params.Action.Wait(reason)
+		}
+		return nil, nil
+	}
 	if !healthy {
 		params.Action.Wait(reason)
+	} else {
+		params.Action.Message(reason)
+	}
+	return &DeployReturns{Returns: DeployResult{Waves: waves}}, nil
+}
+
+// getDeployWaveStatuses returns the wave statuses of the deploy step recorded in the application status
+func getDeployWaveStatuses(app *v1beta1.Application, step string) []WaveStatus {
+	if app == nil || app.Status.Workflow == nil {
+		return nil
+	}
+	var statuses []WaveStatus
+	for _, status := range app.Status.Workflow.DeployWaves {
+		if status.Step == step {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// setDeployWaveStatuses records the wave statuses of the deploy step in the application status
+func setDeployWaveStatuses(app *v1beta1.Application, step string, statuses []WaveStatus) {
+	if app == nil || app.Status.Workflow == nil {
+		return
+	}
+	var waves []WaveStatus
+	for _, status := range app.Status.Workflow.DeployWaves {
+		if status.Step != step {
+			waves = append(waves, status)
+		}
+	}
+	for _, status := range statuses {
+		status.Step = step
+		waves = append(waves, status)
 	}
-	return nil, nil
+	app.Status.Workflow.DeployWaves = waves
 }
 
 // PoliciesVars is the vars for getting placements from topology policies
@@ -131,7 +189,7 @@ func GetPlacementsFromTopologyPolicies(ctx context.Context, params *PoliciesPara
 	if err != nil {
 		return nil, err
 	}
-	placements, err := pkgpolicy.GetPlacementsFromTopologyPolicies(ctx, params.KubeClient, params.Appfile.Namespace, policies, true)
+	placements, err := pkgpolicy.GetPlacementsFromTopologyPoliciesWithFailover(ctx, params.KubeClient, params.Appfile.Namespace, policies, params.Appfile.PlacementFailovers(), true)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +209,6 @@ func GetProviders() map[string]cuexruntime.ProviderFn {
 	return map[string]cuexruntime.ProviderFn{
 		"list-clusters":                         oamprovidertypes.GenericProviderFn[any, ClusterReturns](ListClusters),
 		"get-placements-from-topology-policies": oamprovidertypes.GenericProviderFn[PoliciesVars, PoliciesReturns](GetPlacementsFromTopologyPolicies),
-		"deploy":                                oamprovidertypes.GenericProviderFn[DeployParameter, any](Deploy),
+		"deploy":                                oamprovidertypes.GenericProviderFn[DeployParameter, DeployReturns](Deploy),
 	}
 }
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	velaerrors "github.com/oam-dev/kubevela/pkg/utils/errors"
+	oamprovidertypes "github.com/oam-dev/kubevela/pkg/workflow/providers/types"
+)
+
+const (
+	// WavePhasePending the wave is waiting for the previous waves
+	WavePhasePending = "pending"
+	// WavePhaseProgressing the wave is deployed and waiting for the clusters to be healthy
+	WavePhaseProgressing = "progressing"
+	// WavePhaseSucceeded the healthy clusters in the wave reach the requirement
+	WavePhaseSucceeded = "succeeded"
+	// WavePhaseHalted the health checks fail in the wave, the later waves will not be deployed
+	WavePhaseHalted = "halted"
+)
+
+// DeployWave is a batch of clusters to deploy together in the deploy step
+type DeployWave struct {
+	// Name of the wave, default to be wave-<index>
+	Name string `json:"name,omitempty"`
+	// Clusters the names of the clusters in the wave
+	Clusters []string `json:"clusters,omitempty"`
+	// ClusterLabelSelector selects the clusters in the wave by labels
+	ClusterLabelSelector map[string]string `json:"clusterLabelSelector,omitempty"`
+	// MaxUnavailableClusters the max number of unhealthy clusters allowed before moving on
+	// to the next wave, override the one in the deploy parameter
+	MaxUnavailableClusters *int `json:"maxUnavailableClusters,omitempty"`
+}
+
+// WaveStatus is the status of one wave in the deploy step
+type WaveStatus = common.DeployWaveStatus
+
+func waveStatusString(s WaveStatus) string {
+	str := fmt.Sprintf("wave %s %s (%d/%d clusters healthy)", s.Name, s.Phase, len(s.HealthyClusters), len(s.Clusters))
+	if s.Message != "" {
+		str += ": " + s.Message
+	}
+	return str
+}
+
+type deployWave struct {
+	DeployWave
+	placements []v1alpha1.PlacementDecision
+	clusters   []string
+}
+
+// partitionWaves assigns the placements into waves by cluster names or cluster labels. A
+// cluster belongs to the first wave that matches it. The placements not matched by any wave
+// are deployed in the last implicit wave.
+func partitionWaves(ctx context.Context, cli client.Client, waves []DeployWave, placements []v1alpha1.PlacementDecision) ([]*deployWave, error) {
+	clusterLabels := map[string]map[string]string{}
+	getLabels := func(cluster string) (map[string]string, error) {
+		if ls, found := clusterLabels[cluster]; found {
+			return ls, nil
+		}
+		vc, err := multicluster.GetVirtualCluster(ctx, cli, cluster)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get cluster %s", cluster)
+		}
+		clusterLabels[cluster] = vc.Labels
+		return vc.Labels, nil
+	}
+	var result []*deployWave
+	names := map[string]bool{}
+	for i, wave := range waves {
+		if wave.Name == "" {
+			wave.Name = fmt.Sprintf("wave-%d", i)
+		}
+		if names[wave.Name] {
+			return nil, errors.Errorf("duplicated wave name %s", wave.Name)
+		}
+		names[wave.Name] = true
+		if len(wave.Clusters) == 0 && len(wave.ClusterLabelSelector) == 0 {
+			return nil, errors.Errorf("wave %s must specify clusters or clusterLabelSelector", wave.Name)
+		}
+		result = append(result, &deployWave{DeployWave: wave})
+	}
+	implicit := &deployWave{DeployWave: DeployWave{Name: "default"}}
+	assigned := map[string]*deployWave{}
+	for _, pl := range placements {
+		w, found := assigned[pl.Cluster]
+		if !found {
+			w = implicit
+			for _, wave := range result {
+				matched, err := wave.match(pl.Cluster, getLabels)
+				if err != nil {
+					return nil, err
+				}
+				if matched {
+					w = wave
+					break
+				}
+			}
+			assigned[pl.Cluster] = w
+			w.clusters = append(w.clusters, pl.Cluster)
+		}
+		w.placements = append(w.placements, pl)
+	}
+	if len(implicit.placements) > 0 {
+		if names[implicit.Name] {
+			implicit.Name = fmt.Sprintf("wave-%d", len(result))
+		}
+		result = append(result, implicit)
+	}
+	return result, nil
+}
+
+func (w *deployWave) match(cluster string, getLabels func(string) (map[string]string, error)) (bool, error) {
+	for _, c := range w.Clusters {
+		if c == cluster {
+			return true, nil
+		}
+	}
+	if len(w.ClusterLabelSelector) == 0 {
+		return false, nil
+	}
+	ls, err := getLabels(cluster)
+	if err != nil {
+		return false, err
+	}
+	return labels.SelectorFromSet(w.ClusterLabelSelector).Matches(labels.Set(ls)), nil
+}
+
+// applyWaves deploys the waves in order. A wave is deployed only after all the previous waves
+// succeed, which means the number of unhealthy clusters in the wave is no more than the max
+// unavailable clusters. The clusters failing the health checks with errors, and the clusters
+// still unhealthy after the wave has been progressing longer than the timeout, are unavailable.
+// If there are more unavailable clusters than allowed, the wave is halted and the later waves
+// will not be deployed. The start time of each wave is carried over from the previous statuses.
+func applyWaves(ctx context.Context, apply oamprovidertypes.ComponentApply, healthCheck oamprovidertypes.ComponentHealthCheck, componentsOf func(cluster string) []common.ApplicationComponent, waves []*deployWave, previous []WaveStatus, maxUnavailable int, timeout time.Duration, parallelism int) ([]WaveStatus, bool, error) {
+	startTimes := map[string]*metav1.Time{}
+	for _, status := range previous {
+		if status.Phase != WavePhasePending && status.StartTime != nil {
+			startTimes[status.Name] = status.StartTime
+		}
+	}
+	statuses := make([]WaveStatus, len(waves))
+	for i, wave := range waves {
+		statuses[i] = WaveStatus{Name: wave.Name, Phase: WavePhasePending, Clusters: wave.clusters}
+	}
+	for i, wave := range waves {
+		startTime, found := startTimes[wave.Name]
+		if !found {
+			startTime = ptr.To(metav1.Now())
+		}
+		statuses[i].StartTime = startTime
+		if len(wave.clusters) == 0 {
+			statuses[i].Phase = WavePhaseSucceeded
+			continue
+		}
+		results := applyComponentsByCluster(ctx, apply, healthCheck, componentsOf, wave.placements, parallelism)
+
+		timedOut := timeout > 0 && time.Since(startTime.Time) > timeout
+		var reasons []string
+		var errs []error
+		for _, res := range results {
+			switch {
+			case res.err != nil:
+				errs = append(errs, errors.WithMessagef(res.err, "cluster %s", res.cluster))
+				statuses[i].UnavailableClusters = append(statuses[i].UnavailableClusters, res.cluster)
+			case res.healthy:
+				statuses[i].HealthyClusters = append(statuses[i].HealthyClusters, res.cluster)
+			default:
+				if timedOut {
+					errs = append(errs, errors.Errorf("cluster %s is not healthy after %s: %s", res.cluster, timeout, res.reason))
+					statuses[i].UnavailableClusters = append(statuses[i].UnavailableClusters, res.cluster)
+				}
+				if res.reason != "" {
+					reasons = append(reasons, res.reason)
+				}
+			}
+		}
+		allowed := maxUnavailable
+		if wave.MaxUnavailableClusters != nil {
+			allowed = *wave.MaxUnavailableClusters
+		}
+		unavailable := len(wave.clusters) - len(statuses[i].HealthyClusters)
+		if unavailable <= allowed {
+			statuses[i].Phase = WavePhaseSucceeded
+			continue
+		}
+		if len(statuses[i].UnavailableClusters) > allowed {
+			statuses[i].Phase = WavePhaseHalted
+			statuses[i].Message = fmt.Sprintf("%d clusters are unavailable, exceeds the max unavailable clusters %d", len(statuses[i].UnavailableClusters), allowed)
+			return statuses, false, errors.WithMessagef(velaerrors.AggregateErrors(errs), "wave %s is halted", wave.Name)
+		}
+		statuses[i].Phase = WavePhaseProgressing
+		statuses[i].Message = strings.Join(reasons, ",")
+		return statuses, false, nil
+	}
+	return statuses, true, nil
+}
+
+func formatWaveStatuses(statuses []WaveStatus) string {
+	var s []string
+	for _, status := range statuses {
+		s = append(s, waveStatusString(status))
+	}
+	return strings.Join(s, "; ")
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"cuelang.org/go/cue"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	clustercommon "github.com/oam-dev/cluster-gateway/pkg/common"
+
+	apicommon "github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	commontypes "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestPartitionWaves(t *testing.T) {
+	r := require.New(t)
+	originalNS := multicluster.ClusterGatewaySecretNamespace
+	multicluster.ClusterGatewaySecretNamespace = types.DefaultKubeVelaNS
+	t.Cleanup(func() {
+		multicluster.ClusterGatewaySecretNamespace = originalNS
+	})
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(commontypes.Scheme).Build()
+	for name, region := range map[string]string{"canary": "hangzhou", "hz-1": "hangzhou", "bj-1": "beijing", "sh-1": "shanghai"} {
+		r.NoError(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: multicluster.ClusterGatewaySecretNamespace,
+				Labels: map[string]string{
+					clustercommon.LabelKeyClusterCredentialType: string(clusterv1alpha1.CredentialTypeX509Certificate),
+					"region": region,
+				},
+			},
+		}))
+	}
+	placements := []v1alpha1.PlacementDecision{
+		{Cluster: "local", Namespace: "default"},
+		{Cluster: "sh-1", Namespace: "default"},
+		{Cluster: "bj-1", Namespace: "default"},
+		{Cluster: "hz-1", Namespace: "default"},
+		{Cluster: "canary", Namespace: "default"},
+		{Cluster: "canary", Namespace: "test"},
+	}
+	waves, err := partitionWaves(ctx, cli, []DeployWave{
+		{Name: "canary", Clusters: []string{"canary"}},
+		{ClusterLabelSelector: map[string]string{"region": "hangzhou"}},
+		{Name: "beijing", ClusterLabelSelector: map[string]string{"region": "beijing"}},
+	}, placements)
+	r.NoError(err)
+	r.Equal(4, len(waves))
+	r.Equal("canary", waves[0].Name)
+	r.Equal([]string{"canary"}, waves[0].clusters)
+	r.Equal(2, len(waves[0].placements))
+	r.Equal("wave-1", waves[1].Name)
+	r.Equal([]string{"hz-1"}, waves[1].clusters)
+	r.Equal("beijing", waves[2].Name)
+	r.Equal([]string{"bj-1"}, waves[2].clusters)
+	r.Equal("default", waves[3].Name)
+	r.Equal([]string{"local", "sh-1"}, waves[3].clusters)
+
+	_, err = partitionWaves(ctx, cli, []DeployWave{{Name: "empty"}}, placements)
+	r.Error(err)
+	_, err = partitionWaves(ctx, cli, []DeployWave{{Name: "a", Clusters: []string{"canary"}}, {Name: "a", Clusters: []string{"hz-1"}}}, placements)
+	r.Error(err)
+	_, err = partitionWaves(ctx, cli, []DeployWave{{ClusterLabelSelector: map[string]string{"region": "hangzhou"}}}, []v1alpha1.PlacementDecision{{Cluster: "not-exist"}})
+	r.Error(err)
+}
+
+func TestApplyWaves(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	componentsOf := func(string) []apicommon.ApplicationComponent {
+		return []apicommon.ApplicationComponent{{Name: "comp"}}
+	}
+	waves := func() []*deployWave {
+		return []*deployWave{{
+			DeployWave: DeployWave{Name: "canary"},
+			clusters:   []string{"canary"},
+			placements: []v1alpha1.PlacementDecision{{Cluster: "canary"}},
+		}, {
+			DeployWave: DeployWave{Name: "hangzhou"},
+			clusters:   []string{"hz-1", "hz-2"},
+			placements: []v1alpha1.PlacementDecision{{Cluster: "hz-1"}, {Cluster: "hz-2"}},
+		}}
+	}
+
+	var mu sync.Mutex
+	applied := map[string]bool{}
+	unhealthy := map[string]bool{}
+	failed := map[string]bool{}
+	apply := func(_ context.Context, comp apicommon.ApplicationComponent, _ *cue.Value, clusterName string, _ string) (*unstructured.Unstructured, []*unstructured.Unstructured, bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		applied[clusterName] = true
+		if failed[clusterName] {
+			return nil, nil, false, errors.Errorf("failed to apply %s", comp.Name)
+		}
+		return nil, nil, !unhealthy[clusterName], nil
+	}
+	healthCheck := func(_ context.Context, _ apicommon.ApplicationComponent, _ *cue.Value, clusterName string, _ string) (bool, *apicommon.ApplicationComponentStatus, *unstructured.Unstructured, []*unstructured.Unstructured, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return applied[clusterName] && !unhealthy[clusterName] && !failed[clusterName], nil, nil, nil, nil
+	}
+
+	// the canary wave is unhealthy, the later waves are not deployed
+	unhealthy["canary"] = true
+	statuses, healthy, err := applyWaves(ctx, apply, healthCheck, componentsOf, waves(), nil, 0, 0, 5)
+	r.NoError(err)
+	r.False(healthy)
+	r.Equal(WavePhaseProgressing, statuses[0].Phase)
+	r.Equal(WavePhasePending, statuses[1].Phase)
+	r.False(applied["hz-1"])
+	r.Contains(formatWaveStatuses(statuses), "wave canary progressing (0/1 clusters healthy)")
+
+	// the canary wave becomes healthy, move on to the next wave
+	unhealthy["canary"] = false
+	unhealthy["hz-2"] = true
+	statuses, healthy, err = applyWaves(ctx, apply, healthCheck, componentsOf, waves(), nil, 0, 0, 5)
+	r.NoError(err)
+	r.False(healthy)
+	r.Equal(WavePhaseSucceeded, statuses[0].Phase)
+	r.Equal(WavePhaseProgressing, statuses[1].Phase)
+	r.Equal([]string{"hz-1"}, statuses[1].HealthyClusters)
+
+	// one unhealthy cluster is tolerated
+	statuses, healthy, err = applyWaves(ctx, apply, healthCheck, componentsOf, waves(), nil, 1, 0, 5)
+	r.NoError(err)
+	r.True(healthy)
+	r.Equal(WavePhaseSucceeded, statuses[1].Phase)
+
+	// the max unavailable clusters in the wave overrides the global one
+	ws := waves()
+	ws[1].MaxUnavailableClusters = ptr.To(0)
+	_, healthy, err = applyWaves(ctx, apply, healthCheck, componentsOf, ws, nil, 1, 0, 5)
+	r.NoError(err)
+	r.False(healthy)
+
+	// the unhealthy clusters are unavailable after the wave timeout
+	statuses, healthy, err = applyWaves(ctx, apply, healthCheck, componentsOf, waves(), nil, 0, time.Minute, 5)
+	r.NoError(err)
+	r.False(healthy)
+	r.Equal(WavePhaseProgressing, statuses[1].Phase)
+	r.NotNil(statuses[1].StartTime)
+	r.Empty(statuses[1].UnavailableClusters)
+	statuses[1].StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+	statuses, healthy, err = applyWaves(ctx, apply, healthCheck, componentsOf, waves(), statuses, 0, time.Minute, 5)
+	r.Error(err)
+	r.Contains(err.Error(), "wave hangzhou is halted")
+	r.Contains(err.Error(), "cluster hz-2 is not healthy after 1m0s")
+	r.False(healthy)
+	r.Equal(WavePhaseHalted, statuses[1].Phase)
+	r.Equal([]string{"hz-2"}, statuses[1].UnavailableClusters)
+	statuses, healthy, err = applyWaves(ctx, apply, healthCheck, componentsOf, waves(), statuses, 1, time.Minute, 5)
+	r.NoError(err)
+	r.True(healthy)
+	r.Equal(WavePhaseSucceeded, statuses[1].Phase)
+
+	// the wave is halted when the health checks fail
+	failed["canary"] = true
+	statuses, healthy, err = applyWaves(ctx, apply, healthCheck, componentsOf, waves(), nil, 0, 0, 5)
+	r.Error(err)
+	r.Contains(err.Error(), "wave canary is halted")
+	r.False(healthy)
+	r.Equal(WavePhaseHalted, statuses[0].Phase)
+	r.Equal(WavePhasePending, statuses[1].Phase)
+}
+
+func TestDeployWaveStatuses(t *testing.T) {
+	r := require.New(t)
+	app := &v1beta1.Application{}
+	setDeployWaveStatuses(app, "deploy", []WaveStatus{{Name: "canary", Phase: WavePhaseSucceeded}})
+	r.Empty(getDeployWaveStatuses(app, "deploy"))
+
+	app.Status.Workflow = &apicommon.WorkflowStatus{}
+	setDeployWaveStatuses(app, "deploy-staging", []WaveStatus{{Name: "canary", Phase: WavePhaseSucceeded}})
+	setDeployWaveStatuses(app, "deploy-prod", []WaveStatus{{Name: "canary", Phase: WavePhaseProgressing}})
+	setDeployWaveStatuses(app, "deploy-prod", []WaveStatus{{Name: "canary", Phase: WavePhaseSucceeded}, {Name: "default", Phase: WavePhaseProgressing}})
+	r.Len(app.Status.Workflow.DeployWaves, 3)
+	r.Equal([]WaveStatus{
+		{Step: "deploy-prod", Name: "canary", Phase: WavePhaseSucceeded},
+		{Step: "deploy-prod", Name: "default", Phase: WavePhaseProgressing},
+	}, getDeployWaveStatuses(app, "deploy-prod"))
+}
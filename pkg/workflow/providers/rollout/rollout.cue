@@ -0,0 +1,21 @@
+// rollout.cue
+
+#Progress: {
+	#provider: "rollout"
+	#do:       "progress"
+
+	$params: {
+		// +usage=The name of the progressive-rollout policy
+		policy: string
+		// +usage=The cluster to roll out the components
+		cluster: *"" | string
+		// +usage=The namespace to roll out the components
+		namespace: *"" | string
+	}
+
+	$returns?: {
+		// +usage=The canary step of each component
+		steps: {[string]: int}
+	}
+	...
+}
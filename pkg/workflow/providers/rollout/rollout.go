@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	cuexruntime "github.com/kubevela/pkg/cue/cuex/runtime"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/rollout"
+	oamprovidertypes "github.com/oam-dev/kubevela/pkg/workflow/providers/types"
+)
+
+// ProgressVars is the vars for progressive rollout
+type ProgressVars struct {
+	Policy    string `json:"policy"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+}
+
+// ProgressResult is the result for progressive rollout
+type ProgressResult struct {
+	// Steps the current canary step of each component
+	Steps map[string]int `json:"steps"`
+}
+
+// ProgressParams is the params for progressive rollout
+type ProgressParams = oamprovidertypes.Params[ProgressVars]
+
+// ProgressReturns is the returns for progressive rollout
+type ProgressReturns = oamprovidertypes.Returns[ProgressResult]
+
+// Progress rolls out the components of the application with the canary steps in the
+// progressive-rollout policy. The step waits until all the components are promoted and
+// healthy, and fails if the analysis of any canary fails.
+func Progress(ctx context.Context, params *ProgressParams) (*ProgressReturns, error) {
+	spec, err := loadPolicy(params)
+	if err != nil {
+		return nil, err
+	}
+	selected := map[string]bool{}
+	for _, name := range spec.Components {
+		selected[name] = true
+	}
+	result := &ProgressReturns{Returns: ProgressResult{Steps: map[string]int{}}}
+	var pending []string
+	for _, c := range params.Appfile.Components {
+		loaded, err := params.Appfile.LoadDynamicComponent(ctx, params.KubeClient, c.DeepCopy())
+		if err != nil {
+			return nil, err
+		}
+		comp := *loaded
+		if len(selected) > 0 && !selected[comp.Name] {
+			_, _, healthy, err := params.ComponentApply(ctx, comp, nil, params.Params.Cluster, params.Params.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			if !healthy {
+				pending = append(pending, fmt.Sprintf("component %s is not healthy", comp.Name))
+			}
+			continue
+		}
+		workload, _, err := params.ComponentRender(ctx, comp, nil, params.Params.Cluster, params.Params.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		r := rollout.NewProgressiveRollout(params.KubeClient, params.Params.Cluster, spec)
+		status, err := r.Progress(ctx, workload)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to roll out component %s", comp.Name)
+		}
+		result.Returns.Steps[comp.Name] = status.Step
+		switch status.Phase {
+		case rollout.ProgressPhaseFailed:
+			params.Action.Fail(fmt.Sprintf("component %s: %s", comp.Name, status.Message))
+			return result, nil
+		case rollout.ProgressPhaseProgressing:
+			pending = append(pending, fmt.Sprintf("component %s: %s", comp.Name, status.Message))
+			continue
+		}
+		_, _, healthy, err := params.ComponentApply(ctx, comp, nil, params.Params.Cluster, params.Params.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !healthy {
+			pending = append(pending, fmt.Sprintf("component %s is promoting", comp.Name))
+			continue
+		}
+		if err = r.Finalize(ctx, workload); err != nil {
+			return nil, errors.WithMessagef(err, "failed to finalize rollout of component %s", comp.Name)
+		}
+	}
+	if len(pending) > 0 {
+		params.Action.Wait(strings.Join(pending, "; "))
+	}
+	return result, nil
+}
+
+func loadPolicy(params *ProgressParams) (*v1alpha1.ProgressiveRolloutPolicySpec, error) {
+	for _, policy := range params.Appfile.Policies {
+		if policy.Name != params.Params.Policy {
+			continue
+		}
+		if policy.Type != v1alpha1.ProgressiveRolloutPolicyType {
+			return nil, errors.Errorf("policy %s is not a %s policy", policy.Name, v1alpha1.ProgressiveRolloutPolicyType)
+		}
+		spec := &v1alpha1.ProgressiveRolloutPolicySpec{}
+		if policy.Properties != nil && policy.Properties.Raw != nil {
+			if err := json.Unmarshal(policy.Properties.Raw, spec); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse policy %s", policy.Name)
+			}
+		}
+		return spec, nil
+	}
+	return nil, errors.Errorf("policy %s not found", params.Params.Policy)
+}
+
+//go:embed rollout.cue
+var template string
+
+// GetTemplate returns the cue template.
+func GetTemplate() string {
+	return template
+}
+
+// GetProviders returns the cue providers.
+func GetProviders() map[string]cuexruntime.ProviderFn {
+	return map[string]cuexruntime.ProviderFn{
+		"progress": oamprovidertypes.GenericProviderFn[ProgressVars, ProgressReturns](Progress),
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/appfile"
+	oamprovidertypes "github.com/oam-dev/kubevela/pkg/workflow/providers/types"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	r := require.New(t)
+	af := &appfile.Appfile{Policies: []v1beta1.AppPolicy{{
+		Name:       "canary",
+		Type:       v1alpha1.ProgressiveRolloutPolicyType,
+		Properties: &runtime.RawExtension{Raw: []byte(`{"components":["web"],"steps":[{"canaryPercent":20,"pause":"1m"}]}`)},
+	}, {
+		Name: "topology",
+		Type: v1alpha1.TopologyPolicyType,
+	}}}
+	newParams := func(policy string) *ProgressParams {
+		return &ProgressParams{Params: ProgressVars{Policy: policy}, RuntimeParams: oamprovidertypes.RuntimeParams{Appfile: af}}
+	}
+	spec, err := loadPolicy(newParams("canary"))
+	r.NoError(err)
+	r.Equal([]string{"web"}, spec.Components)
+	r.Len(spec.Steps, 1)
+	r.Equal(int32(20), *spec.Steps[0].CanaryPercent)
+	r.Equal("1m", spec.Steps[0].Pause)
+	_, err = loadPolicy(newParams("topology"))
+	r.Error(err)
+	_, err = loadPolicy(newParams("not-exist"))
+	r.Error(err)
+}
@@ -26,6 +26,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"helm.sh/helm/v3/pkg/strvals"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 	"sigs.k8s.io/yaml"
@@ -55,6 +57,8 @@ func ConfigCommandGroup(f velacmd.Factory, order string, streams util.IOStreams)
 	cmd.AddCommand(NewCreateConfigCommand(f, streams))
 	cmd.AddCommand(NewDistributeConfigCommand(f, streams))
 	cmd.AddCommand(NewDeleteConfigCommand(f, streams))
+	cmd.AddCommand(NewConfigHistoryCommand(f, streams))
+	cmd.AddCommand(NewRollbackConfigCommand(f, streams))
 	return cmd
 }
 
@@ -254,6 +258,7 @@ type DistributeConfigCommandOptions struct {
 	Config    string
 	Namespace string
 	Recalled  bool
+	Version   int
 }
 
 // CreateConfigCommandOptions the options of the command that create the config.
@@ -457,7 +462,7 @@ func NewCreateConfigCommand(f velacmd.Factory, streams util.IOStreams) *cobra.Co
 				_, err = streams.Out.Write(outBuilder.Bytes())
 				return err
 			}
-			if err := inf.CreateOrUpdateConfig(context.Background(), configItem, options.Namespace); err != nil {
+			if err := inf.CreateOrUpdateConfig(contextWithConfigUser(f), configItem, options.Namespace); err != nil {
 				return err
 			}
 			if len(options.Targets) > 0 {
@@ -511,6 +516,9 @@ func NewDistributeConfigCommand(f velacmd.Factory, streams util.IOStreams) *cobr
 		# distribute the config(test-registry) from the vela-system namespace to the other clusters.
 		vela config d test-registry -t cluster1/default -t cluster2/default
 
+		# distribute the version 2 of the config(test-registry), the distribution follows the latest version by default.
+		vela config d test-registry -t cluster1/default --version 2
+
 		# recall the config
 		vela config d test-registry --recall
 		`))
@@ -555,6 +563,9 @@ func NewDistributeConfigCommand(f velacmd.Factory, streams util.IOStreams) *cobr
 					},
 				},
 			}
+			if options.Version > 0 {
+				ads.Versions = map[string]int{options.Config: options.Version}
+			}
 			for _, t := range options.Targets {
 				ti := strings.Split(t, "/")
 				if len(ti) == 2 {
@@ -579,6 +590,7 @@ func NewDistributeConfigCommand(f velacmd.Factory, streams util.IOStreams) *cobr
 	cmd.Flags().StringArrayVarP(&options.Targets, "target", "t", []string{}, "specify the targets that want to distribute,the format is: <clusterName>/<namespace>")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", types.DefaultKubeVelaNS, "specify the namespace of the distribution")
 	cmd.Flags().BoolVarP(&options.Recalled, "recall", "r", false, "this field means recalling the configs from all targets.")
+	cmd.Flags().IntVarP(&options.Version, "version", "", 0, "pin the distribution to the version of the config, follow the latest version if not set.")
 	return cmd
 }
 
@@ -631,3 +643,99 @@ func NewDeleteConfigCommand(f velacmd.Factory, streams util.IOStreams) *cobra.Co
 	cmd.Flags().BoolVarP(&options.NotRecall, "not-recall", "", false, "means only deleting the config from the local and do not recall from targets.")
 	return cmd
 }
+
+// ConfigHistoryCommandOptions the options of the command that list the versions of the config.
+type ConfigHistoryCommandOptions struct {
+	Namespace string
+	Name      string
+}
+
+// NewConfigHistoryCommand command for listing the versions of the config
+func NewConfigHistoryCommand(f velacmd.Factory, streams util.IOStreams) *cobra.Command {
+	var options ConfigHistoryCommandOptions
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: i18n.T("List the versions of a config."),
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeCD,
+		},
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Name = args[0]
+			inf := config.NewConfigFactory(f.Client())
+			versions, err := inf.ListConfigVersions(context.Background(), options.Namespace, options.Name)
+			if err != nil {
+				return err
+			}
+			table := newUITable()
+			table.AddRow("VERSION", "CURRENT", "UPDATED-BY", "UPDATED-TIME")
+			for _, v := range versions {
+				current := ""
+				if v.Current {
+					current = "*"
+				}
+				table.AddRow(v.Version, current, v.UpdatedBy, v.UpdatedAt)
+			}
+			if _, err := streams.Out.Write(table.Bytes()); err != nil {
+				return err
+			}
+			if _, err := streams.Out.Write([]byte("\n")); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", types.DefaultKubeVelaNS, "specify the namespace of the config")
+	return cmd
+}
+
+// ConfigRollbackCommandOptions the options of the command that roll back the config.
+type ConfigRollbackCommandOptions struct {
+	Namespace string
+	Name      string
+	Version   int
+}
+
+// NewRollbackConfigCommand command for rolling back the config to a version
+func NewRollbackConfigCommand(f velacmd.Factory, streams util.IOStreams) *cobra.Command {
+	var options ConfigRollbackCommandOptions
+	rollbackExample := templates.Examples(i18n.T(`
+		# roll back the config(test-registry) to the version 2, it is saved as a new version.
+		vela config rollback test-registry --version 2
+		`))
+	cmd := &cobra.Command{
+		Use:     "rollback",
+		Short:   i18n.T("Roll back a config to a version."),
+		Example: rollbackExample,
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeCD,
+		},
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Name = args[0]
+			if options.Version <= 0 {
+				return fmt.Errorf("the version must be specified")
+			}
+			inf := config.NewConfigFactory(f.Client())
+			if err := inf.RollbackConfig(contextWithConfigUser(f), options.Namespace, options.Name, options.Version); err != nil {
+				return err
+			}
+			streams.Infof("the config %s rolled back to the version %d successfully\n", options.Name, options.Version)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", types.DefaultKubeVelaNS, "specify the namespace of the config")
+	cmd.Flags().IntVarP(&options.Version, "version", "", 0, "specify the version to roll back to")
+	return cmd
+}
+
+// contextWithConfigUser returns the context with the current user, who is recorded in the config version
+func contextWithConfigUser(f velacmd.Factory) context.Context {
+	ctx := context.Background()
+	if cfg := f.Config(); cfg != nil {
+		if userInfo := pkgUtils.GetUserInfoFromConfig(cfg); userInfo != nil {
+			ctx = request.WithUser(ctx, &user.DefaultInfo{Name: userInfo.Username, Groups: userInfo.Groups})
+		}
+	}
+	return ctx
+}
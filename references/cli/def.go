@@ -109,6 +109,10 @@ func DefinitionCommandGroup(c common.Args, order string, ioStreams util.IOStream
 		NewDefinitionListModuleCommand(c, ioStreams),
 		NewDefinitionValidateModuleCommand(c, ioStreams),
 		NewDefinitionGenModuleCommand(c, ioStreams),
+		NewDefinitionPushModuleCommand(c, ioStreams),
+		NewDefinitionInstallModuleCommand(c, ioStreams),
+		NewDefinitionToGoCommand(c, ioStreams),
+		NewDefinitionTestCommand(c, ioStreams),
 	)
 	// Set custom help function for grouped output
 	cmd.SetHelpFunc(defHelpFunc)
@@ -1840,7 +1844,7 @@ func validateGoDefinitionFile(fileName string, c common.Args) (string, error) {
 	return fmt.Sprintf("Validation %s succeed (definitions: %s).\n", fileName, strings.Join(validatedDefs, ", ")), nil
 }
 
-// NewDefinitionGenAPICommand create the `vela def gen-api` command to help user generate Go code from the definition
+// NewDefinitionGenAPICommand create the `vela def gen-api` command to help user generate SDK code from the definition
 func NewDefinitionGenAPICommand(c common.Args) *cobra.Command {
 	meta := gen_sdk.GenMeta{}
 	var languageArgs []string
@@ -1849,14 +1853,19 @@ func NewDefinitionGenAPICommand(c common.Args) *cobra.Command {
 		Use:   "gen-api DEFINITION.cue",
 		Short: "Generate SDK from X-Definition.",
 		Long: "Generate SDK from X-definition file.\n" +
-			"* This command leverage openapi-generator project. Therefore demands \"docker\" exist in PATH\n" +
+			"* For go, this command leverage openapi-generator project. Therefore demands \"docker\" exist in PATH\n" +
+			"* For typescript and python, the code is rendered from the OpenAPI schema directly, docker is not needed\n" +
 			"* Currently, this function is still working in progress and not all formats of parameter in X-definition are supported yet.",
 		Example: "# Generate SDK for golang with scaffold initialized\n" +
 			"> vela def gen-api --init --language go -f /path/to/def -o /path/to/sdk\n" +
 			"# Generate incremental definition files to existing sdk directory\n" +
 			"> vela def gen-api --language go -f /path/to/def -o /path/to/sdk\n" +
 			"# Generate definitions to a sub-module\n" +
-			"> vela def gen-api --language go -f /path/to/def -o /path/to/sdk --submodule --api-dir path/relative/to/output --language-args arg1=val1,arg2=val2\n",
+			"> vela def gen-api --language go -f /path/to/def -o /path/to/sdk --submodule --api-dir path/relative/to/output --language-args arg1=val1,arg2=val2\n" +
+			"# Generate SDK for typescript with scaffold initialized\n" +
+			"> vela def gen-api --init --language typescript -f /path/to/def -o /path/to/sdk --package my-vela-sdk\n" +
+			"# Generate SDK for python with scaffold initialized\n" +
+			"> vela def gen-api --init --language python -f /path/to/def -o /path/to/sdk --package my_vela_sdk\n",
 		Annotations: map[string]string{
 			types.TagCommandType:  types.TypeDefGeneration,
 			types.TagCommandOrder: "2",
@@ -1884,10 +1893,10 @@ func NewDefinitionGenAPICommand(c common.Args) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&meta.Output, "output", "o", "./apis", "Output directory path")
-	cmd.Flags().StringVar(&meta.APIDirectory, "api-dir", "", "API directory path to put definition API files, relative to output directory. Default value: go: pkg/apis")
+	cmd.Flags().StringVar(&meta.APIDirectory, "api-dir", "", "API directory path to put definition API files, relative to output directory. Default value: go: pkg/apis, typescript: src/apis, python: {package}/apis")
 	cmd.Flags().BoolVar(&meta.IsSubModule, "submodule", false, "Whether the generated code is a submodule of the project. If set, the directory specified by `api-dir` will be treated as a submodule of the project")
-	cmd.Flags().StringVarP(&meta.Package, "package", "p", gen_sdk.PackagePlaceHolder, "Package name of generated code")
-	cmd.Flags().StringVarP(&meta.Lang, "language", "g", "go", "Language to generate code. Valid languages: go")
+	cmd.Flags().StringVarP(&meta.Package, "package", "p", gen_sdk.PackagePlaceHolder, "Package name of generated code. Default value for typescript: vela-sdk, python: vela_sdk")
+	cmd.Flags().StringVarP(&meta.Lang, "language", "g", "go", "Language to generate code. Valid languages: go, typescript, python")
 	cmd.Flags().StringVarP(&meta.Template, "template", "t", "", "Template file path, if not specified, the default template will be used")
 	cmd.Flags().StringSliceVarP(&meta.File, "file", "f", nil, "File name of definitions, can be specified multiple times, or use comma to separate multiple files. If directory specified, all files found recursively in the directory will be used")
 	cmd.Flags().BoolVar(&meta.InitSDK, "init", false, "Init the whole SDK project, if not set, only the API file will be generated")
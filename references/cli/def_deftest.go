@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/definition/deftest"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+// FlagUpdate is the flag for updating the expected results of the golden-file tests
+const FlagUpdate = "update"
+
+// NewDefinitionTestCommand create the `vela def test` command to run the golden-file tests of definitions
+func NewDefinitionTestCommand(_ common.Args, streams util.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test [flags] PATH...",
+		Short: "Run the golden-file tests of definitions.",
+		Long: `Run the declarative golden-file tests of component and trait definitions.
+
+The tests are YAML files in a tests directory next to the definitions. Each file
+tests one definition, named by the definition field or the file name, and lists
+the cases with the parameters, the rendering context and the expected results:
+
+  definition: webservice
+  cases:
+    - name: default
+      parameter:
+        image: nginx
+      context:
+        namespace: prod
+        clusterVersion: "1.28"
+      outputStatus:
+        readyReplicas: 1
+      expected:
+        output: {...}
+        outputs: {...}
+        status: {...}
+
+A case expecting a rendering error sets expected.error to a part of the error
+message, or sets expectError to true to get the error recorded by --update.
+The rendering errors of the other cases are never recorded as the expected
+results. The status is evaluated only if outputStatus or outputsStatus is set.
+
+A PATH is either a CUE definition file or a directory. In a directory, the CUE
+definitions and, for a Go definition module, the defkit definitions are tested,
+so that the CUE and the Go versions of a definition are checked against the
+same results. The CUE files without a template, e.g. shared schemas, and the
+cue.mod directory are skipped.`,
+		Example: `# Run the tests of a CUE definition in ./tests
+> vela def test webservice.cue
+
+# Run the tests of all the definitions in a directory or a Go definition module
+> vela def test ./my-definitions
+
+# Record the rendered results as the expected ones
+> vela def test ./my-definitions --update`,
+		Args: cobra.MinimumNArgs(1),
+		Annotations: map[string]string{
+			types.TagCommandType:  types.TypeDefModule,
+			types.TagCommandOrder: "7",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			update, err := cmd.Flags().GetBool(FlagUpdate)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagUpdate)
+			}
+			return testDefinitions(cmd.Context(), streams, args, deftest.Options{Update: update})
+		},
+	}
+
+	cmd.Flags().Bool(FlagUpdate, false, "Record the rendered results as the expected ones instead of comparing them")
+
+	return cmd
+}
+
+// testDefinitions runs the test files found in the paths against the
+// definitions with the same name
+func testDefinitions(ctx context.Context, streams util.IOStreams, paths []string, opts deftest.Options) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	defs, suites, err := deftest.Discover(ctx, paths)
+	if err != nil {
+		return err
+	}
+	if len(suites) == 0 {
+		streams.Infof("No test files found in %s\n", strings.Join(paths, ", "))
+		return nil
+	}
+	defsByName := map[string][]*deftest.Definition{}
+	for _, def := range defs {
+		defsByName[def.GetName()] = append(defsByName[def.GetName()], def)
+	}
+
+	passed, failed, updated := 0, 0, 0
+	for _, suite := range suites {
+		targets := defsByName[suite.Definition]
+		if len(targets) == 0 {
+			streams.Infof("✗ %s: definition %s not found\n", suite.Path(), suite.Definition)
+			failed += len(suite.Cases)
+			continue
+		}
+		results, err := deftest.Run(suite, targets, opts)
+		if err != nil {
+			return err
+		}
+		for _, res := range results {
+			name := fmt.Sprintf("%s (%s: %s) / %s", res.Definition.GetName(), res.Definition.Source, res.Definition.Path, res.Case)
+			switch {
+			case res.Err != nil:
+				failed++
+				streams.Infof("✗ %s\n  %s\n", name, res.Err.Error())
+			case !res.Passed:
+				failed++
+				streams.Infof("✗ %s\n%s", name, indentLines(res.Diff, "  "))
+			case res.Updated:
+				updated++
+				streams.Infof("✓ %s (updated)\n", name)
+			default:
+				passed++
+				streams.Infof("✓ %s\n", name)
+			}
+		}
+	}
+
+	streams.Infof("\n%d passed, %d failed, %d updated\n", passed, failed, updated)
+	if failed > 0 {
+		return fmt.Errorf("%d test case(s) failed", failed)
+	}
+	return nil
+}
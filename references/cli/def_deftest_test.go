@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+const defTestSuiteYAML = `cases:
+  - name: default
+    parameter:
+      team: payments
+`
+
+func TestDefinitionTestCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	cueFile := filepath.Join(tmpDir, "my-labels.cue")
+	require.NoError(t, os.WriteFile(cueFile, []byte(toGoTraitCUE), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "tests"), 0750))
+	suiteFile := filepath.Join(tmpDir, "tests", "my-labels.yaml")
+	require.NoError(t, os.WriteFile(suiteFile, []byte(defTestSuiteYAML), 0600))
+
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := NewDefinitionTestCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: &buf, ErrOut: &buf})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	// the trait patches a workload, which is not set in the cases
+	out, err := run(cueFile)
+	require.Error(t, err)
+	assert.Contains(t, out, "✗ my-labels (cue: "+cueFile+") / default")
+	assert.Contains(t, out, "no expected results")
+
+	workload := "    workload:\n      apiVersion: apps/v1\n      kind: Deployment\n      spec:\n        template:\n          metadata:\n            name: web\n"
+	content := "cases:\n  - name: default\n    parameter:\n      team: payments\n" + workload
+	require.NoError(t, os.WriteFile(suiteFile, []byte(content), 0600))
+
+	out, err = run(tmpDir, "--update")
+	require.NoError(t, err)
+	assert.Contains(t, out, "✓ my-labels (cue: "+cueFile+") / default (updated)")
+	assert.Contains(t, out, "0 passed, 0 failed, 1 updated")
+	bs, err := os.ReadFile(suiteFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(bs), "team: payments")
+	assert.Contains(t, string(bs), "app: test-app")
+
+	out, err = run(tmpDir)
+	require.NoError(t, err)
+	assert.Contains(t, out, "1 passed, 0 failed, 0 updated")
+
+	require.NoError(t, os.WriteFile(suiteFile, bytes.Replace(bs, []byte("team: payments"), []byte("team: orders"), 1), 0600))
+	out, err = run(tmpDir)
+	require.Error(t, err)
+	assert.Contains(t, out, "-           team: payments")
+	assert.Contains(t, out, "+           team: orders")
+	assert.Contains(t, out, "0 passed, 1 failed, 0 updated")
+}
+
+func TestDefinitionTestInCommandGroup(t *testing.T) {
+	ioStreams := util.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+	defCmd := DefinitionCommandGroup(common.Args{}, "1", ioStreams)
+	cmd, _, err := defCmd.Find([]string{"test"})
+	require.NoError(t, err)
+	assert.Equal(t, "test [flags] PATH...", cmd.Use)
+}
@@ -748,7 +748,11 @@ func NewDefinitionListModuleCommand(c common.Args, streams util.IOStreams) *cobr
 
 Supports both local paths and remote Go modules:
   - Local path: ./my-definitions, /path/to/definitions
-  - Go module: github.com/myorg/definitions@v1.0.0`,
+  - Go module: github.com/myorg/definitions@v1.0.0
+
+With --installed, list the modules installed in the cluster by
+'vela def install-module' instead, with their versions and the definitions
+changed or deleted since they were installed.`,
 		Example: `# List definitions in a local directory
 > vela def list-module ./my-definitions
 
@@ -756,8 +760,16 @@ Supports both local paths and remote Go modules:
 > vela def list-module github.com/myorg/definitions@v1.0.0
 
 # List only component definitions
-> vela def list-module ./my-definitions --types component`,
-		Args: cobra.ExactArgs(1),
+> vela def list-module ./my-definitions --types component
+
+# List the modules installed in the cluster
+> vela def list-module --installed`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if installed, _ := cmd.Flags().GetBool(FlagInstalled); installed {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		Annotations: map[string]string{
 			types.TagCommandType:  types.TypeDefModule,
 			types.TagCommandOrder: "3",
@@ -765,6 +777,22 @@ Supports both local paths and remote Go modules:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			installed, err := cmd.Flags().GetBool(FlagInstalled)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagInstalled)
+			}
+			if installed {
+				namespace, err := cmd.Flags().GetString(FlagNamespace)
+				if err != nil {
+					return errors.Wrapf(err, "failed to get `%s`", FlagNamespace)
+				}
+				var name string
+				if len(args) > 0 {
+					name = args[0]
+				}
+				return listInstalledModules(ctx, c, streams, namespace, name)
+			}
+
 			version, err := cmd.Flags().GetString(FlagModuleVersion)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get `%s`", FlagModuleVersion)
@@ -792,6 +820,8 @@ Supports both local paths and remote Go modules:
 
 	cmd.Flags().StringP(FlagModuleVersion, "v", "", "Version of the module (for remote modules)")
 	cmd.Flags().StringP(FlagModuleTypes, "t", "", "Comma-separated list of definition types to list")
+	cmd.Flags().BoolP(FlagInstalled, "", false, "List the modules installed in the cluster, optionally filtered by the module name")
+	cmd.Flags().StringP(Namespace, "n", types.DefaultKubeVelaNS, "Namespace of the installed modules, used with --installed")
 
 	return cmd
 }
@@ -0,0 +1,597 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	types2 "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	pkgdef "github.com/oam-dev/kubevela/pkg/definition"
+	"github.com/oam-dev/kubevela/pkg/definition/goloader"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+	velaversion "github.com/oam-dev/kubevela/version"
+)
+
+const (
+	// FlagRegistryInsecure is the flag to access the registry over plain HTTP
+	FlagRegistryInsecure = "insecure"
+	// FlagRegistryUsername is the flag for the registry username
+	FlagRegistryUsername = "username"
+	// FlagRegistryPassword is the flag for the registry password
+	FlagRegistryPassword = "password"
+	// FlagSkipDependencies is the flag to install a module without its dependencies
+	FlagSkipDependencies = "skip-dependencies"
+	// FlagInstalled is the flag to list the modules installed in the cluster
+	FlagInstalled = "installed"
+)
+
+// NewDefinitionPushModuleCommand creates the `vela def push-module` command
+// to publish a definition module to an OCI registry
+func NewDefinitionPushModuleCommand(_ common.Args, streams util.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "push-module",
+		Short: "Push a Go definition module to an OCI registry.",
+		Long: `Push a Go definition module to an OCI registry.
+
+The definitions of the module are compiled to CUE and packaged together with
+the module.yaml into an OCI artifact, tagged with the module version. The
+module.yaml must set metadata.name. The version defaults to the git tag of
+the module and must be a valid semver version.
+
+Dependencies declared in module.yaml refer to other modules by their OCI
+repository, with a semver range as the version:
+
+  dependencies:
+    - module: ghcr.io/myorg/base-definitions
+      version: ">=1.2.0 <2.0.0"
+
+Hooks are not packaged: a module installed from a registry never runs scripts
+on the machine installing it.`,
+		Example: `# Push the module as version 1.2.0
+> vela def push-module ./my-definitions ghcr.io/myorg/definitions --version 1.2.0
+
+# The version can also be given as the tag of the reference
+> vela def push-module ./my-definitions ghcr.io/myorg/definitions:1.2.0
+
+# Push to a local registry over plain HTTP
+> vela def push-module ./my-definitions localhost:5000/definitions --insecure`,
+		Args: cobra.ExactArgs(2),
+		Annotations: map[string]string{
+			types.TagCommandType:  types.TypeDefModule,
+			types.TagCommandOrder: "8",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := cmd.Flags().GetString(FlagModuleVersion)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagModuleVersion)
+			}
+			registryOpts, err := getRegistryOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return pushModule(context.Background(), streams, args[0], args[1], version, goloader.NewOCIModuleRegistry(registryOpts))
+		},
+	}
+
+	cmd.Flags().StringP(FlagModuleVersion, "v", "", "Version of the module, defaults to the tag of the reference or the git tag of the module")
+	addRegistryFlags(cmd)
+
+	return cmd
+}
+
+// modulePusher uploads module artifacts to a registry
+type modulePusher interface {
+	Push(ctx context.Context, artifact *goloader.ModuleArtifact, repository string) (string, error)
+}
+
+// pushModule loads the module, packages it and pushes it to the repository
+func pushModule(ctx context.Context, streams util.IOStreams, modulePath, ref, version string, pusher modulePusher) error {
+	repository, tag := goloader.SplitModuleReference(ref)
+	if tag != "" && version != "" && tag != version {
+		return errors.Errorf("the tag %s of the reference conflicts with --%s %s", tag, FlagModuleVersion, version)
+	}
+
+	streams.Infof("Loading module from %s...\n", modulePath)
+	module, err := goloader.LoadModule(ctx, modulePath, goloader.DefaultModuleLoadOptions())
+	if err != nil {
+		return errors.Wrapf(err, "failed to load module from %s", modulePath)
+	}
+	if errs := goloader.ValidateModule(module, ""); len(errs) > 0 {
+		for _, e := range errs {
+			streams.Infof("  - %s\n", e)
+		}
+		return errors.Errorf("module validation failed with %d errors", len(errs))
+	}
+
+	switch {
+	case version != "":
+	case tag != "":
+		version = tag
+	default:
+		version = module.Version
+	}
+	artifact, err := goloader.PackageModule(module, version)
+	if err != nil {
+		return errors.Wrap(err, "failed to package the module")
+	}
+	if hooks := module.Metadata.Spec.Hooks; hooks != nil && !hooks.IsEmpty() {
+		streams.Infof("Warning: hooks are not packaged and will not run when the module is installed from the registry\n")
+	}
+
+	streams.Infof("Pushing %s %s to %s...\n", artifact.Name(), artifact.Version, repository)
+	digest, err := pusher.Push(ctx, artifact, repository)
+	if err != nil {
+		return errors.Wrapf(err, "failed to push module %s", artifact.Name())
+	}
+	streams.Infof("✓ Pushed %d definitions\n", len(artifact.Definitions))
+	streams.Infof("  Digest: %s\n", digest)
+	return nil
+}
+
+// NewDefinitionInstallModuleCommand creates the `vela def install-module` command
+// to install a definition module and its dependencies from an OCI registry
+func NewDefinitionInstallModuleCommand(c common.Args, streams util.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-module",
+		Short: "Install a definition module from an OCI registry.",
+		Long: `Install a definition module and its dependencies from an OCI registry.
+
+The version is either the tag of the reference or the highest version
+satisfying the semver range given by --version. The dependencies are
+resolved recursively to the highest versions satisfying the ranges of all
+the modules requiring them, including the installed modules, and are
+installed before the module. The installation fails if a version conflicts
+with the range required by an installed module.
+
+Every installed definition is labeled with the module, and an install record
+is kept in the cluster, so 'vela def list-module --installed' can show the
+installed versions and the definitions changed since.
+
+Installing a new version of a module upgrades its definitions and deletes
+the definitions the new version no longer contains. Definitions owned by
+other modules or created by hand are handled by --conflict.`,
+		Example: `# Install the latest version of a module
+> vela def install-module ghcr.io/myorg/definitions
+
+# Install a specific version
+> vela def install-module ghcr.io/myorg/definitions:1.2.0
+
+# Install the highest 1.x version
+> vela def install-module ghcr.io/myorg/definitions --version "^1.0.0"
+
+# Preview what would be installed
+> vela def install-module ghcr.io/myorg/definitions --dry-run`,
+		Args: cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			types.TagCommandType:  types.TypeDefModule,
+			types.TagCommandOrder: "9",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			dryRun, err := cmd.Flags().GetBool(FlagDryRun)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagDryRun)
+			}
+			namespace, err := cmd.Flags().GetString(FlagNamespace)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagNamespace)
+			}
+			version, err := cmd.Flags().GetString(FlagModuleVersion)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagModuleVersion)
+			}
+			conflictStr, err := cmd.Flags().GetString(FlagConflictStrategy)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagConflictStrategy)
+			}
+			conflict := ConflictStrategy(conflictStr)
+			if !conflict.IsValid() || conflict == ConflictStrategyRename {
+				return errors.Errorf("invalid conflict strategy %q; valid values: skip, overwrite, fail", conflictStr)
+			}
+			skipDeps, err := cmd.Flags().GetBool(FlagSkipDependencies)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagSkipDependencies)
+			}
+			registryOpts, err := getRegistryOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			opts := installModuleOptions{
+				namespace: namespace,
+				version:   version,
+				conflict:  conflict,
+				skipDeps:  skipDeps,
+			}
+			k8sClient, err := c.GetClient()
+			if err != nil {
+				return errors.Wrap(err, "failed to get kubernetes client")
+			}
+			installed, err := goloader.ListModuleRecords(ctx, k8sClient, namespace)
+			if err != nil {
+				return err
+			}
+			registry := goloader.NewOCIModuleRegistry(registryOpts)
+			artifacts, err := resolveModuleInstall(ctx, streams, registry, args[0], opts, installed)
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				printModuleInstallPlan(streams, artifacts)
+				return nil
+			}
+			return installModules(ctx, k8sClient, streams, artifacts, opts)
+		},
+	}
+
+	cmd.Flags().BoolP(FlagDryRun, "", false, "Preview what would be installed without making changes")
+	cmd.Flags().StringP(Namespace, "n", types.DefaultKubeVelaNS, "Namespace to install definitions to")
+	cmd.Flags().StringP(FlagModuleVersion, "v", "", "Semver range of the version to install, defaults to the latest version")
+	cmd.Flags().StringP(FlagConflictStrategy, "c", string(ConflictStrategyFail), "How to handle definitions not installed by the module: skip, overwrite, fail")
+	cmd.Flags().BoolP(FlagSkipDependencies, "", false, "Install the module without its dependencies")
+	addRegistryFlags(cmd)
+
+	return cmd
+}
+
+// installModuleOptions contains options for installing a module from a registry
+type installModuleOptions struct {
+	namespace string
+	version   string
+	conflict  ConflictStrategy
+	skipDeps  bool
+}
+
+// resolveModuleInstall pulls the module and resolves its dependencies. The modules are
+// returned in install order, the requested module last. The versions must satisfy the
+// version ranges required by the installed modules which are not reinstalled.
+func resolveModuleInstall(ctx context.Context, streams util.IOStreams, registry goloader.ModuleRegistry, ref string, opts installModuleOptions, installed []goloader.InstalledModule) ([]*goloader.ModuleArtifact, error) {
+	repository, version := goloader.SplitModuleReference(ref)
+	if version != "" && opts.version != "" {
+		return nil, errors.Errorf("--%s cannot be used with the reference %s having a tag", FlagModuleVersion, ref)
+	}
+	if version == "" {
+		versions, err := registry.ListVersions(ctx, repository)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list versions of %s", repository)
+		}
+		if version, err = goloader.ResolveVersion(versions, opts.version); err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve the version of %s", repository)
+		}
+	}
+
+	streams.Infof("Pulling %s:%s...\n", repository, version)
+	root, err := registry.Pull(ctx, repository, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to pull %s:%s", repository, version)
+	}
+	root.Repository = repository
+	artifacts := []*goloader.ModuleArtifact{root}
+	if !opts.skipDeps && len(root.Metadata.Spec.Dependencies) > 0 {
+		streams.Infof("Resolving dependencies...\n")
+		deps, err := goloader.ResolveModuleDependencies(ctx, registry, root, installed)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve the dependencies of %s", root.Name())
+		}
+		artifacts = append(deps, root)
+	}
+	if err := goloader.CheckInstalledDependents(artifacts, installed); err != nil {
+		return nil, errors.Wrapf(err, "cannot install module %s %s", root.Name(), root.Version)
+	}
+	return artifacts, nil
+}
+
+// printModuleInstallPlan prints the modules and definitions which would be installed
+func printModuleInstallPlan(streams util.IOStreams, artifacts []*goloader.ModuleArtifact) {
+	streams.Infof("\nModules to install (dry-run):\n")
+	for _, artifact := range artifacts {
+		streams.Infof("  %s %s from %s\n", artifact.Name(), artifact.Version, artifact.Repository)
+		for _, def := range artifact.Definitions {
+			streams.Infof("    - %s %s\n", def.Type, def.Name)
+		}
+	}
+}
+
+// installModules installs the modules in order and records them in the cluster
+func installModules(ctx context.Context, k8sClient client.Client, streams util.IOStreams, artifacts []*goloader.ModuleArtifact, opts installModuleOptions) error {
+	names := map[string]string{}
+	for _, artifact := range artifacts {
+		if err := goloader.CheckMinVelaVersion(artifact.Metadata.Spec.MinVelaVersion, velaversion.VelaVersion); err != nil {
+			return errors.Wrapf(err, "cannot install module %s %s", artifact.Name(), artifact.Version)
+		}
+		if artifact.Name() == "" {
+			return errors.Errorf("module %s %s has no name", artifact.Repository, artifact.Version)
+		}
+		if err := goloader.ValidateModuleName(artifact.Name()); err != nil {
+			return errors.Wrapf(err, "cannot install module %s %s", artifact.Repository, artifact.Version)
+		}
+		if repo, ok := names[artifact.Name()]; ok {
+			return errors.Errorf("modules %s and %s have the same name %s", repo, artifact.Repository, artifact.Name())
+		}
+		names[artifact.Name()] = artifact.Repository
+	}
+
+	for i, artifact := range artifacts {
+		record, err := goloader.GetModuleRecord(ctx, k8sClient, opts.namespace, artifact.Name())
+		if err != nil {
+			return err
+		}
+		// The requested module is always reinstalled, which also repairs its drifted definitions
+		isDependency := i < len(artifacts)-1
+		if isDependency && record != nil && record.Version == artifact.Version && record.Digest == artifact.Digest {
+			streams.Infof("\nModule %s %s is already installed\n", artifact.Name(), artifact.Version)
+			continue
+		}
+		if record != nil && record.Repository != artifact.Repository {
+			return errors.Errorf("module %s is already installed from %s", artifact.Name(), record.Repository)
+		}
+		if err := installModule(ctx, k8sClient, streams, artifact, record, opts); err != nil {
+			return errors.Wrapf(err, "failed to install module %s %s", artifact.Name(), artifact.Version)
+		}
+	}
+	return nil
+}
+
+// installModule applies the definitions of the module, deletes the definitions removed
+// since the previous installed version and saves the install record
+func installModule(ctx context.Context, k8sClient client.Client, streams util.IOStreams, artifact *goloader.ModuleArtifact, previous *goloader.InstalledModule, opts installModuleOptions) error {
+	if previous != nil {
+		streams.Infof("\nUpgrading module %s %s -> %s\n", artifact.Name(), previous.Version, artifact.Version)
+	} else {
+		streams.Infof("\nInstalling module %s %s\n", artifact.Name(), artifact.Version)
+	}
+
+	record := &goloader.InstalledModule{
+		Name:         artifact.Name(),
+		Version:      artifact.Version,
+		Repository:   artifact.Repository,
+		Digest:       artifact.Digest,
+		InstalledAt:  time.Now().UTC(),
+		Dependencies: artifact.Metadata.Spec.Dependencies,
+	}
+	installed := map[string]bool{}
+	for _, d := range artifact.Definitions {
+		def := pkgdef.Definition{}
+		if err := def.FromCUEString(d.CUE, nil); err != nil {
+			return errors.Wrapf(err, "invalid %s definition %s", d.Type, d.Name)
+		}
+		def.SetNamespace(opts.namespace)
+		labels := def.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[types.LabelDefinitionModule] = artifact.Name()
+		def.SetLabels(labels)
+		annotations := def.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[types.AnnoDefinitionModuleVersion] = artifact.Version
+		def.SetAnnotations(annotations)
+
+		existing := pkgdef.Definition{}
+		existing.SetGroupVersionKind(def.GroupVersionKind())
+		err := k8sClient.Get(ctx, types2.NamespacedName{Namespace: opts.namespace, Name: def.GetName()}, &existing)
+		if err != nil && !errors2.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get %s %s", def.GetKind(), def.GetName())
+		}
+		exists := err == nil
+
+		if exists {
+			if owner := existing.GetLabels()[types.LabelDefinitionModule]; owner != artifact.Name() {
+				switch opts.conflict {
+				case ConflictStrategySkip:
+					streams.Infof("  - %s %s: skipped (%s)\n", def.GetKind(), def.GetName(), describeDefinitionOwner(owner))
+					continue
+				case ConflictStrategyOverwrite:
+				default:
+					return errors.Errorf("%s %s already exists in namespace %s (%s); use --conflict=overwrite to take it over",
+						def.GetKind(), def.GetName(), opts.namespace, describeDefinitionOwner(owner))
+				}
+			}
+			def.SetResourceVersion(existing.GetResourceVersion())
+			def.SetUID(existing.GetUID())
+			if err := k8sClient.Update(ctx, &def); err != nil {
+				return errors.Wrapf(err, "failed to update %s %s", def.GetKind(), def.GetName())
+			}
+			streams.Infof("  ✓ %s %s updated\n", def.GetKind(), def.GetName())
+		} else {
+			if err := k8sClient.Create(ctx, &def); err != nil {
+				return errors.Wrapf(err, "failed to create %s %s", def.GetKind(), def.GetName())
+			}
+			streams.Infof("  ✓ %s %s created\n", def.GetKind(), def.GetName())
+		}
+
+		// hash the stored object, which is what the drift check compares with
+		stored := pkgdef.Definition{}
+		stored.SetGroupVersionKind(def.GroupVersionKind())
+		if err := k8sClient.Get(ctx, types2.NamespacedName{Namespace: opts.namespace, Name: def.GetName()}, &stored); err != nil {
+			return errors.Wrapf(err, "failed to get %s %s", def.GetKind(), def.GetName())
+		}
+		hash, err := goloader.DefinitionSpecHash(stored.Object)
+		if err != nil {
+			return err
+		}
+		record.Definitions = append(record.Definitions, goloader.InstalledDefinition{
+			Name:     def.GetName(),
+			Kind:     def.GetKind(),
+			SpecHash: hash,
+		})
+		installed[def.GetKind()+"/"+def.GetName()] = true
+	}
+
+	if previous != nil {
+		for _, d := range previous.Definitions {
+			if installed[d.Kind+"/"+d.Name] {
+				continue
+			}
+			if err := pruneModuleDefinition(ctx, k8sClient, opts.namespace, artifact.Name(), d); err != nil {
+				return err
+			}
+			streams.Infof("  ✓ %s %s deleted (removed from the module)\n", d.Kind, d.Name)
+		}
+	}
+
+	if err := goloader.SaveModuleRecord(ctx, k8sClient, opts.namespace, record); err != nil {
+		return err
+	}
+	streams.Infof("Module %s %s installed in namespace %s\n", artifact.Name(), artifact.Version, opts.namespace)
+	return nil
+}
+
+// pruneModuleDefinition deletes a definition if it is still owned by the module
+func pruneModuleDefinition(ctx context.Context, k8sClient client.Client, namespace, module string, d goloader.InstalledDefinition) error {
+	def := pkgdef.Definition{}
+	def.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(d.Kind))
+	if err := k8sClient.Get(ctx, types2.NamespacedName{Namespace: namespace, Name: d.Name}, &def); err != nil {
+		if errors2.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get %s %s", d.Kind, d.Name)
+	}
+	if def.GetLabels()[types.LabelDefinitionModule] != module {
+		return nil
+	}
+	if err := k8sClient.Delete(ctx, &def); err != nil && !errors2.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete %s %s", d.Kind, d.Name)
+	}
+	return nil
+}
+
+// describeDefinitionOwner describes who owns an existing definition
+func describeDefinitionOwner(owner string) string {
+	if owner == "" {
+		return "not installed by a module"
+	}
+	return "installed by module " + owner
+}
+
+// listInstalledModules prints the modules installed in the namespace and the drift of their definitions
+func listInstalledModules(ctx context.Context, c common.Args, streams util.IOStreams, namespace, name string) error {
+	k8sClient, err := c.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to get kubernetes client")
+	}
+	records, err := goloader.ListModuleRecords(ctx, k8sClient, namespace)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		filtered := records[:0]
+		for _, record := range records {
+			if record.Name == name {
+				filtered = append(filtered, record)
+			}
+		}
+		records = filtered
+	}
+	if len(records) == 0 {
+		streams.Infof("No definition modules installed in namespace %s.\n", namespace)
+		return nil
+	}
+
+	drifted := map[string][]goloader.DefinitionDrift{}
+	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tREPOSITORY\tDEFINITIONS\tINSTALLED\tSTATUS")
+	for i := range records {
+		record := &records[i]
+		drifts, err := goloader.CheckModuleDrift(ctx, k8sClient, namespace, record)
+		if err != nil {
+			return err
+		}
+		for _, d := range drifts {
+			if d.Status != goloader.DriftStatusInSync {
+				drifted[record.Name] = append(drifted[record.Name], d)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+			record.Name,
+			record.Version,
+			record.Repository,
+			len(record.Definitions),
+			record.InstalledAt.Format(time.RFC3339),
+			summarizeDrift(drifted[record.Name]))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if len(drifted[record.Name]) == 0 {
+			continue
+		}
+		streams.Infof("\nDrifted definitions of %s:\n", record.Name)
+		for _, d := range drifted[record.Name] {
+			streams.Infof("  ✗ %s %s: %s\n", d.Kind, d.Name, d.Status)
+		}
+	}
+	return nil
+}
+
+// summarizeDrift returns the status column of an installed module
+func summarizeDrift(drifts []goloader.DefinitionDrift) string {
+	if len(drifts) == 0 {
+		return string(goloader.DriftStatusInSync)
+	}
+	counts := map[goloader.DriftStatus]int{}
+	for _, d := range drifts {
+		counts[d.Status]++
+	}
+	var parts []string
+	for _, status := range []goloader.DriftStatus{goloader.DriftStatusModified, goloader.DriftStatusMissing, goloader.DriftStatusTakenOver} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status], strings.ToLower(string(status))))
+		}
+	}
+	return "Drifted (" + strings.Join(parts, ", ") + ")"
+}
+
+// addRegistryFlags adds the flags to access the OCI registry
+func addRegistryFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP(FlagRegistryInsecure, "", false, "Access the registry over plain HTTP")
+	cmd.Flags().StringP(FlagRegistryUsername, "", "", "Username of the registry, defaults to the docker credentials")
+	cmd.Flags().StringP(FlagRegistryPassword, "", "", "Password of the registry")
+}
+
+// getRegistryOptions reads the registry flags
+func getRegistryOptions(cmd *cobra.Command) (goloader.RegistryOptions, error) {
+	var opts goloader.RegistryOptions
+	var err error
+	if opts.Insecure, err = cmd.Flags().GetBool(FlagRegistryInsecure); err != nil {
+		return opts, errors.Wrapf(err, "failed to get `%s`", FlagRegistryInsecure)
+	}
+	if opts.Username, err = cmd.Flags().GetString(FlagRegistryUsername); err != nil {
+		return opts, errors.Wrapf(err, "failed to get `%s`", FlagRegistryUsername)
+	}
+	if opts.Password, err = cmd.Flags().GetString(FlagRegistryPassword); err != nil {
+		return opts, errors.Wrapf(err, "failed to get `%s`", FlagRegistryPassword)
+	}
+	return opts, nil
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/definition/goloader"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+// memoryModuleRegistry serves module artifacts from memory, keyed by repository and version
+type memoryModuleRegistry map[string]map[string]*goloader.ModuleArtifact
+
+func (r memoryModuleRegistry) add(repo, name, version string, deps []goloader.ModuleDependency, traits ...string) {
+	if r[repo] == nil {
+		r[repo] = map[string]*goloader.ModuleArtifact{}
+	}
+	artifact := &goloader.ModuleArtifact{Version: version, Digest: "sha256:" + name + version}
+	artifact.Metadata.Metadata.Name = name
+	artifact.Metadata.Spec.Dependencies = deps
+	for _, trait := range traits {
+		artifact.Definitions = append(artifact.Definitions, goloader.ArtifactDefinition{
+			Name: trait,
+			Type: "trait",
+			CUE: fmt.Sprintf(`%q: {
+	type: "trait"
+	attributes: {}
+	description: "%s of %s %s"
+}
+template: {
+	parameter: replicas: *1 | int
+	patch: spec: replicas: parameter.replicas
+}
+`, trait, trait, name, version),
+		})
+	}
+	r[repo][version] = artifact
+}
+
+func (r memoryModuleRegistry) ListVersions(_ context.Context, repo string) ([]string, error) {
+	if r[repo] == nil {
+		return nil, errors.New("repository not found")
+	}
+	var versions []string
+	for v := range r[repo] {
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+func (r memoryModuleRegistry) Pull(_ context.Context, repo, version string) (*goloader.ModuleArtifact, error) {
+	artifact, ok := r[repo][version]
+	if !ok {
+		return nil, errors.New("manifest unknown")
+	}
+	copied := *artifact
+	return &copied, nil
+}
+
+func newTestModuleRegistry() memoryModuleRegistry {
+	reg := memoryModuleRegistry{}
+	base := []goloader.ModuleDependency{{Module: "registry/base", Version: "^1.0.0"}}
+	reg.add("registry/base", "base", "1.0.0", nil, "base-scaler")
+	reg.add("registry/base", "base", "2.0.0", nil, "base-scaler")
+	reg.add("registry/my-defs", "my-defs", "1.0.0", base, "scaler", "expose")
+	reg.add("registry/my-defs", "my-defs", "2.0.0", base, "scaler")
+	return reg
+}
+
+func getTestTrait(t *testing.T, cli client.Client, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(v1beta1.TraitDefinitionGroupVersionKind)
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Namespace: types.DefaultKubeVelaNS, Name: name}, obj))
+	return obj
+}
+
+func TestNewDefinitionPushModuleCommand(t *testing.T) {
+	cmd := NewDefinitionPushModuleCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	assert.Equal(t, "push-module", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup(FlagModuleVersion))
+	assert.NotNil(t, cmd.Flags().Lookup(FlagRegistryInsecure))
+
+	cmd.SetArgs([]string{"./my-definitions"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "accepts 2 arg")
+}
+
+func TestPushModuleVersionConflict(t *testing.T) {
+	streams := util.IOStreams{Out: &bytes.Buffer{}}
+	err := pushModule(context.Background(), streams, "./my-definitions", "ghcr.io/myorg/defs:1.0.0", "2.0.0", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with --version 2.0.0")
+}
+
+func TestNewDefinitionInstallModuleCommand(t *testing.T) {
+	cmd := NewDefinitionInstallModuleCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	assert.Equal(t, "install-module", cmd.Use)
+	for _, flag := range []string{FlagDryRun, FlagNamespace, FlagModuleVersion, FlagConflictStrategy, FlagSkipDependencies, FlagRegistryUsername} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), flag)
+	}
+	assert.Equal(t, string(ConflictStrategyFail), cmd.Flags().Lookup(FlagConflictStrategy).DefValue)
+
+	cmd.SetArgs([]string{"ghcr.io/myorg/defs", "--conflict", "rename"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid conflict strategy")
+}
+
+func TestListModuleInstalledArgs(t *testing.T) {
+	cmd := NewDefinitionListModuleCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	assert.NoError(t, cmd.Args(cmd, []string{"./my-definitions"}))
+	assert.Error(t, cmd.Args(cmd, []string{}))
+
+	require.NoError(t, cmd.Flags().Set(FlagInstalled, "true"))
+	assert.NoError(t, cmd.Args(cmd, []string{}))
+	assert.NoError(t, cmd.Args(cmd, []string{"my-defs"}))
+	assert.Error(t, cmd.Args(cmd, []string{"a", "b"}))
+}
+
+func TestResolveModuleInstall(t *testing.T) {
+	ctx := context.Background()
+	reg := newTestModuleRegistry()
+	streams := util.IOStreams{Out: &bytes.Buffer{}}
+
+	artifacts, err := resolveModuleInstall(ctx, streams, reg, "registry/my-defs", installModuleOptions{version: "^1.0.0"}, nil)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+	assert.Equal(t, "base", artifacts[0].Name())
+	assert.Equal(t, "my-defs", artifacts[1].Name())
+	assert.Equal(t, "1.0.0", artifacts[1].Version)
+	assert.Equal(t, "registry/my-defs", artifacts[1].Repository)
+
+	artifacts, err = resolveModuleInstall(ctx, streams, reg, "oci://registry/my-defs", installModuleOptions{skipDeps: true}, nil)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1)
+	assert.Equal(t, "2.0.0", artifacts[0].Version)
+
+	_, err = resolveModuleInstall(ctx, streams, reg, "registry/my-defs:1.0.0", installModuleOptions{version: "^1.0.0"}, nil)
+	assert.Error(t, err)
+	_, err = resolveModuleInstall(ctx, streams, reg, "registry/my-defs", installModuleOptions{version: "^3.0.0"}, nil)
+	assert.Error(t, err)
+}
+
+func TestInstallModulesAndListInstalled(t *testing.T) {
+	ctx := context.Background()
+	reg := newTestModuleRegistry()
+	k8sClient := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+	args := common.Args{}
+	args.SetClient(k8sClient)
+	opts := installModuleOptions{namespace: types.DefaultKubeVelaNS, conflict: ConflictStrategyFail}
+	out := &bytes.Buffer{}
+	streams := util.IOStreams{Out: out}
+
+	artifacts, err := resolveModuleInstall(ctx, streams, reg, "registry/my-defs:1.0.0", opts, nil)
+	require.NoError(t, err)
+	require.NoError(t, installModules(ctx, k8sClient, streams, artifacts, opts))
+
+	scaler := getTestTrait(t, k8sClient, "scaler")
+	assert.Equal(t, "my-defs", scaler.GetLabels()[types.LabelDefinitionModule])
+	assert.Equal(t, "1.0.0", scaler.GetAnnotations()[types.AnnoDefinitionModuleVersion])
+	assert.Equal(t, "base", getTestTrait(t, k8sClient, "base-scaler").GetLabels()[types.LabelDefinitionModule])
+
+	out.Reset()
+	require.NoError(t, listInstalledModules(ctx, args, streams, types.DefaultKubeVelaNS, ""))
+	assert.Contains(t, out.String(), "base     1.0.0")
+	assert.Contains(t, out.String(), "my-defs  1.0.0")
+	assert.NotContains(t, out.String(), "Drifted")
+
+	// change a definition and delete another one behind the back of the modules
+	expose := getTestTrait(t, k8sClient, "expose")
+	require.NoError(t, unstructured.SetNestedField(expose.Object, "patch: {}\nparameter: {}\n", "spec", "schematic", "cue", "template"))
+	require.NoError(t, k8sClient.Update(ctx, expose))
+	require.NoError(t, k8sClient.Delete(ctx, getTestTrait(t, k8sClient, "base-scaler")))
+
+	out.Reset()
+	require.NoError(t, listInstalledModules(ctx, args, streams, types.DefaultKubeVelaNS, "my-defs"))
+	assert.Contains(t, out.String(), "Drifted (1 modified)")
+	assert.Contains(t, out.String(), "✗ TraitDefinition expose: Modified")
+	assert.NotContains(t, out.String(), "base-scaler")
+
+	// the installed my-defs requires base 1.x
+	installed, err := goloader.ListModuleRecords(ctx, k8sClient, types.DefaultKubeVelaNS)
+	require.NoError(t, err)
+	_, err = resolveModuleInstall(ctx, streams, reg, "registry/base:2.0.0", opts, installed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `registry/base 2.0.0 conflicts with registry/my-defs (installed) requiring "^1.0.0"`)
+	_, err = resolveModuleInstall(ctx, streams, reg, "registry/base", opts, installed)
+	require.Error(t, err)
+	artifacts, err = resolveModuleInstall(ctx, streams, reg, "registry/base", installModuleOptions{version: "^1.0.0"}, installed)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", artifacts[0].Version)
+
+	// upgrading deletes the definitions removed from the module, the installed dependency is kept
+	out.Reset()
+	artifacts, err = resolveModuleInstall(ctx, streams, reg, "registry/my-defs:2.0.0", opts, installed)
+	require.NoError(t, err)
+	require.NoError(t, installModules(ctx, k8sClient, streams, artifacts, opts))
+	assert.Contains(t, out.String(), "Module base 1.0.0 is already installed")
+	assert.Contains(t, out.String(), "Upgrading module my-defs 1.0.0 -> 2.0.0")
+	assert.Contains(t, out.String(), "TraitDefinition expose deleted")
+	assert.Equal(t, "2.0.0", getTestTrait(t, k8sClient, "scaler").GetAnnotations()[types.AnnoDefinitionModuleVersion])
+
+	record, err := goloader.GetModuleRecord(ctx, k8sClient, types.DefaultKubeVelaNS, "my-defs")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", record.Version)
+	assert.Equal(t, "registry/my-defs", record.Repository)
+	require.Len(t, record.Definitions, 1)
+	assert.Equal(t, "scaler", record.Definitions[0].Name)
+
+	out.Reset()
+	require.NoError(t, listInstalledModules(ctx, args, streams, types.DefaultKubeVelaNS, ""))
+	assert.Contains(t, out.String(), "my-defs  2.0.0")
+	assert.Contains(t, out.String(), "Drifted (1 missing)")
+	assert.Contains(t, out.String(), "✗ TraitDefinition base-scaler: Missing")
+}
+
+func TestInstallModuleConflicts(t *testing.T) {
+	ctx := context.Background()
+	reg := newTestModuleRegistry()
+	streams := util.IOStreams{Out: &bytes.Buffer{}}
+	artifacts, err := resolveModuleInstall(ctx, streams, reg, "registry/base:1.0.0", installModuleOptions{}, nil)
+	require.NoError(t, err)
+
+	// a definition created by hand has the same name as the one of the module
+	existing := &v1beta1.TraitDefinition{}
+	existing.SetNamespace(types.DefaultKubeVelaNS)
+	existing.SetName("base-scaler")
+	newClient := func() client.Client {
+		return fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(existing.DeepCopy()).Build()
+	}
+
+	k8sClient := newClient()
+	err = installModules(ctx, k8sClient, streams, artifacts, installModuleOptions{namespace: types.DefaultKubeVelaNS, conflict: ConflictStrategyFail})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not installed by a module")
+
+	k8sClient = newClient()
+	require.NoError(t, installModules(ctx, k8sClient, streams, artifacts, installModuleOptions{namespace: types.DefaultKubeVelaNS, conflict: ConflictStrategySkip}))
+	assert.Empty(t, getTestTrait(t, k8sClient, "base-scaler").GetLabels()[types.LabelDefinitionModule])
+	record, err := goloader.GetModuleRecord(ctx, k8sClient, types.DefaultKubeVelaNS, "base")
+	require.NoError(t, err)
+	assert.Empty(t, record.Definitions)
+
+	k8sClient = newClient()
+	require.NoError(t, installModules(ctx, k8sClient, streams, artifacts, installModuleOptions{namespace: types.DefaultKubeVelaNS, conflict: ConflictStrategyOverwrite}))
+	assert.Equal(t, "base", getTestTrait(t, k8sClient, "base-scaler").GetLabels()[types.LabelDefinitionModule])
+
+	// a module installed from another repository is not replaced
+	artifacts[0].Repository = "registry/fork"
+	err = installModules(ctx, k8sClient, streams, artifacts, installModuleOptions{namespace: types.DefaultKubeVelaNS, conflict: ConflictStrategyOverwrite})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already installed from registry/base")
+}
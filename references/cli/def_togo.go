@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/definition/togo"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+const (
+	// FlagGoPackage is the flag for the package of the generated Go file
+	FlagGoPackage = "package"
+	// FlagFuncName is the flag for the name of the generated function
+	FlagFuncName = "func-name"
+	// FlagSkipVerify is the flag for skipping the round trip verification and the type check
+	FlagSkipVerify = "skip-verify"
+)
+
+// NewDefinitionToGoCommand create the `vela def to-go` command to convert CUE definitions into defkit Go code
+func NewDefinitionToGoCommand(_ common.Args, streams util.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "to-go [flags] SOURCE.cue",
+		Short: "Convert a CUE definition into defkit Go code.",
+		Long: `Convert a CUE X-Definition into defkit Go code for a Go definition module.
+
+Parameters, output resources, conditionals, trait patches and status/health
+policies are translated into the fluent defkit API. The parts without a defkit
+equivalent are kept as raw CUE and reported as issues.
+
+The conversion is verified by running the generated code with 'go run' and
+comparing the CUE it renders with the original one semantically. The command
+fails if any difference is found, the generated code is written anyway so
+that it can be fixed by hand.
+
+The generated code is type-checked and run in the Go module of the output
+directory, or of the current directory if the code is printed to stdout.
+Run the command inside a Go module requiring kubevela, e.g. a definition
+module, to get it verified.`,
+		Example: `# Convert a component definition and print the Go code
+> vela def to-go webservice.cue
+
+# Write the Go code into a definition module
+> vela def to-go webservice.cue -o ./my-definitions/components/webservice.go
+
+# Use a custom package and function name
+> vela def to-go my-trait.cue --package traits --func-name MyTrait`,
+		Args: cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			types.TagCommandType:  types.TypeDefModule,
+			types.TagCommandOrder: "6",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := togo.Options{}
+			var err error
+			if opts.Package, err = cmd.Flags().GetString(FlagGoPackage); err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagGoPackage)
+			}
+			if opts.FuncName, err = cmd.Flags().GetString(FlagFuncName); err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagFuncName)
+			}
+			if opts.SkipVerify, err = cmd.Flags().GetBool(FlagSkipVerify); err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagSkipVerify)
+			}
+			output, err := cmd.Flags().GetString(FlagOutputDir)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagOutputDir)
+			}
+			return definitionToGo(streams, args[0], output, opts)
+		},
+	}
+
+	cmd.Flags().StringP(FlagOutputDir, "o", "", "Output file of the generated Go code, print to stdout if not set")
+	cmd.Flags().String(FlagGoPackage, "", "Package of the generated Go file, default to the package of the definition type, e.g. components")
+	cmd.Flags().String(FlagFuncName, "", "Name of the generated function, default to the PascalCase of the definition name with the type suffix, e.g. WebserviceComponent")
+	cmd.Flags().Bool(FlagSkipVerify, false, "Skip type-checking and running the generated code to verify the round trip of the conversion")
+
+	return cmd
+}
+
+// definitionToGo converts the CUE definition file into defkit Go code, the
+// issues and the round trip result are printed to the error output so that
+// the code printed to stdout can be redirected into a file
+func definitionToGo(streams util.IOStreams, file string, output string, opts togo.Options) error {
+	if !strings.HasSuffix(file, CUEExtension) {
+		return fmt.Errorf("invalid file %s, must be a cue file", file)
+	}
+	bs, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", file)
+	}
+	var uncheckedReason, unverifiedReason error
+	if !opts.SkipVerify {
+		dir := "."
+		if output != "" {
+			dir = filepath.Dir(output)
+		}
+		if opts.TypeChecker, err = togo.NewTypeChecker(dir); err != nil {
+			uncheckedReason = err
+		}
+		if opts.Runner, err = togo.NewRunner(dir); err != nil {
+			unverifiedReason = err
+		}
+	}
+	res, err := togo.Convert(string(bs), opts)
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert %s", file)
+	}
+
+	if output == "" {
+		streams.Infonln(string(res.Source))
+	} else {
+		if err := os.WriteFile(filepath.Clean(output), res.Source, 0600); err != nil {
+			return errors.Wrapf(err, "failed to write %s", output)
+		}
+		streams.Errorf("Generated %s %s into %s\n", res.Type, res.Name, output)
+	}
+
+	if len(res.Issues) > 0 {
+		streams.Errorf("%d part(s) could not be translated into the fluent defkit API:\n", len(res.Issues))
+		for _, issue := range res.Issues {
+			streams.Errorf("  - %s\n", issue)
+		}
+	}
+	if opts.SkipVerify {
+		return nil
+	}
+	if !res.TypeChecked {
+		streams.Errorf("The generated code is not type-checked: %v\n", uncheckedReason)
+	}
+	if res.RoundTrip == nil {
+		streams.Errorf("The round trip is not verified, the generated code cannot be run: %v\n", unverifiedReason)
+		return nil
+	}
+	for _, path := range res.RoundTrip.Unverified {
+		streams.Errorf("Unverified: %s\n", path)
+	}
+	if !res.RoundTrip.Equivalent {
+		for _, diff := range res.RoundTrip.Differences {
+			streams.Errorf("Difference: %s\n", diff)
+		}
+		return fmt.Errorf("the generated definition is not equivalent to %s, found %d difference(s)", file, len(res.RoundTrip.Differences))
+	}
+	streams.Errorf("Round trip verified: the generated code renders a definition equivalent to %s\n", file)
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+const toGoTraitCUE = `
+"my-labels": {
+	type: "trait"
+	annotations: {}
+	labels: {}
+	description: "Add labels to the pod"
+	attributes: podDisruptive: true
+}
+template: {
+	patch: spec: template: metadata: labels: {
+		if parameter.team != _|_ {
+			team: parameter.team
+		}
+		app: context.appName
+	}
+	parameter: team?: string
+}
+`
+
+func TestDefinitionToGoCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	cueFile := filepath.Join(tmpDir, "my-labels.cue")
+	require.NoError(t, os.WriteFile(cueFile, []byte(toGoTraitCUE), 0600))
+
+	t.Run("print to stdout", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		cmd := NewDefinitionToGoCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: &out, ErrOut: &errOut})
+		cmd.SetArgs([]string{cueFile})
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, out.String(), "package traits")
+		assert.Contains(t, out.String(), "func MyLabelsTrait() *defkit.TraitDefinition {")
+		assert.Contains(t, out.String(), `SetIf(team.IsSet(), "spec.template.metadata.labels.team", team)`)
+		assert.Contains(t, errOut.String(), "Round trip verified")
+	})
+
+	t.Run("write to file", func(t *testing.T) {
+		output := filepath.Join(tmpDir, "labels.go")
+		var buf bytes.Buffer
+		cmd := NewDefinitionToGoCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: &buf, ErrOut: &buf})
+		cmd.SetArgs([]string{cueFile, "-o", output, "--package", "mytraits", "--func-name", "Labels", "--skip-verify"})
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), "Generated trait my-labels into "+output)
+		assert.NotContains(t, buf.String(), "Round trip verified")
+		bs, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Contains(t, string(bs), "package mytraits")
+		assert.Contains(t, string(bs), "func Labels() *defkit.TraitDefinition {")
+	})
+
+	t.Run("write outside a go module", func(t *testing.T) {
+		output := filepath.Join(tmpDir, "verified-labels.go")
+		var buf bytes.Buffer
+		cmd := NewDefinitionToGoCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: &buf, ErrOut: &buf})
+		cmd.SetArgs([]string{cueFile, "-o", output})
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), "The generated code is not type-checked")
+		assert.Contains(t, buf.String(), "The round trip is not verified, the generated code cannot be run")
+		assert.NotContains(t, buf.String(), "Round trip verified")
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		cmd := NewDefinitionToGoCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}})
+		cmd.SetArgs([]string{filepath.Join(tmpDir, "my-labels.yaml")})
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be a cue file")
+	})
+}
+
+func TestDefinitionToGoInCommandGroup(t *testing.T) {
+	ioStreams := util.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+	defCmd := DefinitionCommandGroup(common.Args{}, "1", ioStreams)
+	cmd, _, err := defCmd.Find([]string{"to-go"})
+	require.NoError(t, err)
+	assert.Equal(t, "to-go [flags] SOURCE.cue", cmd.Use)
+}
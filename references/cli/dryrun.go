@@ -57,6 +57,8 @@ type DryRunCmdOptions struct {
 	OfflineMode          bool
 	MergeStandaloneFiles bool
 	DefinitionNamespace  string
+	Workflow             bool
+	ClusterInventory     string
 }
 
 // NewDryRunCommand creates `dry-run` command
@@ -80,9 +82,20 @@ Additionally, if the provided policy and workflow files are not referenced by ap
 and those files will be ignored. You can use "merge" flag to make those standalone files effective:
 	vela dry-run -d /definition/directory/or/file/ -f /path/to/app.yaml,/path/to/policy.yaml,/path/to/workflow.yaml --merge
 
+You can also execute the workflow of the application, the placements of topology policies will be resolved from a
+cluster inventory file, and the rendered resources will be printed per step and per cluster:
+	vela dry-run -f /path/to/app.yaml --offline --workflow --cluster-inventory /path/to/clusters.yaml
+
+The cluster inventory file lists the clusters with their labels, for example:
+	clusters:
+	- name: cluster-hangzhou
+	  labels:
+	    region: hangzhou
+
 Limitation:
 	1. Only support one object per file(yaml) for "-f" flag. More support will be added in the future improvement.
 	2. Dry Run with policy and workflow will only take override/topology policies and deploy workflow step into considerations. Other workflow step will be ignored.
+	3. Dry Run with "--workflow" executes deploy, apply-component and step-group steps. Other steps are reported as skipped.
 `,
 		Example: `
 # dry-run application 
@@ -90,6 +103,9 @@ vela dry-run -f app.yaml
 
 # dry-run application with policy and workflow
 vela dry-run -f app.yaml -f policy.yaml -f workflow.yaml
+
+# dry-run application by executing the workflow offline against simulated clusters
+vela dry-run -f app.yaml --offline --workflow --cluster-inventory clusters.yaml
 `,
 		Annotations: map[string]string{
 			types.TagCommandType:  types.TypeApp,
@@ -114,6 +130,10 @@ vela dry-run -f app.yaml -f policy.yaml -f workflow.yaml
 
 			buff, err := DryRunApplication(o, c, namespace, namespaceEnv)
 			if err != nil {
+				if o.Workflow && buff.Len() > 0 {
+					// print the steps executed before the failure
+					o.Info(buff.String())
+				}
 				return err
 			}
 			o.Info(buff.String())
@@ -126,6 +146,8 @@ vela dry-run -f app.yaml -f policy.yaml -f workflow.yaml
 	cmd.Flags().BoolVar(&o.OfflineMode, "offline", false, "Run `dry-run` in offline / local mode, all validation steps will be skipped")
 	cmd.Flags().BoolVar(&o.MergeStandaloneFiles, "merge", false, "Merge standalone files to produce dry-run results")
 	cmd.Flags().StringVarP(&o.DefinitionNamespace, "definition-namespace", "x", "", "Specify which namespace the definition locates. (default \"vela-system\")")
+	cmd.Flags().BoolVar(&o.Workflow, "workflow", false, "Execute the workflow of the application and output the rendered resources of each step and cluster")
+	cmd.Flags().StringVar(&o.ClusterInventory, "cluster-inventory", "", "Specify a file listing the clusters and their labels to simulate multicluster placement, only works with --offline and --workflow")
 	addNamespaceAndEnvArg(cmd)
 	cmd.SetOut(ioStreams.Out)
 	return cmd
@@ -144,6 +166,21 @@ func DryRunApplication(cmdOption *DryRunCmdOptions, c common.Args, namespace str
 		}
 	}
 
+	if cmdOption.ClusterInventory != "" {
+		if !cmdOption.OfflineMode || !cmdOption.Workflow {
+			return buff, errors.New("--cluster-inventory can only be used together with --offline and --workflow")
+		}
+		inventory, err := dryrun.LoadClusterInventory(cmdOption.ClusterInventory)
+		if err != nil {
+			return buff, err
+		}
+		clusters, err := inventory.ToObjects()
+		if err != nil {
+			return buff, err
+		}
+		objs = append(objs, clusters...)
+	}
+
 	// Load a kubernetes client
 	var newClient client.Client
 	if cmdOption.OfflineMode {
@@ -198,6 +235,11 @@ func DryRunApplication(cmdOption *DryRunCmdOptions, c common.Args, namespace str
 		ctx = oamutil.SetNamespaceInCtx(ctx, app.Namespace)
 	}
 
+	if cmdOption.Workflow {
+		err = dryRunWorkflow(ctx, dryRunOpt, app, &buff)
+		return buff, err
+	}
+
 	err = dryRunOpt.ExecuteDryRunWithPolicies(ctx, app, &buff)
 	if err != nil {
 		return buff, err
@@ -205,6 +247,30 @@ func DryRunApplication(cmdOption *DryRunCmdOptions, c common.Args, namespace str
 	return buff, nil
 }
 
+// dryRunWorkflow applies the Application-scoped policy transforms and executes the workflow of the application
+func dryRunWorkflow(ctx context.Context, dryRunOpt *dryrun.Option, app *corev1beta1.Application, buff *bytes.Buffer) error {
+	app = app.DeepCopy()
+	if ns, ok := ctx.Value(oamutil.AppDefinitionNamespace).(string); ok && ns != "" {
+		app.Namespace = ns
+	}
+	result, warnings, err := dryRunOpt.ExecuteWorkflowDryRunWithPolicies(ctx, app)
+	if err != nil {
+		return err
+	}
+	for _, msg := range warnings {
+		fmt.Fprintf(buff, "WARNING: %s\n\n", msg)
+	}
+	if err = dryRunOpt.PrintWorkflowDryRun(buff, result); err != nil {
+		return err
+	}
+	for _, stepResult := range result.Steps {
+		if stepResult.Phase == dryrun.StepPhaseFailed {
+			return errors.Errorf("workflow step %s failed: %s", stepResult.Name, stepResult.Message)
+		}
+	}
+	return nil
+}
+
 func readObj(path string) (*unstructured.Unstructured, error) {
 	switch {
 	case strings.HasSuffix(path, CUEExtension):
@@ -44,6 +44,8 @@ type LiveDiffCmdOptions struct {
 	Revision          string
 	SecondaryRevision string
 	Context           int
+	Output            string
+	Live              bool
 }
 
 // NewLiveDiffCommand creates `live-diff` command
@@ -54,7 +56,7 @@ func NewLiveDiffCommand(c common.Args, order string, ioStreams cmdutil.IOStreams
 		Use:                   "live-diff",
 		DisableFlagsInUseLine: true,
 		Short:                 "Compare application and revisions.",
-		Long:                  "Compare application and revisions.",
+		Long:                  "Compare application and revisions. With --live, compare the desired state of the application with the last-applied and live state of its resources in all the target clusters.",
 		Example: "# compare the current application and the running revision\n" +
 			"> vela live-diff my-app\n" +
 			"# compare the current application and the specified revision\n" +
@@ -62,7 +64,13 @@ func NewLiveDiffCommand(c common.Args, order string, ioStreams cmdutil.IOStreams
 			"# compare two application revisions\n" +
 			"> vela live-diff --revision my-app-v1,my-app-v2\n" +
 			"# compare the application file and the specified revision\n" +
-			"> vela live-diff -f my-app.yaml -r my-app-v1 --context 10",
+			"> vela live-diff -f my-app.yaml -r my-app-v1 --context 10\n" +
+			"# output the diff in json with RFC 6902 JSON Patch for each resource\n" +
+			"> vela live-diff my-app -o json-patch\n" +
+			"# compare the desired, last-applied and live state of the resources in all the target clusters\n" +
+			"> vela live-diff my-app --live\n" +
+			"# compare the application file with the last-applied and live state of the resources\n" +
+			"> vela live-diff -f my-app.yaml --live",
 		Annotations: map[string]string{
 			types.TagCommandOrder: order,
 			types.TagCommandType:  types.TypeApp,
@@ -97,6 +105,8 @@ func NewLiveDiffCommand(c common.Args, order string, ioStreams cmdutil.IOStreams
 	cmd.Flags().StringVarP(&o.DefinitionFile, "definition", "d", "", "specify a file or directory containing capability definitions, they will only be used in dry-run rather than applied to K8s cluster")
 	cmd.Flags().StringVarP(&o.Revision, "revision", "r", "", "specify one or two application revision name(s), by default, it will compare with the latest revision")
 	cmd.Flags().IntVarP(&o.Context, "context", "c", -1, "output number lines of context around changes, by default show all unchanged lines")
+	cmd.Flags().BoolVarP(&o.Live, "live", "", false, "compare the rendered desired state, the last-applied state and the live state of the resources in the target clusters")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", string(dryrun.TextReport), "output format of the diff, support: [text, json, yaml, json-patch]")
 	addNamespaceAndEnvArg(cmd)
 	return cmd
 }
@@ -121,6 +131,9 @@ func LiveDiffApplication(cmdOption *LiveDiffCmdOptions, c common.Args) (bytes.Bu
 		return buff, err
 	}
 	liveDiffOption := dryrun.NewLiveDiffOption(newClient, config, objs)
+	if cmdOption.Live {
+		return cmdOption.liveStateDiff(newClient, liveDiffOption)
+	}
 	if cmdOption.ApplicationFile == "" {
 		return cmdOption.renderlessDiff(newClient, liveDiffOption)
 	}
@@ -166,7 +179,9 @@ func LiveDiffApplication(cmdOption *LiveDiffCmdOptions, c common.Args) (bytes.Bu
 	}
 
 	reportDiffOpt := dryrun.NewReportDiffOption(cmdOption.Context, &buff)
-	reportDiffOpt.PrintDiffReport(diffResult)
+	if err = reportDiffOpt.PrintDiffReportWithFormat(diffResult, dryrun.ReportFormat(cmdOption.Output)); err != nil {
+		return buff, err
+	}
 
 	return buff, nil
 }
@@ -195,6 +210,14 @@ func (o *LiveDiffCmdOptions) loadAndValidate(args []string) error {
 	if o.SecondaryRevision != "" && o.ApplicationFile != "" {
 		return errors.Errorf("cannot use application file and two revisions at the same time")
 	}
+	if o.Live && o.Revision != "" {
+		return errors.Errorf("cannot use revision in live state diff")
+	}
+	switch dryrun.ReportFormat(o.Output) {
+	case "", dryrun.TextReport, dryrun.JSONReport, dryrun.YAMLReport, dryrun.JSONPatchReport:
+	default:
+		return errors.Errorf("unsupported output format %s, support: [text, json, yaml, json-patch]", o.Output)
+	}
 	return nil
 }
 
@@ -242,6 +265,34 @@ func (o *LiveDiffCmdOptions) renderlessDiff(cli client.Client, option *dryrun.Li
 		return buf, errors.WithMessage(err, "cannot calculate diff")
 	}
 	reportDiffOpt := dryrun.NewReportDiffOption(o.Context, &buf)
-	reportDiffOpt.PrintDiffReport(diffResult)
+	if err = reportDiffOpt.PrintDiffReportWithFormat(diffResult, dryrun.ReportFormat(o.Output)); err != nil {
+		return buf, err
+	}
+	return buf, nil
+}
+
+func (o *LiveDiffCmdOptions) liveStateDiff(cli client.Client, option *dryrun.LiveDiffOption) (bytes.Buffer, error) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	app := &v1beta1.Application{}
+	if o.ApplicationFile != "" {
+		var err error
+		if app, err = readApplicationFromFile(o.ApplicationFile); err != nil {
+			return buf, errors.WithMessagef(err, "read application file: %s", o.ApplicationFile)
+		}
+		if app.Namespace == "" {
+			app.SetNamespace(o.Namespace)
+		}
+	} else if err := cli.Get(ctx, client.ObjectKey{Name: o.AppName, Namespace: o.Namespace}, app); err != nil {
+		return buf, errors.Wrapf(err, "cannot get application %s/%s", o.Namespace, o.AppName)
+	}
+	diffResult, err := option.DiffLiveState(ctx, app)
+	if err != nil {
+		return buf, errors.WithMessage(err, "cannot calculate live state diff")
+	}
+	reportDiffOpt := dryrun.NewReportDiffOption(o.Context, &buf)
+	if err = reportDiffOpt.PrintDiffReportWithFormat(diffResult, dryrun.ReportFormat(o.Output)); err != nil {
+		return buf, err
+	}
 	return buf, nil
 }
@@ -99,7 +99,10 @@ func NewAppStatusCommand(c common.Args, order string, ioStreams cmdutil.IOStream
   vela status first-vela-app -o jsonpath='{.status}'
   
   # Get Application metrics status
-  vela status first-vela-app --metrics`,
+  vela status first-vela-app --metrics
+
+  # Show the Applications depended on and depending on the application
+  vela status first-vela-app --deps`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// check args
 			argsLength := len(args)
@@ -137,6 +140,10 @@ func NewAppStatusCommand(c common.Args, order string, ioStreams cmdutil.IOStream
 				return err
 			}
 
+			if printDeps, err := cmd.Flags().GetBool("deps"); err == nil && printDeps {
+				return printAppDependencies(ctx, newClient, cmd.OutOrStdout(), appName, namespace)
+			}
+
 			showEndpoints, err := cmd.Flags().GetBool("endpoint")
 			if showEndpoints && err == nil {
 				_, err := loadRemoteApplication(newClient, namespace, appName)
@@ -181,6 +188,7 @@ func NewAppStatusCommand(c common.Args, order string, ioStreams cmdutil.IOStream
 	cmd.Flags().StringP("detail-format", "", "inline", "the format for displaying details, must be used with --detail. Can be one of inline, wide, list, table, raw.")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "raw Application output format. One of: (json, yaml, jsonpath)")
 	cmd.Flags().BoolP("metrics", "m", false, "show resource quota and consumption metrics of the application")
+	cmd.Flags().BoolP("deps", "", false, "show the dependency graph of the application declared by the app-dependency policy, with the outputs of the dependencies")
 	addNamespaceAndEnvArg(cmd)
 	return cmd
 }
@@ -535,7 +543,7 @@ func printApplicationTree(c common.Args, cmd *cobra.Command, appName string, app
 	var placements []v1alpha1.PlacementDecision
 	af, err := pkgappfile.NewApplicationParser(cli).GenerateAppFile(context.Background(), app)
 	if err == nil {
-		placements, _ = policy.GetPlacementsFromTopologyPolicies(context.Background(), cli, app.GetNamespace(), af.Policies, true)
+		placements, _ = policy.GetPlacementsFromTopologyPoliciesWithFailover(context.Background(), cli, app.GetNamespace(), af.Policies, app.Status.PlacementFailovers, true)
 	}
 	format, _ := cmd.Flags().GetString("detail-format")
 	var maxWidth *int
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/xlab/treeprint"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/policy"
+)
+
+// appDependencyGraph is the graph of the dependencies between Applications declared by the app-dependency policy
+type appDependencyGraph struct {
+	apps         map[types.NamespacedName]*v1beta1.Application
+	dependencies map[types.NamespacedName][]types.NamespacedName
+	dependants   map[types.NamespacedName][]types.NamespacedName
+}
+
+func loadAppDependencyGraph(ctx context.Context, cli client.Client) (*appDependencyGraph, error) {
+	apps := &v1beta1.ApplicationList{}
+	if err := cli.List(ctx, apps); err != nil {
+		return nil, err
+	}
+	g := &appDependencyGraph{
+		apps:         map[types.NamespacedName]*v1beta1.Application{},
+		dependencies: map[types.NamespacedName][]types.NamespacedName{},
+		dependants:   map[types.NamespacedName][]types.NamespacedName{},
+	}
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		key := types.NamespacedName{Namespace: app.Namespace, Name: app.Name}
+		g.apps[key] = app
+		deps, err := policy.GetAppDependencies(app)
+		if err != nil {
+			continue
+		}
+		g.dependencies[key] = deps
+		for _, dep := range deps {
+			g.dependants[dep] = append(g.dependants[dep], key)
+		}
+	}
+	for _, dependants := range g.dependants {
+		sort.Slice(dependants, func(i, j int) bool { return dependants[i].String() < dependants[j].String() })
+	}
+	return g, nil
+}
+
+func (g *appDependencyGraph) label(app types.NamespacedName) string {
+	a, ok := g.apps[app]
+	if !ok {
+		return fmt.Sprintf("%s (not found)", app)
+	}
+	phase := string(a.Status.Phase)
+	if phase == "" {
+		phase = "-"
+	}
+	return fmt.Sprintf("%s (%s)", app, phase)
+}
+
+// addNodes adds the apps and the apps next to them recursively, the apps already on the path are cycles
+func (g *appDependencyGraph) addNodes(branch treeprint.Tree, apps []types.NamespacedName, next map[types.NamespacedName][]types.NamespacedName, path map[types.NamespacedName]bool, withOutputs bool) {
+	for _, app := range apps {
+		if path[app] {
+			branch.AddNode(fmt.Sprintf("%s (cycle)", app))
+			continue
+		}
+		node := branch.AddBranch(g.label(app))
+		if a, ok := g.apps[app]; ok && withOutputs {
+			addExportNodes(node, a.Status.Exports)
+		}
+		path[app] = true
+		g.addNodes(node, next[app], next, path, withOutputs)
+		delete(path, app)
+	}
+}
+
+func addExportNodes(branch treeprint.Tree, exports map[string]string) {
+	keys := make([]string, 0, len(exports))
+	for k := range exports {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		branch.AddMetaNode("output", fmt.Sprintf("%s=%s", k, exports[k]))
+	}
+}
+
+// printAppDependencies prints the Applications the app depends on with their outputs, and the
+// Applications depending on it, in tree format
+func printAppDependencies(ctx context.Context, cli client.Client, out io.Writer, appName, namespace string) error {
+	app := types.NamespacedName{Namespace: namespace, Name: appName}
+	g, err := loadAppDependencyGraph(ctx, cli)
+	if err != nil {
+		return err
+	}
+	root, ok := g.apps[app]
+	if !ok {
+		return fmt.Errorf("application %s not found", app)
+	}
+	if len(g.dependencies[app]) == 0 && len(g.dependants[app]) == 0 {
+		_, err = fmt.Fprintf(out, "Application %s has no dependencies and no dependants.\n", app)
+		return err
+	}
+	tree := treeprint.New()
+	tree.SetValue(g.label(app))
+	addExportNodes(tree, root.Status.Exports)
+	path := map[types.NamespacedName]bool{app: true}
+	if deps := g.dependencies[app]; len(deps) > 0 {
+		g.addNodes(tree.AddBranch("Depends on"), deps, g.dependencies, path, true)
+	}
+	if dependants := g.dependants[app]; len(dependants) > 0 {
+		g.addNodes(tree.AddBranch("Depended on by"), dependants, g.dependants, path, false)
+	}
+	_, err = fmt.Fprint(out, tree.String())
+	return err
+}
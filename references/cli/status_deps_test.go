@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	common2 "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestPrintAppDependencies(t *testing.T) {
+	newApp := func(name, deps string, phase common.ApplicationPhase, exports map[string]string) *v1beta1.Application {
+		app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		if deps != "" {
+			app.Spec.Policies = []v1beta1.AppPolicy{{
+				Name:       "deps",
+				Type:       v1alpha1.AppDependencyPolicyType,
+				Properties: &runtime.RawExtension{Raw: []byte(deps)},
+			}}
+		}
+		app.Status.Phase = phase
+		app.Status.Exports = exports
+		return app
+	}
+	cli := fake.NewClientBuilder().WithScheme(common2.Scheme).WithObjects(
+		newApp("database", "", common.ApplicationRunning, map[string]string{"endpoint": "mysql:3306"}),
+		newApp("web", `{"dependsOn":[{"name":"database"},{"name":"cache"}]}`, common.ApplicationRunning, nil),
+		newApp("frontend", `{"dependsOn":[{"name":"web"}]}`, common.ApplicationStarting, nil),
+		newApp("standalone", "", common.ApplicationRunning, nil),
+	).Build()
+	ctx := context.Background()
+	r := require.New(t)
+
+	out := &bytes.Buffer{}
+	r.NoError(printAppDependencies(ctx, cli, out, "web", "default"))
+	r.Contains(out.String(), "default/web (running)")
+	r.Contains(out.String(), "Depends on")
+	r.Contains(out.String(), "default/database (running)")
+	r.Contains(out.String(), "[output]  endpoint=mysql:3306")
+	r.Contains(out.String(), "default/cache (not found)")
+	r.Contains(out.String(), "Depended on by")
+	r.Contains(out.String(), "default/frontend (starting)")
+
+	out.Reset()
+	r.NoError(printAppDependencies(ctx, cli, out, "standalone", "default"))
+	r.Equal("Application default/standalone has no dependencies and no dependants.\n", out.String())
+
+	r.Error(printAppDependencies(ctx, cli, out, "missing", "default"))
+}
@@ -28,6 +28,7 @@ import (
 	workflowv1alpha1 "github.com/kubevela/workflow/api/v1alpha1"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	pkgmulticluster "github.com/kubevela/pkg/multicluster"
@@ -37,6 +38,7 @@ import (
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/auth"
 	"github.com/oam-dev/kubevela/pkg/utils/common"
 	querytypes "github.com/oam-dev/kubevela/pkg/utils/types"
 	cmdutil "github.com/oam-dev/kubevela/pkg/utils/util"
@@ -62,6 +64,7 @@ func NewWorkflowCommand(c common.Args, order string, ioStreams cmdutil.IOStreams
 	cmd.AddCommand(
 		NewWorkflowSuspendCommand(c, ioStreams, wargs),
 		NewWorkflowResumeCommand(c, ioStreams, wargs),
+		NewWorkflowApproveCommand(c, ioStreams, wargs),
 		NewWorkflowTerminateCommand(c, ioStreams, wargs),
 		NewWorkflowRestartCommand(c, ioStreams, wargs),
 		NewWorkflowRollbackCommand(c, ioStreams, wargs),
@@ -122,6 +125,54 @@ func NewWorkflowResumeCommand(_ common.Args, _ cmdutil.IOStreams, wargs *Workflo
 	return cmd
 }
 
+// readCurrentIdentity reads the identity of the current user from the apiserver
+var readCurrentIdentity = func(ctx context.Context, c common.Args) (*auth.Identity, error) {
+	config, err := c.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	cli, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return auth.ReadIdentityFromSelfSubjectReview(ctx, cli)
+}
+
+// NewWorkflowApproveCommand create workflow approve command
+func NewWorkflowApproveCommand(c common.Args, _ cmdutil.IOStreams, wargs *WorkflowArgs) *cobra.Command {
+	var comment string
+	cmd := &cobra.Command{
+		Use:   "approve",
+		Short: "Approve an approval step of a workflow.",
+		Long: "Approve an approval step of an application workflow as the current user. The approval is recorded in the " +
+			"workflow status, and the step is resumed once the approvals from the distinct approvers reach the quorum.",
+		Example: "vela workflow approve <application-name> --step <step-name> --comment <comment>",
+		PreRun:  wargs.checkWorkflowNotComplete(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := wargs.getWorkflowInstance(ctx, cmd, args); err != nil {
+				return err
+			}
+			if wargs.Type != instanceTypeApplication {
+				return fmt.Errorf("approve is only supported by the workflow of application")
+			}
+			identity, err := readCurrentIdentity(ctx, c)
+			if err != nil {
+				return err
+			}
+			cli, err := c.GetClient()
+			if err != nil {
+				return err
+			}
+			return operation.ApproveWorkflowStep(ctx, cli, wargs.Writer, wargs.App, wargs.StepName, identity, comment)
+		},
+	}
+	addNamespaceAndEnvArg(cmd)
+	cmd.Flags().StringVarP(&wargs.StepName, "step", "s", "", "specify the approval step name in the workflow, required if more than one approval step is waiting")
+	cmd.Flags().StringVarP(&comment, "comment", "m", "", "the comment of the approval")
+	return cmd
+}
+
 // NewWorkflowTerminateCommand create workflow terminate command
 func NewWorkflowTerminateCommand(_ common.Args, _ cmdutil.IOStreams, wargs *WorkflowArgs) *cobra.Command {
 	cmd := &cobra.Command{
@@ -37,6 +37,8 @@ import (
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/auth"
+	common2 "github.com/oam-dev/kubevela/pkg/utils/common"
 	cmdutil "github.com/oam-dev/kubevela/pkg/utils/util"
 )
 
@@ -483,6 +485,65 @@ func TestWorkflowResume(t *testing.T) {
 	}
 }
 
+func TestWorkflowApprove(t *testing.T) {
+	c := initArgs()
+	ioStream := cmdutil.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+	ctx := context.TODO()
+	r := require.New(t)
+	original := readCurrentIdentity
+	t.Cleanup(func() { readCurrentIdentity = original })
+
+	app := &v1beta1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "approval", Namespace: "default"},
+		Spec: v1beta1.ApplicationSpec{
+			Components: workflowSpec.Components,
+			Workflow: &v1beta1.Workflow{Steps: []wfTypesv1alpha1.WorkflowStep{{
+				WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{
+					Name:       "approve-release",
+					Type:       "approval",
+					Properties: &runtime.RawExtension{Raw: []byte(`{"users":["alice"],"groups":["sre"],"quorum":2}`)},
+				},
+			}}},
+		},
+		Status: common.AppStatus{Workflow: &common.WorkflowStatus{
+			Suspend: true,
+			Steps: []workflowv1alpha1.WorkflowStepStatus{{
+				StepStatus: workflowv1alpha1.StepStatus{Name: "approve-release", Type: "approval", Phase: workflowv1alpha1.WorkflowStepPhaseSuspending},
+			}},
+		}},
+	}
+	cli, err := c.GetClient()
+	r.NoError(err)
+	r.NoError(cli.Create(ctx, app))
+
+	approve := func(user string, groups ...string) error {
+		readCurrentIdentity = func(context.Context, common2.Args) (*auth.Identity, error) {
+			return &auth.Identity{User: user, Groups: groups}, nil
+		}
+		cmd := NewWorkflowApproveCommand(c, ioStream, &WorkflowArgs{Args: c, Writer: ioStream.Out})
+		initCommand(cmd)
+		cmd.SetArgs([]string{app.Name, "--comment", "approved by " + user})
+		return cmd.Execute()
+	}
+	resume := func() error {
+		cmd := NewWorkflowResumeCommand(c, ioStream, &WorkflowArgs{Args: c, Writer: ioStream.Out})
+		initCommand(cmd)
+		cmd.SetArgs([]string{app.Name})
+		return cmd.Execute()
+	}
+
+	r.NoError(approve("alice"))
+	r.ErrorContains(resume(), "step approve-release requires 2 approvals but got 1")
+	r.ErrorContains(approve("carol", "dev"), "carol is not allowed to approve step approve-release")
+	r.NoError(approve("bob", "sre"))
+
+	r.NoError(cli.Get(ctx, types.NamespacedName{Namespace: app.Namespace, Name: app.Name}, app))
+	r.False(app.Status.Workflow.Suspend)
+	r.Equal(workflowv1alpha1.WorkflowStepPhaseRunning, app.Status.Workflow.Steps[0].Phase)
+	r.Len(app.Status.Workflow.Approvals, 2)
+	r.Equal("approved by bob", app.Status.Workflow.Approvals[1].Comment)
+}
+
 func TestWorkflowTerminate(t *testing.T) {
 	c := initArgs()
 	ioStream := cmdutil.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
@@ -1,6 +1,7 @@
 // helmchart.cue - Component definition for Helm charts
 
 import (
+	"encoding/json"
 	"vela/helm"
 )
 
@@ -123,6 +124,26 @@ template: {
 					namespace?: string
 				}
 			}
+
+			// Verify the chart before rendering (opt-in). A chart failing the
+			// verification fails the render.
+			//
+			//   - provenance: the Helm .prov file next to the chart is signed by the
+			//                 PGP keyring and records the digest of the chart
+			//   - cosign:     the OCI chart carries a cosign signature made by the
+			//                 public key
+			//
+			// The keyring (property keyring) or the public key (property publicKey)
+			// is read from a vela config in vela-system or the Application namespace.
+			verify?: {
+				provider: "provenance" | "cosign"
+				keyringRef: {
+					// Config name.
+					name: string
+					// Config namespace. MAY be omitted (defaults to vela-system).
+					namespace?: string
+				}
+			}
 		}
 
 		// Release configuration (optional - uses context defaults)
@@ -190,6 +211,21 @@ template: {
 			recreatePods?:    bool | *false  // Recreate pods (upgrade-only; ignored on first install)
 			cleanupOnFail?:   bool | *false  // Cleanup on failure (upgrade-only; ignored on first install)
 
+			// Takeover of an existing release that was not installed by this
+			// component, e.g. by `helm install`. The release history is kept, so
+			// `helm history` continues after the takeover.
+			//
+			//   - IfExists: take the release over in place (default)
+			//   - Never:    fail the render instead
+			//   - DryRun:   leave the release untouched and record what the takeover
+			//               would change in the release ConfigMap (data.adoptionReport)
+			adoption?: {
+				policy?: *"IfExists" | "Never" | "DryRun"
+				// Import the history of a release installed under another name in
+				// the release namespace. The original release records are removed.
+				fromName?: string
+			}
+
 			// Cache configuration
 			cache?: {
 				// Cache key prefix (defaults to "{context.appName}-{context.name}")
@@ -314,6 +350,10 @@ template: {
 				chartVersion: parameter.chart.version
 			}
 			resourceCount: "\(len(_rendered.$returns.resources))"
+			if _rendered.$returns.adoption != _|_ {
+				adoption:       _rendered.$returns.adoption.summary
+				adoptionReport: json.Marshal(_rendered.$returns.adoption)
+			}
 		}
 	}
 
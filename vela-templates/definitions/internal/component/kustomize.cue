@@ -0,0 +1,101 @@
+// kustomize.cue - Component definition for Kustomize bases and overlays
+
+import (
+	"vela/kustomize"
+)
+
+"kustomize": {
+	type: "component"
+	annotations: {}
+	labels: {
+		"custom.definition.oam.dev/category": "kustomize"
+	}
+	description: "Render a Kustomize base with overlays natively in KubeVela"
+	attributes: {
+		workload: type: "autodetects.core.oam.dev"
+	}
+}
+
+template: {
+	output:  _
+	outputs: _
+
+	parameter: {
+		// +usage=Where the kustomization files are loaded from, exactly one of inline, archive and oci must be set
+		source: {
+			// +usage=Inline files keyed by the path relative to the source root, e.g. "base/kustomization.yaml"
+			inline?: [string]: string
+			// +usage=A tar.gz archive of a git repository. The top-level directory added by the git servers is stripped.
+			archive?: {
+				// +usage=URL of the archive, e.g. https://github.com/org/repo/archive/refs/tags/v1.0.0.tar.gz
+				url: string
+			}
+			// +usage=An OCI artifact whose layers are tar.gz archives of the files, e.g. pushed by `flux push artifact`
+			oci?: {
+				// +usage=Artifact reference, e.g. oci://ghcr.io/org/manifests:v1.0.0
+				ref: string
+				// +usage=Allow plain HTTP and self-signed registries
+				insecure?: bool
+			}
+			// +usage=Authentication for the archive and oci sources
+			auth?: {
+				// +usage=Reference to a Secret with username/password or token. It must be in the Application namespace.
+				secretRef?: {
+					name:       string
+					namespace?: string
+				}
+			}
+		}
+		// +usage=Directory of the kustomization inside the source, e.g. overlays/prod. Defaults to the source root.
+		path?: string
+		// +usage=Prefix added to the names of all resources
+		namePrefix?: string
+		// +usage=Suffix added to the names of all resources
+		nameSuffix?: string
+		// +usage=Namespace set on all namespaced resources. Defaults to the namespace in the kustomization or the Application namespace.
+		namespace?: string
+		// +usage=Labels added to all resources and pod templates, the selectors are untouched
+		commonLabels?: [string]: string
+		// +usage=Image overrides
+		images?: [...{
+			// +usage=Image name to match
+			name: string
+			// +usage=New image name
+			newName?: string
+			// +usage=New image tag
+			newTag?: string
+			// +usage=New image digest, overrides the tag
+			digest?: string
+		}]
+		// +usage=Strategic merge or JSON 6902 patches
+		patches?: [...{
+			// +usage=The patch content in YAML
+			patch: string
+			// +usage=The resources to patch, all the resources are patched if not set
+			target?: {
+				group?:              string
+				version?:            string
+				kind?:               string
+				name?:               string
+				namespace?:          string
+				labelSelector?:      string
+				annotationSelector?: string
+			}
+		}]
+	}
+
+	_rendered: kustomize.#Render & {
+		$params: {
+			parameter
+			appNamespace: context.namespace
+		}
+	}
+
+	// The first rendered resource is the primary output, the others are the auxiliary outputs keyed
+	// by kind, namespace and name, so the keys are stable when the order of the resources changes.
+	// All of them are dispatched and recorded in the ResourceTracker of the Application.
+	if len(_rendered.$returns.resources) > 0 {
+		output: _rendered.$returns.resources[0]
+	}
+	outputs: _rendered.$returns.outputs
+}
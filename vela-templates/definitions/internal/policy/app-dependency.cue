@@ -0,0 +1,36 @@
+"app-dependency": {
+	annotations: {}
+	description: "Declare the Applications this application depends on and the outputs it exports to the applications depending on it."
+	labels: {}
+	attributes: {}
+	type: "policy"
+}
+
+template: {
+	#Dependency: {
+		// +usage=Specify the name of the Application depended on
+		name: string
+		// +usage=Specify the namespace of the Application depended on, default to the namespace of this application
+		namespace?: string
+	}
+
+	#Export: {
+		// +usage=Specify the name of the output, available as context.dependencies["<app>"].outputs["<name>"] in the applications depending on this application
+		name: string
+		// +usage=Specify the literal value of the output, or the default value if the detail is not found in the status of the component
+		value?: string
+		// +usage=Specify the component whose status details the value is taken from
+		component?: string
+		// +usage=Specify the key in the status details of the component
+		detail?: string
+	}
+
+	parameter: {
+		// +usage=Specify the Applications this application depends on. The workflow does not start until all of them are running. Their names must be unique, as the outputs are available by name in context.dependencies
+		dependsOn?: [...#Dependency]
+		// +usage=Specify the outputs exported to the applications depending on this application
+		exports?: [...#Export]
+		// +usage=Specify the namespaces of the applications allowed to depend on this application from other namespaces, "*" allows all namespaces
+		exportTo?: [...string]
+	}
+}
@@ -0,0 +1,15 @@
+"auto-rollback": {
+	annotations: {}
+	description: "Roll back the application to the previous healthy revision automatically if the components stay unhealthy after a new revision is published."
+	labels: {}
+	attributes: {}
+	type: "policy"
+}
+
+template: {
+	parameter: {
+		// +usage=Specify the duration to wait for the components to be healthy after the workflow of a new revision succeeds, like 10m.
+		// The application will be rolled back to the previous healthy revision if the components are still unhealthy after the deadline.
+		deadline: *"5m" | string
+	}
+}
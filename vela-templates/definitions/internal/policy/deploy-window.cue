@@ -0,0 +1,38 @@
+"deploy-window": {
+	annotations: {}
+	description: "Postpone the deployments of new revisions to the maintenance windows, outside the blocked windows and the freezes."
+	labels: {}
+	attributes: {}
+	type: "policy"
+}
+
+template: {
+	#Window: {
+		// +usage=Specify the name of the window, shown in the status when the deployment is postponed
+		name?: string
+		// +usage=Specify the cron expression of the start of the window, like "0 22 * * 1-5"
+		schedule: string
+		// +usage=Specify the length of the window, like "2h"
+		duration: string
+	}
+
+	#Freeze: {
+		// +usage=Specify the name of the freeze, shown in the status when the deployment is postponed
+		name?: string
+		// +usage=Specify the first day of the freeze like "2026-12-24", or a RFC3339 time
+		start: string
+		// +usage=Specify the last day of the freeze like "2026-12-26", or a RFC3339 time
+		end: string
+	}
+
+	parameter: {
+		// +usage=Specify the IANA time zone of the schedules and the freeze dates, like "Asia/Shanghai"
+		timeZone: *"UTC" | string
+		// +usage=Specify the windows in which the deployment is allowed. If not set, the deployment is allowed at any time outside the blocked windows and the freezes.
+		allowed?: [...#Window]
+		// +usage=Specify the windows in which the deployment is not allowed
+		blocked?: [...#Window]
+		// +usage=Specify the date ranges in which the deployment is not allowed, like holidays
+		freezes?: [...#Freeze]
+	}
+}
@@ -0,0 +1,39 @@
+"drift-detection": {
+	annotations: {}
+	description: "Configure how the drifted resources are handled in state-keep, either auto-correct or report-only."
+	labels: {}
+	attributes: {}
+	type: "policy"
+}
+
+template: {
+	#PolicyRule: {
+		// +usage=Specify how to select the targets of the rule
+		selector: #RuleSelector
+		// +usage=The drift detection mode for the target resources
+		mode: "auto-correct" | "report-only"
+	}
+
+	#RuleSelector: {
+		// +usage=Select resources by component names
+		componentNames?: [...string]
+		// +usage=Select resources by component types
+		componentTypes?: [...string]
+		// +usage=Select resources by oamTypes (COMPONENT or TRAIT)
+		oamTypes?: [...string]
+		// +usage=Select resources by trait types
+		traitTypes?: [...string]
+		// +usage=Select resources by resource types (like Deployment)
+		resourceTypes?: [...string]
+		// +usage=Select resources by their names
+		resourceNames?: [...string]
+	}
+
+	parameter: {
+		// +usage=Specify the default drift detection mode. The drifted resources will be re-applied in auto-correct mode,
+		// and only recorded in the application status in report-only mode.
+		mode: *"auto-correct" | "report-only"
+		// +usage=Specify the list of rules to control drift detection mode at resource level.
+		rules?: [...#PolicyRule]
+	}
+}
@@ -0,0 +1,36 @@
+"progressive-rollout": {
+	annotations: {}
+	description: "Roll out the new version of components progressively with replica-based canary steps and metric-based analysis. Traffic weight shifting is not supported, the requests are split by the ratio of the canary pods."
+	labels: {}
+	attributes: {}
+	type: "policy"
+}
+
+template: {
+	#Step: {
+		// +usage=Specify the number or percentage of the original replicas running the new version in this step
+		canaryReplicas?: int | string
+		// +usage=Specify the percentage of the original replicas running the new version in this step, ignored if canaryReplicas is set
+		canaryPercent?: int & >=0 & <=100
+		// +usage=Specify the duration to wait after the canary is ready and before the analysis, like 30s or 5m
+		pause?: string
+	}
+
+	#Metric: {
+		// +usage=Specify the name of the metric
+		name: string
+		// +usage=Specify the analysis backend, prometheus or cue
+		type: "prometheus" | "cue" | string
+		// +usage=Specify the parameters of the analysis backend. For prometheus, address, query, min and max are supported. For cue, expression is supported, which can refer to the canary and stable workloads
+		properties: {...}
+	}
+
+	parameter: {
+		// +usage=Specify the components to roll out progressively, all the components will be selected if not set
+		components?: [...string]
+		// +usage=Specify the canary steps, the new version will be promoted after all the steps succeed
+		steps: [...#Step]
+		// +usage=Specify the metrics to judge whether the canary of each step is successful, the rollout will be aborted once any metric fails
+		analysis?: [...#Metric]
+	}
+}
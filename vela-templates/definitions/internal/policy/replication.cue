@@ -9,8 +9,26 @@
 template: {
 	parameter: {
 		// +usage=Spicify the keys of replication. Every key coresponds to a replication components
-		keys: [...string]
+		keys: *[] | [...string]
 		// +usage=Specify the components which will be replicated.
 		selector?: [...string]
+		// +usage=Split the replicas of the selected components across the clusters, the replicas of each cluster are rewritten into the component properties when deploying. If no keys are given, the components are only distributed without replication.
+		distribution?: {
+			// +usage=Specify the total number of replicas of each selected component.
+			replicas: int & >=0
+			// +usage=Split the replicas by the weights of the clusters. The replicas of each weight are split evenly across the matched clusters, and the clusters matching no weight get zero replicas.
+			weights?: [...{
+				// +usage=Specify the names of the clusters to select.
+				clusters?: [...string]
+				// +usage=Specify the label selector for clusters.
+				clusterLabelSelector?: [string]: string
+				// +usage=Specify the weight of the selected clusters.
+				weight: int & >=0
+			}]
+			// +usage=Split the replicas by the allocatable CPU of the clusters, which requires --enable-cluster-metrics in the controller. Exclusive to weights.
+			byCapacity: *false | bool
+			// +usage=Specify the path of the replicas in the component properties.
+			replicasField: *"replicas" | string
+		}
 	}
 }
@@ -18,5 +18,14 @@ template: {
 		clusterSelector?: [string]: string
 		// +usage=Specify the target namespace to deploy in the selected clusters, default inherit the original namespace.
 		namespace?: string
+		// +usage=Move the components from the unhealthy clusters to the spare clusters matching the clusterLabelSelector, ignored if clusters is used. The cluster health is probed by the cluster metrics manager, which requires --enable-cluster-metrics in the controller, otherwise the Failover condition of the application is false.
+		failover?: {
+			// +usage=Specify the number of clusters to deploy to. The matched clusters are sorted by name, the first ones are used and the others are spare. If not set, all the matched clusters are used.
+			replicas?: int
+			// +usage=Specify the number of consecutive failed probes before a cluster is marked as unhealthy.
+			failureThreshold: *3 | int
+			// +usage=Move the components back to the original clusters once they recover.
+			failback: *false | bool
+		}
 	}
 }
@@ -0,0 +1,39 @@
+import (
+	"vela/builtin"
+)
+
+"approval": {
+	type: "workflow-step"
+	annotations: {
+		"category": "Process Control"
+	}
+	labels: {}
+	description: "Suspend the current workflow until enough approvers approve it by 'vela workflow approve' command."
+}
+template: {
+	_approvers: [
+		if parameter.users != _|_ for user in parameter.users {user},
+		if parameter.groups != _|_ for group in parameter.groups {group},
+	]
+	// Fail if nobody is allowed to approve, the workflow could never be resumed otherwise
+	if len(_approvers) == 0 {
+		validateApprovers: builtin.#Fail & {
+			$params: message: "At least one of 'users' or 'groups' must be specified to approve the step"
+		}
+	}
+
+	suspend: builtin.#Suspend & {
+		$params: message: parameter.message
+	}
+
+	parameter: {
+		// +usage=Specify the users allowed to approve, at least one user or group is required. A serviceaccount is specified as "system:serviceaccount:<namespace>:<name>"
+		users?: [...string]
+		// +usage=Specify the groups whose members are allowed to approve
+		groups?: [...string]
+		// +usage=Specify the number of the distinct approvers required to resume the workflow
+		quorum: *1 | int & >=1
+		// +usage=The message to show while waiting for the approvals
+		message: *"Waiting for approval" | string
+	}
+}
@@ -0,0 +1,123 @@
+import (
+	"vela/cloudevents"
+	"vela/kube"
+	"vela/util"
+	"encoding/base64"
+)
+
+"cloudevent": {
+	type: "workflow-step"
+	annotations: {
+		"category": "External Intergration"
+	}
+	labels: {}
+	description: "Send a CloudEvents 1.0 event to the specified sink, with the request signed by HMAC-SHA256 and retried with backoff."
+}
+template: {
+	sink: {
+		if parameter.url.value != _|_ {
+			value: parameter.url.value
+		}
+		if parameter.url.secretRef != _|_ && parameter.url.value == _|_ {
+			read: kube.#Read & {
+				$params: {
+					value: {
+						apiVersion: "v1"
+						kind:       "Secret"
+						metadata: {
+							name:      parameter.url.secretRef.name
+							namespace: context.namespace
+						}
+					}
+				}
+			}
+			stringValue: util.#ConvertString & {$params: bt: base64.Decode(null, read.$returns.value.data[parameter.url.secretRef.key])}
+			value: stringValue.$returns.str
+		}
+	}
+	signing: {
+		if parameter.signingSecretRef != _|_ {
+			read: kube.#Read & {
+				$params: {
+					value: {
+						apiVersion: "v1"
+						kind:       "Secret"
+						metadata: {
+							name:      parameter.signingSecretRef.name
+							namespace: context.namespace
+						}
+					}
+				}
+			}
+			stringValue: util.#ConvertString & {$params: bt: base64.Decode(null, read.$returns.value.data[parameter.signingSecretRef.key])}
+			value: stringValue.$returns.str
+		}
+	}
+	send: cloudevents.#Send & {
+		$params: {
+			url:     sink.value
+			mode:    parameter.mode
+			type:    parameter.type
+			retries: parameter.retries
+			backoff: parameter.backoff
+			if parameter.source != _|_ {
+				source: parameter.source
+			}
+			if parameter.subject != _|_ {
+				subject: parameter.subject
+			}
+			if parameter.data != _|_ {
+				data: parameter.data
+			}
+			if parameter.data == _|_ {
+				data: {
+					application: context.name
+					namespace:   context.namespace
+				}
+			}
+			if signing.value != _|_ {
+				secret: signing.value
+			}
+			if parameter.header != _|_ {
+				header: parameter.header
+			}
+		}
+	}
+
+	parameter: {
+		// +usage=Specify the url of the sink
+		url: close({
+			value: string
+		}) | close({
+			secretRef: {
+				// +usage=name is the name of the secret
+				name: string
+				// +usage=key is the key in the secret
+				key: string
+			}
+		})
+		// +usage=Specify the content mode of the request, structured sends the whole event as the body, binary sends the attributes as ce-* headers
+		mode: *"structured" | "binary"
+		// +usage=Specify the type of the event
+		type: *"dev.oam.vela.application.workflow.notification" | string
+		// +usage=Specify the source of the event, default to the path of the application
+		source?: string
+		// +usage=Specify the subject of the event, default to the name of the application
+		subject?: string
+		// +usage=Specify the data of the event, default to the name and namespace of the application
+		data?: {...}
+		// +usage=Specify the secret holding the key to sign the request, the signature is set in the X-Vela-Signature header and the signing time in the X-Vela-Signature-Timestamp header
+		signingSecretRef?: {
+			// +usage=name is the name of the secret
+			name: string
+			// +usage=key is the key in the secret
+			key: string
+		}
+		// +usage=Specify the extra header of the request
+		header?: [string]: string
+		// +usage=Specify the times to retry after the first failed delivery
+		retries: *3 | int & >=0
+		// +usage=Specify the wait before the first retry, doubled after each retry, the step waits without blocking the workflow
+		backoff: *"1s" | string
+	}
+}
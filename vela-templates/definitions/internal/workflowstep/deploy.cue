@@ -22,6 +22,9 @@ template: {
 			policies:                 parameter.policies
 			parallelism:              parameter.parallelism
 			ignoreTerraformComponent: parameter.ignoreTerraformComponent
+			waves:                    parameter.waves
+			maxUnavailableClusters:   parameter.maxUnavailableClusters
+			waveTimeout:              parameter.waveTimeout
 		}
 	}
 	parameter: {
@@ -33,5 +36,20 @@ template: {
 		parallelism: *5 | int
 		//+usage=If set false, this step will apply the components with the terraform workload.
 		ignoreTerraformComponent: *true | bool
+		//+usage=Deploy the clusters in waves one by one, the next wave starts only after the previous one is healthy. The clusters not selected by any wave are deployed in the last wave.
+		waves: *[] | [...{
+			//+usage=Specify the name of the wave, default to be wave-<index>.
+			name?: string
+			//+usage=Specify the names of the clusters in the wave.
+			clusters?: [...string]
+			//+usage=Select the clusters in the wave by labels.
+			clusterLabelSelector?: [string]: string
+			//+usage=Override the max number of unhealthy clusters allowed in this wave.
+			maxUnavailableClusters?: int
+		}]
+		//+usage=The max number of unhealthy clusters allowed in a wave before moving on to the next wave. If more clusters are unavailable, the deployment is halted.
+		maxUnavailableClusters: *0 | int
+		//+usage=The max duration a wave can be progressing. The clusters failing the health check, or still unhealthy after the timeout, are counted as unavailable.
+		waveTimeout: *"10m" | string
 	}
 }
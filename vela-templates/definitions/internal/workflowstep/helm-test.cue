@@ -0,0 +1,62 @@
+import (
+	"vela/builtin"
+	"vela/helm"
+	"vela/util"
+)
+
+"helm-test": {
+	type: "workflow-step"
+	annotations: {
+		"category": "Application Delivery"
+	}
+	labels: {}
+	description: "Run the test hooks of a Helm release and fail the step when a test fails"
+}
+template: {
+	test: helm.#Test & {
+		$params: {
+			release: {
+				name:      parameter.release
+				namespace: parameter.namespace
+			}
+			timeout: parameter.timeout
+			if parameter.filter != _|_ {
+				filter: parameter.filter
+			}
+		}
+	}
+
+	// Stream the logs of the test pods into `vela workflow logs`
+	log: util.#Log & {
+		$params: {
+			data: test.$returns.message
+			source: resources: [for t in test.$returns.tests {
+				name:      t.name
+				namespace: t.namespace
+			}]
+		}
+	}
+
+	if !test.$returns.passed {
+		fail: builtin.#Fail & {
+			$params: message: test.$returns.message
+		}
+	}
+
+	if test.$returns.passed {
+		msg: builtin.#Message & {
+			$params: message: test.$returns.message
+		}
+	}
+
+	parameter: {
+		// +usage=Name of the Helm release, the helmchart component uses the component name by default
+		release: string
+		// +usage=Namespace of the Helm release
+		namespace: *context.namespace | string
+		// +usage=Time to wait for each test hook
+		timeout: *"5m" | string
+		// +usage=Only run the tests with the names, all the tests are run by default
+		filter?: [...string]
+	}
+}
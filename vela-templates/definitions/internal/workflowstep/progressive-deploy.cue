@@ -0,0 +1,31 @@
+import (
+	"vela/rollout"
+)
+
+"progressive-deploy": {
+	type: "workflow-step"
+	annotations: {
+		"category": "Application Delivery"
+	}
+	labels: {
+		"scope": "Application"
+	}
+	description: "Roll out the components progressively with the canary steps of the progressive-rollout policy."
+}
+template: {
+	progress: rollout.#Progress & {
+		$params: {
+			policy:    parameter.policy
+			cluster:   parameter.cluster
+			namespace: parameter.namespace
+		}
+	}
+	parameter: {
+		//+usage=Declare the name of the progressive-rollout policy.
+		policy: string
+		//+usage=Declare the cluster to roll out the components, default to be the hub cluster.
+		cluster: *"" | string
+		//+usage=Declare the namespace to roll out the components, default to be the namespace of the application.
+		namespace: *"" | string
+	}
+}